@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/spec"
+)
+
+var args struct {
+	file   string
+	dryRun bool
+	prune  bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a cluster spec file to an existing cluster",
+	Long: "Compare the state declared in a cluster spec file with the existing cluster that has " +
+		"the same name, print the changes needed to converge them and apply them.\n\n" +
+		"The settings that can be changed are the privacy of the API, the monitoring of user " +
+		"workloads, the size or autoscaling limits of the default machine pool, the additional " +
+		"machine pools, the mapping methods of the identity providers and the schedule of the " +
+		"automatic upgrades. Settings that aren't in the spec file are left unchanged.",
+	Example: `  # Print the changes needed to converge the cluster with the spec file
+  rosa apply -f cluster.yaml --dry-run
+
+  # Apply the changes, deleting the machine pools that aren't in the spec file
+  rosa apply -f cluster.yaml --prune`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.file,
+		"file",
+		"f",
+		"",
+		"Cluster spec file, in YAML or JSON format (required). Run 'rosa validate spec-file "+
+			"--schema' to get the format.",
+	)
+	Cmd.MarkFlagRequired("file")
+
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Print the changes without applying them.",
+	)
+
+	flags.BoolVar(
+		&args.prune,
+		"prune",
+		false,
+		"Delete the additional machine pools that aren't declared in the spec file. Only used "+
+			"when the spec file declares machine pools.",
+	)
+
+	arguments.AddProfileFlag(flags)
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	manifest, err := spec.Load(args.file)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+	state, err := manifest.State()
+	if err != nil {
+		reporter.Errorf("Failed to apply spec file '%s': %v", args.file, err)
+		exit.Fail()
+	}
+	clusterKey := state.ClusterName
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Use the shared OCM connection, so that all the steps of the plan reuse the same TLS session
+	// and tokens:
+	ocmConnection, err := ocm.AcquireConnection(logger)
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocm.ReleaseConnection()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		exit.Fail()
+	}
+
+	planner := &planner{
+		reporter:   reporter,
+		connection: ocmConnection,
+		cluster:    cluster,
+		prune:      args.prune,
+	}
+	changes, err := planner.plan(state)
+	if err != nil {
+		reporter.Errorf("Failed to compare cluster '%s' with spec file '%s': %v", clusterKey,
+			args.file, err)
+		exit.Fail()
+	}
+	if len(changes) == 0 {
+		reporter.Infof("Cluster '%s' is up to date", clusterKey)
+		return
+	}
+
+	reporter.Infof("Changes needed to apply spec file '%s' to cluster '%s':", args.file, clusterKey)
+	for _, change := range changes {
+		fmt.Printf("  %s %s\n", change.symbol, change.description)
+	}
+	if args.dryRun {
+		return
+	}
+	if !confirm.Confirm("apply %d change(s) to cluster '%s'", len(changes), clusterKey) {
+		exit.Fail()
+	}
+
+	for _, change := range changes {
+		reporter.Debugf("Applying change '%s'", change.description)
+		err = change.apply()
+		if err != nil {
+			reporter.Errorf("Failed to apply change '%s' to cluster '%s': %v", change.description,
+				clusterKey, err)
+			exit.Fail()
+		}
+	}
+	reporter.Successf("Applied %d change(s) to cluster '%s'", len(changes), clusterKey)
+}
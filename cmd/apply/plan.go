@@ -0,0 +1,443 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that compare the state declared in the spec file with the
+// state of the cluster, and return the changes needed to converge them.
+
+package apply
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/idps"
+	"github.com/openshift/rosa/pkg/ocm/machines"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+	"github.com/openshift/rosa/pkg/ocm/upgrades"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/spec"
+)
+
+// Symbols used to print the kind of each change:
+const (
+	symbolAdd    = "+"
+	symbolUpdate = "~"
+	symbolDelete = "-"
+)
+
+// change is one of the differences between the declared state and the state of the cluster,
+// together with the function that applies it.
+type change struct {
+	symbol      string
+	description string
+	apply       func() error
+}
+
+// planner contains the information needed to compare the declared state with the cluster.
+type planner struct {
+	reporter   *rprtr.Object
+	connection *sdk.Connection
+	cluster    *cmv1.Cluster
+	prune      bool
+	changes    []*change
+}
+
+// plan returns the changes needed to converge the cluster with the declared state, in the order
+// that they have to be applied.
+func (p *planner) plan(state *spec.State) ([]*change, error) {
+	err := p.planCluster(state)
+	if err != nil {
+		return nil, err
+	}
+	if state.MachinePools != nil {
+		err = p.planMachinePools(state.MachinePools)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(state.IdentityProviders) > 0 {
+		err = p.planIdentityProviders(state.IdentityProviders)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if state.UpgradeSchedule != nil {
+		err = p.planUpgradeSchedule(*state.UpgradeSchedule)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.changes, nil
+}
+
+func (p *planner) add(symbol string, apply func() error, format string, a ...interface{}) {
+	p.changes = append(p.changes, &change{
+		symbol:      symbol,
+		description: fmt.Sprintf(format, a...),
+		apply:       apply,
+	})
+}
+
+func (p *planner) clusterClient() *cmv1.ClusterClient {
+	return p.connection.ClustersMgmt().V1().Clusters().Cluster(p.cluster.ID())
+}
+
+// planCluster compares the settings of the cluster itself, including the size of the default
+// machine pool.
+func (p *planner) planCluster(state *spec.State) error {
+	if state.Private != nil {
+		private := p.cluster.API().Listening() == cmv1.ListeningMethodInternal
+		if private != *state.Private {
+			listening := cmv1.ListeningMethodExternal
+			if *state.Private {
+				listening = cmv1.ListeningMethodInternal
+			}
+			p.add(symbolUpdate, func() error {
+				return p.updateCluster(cmv1.NewCluster().API(cmv1.NewClusterAPI().Listening(listening)))
+			}, "private API: %t -> %t", private, *state.Private)
+		}
+	}
+
+	if state.DisableWorkloadMonitoring != nil {
+		disabled, err := clusterprovider.IsWorkloadMonitoringDisabled(p.connection, p.cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get workload monitoring: %v", err)
+		}
+		if disabled != *state.DisableWorkloadMonitoring {
+			disable := *state.DisableWorkloadMonitoring
+			p.add(symbolUpdate, func() error {
+				return clusterprovider.SetWorkloadMonitoring(p.connection, p.cluster.ID(), disable)
+			}, "disable workload monitoring: %t -> %t", disabled, disable)
+		}
+	}
+
+	// Size of the default machine pool, either fixed or autoscaled:
+	autoscaling, autoscaled := p.cluster.Nodes().GetAutoscaleCompute()
+	switch {
+	case state.EnableAutoscaling != nil && *state.EnableAutoscaling:
+		minReplicas, maxReplicas := *state.MinReplicas, *state.MaxReplicas
+		if autoscaled && autoscaling.MinReplicas() == minReplicas &&
+			autoscaling.MaxReplicas() == maxReplicas {
+			break
+		}
+		p.add(symbolUpdate, func() error {
+			return p.updateCluster(cmv1.NewCluster().Nodes(cmv1.NewClusterNodes().AutoscaleCompute(
+				cmv1.NewMachinePoolAutoscaling().MinReplicas(minReplicas).MaxReplicas(maxReplicas))))
+		}, "default machine pool: %s -> autoscaling %d-%d", describeClusterNodes(p.cluster),
+			minReplicas, maxReplicas)
+	case state.ComputeNodes != nil:
+		computeNodes := *state.ComputeNodes
+		if !autoscaled && p.cluster.Nodes().Compute() == computeNodes {
+			break
+		}
+		p.add(symbolUpdate, func() error {
+			return p.updateCluster(cmv1.NewCluster().Nodes(cmv1.NewClusterNodes().Compute(computeNodes)))
+		}, "default machine pool: %s -> %d replicas", describeClusterNodes(p.cluster), computeNodes)
+	case state.EnableAutoscaling != nil && autoscaled:
+		return fmt.Errorf("Field 'computeNodes' is required to disable the autoscaling of the " +
+			"default machine pool")
+	}
+	return nil
+}
+
+func (p *planner) updateCluster(builder *cmv1.ClusterBuilder) error {
+	body, err := builder.Build()
+	if err != nil {
+		return err
+	}
+	response, err := p.clusterClient().Update().Body(body).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func describeClusterNodes(cluster *cmv1.Cluster) string {
+	if autoscaling, ok := cluster.Nodes().GetAutoscaleCompute(); ok {
+		return fmt.Sprintf("autoscaling %d-%d", autoscaling.MinReplicas(), autoscaling.MaxReplicas())
+	}
+	return fmt.Sprintf("%d replicas", cluster.Nodes().Compute())
+}
+
+// planMachinePools compares the additional machine pools. The default machine pool is managed
+// with the fields of the cluster.
+func (p *planner) planMachinePools(declared []*spec.MachinePoolState) error {
+	current, err := ocm.GetMachinePools(p.connection.ClustersMgmt().V1().Clusters(), p.cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pools: %v", err)
+	}
+	existing := map[string]*cmv1.MachinePool{}
+	for _, pool := range current {
+		existing[pool.ID()] = pool
+	}
+
+	for _, pool := range declared {
+		if pool.Name == "default" {
+			return fmt.Errorf("The default machine pool can't be declared as a machine pool, " +
+				"use the 'computeNodes' or autoscaling fields instead")
+		}
+		if p.cluster.MultiAZ() && !multipleOfThree(pool.Replicas, pool.MinReplicas, pool.MaxReplicas) {
+			return fmt.Errorf("Multi AZ clusters require that the number of replicas of machine "+
+				"pool '%s' be a multiple of 3", pool.Name)
+		}
+		desired, err := buildMachinePool(pool, true)
+		if err != nil {
+			return err
+		}
+		old, ok := existing[pool.Name]
+		if !ok {
+			p.add(symbolAdd, func() error {
+				response, err := p.clusterClient().MachinePools().Add().Body(desired).Send()
+				if err != nil {
+					return handleErr(response.Error(), err)
+				}
+				return nil
+			}, "machine pool '%s': %s", pool.Name, describeMachinePool(desired))
+			continue
+		}
+		if old.InstanceType() != desired.InstanceType() {
+			return fmt.Errorf("Machine pool '%s' uses instance type '%s' instead of '%s'. To "+
+				"replace its nodes, run 'rosa edit machinepool --instance-type=%s -c %s %s'",
+				pool.Name, old.InstanceType(), desired.InstanceType(), desired.InstanceType(),
+				p.cluster.Name(), pool.Name)
+		}
+		before, after := describeMachinePool(old), describeMachinePool(desired)
+		if before == after {
+			continue
+		}
+		// The instance type can't be changed, so it isn't sent:
+		body, err := buildMachinePool(pool, false)
+		if err != nil {
+			return err
+		}
+		p.add(symbolUpdate, func() error {
+			response, err := p.clusterClient().MachinePools().MachinePool(body.ID()).Update().
+				Body(body).Send()
+			if err != nil {
+				return handleErr(response.Error(), err)
+			}
+			return nil
+		}, "machine pool '%s': %s -> %s", pool.Name, before, after)
+	}
+
+	names := map[string]bool{}
+	for _, pool := range declared {
+		names[pool.Name] = true
+	}
+	for _, pool := range current {
+		id := pool.ID()
+		if id == "default" || names[id] {
+			continue
+		}
+		if !p.prune {
+			p.reporter.Infof("Machine pool '%s' isn't declared in the spec file, use '--prune' "+
+				"to delete it", id)
+			continue
+		}
+		p.add(symbolDelete, func() error {
+			response, err := p.clusterClient().MachinePools().MachinePool(id).Delete().Send()
+			if err != nil {
+				return handleErr(response.Error(), err)
+			}
+			return nil
+		}, "machine pool '%s'", id)
+	}
+	return nil
+}
+
+func buildMachinePool(pool *spec.MachinePoolState, instanceType bool) (*cmv1.MachinePool, error) {
+	builder := cmv1.NewMachinePool().
+		ID(pool.Name)
+	if instanceType {
+		builder = builder.InstanceType(pool.InstanceType)
+	}
+	labels := pool.Labels
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	builder = builder.Labels(labels)
+	taints := []*cmv1.TaintBuilder{}
+	for _, taint := range pool.Taints {
+		keyValue := strings.SplitN(taint, ":", 2)
+		tokens := strings.SplitN(keyValue[0], "=", 2)
+		taints = append(taints, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(keyValue[1]))
+	}
+	builder = builder.Taints(taints...)
+	if pool.Autoscaling() {
+		builder = builder.Autoscaling(cmv1.NewMachinePoolAutoscaling().
+			MinReplicas(*pool.MinReplicas).
+			MaxReplicas(*pool.MaxReplicas))
+	} else {
+		builder = builder.Replicas(*pool.Replicas)
+	}
+	machinePool, err := builder.Build()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to build machine pool '%s': %v", pool.Name, err)
+	}
+	return machinePool, nil
+}
+
+// describeMachinePool returns the text that describes the mutable settings of the machine pool,
+// used both to detect differences and to print them.
+func describeMachinePool(pool *cmv1.MachinePool) string {
+	var size string
+	if autoscaling, ok := pool.GetAutoscaling(); ok {
+		size = fmt.Sprintf("autoscaling %d-%d", autoscaling.MinReplicas(), autoscaling.MaxReplicas())
+	} else {
+		size = fmt.Sprintf("%d replicas", pool.Replicas())
+	}
+	parts := []string{pool.InstanceType(), size}
+	if len(pool.Labels()) > 0 {
+		parts = append(parts, fmt.Sprintf("labels %s", machines.FormatLabels(pool.Labels())))
+	}
+	if len(pool.Taints()) > 0 {
+		taints := make([]string, 0, len(pool.Taints()))
+		for _, taint := range pool.Taints() {
+			taints = append(taints, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+		}
+		sort.Strings(taints)
+		parts = append(parts, fmt.Sprintf("taints %s", strings.Join(taints, ",")))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func multipleOfThree(values ...*int) bool {
+	for _, value := range values {
+		if value != nil && *value%3 != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// planIdentityProviders compares the mapping methods of the identity providers. Identity
+// providers can't be created from the spec file, as it doesn't contain their secrets.
+func (p *planner) planIdentityProviders(declared []*spec.IdentityProviderState) error {
+	current, err := ocm.GetIdentityProviders(p.connection.ClustersMgmt().V1().Clusters(), p.cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get identity providers: %v", err)
+	}
+	existing := map[string]*cmv1.IdentityProvider{}
+	for _, idp := range current {
+		existing[idp.Name()] = idp
+	}
+	for _, declaredIdp := range declared {
+		idp, ok := existing[declaredIdp.Name]
+		if !ok {
+			return fmt.Errorf("Identity provider '%s' doesn't exist. Create it first with "+
+				"'rosa create idp', the spec file can't contain its secrets", declaredIdp.Name)
+		}
+		if string(idp.MappingMethod()) == declaredIdp.MappingMethod {
+			continue
+		}
+		raw, err := idps.GetIdentityProvider(p.connection, p.cluster.ID(), idp.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get identity provider '%s': %v", idp.Name(), err)
+		}
+		mappingMethod := declaredIdp.MappingMethod
+		plan, err := idps.NewPlan(raw, &idps.Changes{
+			MappingMethod: &mappingMethod,
+		})
+		if err != nil {
+			return fmt.Errorf("Identity provider '%s': %v", idp.Name(), err)
+		}
+		if plan.Empty() {
+			continue
+		}
+		idpID := idp.ID()
+		p.add(symbolUpdate, func() error {
+			return plan.Apply(p.connection, p.cluster.ID(), idpID)
+		}, "identity provider '%s': mapping method %s -> %s", idp.Name(), idp.MappingMethod(),
+			mappingMethod)
+	}
+	return nil
+}
+
+// planUpgradeSchedule compares the schedule of the automatic upgrades. An empty schedule removes
+// the automatic upgrades.
+func (p *planner) planUpgradeSchedule(schedule string) error {
+	if schedule != "" {
+		_, err := schedules.ParseCron(schedule)
+		if err != nil {
+			return err
+		}
+	}
+	policies, err := upgrades.GetUpgradePolicies(p.connection.ClustersMgmt().V1(), p.cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get upgrade policies: %v", err)
+	}
+	var automatic *cmv1.UpgradePolicy
+	for _, policy := range policies {
+		if policy.UpgradeType() != "OSD" {
+			continue
+		}
+		if policy.ScheduleType() == "automatic" {
+			automatic = policy
+			continue
+		}
+		if schedule != "" {
+			return fmt.Errorf("Cluster already has an upgrade to version %s scheduled. Run "+
+				"'rosa delete upgrade -c %s' before enabling automatic upgrades", policy.Version(),
+				p.cluster.Name())
+		}
+	}
+
+	if automatic != nil && automatic.Schedule() == schedule {
+		return nil
+	}
+	if automatic != nil {
+		policyID := automatic.ID()
+		p.add(symbolDelete, func() error {
+			response, err := p.clusterClient().UpgradePolicies().UpgradePolicy(policyID).Delete().Send()
+			if err != nil {
+				return handleErr(response.Error(), err)
+			}
+			return nil
+		}, "automatic upgrades with schedule '%s'", automatic.Schedule())
+	}
+	if schedule == "" {
+		return nil
+	}
+	p.add(symbolAdd, func() error {
+		policy, err := cmv1.NewUpgradePolicy().
+			UpgradeType("OSD").
+			ScheduleType("automatic").
+			Schedule(schedule).
+			Build()
+		if err != nil {
+			return err
+		}
+		_, err = upgrades.AddUpgradePolicy(p.connection, p.cluster.ID(), policy, 0)
+		return err
+	}, "automatic upgrades with schedule '%s'", schedule)
+	return nil
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return errors.New(ocm.WithOperationID(msg, res))
+}
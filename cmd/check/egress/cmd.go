@@ -0,0 +1,228 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package egress
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/network/egress"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// localSource is the name of the host that runs the tool in the results.
+const localSource = "local"
+
+var args struct {
+	version      string
+	subnetIDs    []string
+	timeout      time.Duration
+	probeTimeout time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "egress",
+	Short: "Check that the endpoints required by clusters aren't blocked",
+	Long: "Check that the endpoints that clusters need to reach to be installed and managed " +
+		"aren't blocked by a firewall. The endpoints are checked from the host that runs the " +
+		"command and, with '--subnet-ids', from probe instances launched in the given subnets.",
+	Example: `  # Check the endpoints required by clusters in region us-east-2 from this host
+  rosa check egress --region=us-east-2
+
+  # Check the endpoints required by OpenShift 4.10 clusters from two private subnets
+  rosa check egress --version=4.10 --subnet-ids=subnet-1,subnet-2`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"OpenShift version of the cluster. By default the endpoints of all versions are checked.",
+	)
+
+	flags.StringSliceVar(
+		&args.subnetIDs,
+		"subnet-ids",
+		nil,
+		"Subnets to launch a probe instance in, to check the endpoints from the subnets of the "+
+			"cluster. The instances are terminated once the check finishes.",
+	)
+
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		10*time.Second,
+		"Maximum time to wait for the connection to each endpoint.",
+	)
+
+	flags.DurationVar(
+		&args.probeTimeout,
+		"probe-timeout",
+		15*time.Minute,
+		"Maximum time to wait for the results of each probe instance.",
+	)
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+	output.AddFlag(flags, output.JSON)
+}
+
+// sourceResults are the results of checking the endpoints from a host or subnet.
+type sourceResults struct {
+	Source  string           `json:"source"`
+	Results []*egress.Result `json:"results,omitempty"`
+	Error   string           `json:"error,omitempty"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+	if args.timeout <= 0 {
+		reporter.Errorf("Expected a positive timeout, got %s", args.timeout)
+		exit.Fail()
+	}
+
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		reporter.Errorf("Error getting region: %v", err)
+		exit.Fail()
+	}
+	endpoints := egress.Required(region, args.version)
+
+	reporter.Infof("Checking %d endpoints from this host", len(endpoints))
+	all := []*sourceResults{{
+		Source:  localSource,
+		Results: egress.Check(endpoints, args.timeout),
+	}}
+
+	if len(args.subnetIDs) > 0 {
+		awsClient, err := aws.NewClient().
+			Logger(logger).
+			Region(region).
+			Build()
+		if err != nil {
+			reporter.Errorf("Failed to create AWS client: %v", err)
+			exit.Fail()
+		}
+		all = append(all, probeSubnets(reporter, awsClient, endpoints)...)
+	}
+
+	err = output.WriteFile(all)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(all)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		if failed(all) {
+			exit.Fail()
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	header := false
+	for _, source := range all {
+		if source.Error != "" {
+			reporter.Errorf("Failed to check endpoints from '%s': %s", source.Source, source.Error)
+			continue
+		}
+		blocked := egress.Blocked(source.Results)
+		if len(blocked) == 0 {
+			reporter.Infof("All %d endpoints are reachable from '%s'", len(source.Results), source.Source)
+			continue
+		}
+		if !header {
+			fmt.Fprintf(writer, "SOURCE\tENDPOINT\tPURPOSE\tERROR\n")
+			header = true
+		}
+		for _, result := range blocked {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", source.Source, result.Address(), result.Purpose,
+				result.Error)
+		}
+	}
+	writer.Flush()
+	if failed(all) {
+		reporter.Errorf("Some endpoints required by clusters can't be reached, allow them in the " +
+			"firewall before creating the cluster")
+		exit.Fail()
+	}
+}
+
+// probeSubnets launches a probe instance in each of the subnets, in parallel, and returns the
+// results of each of them.
+func probeSubnets(reporter *rprtr.Object, awsClient aws.Client,
+	endpoints []egress.Endpoint) []*sourceResults {
+	script := egress.ProbeScript(endpoints, args.timeout)
+	all := make([]*sourceResults, len(args.subnetIDs))
+	var wg sync.WaitGroup
+	for i, subnetID := range args.subnetIDs {
+		reporter.Infof("Launching probe instance in subnet '%s'", subnetID)
+		wg.Add(1)
+		go func(i int, subnetID string) {
+			defer wg.Done()
+			source := &sourceResults{
+				Source: subnetID,
+			}
+			console, err := awsClient.RunEgressProbe(subnetID, script, egress.ProbeDone,
+				args.probeTimeout)
+			if err != nil {
+				source.Error = err.Error()
+			} else {
+				source.Results = egress.ParseProbeOutput(endpoints, console)
+			}
+			all[i] = source
+		}(i, subnetID)
+	}
+	reporter.Infof("Waiting for the results of the probe instances, this may take several minutes")
+	wg.Wait()
+	return all
+}
+
+func failed(all []*sourceResults) bool {
+	for _, source := range all {
+		if source.Error != "" || len(egress.Blocked(source.Results)) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/config/get"
+	"github.com/openshift/rosa/cmd/config/set"
+	"github.com/openshift/rosa/cmd/config/unset"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the default values of flags and other settings",
+	Long: "Manage the global and per-cluster default values of flags, and other settings like the " +
+		"notification webhooks, stored in the '~/.rosa/config.yaml' file. Flags can also be set " +
+		"with environment variables named after them, like 'ROSA_REGION' for '--region', which " +
+		"take precedence over the defaults.",
+}
+
+func init() {
+	Cmd.AddCommand(get.Cmd)
+	Cmd.AddCommand(set.Cmd)
+	Cmd.AddCommand(unset.Cmd)
+}
@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/defaults"
+	"github.com/openshift/rosa/pkg/exit"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "get [KEY]",
+	Short: "Show default values of flags and other settings",
+	Long:  "Show the global or per-cluster default values of flags, and the other settings.",
+	Example: `  # Show the global defaults
+  rosa config get
+
+  # Show the default region used for the cluster named "mycluster"
+  rosa config get --cluster mycluster default.region`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name or ID of the cluster whose defaults will be shown. Global defaults that the "+
+			"cluster doesn't override are also shown.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	cfg, err := defaults.Load()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	// Cluster defaults override the global ones:
+	values := map[string]string{}
+	for name, value := range cfg.Get("") {
+		values[name] = value
+	}
+	if args.clusterKey != "" {
+		for name, value := range cfg.Get(args.clusterKey) {
+			values[name] = value
+		}
+	}
+
+	settings := map[string]string{}
+	for _, key := range defaults.SettingKeys {
+		if value := cfg.GetSetting(key); value != "" {
+			settings[key] = value
+		}
+	}
+
+	if len(argv) == 1 && defaults.IsSetting(argv[0]) {
+		value, ok := settings[argv[0]]
+		if !ok {
+			reporter.Errorf("Setting '%s' isn't set", argv[0])
+			exit.Fail()
+		}
+		fmt.Fprintf(os.Stdout, "%s\n", value)
+		return
+	}
+	if len(argv) == 1 {
+		name, err := defaults.ParseKey(argv[0])
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		value, ok := values[name]
+		if !ok {
+			reporter.Errorf("There is no default for key '%s'", argv[0])
+			exit.Fail()
+		}
+		fmt.Fprintf(os.Stdout, "%s\n", value)
+		return
+	}
+
+	if len(values) == 0 && len(settings) == 0 {
+		reporter.Infof("There are no defaults")
+		return
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "KEY\tVALUE\n")
+	for _, name := range defaults.Keys(values) {
+		fmt.Fprintf(writer, "%s%s\t%s\n", defaults.KeyPrefix, name, values[name])
+	}
+	for _, key := range defaults.Keys(settings) {
+		fmt.Fprintf(writer, "%s\t%s\n", key, settings[key])
+	}
+	writer.Flush()
+}
@@ -0,0 +1,143 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package set
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/defaults"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/notify"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "set KEY=VALUE...",
+	Short: "Set default values of flags and other settings",
+	Long: "Set global or per-cluster default values of flags. The defaults are used by all the " +
+		"commands that have the flag, unless it is given explicitly in the command line. " +
+		"Also set the comma separated list of webhooks notified by long running commands with " +
+		"the 'notify.webhooks' key, and the template of their payload with 'notify.template'.",
+	Example: `  # Use the 'us-east-1' region by default
+  rosa config set default.region=us-east-1
+
+  # Print only identifiers by default for commands that select the cluster named "mycluster"
+  rosa config set --cluster mycluster default.output=name
+
+  # Notify a Slack channel when clusters are created, upgraded or deleted
+  rosa config set notify.webhooks=https://hooks.slack.com/services/...`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name or ID of the cluster the defaults apply to, as given in the '--cluster' flag. "+
+			"If not given the defaults apply to all clusters.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	cfg, err := defaults.Load()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	values := cfg.Get(args.clusterKey)
+
+	for _, arg := range argv {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			reporter.Errorf("Expected a value of the form KEY=VALUE, got '%s'", arg)
+			exit.Fail()
+		}
+		if defaults.IsSetting(parts[0]) {
+			setSetting(reporter, cfg, parts[0], parts[1])
+			continue
+		}
+		name, err := defaults.ParseKey(parts[0])
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		flag := defaults.FindFlag(cmd.Root(), name)
+		if flag == nil {
+			reporter.Errorf("There is no command with a '--%s' flag", name)
+			exit.Fail()
+		}
+		// The command isn't going to use the flag, so it is safe to change its value to check
+		// that the default is valid:
+		err = flag.Value.Set(parts[1])
+		if err != nil {
+			reporter.Errorf("Invalid value '%s' for flag '%s': %v", parts[1], name, err)
+			exit.Fail()
+		}
+		values[name] = parts[1]
+	}
+
+	err = defaults.Save(cfg)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if args.clusterKey != "" {
+		reporter.Infof("Defaults for cluster '%s' have been saved", args.clusterKey)
+	} else {
+		reporter.Infof("Defaults have been saved")
+	}
+}
+
+// setSetting changes a setting that isn't the default value of a flag. Settings apply to all the
+// clusters.
+func setSetting(reporter *rprtr.Object, cfg *defaults.Config, key string, value string) {
+	if args.clusterKey != "" {
+		reporter.Errorf("Setting '%s' applies to all clusters, it can't be used with '--cluster'", key)
+		exit.Fail()
+	}
+	if key == defaults.NotifyWebhooksKey {
+		for _, hook := range strings.Split(value, ",") {
+			hook = strings.TrimSpace(hook)
+			if hook == "" {
+				continue
+			}
+			err := notify.ValidateWebhook(hook)
+			if err != nil {
+				reporter.Errorf("%v", err)
+				exit.Fail()
+			}
+		}
+	}
+	err := cfg.SetSetting(key, value)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package unset
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/defaults"
+	"github.com/openshift/rosa/pkg/exit"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "unset KEY...",
+	Short: "Remove default values of flags and other settings",
+	Long:  "Remove global or per-cluster default values of flags, or other settings.",
+	Example: `  # Stop using a default region
+  rosa config unset default.region
+
+  # Remove the default output format of the cluster named "mycluster"
+  rosa config unset --cluster mycluster default.output`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name or ID of the cluster whose defaults will be removed. "+
+			"If not given the global defaults are removed.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	cfg, err := defaults.Load()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	values := cfg.Get(args.clusterKey)
+
+	for _, key := range argv {
+		if defaults.IsSetting(key) {
+			if args.clusterKey != "" {
+				reporter.Errorf("Setting '%s' applies to all clusters, it can't be used with '--cluster'", key)
+				exit.Fail()
+			}
+			if cfg.GetSetting(key) == "" {
+				reporter.Warnf("Setting '%s' isn't set", key)
+				continue
+			}
+			err = cfg.SetSetting(key, "")
+			if err != nil {
+				reporter.Errorf("%v", err)
+				exit.Fail()
+			}
+			continue
+		}
+		name, err := defaults.ParseKey(key)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		if _, ok := values[name]; !ok {
+			reporter.Warnf("There is no default for key '%s'", key)
+			continue
+		}
+		delete(values, name)
+	}
+
+	cfg.Prune()
+	err = defaults.Save(cfg)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+}
@@ -19,20 +19,23 @@ package admin
 import (
 	"crypto/rand"
 	"math/big"
-	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ifexists"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
 const (
-	idpName  = "Cluster-Admin"
-	username = "cluster-admin"
+	idpName    = "Cluster-Admin"
+	username   = "cluster-admin"
+	adminGroup = "cluster-admins"
 )
 
 var args struct {
@@ -44,7 +47,10 @@ var Cmd = &cobra.Command{
 	Short: "Creates an admin user to login to the cluster",
 	Long:  "Creates a cluster-admin user with an auto-generated password to login to the cluster",
 	Example: `  # Create an admin user to login to the cluster
-  rosa create admin --cluster=mycluster`,
+  rosa create admin --cluster=mycluster
+
+  # Create the admin user only if the cluster doesn't have it yet
+  rosa create admin --cluster=mycluster --if-not-exists`,
 	Run: run,
 }
 
@@ -56,9 +62,11 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to add the admin user to (required).",
+		"Name, ID or external ID of the cluster to add the admin user to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
+
+	ifexists.AddFlags(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -74,11 +82,18 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	err := ifexists.Validate()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
 	}
 
-	reporter.Warnf("It is recommended to add an identity provider to login to this cluster. " +
-		"See 'rosa create idp --help' for more information.")
+	reporter.Warn(rprtr.WarnNoIdentityProvider,
+		"It is recommended to add an identity provider to login to this cluster. "+
+			"See 'rosa create idp --help' for more information.")
 
 	// Create the AWS client:
 	awsClient, err := aws.NewClient().
@@ -86,13 +101,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -101,7 +116,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -115,43 +130,49 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
-	// TODO: Verify that the htpasswd IdP does not already exist
-
-	// TODO: Verify that the user does not already exist
+	// Check if the cluster already has the admin identity provider:
+	reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	idps, err := ocm.GetIdentityProviders(clustersCollection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	for _, idp := range idps {
+		if idp.Name() != idpName {
+			continue
+		}
+		switch {
+		case ifexists.Skip():
+			reporter.Infof("Cluster '%s' already has an admin user", clusterKey)
+		case ifexists.Update():
+			ensureAdminGroup(reporter, clustersCollection, cluster, clusterKey)
+		default:
+			reporter.Errorf("Cluster '%s' already has an admin user. To create it again, run "+
+				"'rosa delete admin -c %s' first", clusterKey, clusterKey)
+			exit.Fail()
+		}
+		return
+	}
 
 	password, err := generateRandomPassword(23)
 	if err != nil {
 		reporter.Errorf("Failed to generate a random password")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Add admin user to the cluster-admins group:
-	reporter.Debugf("Adding '%s' user to cluster '%s'", username, clusterKey)
-	user, err := cmv1.NewUser().ID(username).Build()
-	if err != nil {
-		reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
-		os.Exit(1)
-	}
-	userResp, err := clustersCollection.Cluster(cluster.ID()).
-		Groups().Group("cluster-admins").
-		Users().Add().Body(user).
-		Send()
-	if err != nil {
-		reporter.Errorf("Failed to add user '%s' to cluster '%s': %s",
-			username, clusterKey, userResp.Error().Reason())
-		os.Exit(1)
-	}
+	addAdminUser(reporter, clustersCollection, cluster, clusterKey)
 
 	// Create HTPasswd IDP configuration:
 	reporter.Debugf("Adding '%s' udp to cluster '%s'", idpName, clusterKey)
@@ -168,7 +189,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create '%s' identity provider for cluster '%s'", idpName, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Add HTPasswd IDP to cluster:
@@ -179,8 +200,8 @@ func run(cmd *cobra.Command, _ []string) {
 		Send()
 	if err != nil {
 		reporter.Errorf("Failed to add '%s' identity provider to cluster '%s': %s",
-			idpName, clusterKey, idpResp.Error().Reason())
-		os.Exit(1)
+			idpName, clusterKey, ocm.ErrorReason(idpResp.Error()))
+		exit.Fail()
 	}
 
 	reporter.Infof("Admin account has been added to cluster '%s'.", clusterKey)
@@ -191,6 +212,55 @@ func run(cmd *cobra.Command, _ []string) {
 	reporter.Infof("It may take up to a minute for the account to become active.")
 }
 
+// ensureAdminGroup makes sure that the admin user of an existing admin identity provider is in
+// the cluster-admins group. The password isn't changed, as it can't be read back.
+func ensureAdminGroup(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterKey string) {
+	reporter.Debugf("Loading users of group '%s' of cluster '%s'", adminGroup, clusterKey)
+	response, err := clusters.Cluster(cluster.ID()).
+		Groups().Group(adminGroup).
+		Users().List().
+		Size(-1).
+		Send()
+	if err != nil {
+		reporter.Errorf("Failed to get users of group '%s' of cluster '%s': %s",
+			adminGroup, clusterKey, ocm.ErrorReason(response.Error()))
+		exit.Fail()
+	}
+	found := false
+	response.Items().Each(func(user *cmv1.User) bool {
+		found = user.ID() == username
+		return !found
+	})
+	if found {
+		reporter.Infof("Admin user of cluster '%s' is up to date", clusterKey)
+		return
+	}
+	addAdminUser(reporter, clusters, cluster, clusterKey)
+	reporter.Infof("Admin user has been added back to group '%s' of cluster '%s'. "+
+		"Its password hasn't changed.", adminGroup, clusterKey)
+}
+
+// addAdminUser adds the admin user to the cluster-admins group of the cluster.
+func addAdminUser(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterKey string) {
+	reporter.Debugf("Adding '%s' user to cluster '%s'", username, clusterKey)
+	user, err := cmv1.NewUser().ID(username).Build()
+	if err != nil {
+		reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
+		exit.Fail()
+	}
+	userResp, err := clusters.Cluster(cluster.ID()).
+		Groups().Group(adminGroup).
+		Users().Add().Body(user).
+		Send()
+	if err != nil {
+		reporter.Errorf("Failed to add user '%s' to cluster '%s': %s",
+			username, clusterKey, ocm.ErrorReason(userResp.Error()))
+		exit.Fail()
+	}
+}
+
 func generateRandomPassword(length int) (string, error) {
 	const (
 		lowerLetters = "abcdefghijkmnopqrstuvwxyz"
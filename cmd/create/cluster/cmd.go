@@ -19,11 +19,13 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"strings"
 	"time"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	clusterdescribe "github.com/openshift/rosa/cmd/describe/cluster"
 	installLogs "github.com/openshift/rosa/cmd/logs/install"
@@ -33,24 +35,37 @@ import (
 	awssdk "github.com/aws/aws-sdk-go/aws"
 	v "github.com/openshift/rosa/cmd/validations"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/stsendpoint"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/notify"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/dnsdomains"
+	"github.com/openshift/rosa/pkg/ocm/flavours"
 	"github.com/openshift/rosa/pkg/ocm/machines"
 	"github.com/openshift/rosa/pkg/ocm/properties"
 	"github.com/openshift/rosa/pkg/ocm/regions"
+	"github.com/openshift/rosa/pkg/ocm/registries"
 	"github.com/openshift/rosa/pkg/ocm/versions"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/spec"
+	"github.com/openshift/rosa/pkg/validation"
 )
 
 var args struct {
 	// Watch logs during cluster installation
 	watch bool
 
+	// Delete the cluster if the installation fails or doesn't finish in time
+	cleanupOnFailure bool
+	installTimeout   time.Duration
+
 	// Simulate creating a cluster
 	dryRun bool
 	// Create a fake cluster with no AWS resources
@@ -66,10 +81,12 @@ var args struct {
 	expirationDuration time.Duration
 	expirationTime     string
 	clusterName        string
+	baseDomain         string
 	region             string
 	version            string
 	channelGroup       string
 	flavour            string
+	flavourID          string
 
 	// Scaling options
 	computeMachineType string
@@ -77,6 +94,10 @@ var args struct {
 	autoscalingEnabled bool
 	minReplicas        int
 	maxReplicas        int
+	defaultMPLabels    string
+
+	// Additional machine pools created right after the cluster
+	machinePools []string
 
 	// Networking options
 	hostPrefix  int
@@ -88,6 +109,40 @@ var args struct {
 	// SubnetIDs should come in pairs; two per availability zone, one private and one public,
 	// unless using PrivateLink, in which case it should only be one private per availability zone
 	subnetIDs []string
+
+	// Load balancer type of the default ingress
+	defaultIngressLBType string
+
+	// Registries of the image configuration
+	allowedRegistries          []string
+	blockedRegistries          []string
+	insecureRegistries         []string
+	allowedRegistriesForImport []string
+
+	// Encryption of etcd
+	etcdEncryption bool
+	kmsKeyARN      string
+
+	// Time to wait for pods to be drained when removing nodes
+	nodeDrainGracePeriod time.Duration
+
+	// Disable the monitoring of user workloads
+	disableWorkloadMonitoring bool
+
+	// Cross-account provisioning options
+	awsAccountID string
+	assumeRole   string
+
+	// Billing options
+	billingModel   string
+	billingAccount string
+
+	// File containing the cluster options
+	specFile string
+
+	// Files containing the answers of interactive mode
+	answersFile     string
+	saveAnswersFile string
 }
 
 var Cmd = &cobra.Command{
@@ -98,7 +153,13 @@ var Cmd = &cobra.Command{
   rosa create cluster --cluster-name=mycluster
 
   # Create a cluster in the us-east-2 region
-  rosa create cluster --cluster-name=mycluster --region=us-east-2`,
+  rosa create cluster --cluster-name=mycluster --region=us-east-2
+
+  # Create a cluster with an additional machine pool of GPU instances
+  rosa create cluster --cluster-name=mycluster --machine-pool=name=gpu,type=p3.2xlarge,replicas=2
+
+  # Create a cluster using the options from a spec file
+  rosa create cluster --spec-file=cluster.yaml`,
 	Run:              run,
 	PersistentPreRun: v.Validations,
 }
@@ -123,6 +184,13 @@ func init() {
 		"",
 		"Name of the cluster. This will be used when generating a sub-domain for your cluster on openshiftapps.com.",
 	)
+	flags.StringVar(
+		&args.baseDomain,
+		"base-domain",
+		"",
+		"Base DNS domain of the cluster, reserved with 'rosa create dns-domain'. By default a "+
+			"domain is generated for the cluster.",
+	)
 	flags.BoolVar(
 		&args.multiAZ,
 		"multi-az",
@@ -146,11 +214,21 @@ func init() {
 	flags.StringVar(
 		&args.flavour,
 		"flavour",
-		"osd-4",
+		flavours.DefaultFlavour,
 		"Set of predefined properties of a cluster",
 	)
 	flags.MarkHidden("flavour")
 
+	flags.StringVar(
+		&args.flavourID,
+		"flavour-id",
+		"",
+		"Identifier of the flavour, the set of predefined properties like the instance types "+
+			"of the nodes, to use instead of the default one. Some flavours are only available "+
+			"to organizations with the corresponding entitlements. Run 'rosa list flavours' to "+
+			"see the available flavours.",
+	)
+
 	flags.StringVar(
 		&args.expirationTime,
 		"expiration-time",
@@ -185,6 +263,66 @@ func init() {
 			"Leave empty for installer provisioned subnet IDs.",
 	)
 
+	flags.StringVar(
+		&args.awsAccountID,
+		"aws-account-id",
+		"",
+		"Identifier of the AWS account where the cluster will be provisioned, when it is different "+
+			"from the account of the current credentials. Requires '--assume-role'.",
+	)
+	flags.StringVar(
+		&args.assumeRole,
+		"assume-role",
+		"",
+		"ARN of the role to assume in order to provision the cluster in a different AWS account.",
+	)
+
+	flags.StringVar(
+		&args.billingModel,
+		"billing-model",
+		ocm.BillingModelStandard,
+		fmt.Sprintf("Billing model of the cluster, one of '%s'.",
+			strings.Join(ocm.BillingModels, "', '")),
+	)
+	flags.StringVar(
+		&args.billingAccount,
+		"billing-account",
+		"",
+		"Identifier of the AWS account that pays for the cluster when using a marketplace "+
+			"billing model. It must be linked to your organization.",
+	)
+
+	flags.BoolVar(
+		&args.etcdEncryption,
+		"etcd-encryption",
+		false,
+		"Add etcd encryption. By default etcd data is encrypted at rest. "+
+			"This option configures etcd encryption on top of existing storage encryption.",
+	)
+	flags.StringVar(
+		&args.kmsKeyARN,
+		"kms-key-arn",
+		"",
+		"ARN of a customer managed KMS key to use for etcd encryption. Implies '--etcd-encryption'. "+
+			"The key policy must allow the '"+aws.AdminUserName+"' user to use the key.",
+	)
+
+	flags.DurationVar(
+		&args.nodeDrainGracePeriod,
+		"node-drain-grace-period",
+		0,
+		"Time to wait for pods protected by pod disruption budgets to be drained when removing "+
+			"nodes, like 30m or 1h. Must be a whole number of minutes, up to one week.",
+	)
+
+	flags.BoolVar(
+		&args.disableWorkloadMonitoring,
+		"disable-workload-monitoring",
+		false,
+		"Disable the monitoring of user-defined projects, for clusters that run their own "+
+			"monitoring stack. The monitoring of the platform is always enabled.",
+	)
+
 	// Scaling options
 	flags.StringVar(
 		&args.computeMachineType,
@@ -221,6 +359,25 @@ func init() {
 		"Maximum number of compute nodes.",
 	)
 
+	flags.StringVar(
+		&args.defaultMPLabels,
+		"default-mp-labels",
+		"",
+		"Labels for the default machine pool. Format should be a comma-separated list of "+
+			"'key=value'. This list will overwrite any modifications made to node labels on an "+
+			"ongoing basis.",
+	)
+
+	flags.StringArrayVar(
+		&args.machinePools,
+		"machine-pool",
+		nil,
+		"Additional machine pool to create right after the cluster, as a comma separated list "+
+			"of options, for example 'name=gpu,type=p3.2xlarge,replicas=2'. The options are "+
+			"'name', 'type', and either 'replicas' or 'min-replicas' and 'max-replicas' for "+
+			"autoscaling. Can be repeated.",
+	)
+
 	flags.IPNetVar(
 		&args.machineCIDR,
 		"machine-cidr",
@@ -253,6 +410,42 @@ func init() {
 		"Restrict master API endpoint and application routes to direct, private connectivity.",
 	)
 
+	flags.StringVar(
+		&args.defaultIngressLBType,
+		"lb-type",
+		"",
+		"Type of load balancer of the default ingress, either 'classic' or 'nlb'.",
+	)
+
+	flags.StringSliceVar(
+		&args.allowedRegistries,
+		"registry-config-allowed-registries",
+		nil,
+		"Comma-separated list of the only registries that images can be pulled from, like "+
+			"'quay.io,*.example.com'. Can't be used together with "+
+			"'--registry-config-blocked-registries'.",
+	)
+	flags.StringSliceVar(
+		&args.blockedRegistries,
+		"registry-config-blocked-registries",
+		nil,
+		"Comma-separated list of registries that images can't be pulled from.",
+	)
+	flags.StringSliceVar(
+		&args.insecureRegistries,
+		"registry-config-insecure-registries",
+		nil,
+		"Comma-separated list of registries that don't have a valid TLS certificate or only "+
+			"support HTTP.",
+	)
+	flags.StringSliceVar(
+		&args.allowedRegistriesForImport,
+		"registry-config-allowed-registries-for-import",
+		nil,
+		"Comma-separated list of the registries that image streams can import from. Each "+
+			"registry can be followed by ':true' if it is insecure, like 'registry.example.com:true'.",
+	)
+
 	flags.BoolVar(
 		&args.disableSCPChecks,
 		"disable-scp-checks",
@@ -267,6 +460,23 @@ func init() {
 		"Watch cluster installation logs.",
 	)
 
+	flags.BoolVar(
+		&args.cleanupOnFailure,
+		"cleanup-on-failure",
+		false,
+		"Wait for the installation to finish, and delete the cluster if the installation fails "+
+			"or doesn't finish within the time given with '--install-timeout'.",
+	)
+
+	flags.DurationVar(
+		&args.installTimeout,
+		"install-timeout",
+		90*time.Minute,
+		"Maximum time to wait for the installation when using '--cleanup-on-failure'.",
+	)
+
+	notify.AddFlags(flags)
+
 	flags.BoolVar(
 		&args.dryRun,
 		"dry-run",
@@ -282,7 +492,35 @@ func init() {
 	)
 	flags.MarkHidden("fake-cluster")
 
+	flags.StringVar(
+		&args.specFile,
+		"spec-file",
+		"",
+		"YAML or JSON file containing the options of the cluster. Options given in the command "+
+			"line take precedence, with a warning when they differ. Run 'rosa validate spec-file "+
+			"--schema' to get the format.",
+	)
+
 	interactive.AddFlag(flags)
+
+	flags.StringVar(
+		&args.answersFile,
+		"answers",
+		"",
+		"YAML file containing the answers to the questions of interactive mode, as written by "+
+			"'--save-answers'. Only the questions without an answer in the file are asked. "+
+			"Implies interactive mode.",
+	)
+
+	flags.StringVar(
+		&args.saveAnswersFile,
+		"save-answers",
+		"",
+		"Write the answers given in interactive mode to this YAML file, so that they can be "+
+			"used again with '--answers'. Passwords are never written. Implies interactive mode.",
+	)
+
+	output.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -290,13 +528,69 @@ func run(cmd *cobra.Command, _ []string) {
 	logger := logging.CreateLoggerOrExit(reporter)
 	var err error
 
+	err = output.Validate()
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	if args.cleanupOnFailure && args.watch {
+		reporter.Errorf("The '--watch' and '--cleanup-on-failure' flags can't be used together. " +
+			"Run 'rosa logs install --watch' in another terminal to follow the installation")
+		exit.Fail()
+	}
+	if cmd.Flags().Changed("install-timeout") && !args.cleanupOnFailure {
+		reporter.Errorf("The '--install-timeout' flag can only be used with '--cleanup-on-failure'")
+		exit.Fail()
+	}
+	if args.installTimeout <= 0 {
+		reporter.Errorf("Expected a positive install timeout, got %s", args.installTimeout)
+		exit.Fail()
+	}
+
+	// Load the options from the spec file, if any:
+	if args.specFile != "" {
+		manifest, err := spec.Load(args.specFile)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		conflicts, err := manifest.Apply(cmd.Flags())
+		if err != nil {
+			reporter.Errorf("Failed to apply spec file '%s': %v", args.specFile, err)
+			exit.Fail()
+		}
+		for _, conflict := range conflicts {
+			reporter.Warnf("Spec file '%s' conflicts with the command line, the %s", args.specFile,
+				conflict)
+		}
+		if fields := manifest.StateFields(); len(fields) > 0 {
+			reporter.Warnf("Spec fields '%s' are ignored when creating the cluster. Run "+
+				"'rosa apply -f %s' once the cluster is ready to apply them",
+				strings.Join(fields, "', '"), args.specFile)
+		}
+	}
+
+	// Load the answers of a previous interactive session, if any:
+	if args.answersFile != "" {
+		err = interactive.LoadAnswers(args.answersFile)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		interactive.Enable()
+	}
+	if args.saveAnswersFile != "" {
+		interactive.Enable()
+	}
+
 	// Create the client for the OCM API:
 	ocmConnection, err := ocm.NewConnection().
 		Logger(logger).
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -321,14 +615,15 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if interactive.Enabled() {
 		clusterName, err = interactive.GetString(interactive.Input{
-			Question: "Cluster name",
-			Help:     cmd.Flags().Lookup("cluster-name").Usage,
-			Default:  clusterName,
-			Required: true,
+			Question:   "Cluster name",
+			Help:       cmd.Flags().Lookup("cluster-name").Usage,
+			Default:    clusterName,
+			Required:   true,
+			Validators: []interactive.Validator{validation.ClusterName},
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid cluster name: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -336,10 +631,22 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterName = strings.Trim(clusterName, " \t")
 
 	if !clusterprovider.IsValidClusterName(clusterName) {
-		reporter.Errorf("Cluster name must consist" +
-			" of no more than 15 lowercase alphanumeric characters or '-', " +
-			"start with a letter, and end with an alphanumeric character.")
-		os.Exit(1)
+		reporter.Errorf("%s", validation.ClusterName(clusterName))
+		exit.Fail()
+	}
+
+	// Base DNS domain:
+	baseDomain := strings.TrimSuffix(strings.ToLower(args.baseDomain), ".")
+	if baseDomain != "" {
+		if !dnsdomains.IsValidDomain(baseDomain) {
+			reporter.Errorf("Expected a valid base DNS domain, got '%s'", args.baseDomain)
+			exit.Fail()
+		}
+		err = dnsdomains.ValidateBaseDomain(ocmConnection, baseDomain)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
 	}
 
 	// Multi-AZ:
@@ -352,7 +659,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid multi-AZ value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -360,13 +667,13 @@ func run(cmd *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	regionList, regionAZ, err := regions.GetRegionList(ocmClient, multiAZ)
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		exit.Fail()
 	}
 	if interactive.Enabled() {
 		region, err = interactive.GetOption(interactive.Input{
@@ -378,22 +685,22 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid AWS region: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
 	if region == "" {
 		reporter.Errorf("Expected a valid AWS region")
-		os.Exit(1)
+		exit.Fail()
 	} else {
 		if supportsMultiAZ, found := regionAZ[region]; found {
 			if !supportsMultiAZ && multiAZ {
 				reporter.Errorf("Region '%s' does not support multiple availability zones", region)
-				os.Exit(1)
+				exit.Fail()
 			}
 		} else {
 			reporter.Errorf("Region '%s' is not supported for this AWS account", region)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -403,7 +710,7 @@ func run(cmd *cobra.Command, _ []string) {
 	versionList, err := getVersionList(ocmClient, channelGroup)
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if version == "" {
 		version = versionList[0]
@@ -417,22 +724,91 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid OpenShift version: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	version, err = validateVersion(version, versionList)
 	if err != nil {
 		reporter.Errorf("Expected a valid OpenShift version: %s", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	err = validation.AWSAccountID(args.awsAccountID)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+	if args.awsAccountID != "" && args.assumeRole == "" {
+		reporter.Errorf("Option '--assume-role' is required to provision into AWS account '%s'",
+			args.awsAccountID)
+		exit.Fail()
 	}
 
 	awsClient, err := aws.NewClient().
 		Region(region).
 		Logger(logger).
+		AssumeRole(args.assumeRole).
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create awsClient: %s", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// Check that the credentials and the installer role work with the STS endpoint model
+	// selected by the user:
+	if stsendpoint.Endpoint() != "" {
+		reporter.Debugf("Validating '%s' STS endpoint", stsendpoint.Endpoint())
+		err = awsClient.ValidateSTSEndpoint(args.assumeRole)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
+	if args.assumeRole != "" {
+		awsIdentity, err := awsClient.GetCreator()
+		if err != nil {
+			reporter.Errorf("Failed to get AWS identity for role '%s': %v", args.assumeRole, err)
+			exit.Fail()
+		}
+		if args.awsAccountID != "" && args.awsAccountID != awsIdentity.AccountID {
+			reporter.Errorf("Role '%s' belongs to AWS account '%s' but the cluster should be "+
+				"provisioned in AWS account '%s'",
+				args.assumeRole, awsIdentity.AccountID, args.awsAccountID)
+			exit.Fail()
+		}
+		reporter.Infof("Provisioning into AWS account '%s' as '%s'", awsIdentity.AccountID, awsIdentity.ARN)
+		if !confirm.Confirm("create cluster '%s' in AWS account '%s'", clusterName, awsIdentity.AccountID) {
+			os.Exit(0)
+		}
+	}
+
+	// Billing model and account:
+	billingModel := args.billingModel
+	if interactive.Enabled() {
+		billingModel, err = interactive.GetOption(interactive.Input{
+			Question: "Billing model",
+			Help:     cmd.Flags().Lookup("billing-model").Usage,
+			Options:  ocm.BillingModels,
+			Default:  billingModel,
+			Required: true,
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid billing model: %s", err)
+			exit.Fail()
+		}
+	}
+	if !ocm.IsValidBillingModel(billingModel) {
+		reporter.Errorf("Expected a valid billing model, one of '%s'",
+			strings.Join(ocm.BillingModels, "', '"))
+		exit.Fail()
+	}
+	billingAccount := args.billingAccount
+	if ocm.IsMarketplaceBillingModel(billingModel) {
+		billingAccount = getBillingAccount(cmd, reporter, ocmConnection, awsClient, billingAccount)
+	} else if billingAccount != "" {
+		reporter.Errorf("A billing account can only be used with a marketplace billing model")
+		exit.Fail()
 	}
 
 	useExistingVPC := false
@@ -446,10 +822,11 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid private-link value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	} else if privateLink {
-		reporter.Warnf("You are choosing to use AWS PrivateLink for your cluster. %s", privateLinkWarning)
+		reporter.Warn(rprtr.WarnPrivateLink,
+			"You are choosing to use AWS PrivateLink for your cluster. %s", privateLinkWarning)
 		if !confirm.Confirm("use AWS PrivateLink for cluster '%s'", clusterName) {
 			os.Exit(0)
 		}
@@ -476,7 +853,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -485,7 +862,7 @@ func run(cmd *cobra.Command, _ []string) {
 		subnets, err := awsClient.GetSubnetIDs()
 		if err != nil {
 			reporter.Errorf("Failed to get the list of subnets: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		mapSubnetToAZ := make(map[string]string)
@@ -504,7 +881,7 @@ func run(cmd *cobra.Command, _ []string) {
 				}
 				if !verifiedSubnet {
 					reporter.Errorf("Could not find the following subnet provided: %s", subnetArg)
-					os.Exit(1)
+					exit.Fail()
 				}
 			}
 		}
@@ -534,13 +911,37 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				reporter.Errorf("Expected valid subnet IDs: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 			for i, subnet := range subnetIDs {
 				subnetIDs[i] = parseSubnet(subnet)
 			}
 		}
 
+		// Subnets shared from another account through AWS Resource Access Manager are visible
+		// to this account, but the installer can't create the private hosted zone of the
+		// cluster in the VPC of the owner, so the installation would fail:
+		awsCreator, err := awsClient.GetCreator()
+		if err != nil {
+			reporter.Errorf("Failed to get AWS creator: %v", err)
+			exit.Fail()
+		}
+		for _, subnet := range subnets {
+			subnetID := awssdk.StringValue(subnet.SubnetId)
+			owner := awssdk.StringValue(subnet.OwnerId)
+			if owner == "" || owner == awsCreator.AccountID {
+				continue
+			}
+			for _, subnetArg := range subnetIDs {
+				if subnetArg == subnetID {
+					reporter.Errorf("Subnet '%s' belongs to a VPC shared from AWS account '%s'. "+
+						"Installing clusters into shared VPCs isn't supported, use subnets owned "+
+						"by account '%s'", subnetID, owner, awsCreator.AccountID)
+					exit.Fail()
+				}
+			}
+		}
+
 		for _, subnet := range subnetIDs {
 			az := mapSubnetToAZ[subnet]
 			if !mapAZCreated[az] {
@@ -551,12 +952,81 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	reporter.Debugf("Found the following availability zones for the subnets provided: %v", availabilityZones)
 
+	// Etcd encryption:
+	etcdEncryption := args.etcdEncryption || args.kmsKeyARN != ""
+	if interactive.Enabled() {
+		etcdEncryption, err = interactive.GetBool(interactive.Input{
+			Question: "Enable etcd encryption",
+			Help:     cmd.Flags().Lookup("etcd-encryption").Usage,
+			Default:  etcdEncryption,
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid etcd-encryption value: %s", err)
+			exit.Fail()
+		}
+	}
+	// The KMS key is only used to encrypt etcd, so it is dropped when the user doesn't want
+	// encryption:
+	kmsKeyARN := args.kmsKeyARN
+	if !etcdEncryption {
+		kmsKeyARN = ""
+	}
+	if etcdEncryption && interactive.Enabled() {
+		kmsKeyARN, err = interactive.GetString(interactive.Input{
+			Question:   "KMS key ARN",
+			Help:       cmd.Flags().Lookup("kms-key-arn").Usage,
+			Default:    kmsKeyARN,
+			Validators: []interactive.Validator{validation.KMSKeyARN},
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid KMS key ARN: %s", err)
+			exit.Fail()
+		}
+	}
+	if kmsKeyARN != "" {
+		err = validation.KMSKeyARN(kmsKeyARN)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		reporter.Debugf("Validating KMS key '%s'", kmsKeyARN)
+		allowed, err := awsClient.ValidateKMSKey(kmsKeyARN)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		if !allowed {
+			statement, err := aws.KMSKeyInstallerStatement(kmsKeyARN)
+			if err != nil {
+				reporter.Errorf("%s", err)
+				exit.Fail()
+			}
+			reporter.Warn(rprtr.WarnKMSKeyPolicy,
+				"The policy of KMS key '%s' doesn't allow the '%s' user to use the key. "+
+					"The following statement needs to be added to the key policy:\n%s",
+				kmsKeyARN, aws.AdminUserName, statement)
+			if args.dryRun {
+				reporter.Infof("The policy of KMS key '%s' isn't updated in dry run mode", kmsKeyARN)
+			} else {
+				if !confirm.Confirm("add the statement to the policy of KMS key '%s'", kmsKeyARN) {
+					exit.Fail()
+				}
+				err = awsClient.AddKMSKeyInstallerStatement(kmsKeyARN)
+				if err != nil {
+					reporter.Errorf("%s", err)
+					exit.Fail()
+				}
+				reporter.Infof("Updated the policy of KMS key '%s'", kmsKeyARN)
+			}
+		}
+	}
+
 	// Compute node instance type:
 	computeMachineType := args.computeMachineType
 	computeMachineTypeList, err := machines.GetMachineTypeList(ocmClient)
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		exit.Fail()
 	}
 	if interactive.Enabled() {
 		computeMachineType, err = interactive.GetOption(interactive.Input{
@@ -567,13 +1037,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid machine type: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	computeMachineType, err = machines.ValidateMachineType(computeMachineType, computeMachineTypeList)
 	if err != nil {
 		reporter.Errorf("Expected a valid machine type: %s", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
@@ -590,7 +1060,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid value for enable-autoscaling: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -603,7 +1073,7 @@ func run(cmd *cobra.Command, _ []string) {
 		// if the user set compute-nodes and enabled autoscaling
 		if isReplicasSet {
 			reporter.Errorf("Compute-nodes can't be set when autoscaling is enabled")
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if multiAZ {
@@ -616,14 +1086,15 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
-				Question: "Min replicas",
-				Help:     cmd.Flags().Lookup("min-replicas").Usage,
-				Default:  minReplicas,
-				Required: true,
+				Question:   "Min replicas",
+				Help:       cmd.Flags().Lookup("min-replicas").Usage,
+				Default:    minReplicas,
+				Required:   true,
+				Validators: computeNodesValidators(multiAZ),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of min replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		if interactive.Enabled() || !isMaxReplicasSet {
@@ -632,31 +1103,39 @@ func run(cmd *cobra.Command, _ []string) {
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  maxReplicas,
 				Required: true,
+				Validators: append(computeNodesValidators(multiAZ),
+					validation.MinInt(minReplicas)),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of max replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 
 		if multiAZ && minReplicas < 3 {
 			reporter.Errorf("Multi AZ cluster requires at least 3 compute nodes")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if !multiAZ && minReplicas < 2 {
 			reporter.Errorf("Cluster requires at least 2 compute nodes")
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if minReplicas > maxReplicas {
 			reporter.Errorf("max-replicas must be greater or equal to min-replicas")
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if multiAZ && (minReplicas%3 != 0 || maxReplicas%3 != 0) {
 			reporter.Errorf("Multi AZ clusters require that the number of compute nodes be a multiple of 3")
-			os.Exit(1)
+			exit.Fail()
 		}
+
+		machineType := computeMachineType
+		if machineType == "" {
+			machineType = machines.DefaultMachineType
+		}
+		warnAutoscalingQuota(reporter, ocmConnection, awsClient, machineType, multiAZ, 0, maxReplicas)
 	}
 
 	// Compute nodes:
@@ -669,47 +1148,107 @@ func run(cmd *cobra.Command, _ []string) {
 		// if the user set min/max replicas and hasn't enabled autoscaling
 		if isMinReplicasSet || isMaxReplicasSet {
 			reporter.Errorf("Autoscaling must be enabled in order to set min and max replicas")
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if interactive.Enabled() {
 			computeNodes, err = interactive.GetInt(interactive.Input{
-				Question: "Compute nodes",
-				Help:     cmd.Flags().Lookup("compute-nodes").Usage,
-				Default:  computeNodes,
+				Question:   "Compute nodes",
+				Help:       cmd.Flags().Lookup("compute-nodes").Usage,
+				Default:    computeNodes,
+				Validators: computeNodesValidators(multiAZ),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of compute nodes: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		if multiAZ {
 			if computeNodes < 3 {
 				reporter.Errorf("The number of compute nodes needs to be at least 3")
-				os.Exit(1)
+				exit.Fail()
 			}
 			if computeNodes%3 != 0 {
 				reporter.Errorf("Multi AZ clusters require that the number of compute nodes be a multiple of 3")
-				os.Exit(1)
+				exit.Fail()
 			}
 		} else {
 			if computeNodes < 2 {
 				reporter.Errorf("The number of compute nodes needs to be at least 2")
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 	}
 
+	// Labels of the default machine pool:
+	defaultMPLabels := args.defaultMPLabels
+	if interactive.Enabled() {
+		defaultMPLabels, err = interactive.GetString(interactive.Input{
+			Question:   "Default machine pool labels",
+			Help:       cmd.Flags().Lookup("default-mp-labels").Usage,
+			Default:    defaultMPLabels,
+			Validators: []interactive.Validator{validation.Labels},
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
+			exit.Fail()
+		}
+	}
+	computeLabels, err := machines.ParseLabels(defaultMPLabels)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Additional machine pools:
+	var machinePools []*clusterprovider.MachinePoolSpec
+	for _, text := range args.machinePools {
+		machinePool, err := clusterprovider.ParseMachinePoolSpec(text)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		machinePool.InstanceType, err = machines.ValidateMachineType(machinePool.InstanceType,
+			computeMachineTypeList)
+		if err != nil {
+			reporter.Errorf("Expected a valid instance type for machine pool '%s': %s",
+				machinePool.Name, err)
+			exit.Fail()
+		}
+		machinePools = append(machinePools, machinePool)
+	}
+	err = clusterprovider.ValidateMachinePoolSpecs(machinePools, multiAZ)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	// Validate all remaining flags:
 	expiration, err := validateExpiration()
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		exit.Fail()
 	}
 	var dMachinecidr *net.IPNet
 	var dPodcidr *net.IPNet
 	var dServicecidr *net.IPNet
-	dMachinecidr, dPodcidr, dServicecidr, dhostPrefix := ocm.GetDefaultClusterFlavors(ocmClient, args.flavour)
+	flavour := args.flavour
+	if args.flavourID != "" {
+		if cmd.Flags().Changed("flavour") && args.flavour != args.flavourID {
+			reporter.Errorf("Options '--flavour' and '--flavour-id' can't be used together")
+			exit.Fail()
+		}
+		flavour = args.flavourID
+	}
+	if flavour != flavours.DefaultFlavour {
+		reporter.Debugf("Checking that flavour '%s' is available", flavour)
+		_, err = flavours.GetFlavour(ocmClient, flavour)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+	dMachinecidr, dPodcidr, dServicecidr, dhostPrefix := ocm.GetDefaultClusterFlavors(ocmClient, flavour)
 
 	// Machine CIDR:
 	machineCIDR := args.machineCIDR
@@ -724,7 +1263,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -741,7 +1280,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	// Pod CIDR:
@@ -757,7 +1296,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -768,13 +1307,21 @@ func run(cmd *cobra.Command, _ []string) {
 			hostPrefix = dhostPrefix
 		}
 		hostPrefix, err = interactive.GetInt(interactive.Input{
-			Question: "Host prefix",
-			Help:     cmd.Flags().Lookup("host-prefix").Usage,
-			Default:  hostPrefix,
+			Question:   "Host prefix",
+			Help:       cmd.Flags().Lookup("host-prefix").Usage,
+			Default:    hostPrefix,
+			Validators: []interactive.Validator{validation.HostPrefix},
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid host prefix value: %s", err)
-			os.Exit(1)
+			exit.Fail()
+		}
+	}
+	if hostPrefix != 0 {
+		err = validation.HostPrefix(hostPrefix)
+		if err != nil {
+			reporter.Errorf("Expected a valid host prefix value: %s", err)
+			exit.Fail()
 		}
 	}
 
@@ -793,26 +1340,92 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid private value: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		} else if private {
-			reporter.Warnf("You are choosing to make your cluster private. %s", privateWarning)
+			reporter.Warn(rprtr.WarnPrivateCluster,
+				"You are choosing to make your cluster private. %s", privateWarning)
 			if !confirm.Confirm("set cluster '%s' as private", clusterName) {
 				os.Exit(0)
 			}
 		}
 	}
 
+	// Node drain grace period:
+	var nodeDrainGracePeriod *time.Duration
+	if cmd.Flags().Changed("node-drain-grace-period") {
+		err = clusterprovider.ValidateNodeDrainGracePeriod(args.nodeDrainGracePeriod)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		nodeDrainGracePeriod = &args.nodeDrainGracePeriod
+	}
+
+	// User workload monitoring:
+	var disableWorkloadMonitoring *bool
+	if cmd.Flags().Changed("disable-workload-monitoring") || interactive.Enabled() {
+		disable := args.disableWorkloadMonitoring
+		if interactive.Enabled() {
+			disable, err = interactive.GetBool(interactive.Input{
+				Question: "Disable workload monitoring",
+				Help:     cmd.Flags().Lookup("disable-workload-monitoring").Usage,
+				Default:  disable,
+			})
+			if err != nil {
+				reporter.Errorf("Expected a valid disable-workload-monitoring value: %s", err)
+				exit.Fail()
+			}
+		}
+		disableWorkloadMonitoring = &disable
+	}
+
+	// Default ingress load balancer type:
+	lbType := args.defaultIngressLBType
+	if lbType != "" && !ocm.IsValidLoadBalancerType(lbType) {
+		reporter.Errorf("Expected a valid load balancer type, either '%s' or '%s'",
+			ocm.LoadBalancerTypeClassic, ocm.LoadBalancerTypeNLB)
+		exit.Fail()
+	}
+
+	// Registries of the image configuration:
+	if len(args.allowedRegistries) > 0 && len(args.blockedRegistries) > 0 {
+		reporter.Errorf("Allowed registries and blocked registries are mutually exclusive")
+		exit.Fail()
+	}
+	allowedRegistries, err := registries.ParseRegistries(args.allowedRegistries...)
+	if err != nil {
+		reporter.Errorf("Expected valid allowed registries: %s", err)
+		exit.Fail()
+	}
+	blockedRegistries, err := registries.ParseRegistries(args.blockedRegistries...)
+	if err != nil {
+		reporter.Errorf("Expected valid blocked registries: %s", err)
+		exit.Fail()
+	}
+	insecureRegistries, err := registries.ParseRegistries(args.insecureRegistries...)
+	if err != nil {
+		reporter.Errorf("Expected valid insecure registries: %s", err)
+		exit.Fail()
+	}
+	allowedRegistriesForImport, err := registries.ParseImportRegistries(args.allowedRegistriesForImport...)
+	if err != nil {
+		reporter.Errorf("Expected valid allowed registries for import: %s", err)
+		exit.Fail()
+	}
+
 	clusterConfig := clusterprovider.Spec{
 		Name:               clusterName,
+		BaseDomain:         baseDomain,
 		Region:             region,
 		MultiAZ:            multiAZ,
 		Version:            version,
 		ChannelGroup:       channelGroup,
-		Flavour:            args.flavour,
+		Flavour:            flavour,
 		Expiration:         expiration,
 		ComputeMachineType: computeMachineType,
 		ComputeNodes:       computeNodes,
+		ComputeLabels:      computeLabels,
 		Autoscaling:        autoscaling,
 		MinReplicas:        minReplicas,
 		MaxReplicas:        maxReplicas,
@@ -826,6 +1439,24 @@ func run(cmd *cobra.Command, _ []string) {
 		AvailabilityZones:  availabilityZones,
 		SubnetIds:          subnetIDs,
 		PrivateLink:        &privateLink,
+		AssumeRole:         args.assumeRole,
+
+		DefaultIngressLBType: lbType,
+		EtcdEncryption:       etcdEncryption,
+		KMSKeyARN:            kmsKeyARN,
+		NodeDrainGracePeriod: nodeDrainGracePeriod,
+
+		DisableWorkloadMonitoring: disableWorkloadMonitoring,
+
+		AllowedRegistries:          allowedRegistries,
+		BlockedRegistries:          blockedRegistries,
+		InsecureRegistries:         insecureRegistries,
+		AllowedRegistriesForImport: allowedRegistriesForImport,
+
+		BillingModel:   billingModel,
+		BillingAccount: billingAccount,
+
+		MachinePools: machinePools,
 	}
 
 	if args.fakeCluster {
@@ -838,16 +1469,26 @@ func run(cmd *cobra.Command, _ []string) {
 		command := buildCommand(clusterConfig)
 		reporter.Infof("To create this cluster again in the future, you can run:\n   %s", command)
 	}
+	if args.saveAnswersFile != "" {
+		err = interactive.SaveAnswers(args.saveAnswersFile)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		reporter.Infof("Answers saved to '%s', run with '--answers %s' to use them again",
+			args.saveAnswersFile, args.saveAnswersFile)
+	}
 	reporter.Infof("To view a list of clusters and their status, run 'rosa list clusters'")
 
-	_, err = clusterprovider.CreateCluster(ocmClient.Clusters(), clusterConfig)
+	cluster, err := clusterprovider.CreateCluster(ocmConnection, clusterConfig)
 	if err != nil {
 		if args.dryRun {
 			reporter.Errorf("Creating cluster '%s' should fail: %s", clusterName, err)
 		} else {
 			reporter.Errorf("Failed to create cluster: %s", err)
+			notify.Failed(reporter, "create cluster", clusterName, "", err)
 		}
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if args.dryRun {
@@ -857,6 +1498,30 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 
+	if len(machinePools) > 0 {
+		addMachinePools(reporter, ocmClient.Clusters(), cluster, clusterName, machinePools)
+	}
+
+	if args.cleanupOnFailure {
+		waitOrCleanup(reporter, ocmClient.Clusters(), cluster, clusterName)
+	} else if !args.watch {
+		notify.Accepted(reporter, "create cluster", clusterName, cluster.ID(),
+			"Cluster has been created and will start installing now")
+	}
+
+	err = output.WriteFile(output.Marshaller(func(writer io.Writer) error {
+		return cmv1.MarshalCluster(cluster, writer)
+	}))
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.NameOnly() {
+		fmt.Println(cluster.ID())
+		os.Exit(0)
+	}
+
 	reporter.Infof("Cluster '%s' has been created.", clusterName)
 	reporter.Infof(
 		"Once the cluster is installed you will need to add an Identity Provider " +
@@ -864,7 +1529,7 @@ func run(cmd *cobra.Command, _ []string) {
 			"for more information.")
 
 	if args.watch {
-		installLogs.Cmd.Run(installLogs.Cmd, []string{clusterName})
+		watchInstallation(reporter, ocmClient.Clusters(), cluster, clusterName)
 	} else {
 		reporter.Infof(
 			"To determine when your cluster is Ready, run 'rosa describe cluster -c %s'.",
@@ -879,6 +1544,89 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterdescribe.Cmd.Run(clusterdescribe.Cmd, []string{clusterName})
 }
 
+// addMachinePools adds the additional machine pools to the cluster. Failures aren't fatal because
+// the cluster has already been created, instead the user is told how to add the missing pools.
+func addMachinePools(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterName string, machinePools []*clusterprovider.MachinePoolSpec) {
+	failures := clusterprovider.AddMachinePools(clusters, cluster.ID(), machinePools)
+	for _, machinePool := range machinePools {
+		err, failed := failures[machinePool.Name]
+		if !failed {
+			reporter.Infof("Machine pool '%s' has been added to cluster '%s'", machinePool.Name, clusterName)
+			continue
+		}
+		command := fmt.Sprintf("rosa create machinepool --cluster=%s --name=%s --instance-type=%s",
+			clusterName, machinePool.Name, machinePool.InstanceType)
+		if machinePool.Autoscaling() {
+			command += fmt.Sprintf(" --enable-autoscaling --min-replicas=%d --max-replicas=%d",
+				machinePool.MinReplicas, machinePool.MaxReplicas)
+		} else {
+			command += fmt.Sprintf(" --replicas=%d", machinePool.Replicas)
+		}
+		reporter.Warnf("Failed to add machine pool '%s' to cluster '%s': %v. Once the cluster "+
+			"is ready, run '%s' to add it", machinePool.Name, clusterName, err, command)
+	}
+}
+
+// watchInstallation prints the installation logs of the cluster until the installation finishes,
+// and then notifies the webhooks of the result.
+func watchInstallation(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterName string) {
+	// The logs command exits the process when the installation fails:
+	watching := true
+	exit.OnExit(func(_ int, message string) {
+		if watching {
+			notify.Failed(reporter, "create cluster", clusterName, cluster.ID(), errors.New(message))
+		}
+	})
+	installLogs.Cmd.Run(installLogs.Cmd, []string{clusterName})
+	watching = false
+
+	state, err := ocm.GetClusterState(clusters, cluster.ID())
+	switch {
+	case err != nil:
+		notify.Failed(reporter, "create cluster", clusterName, cluster.ID(), err)
+	case state == cmv1.ClusterStateReady:
+		notify.Succeeded(reporter, "create cluster", clusterName, cluster.ID(), "Cluster has been installed")
+	default:
+		notify.Failed(reporter, "create cluster", clusterName, cluster.ID(),
+			fmt.Errorf("Cluster is in state '%s'", state))
+	}
+}
+
+// waitOrCleanup waits for the installation of the cluster to finish, and deletes the cluster if
+// the installation fails or doesn't finish in time, so that creating it can be retried.
+func waitOrCleanup(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterName string) {
+	reporter.Infof("Waiting up to %s for cluster '%s' to be installed. "+
+		"Run 'rosa logs install -c %s --watch' to follow the installation.",
+		args.installTimeout, clusterName, clusterName)
+	state, err := clusterprovider.WaitForInstallation(clusters, cluster.ID(), args.installTimeout)
+	if err == nil && state == cmv1.ClusterStateReady {
+		reporter.Successf("Cluster '%s' has been successfully installed", clusterName)
+		notify.Succeeded(reporter, "create cluster", clusterName, cluster.ID(), "Cluster has been installed")
+		return
+	}
+	if err != nil {
+		reporter.Errorf("Failed to install cluster '%s': %v", clusterName, err)
+	} else {
+		reporter.Errorf("There was an error installing cluster '%s'", clusterName)
+		err = fmt.Errorf("Cluster is in state '%s'", state)
+	}
+	notify.Failed(reporter, "create cluster", clusterName, cluster.ID(), err)
+
+	reporter.Infof("Deleting cluster '%s' because of '--cleanup-on-failure'", clusterName)
+	err = clusterprovider.DeleteClusterByID(clusters, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to delete cluster '%s': %v. Run 'rosa delete cluster -c %s' "+
+			"to delete it", clusterName, err, clusterName)
+		exit.Fail()
+	}
+	reporter.Infof("Cluster '%s' will start uninstalling now. "+
+		"Run 'rosa logs uninstall -c %s --watch' to follow the uninstallation.", clusterName, clusterName)
+	exit.Fail()
+}
+
 // Validate OpenShift versions
 func validateVersion(version string, versionList []string) (string, error) {
 	if version != "" {
@@ -945,6 +1693,82 @@ func validateExpiration() (expiration time.Time, err error) {
 	return
 }
 
+// getBillingAccount returns the AWS account that pays for a cluster billed through a marketplace,
+// checking that it is linked to the organization of the user. In interactive mode the user selects
+// it from the linked accounts, defaulting to the account of the current AWS credentials.
+func getBillingAccount(cmd *cobra.Command, reporter *rprtr.Object, connection *sdk.Connection,
+	awsClient aws.Client, billingAccount string) string {
+	accounts, err := ocm.GetBillingAccounts(connection)
+	if err != nil {
+		reporter.Errorf("Failed to get billing accounts: %v", err)
+		exit.Fail()
+	}
+	if len(accounts) == 0 {
+		reporter.Errorf("There are no AWS billing accounts linked to your organization. Link one " +
+			"by subscribing to Red Hat OpenShift Service on AWS in the AWS marketplace")
+		exit.Fail()
+	}
+	if billingAccount == "" {
+		billingAccount = accounts[0]
+		awsCreator, err := awsClient.GetCreator()
+		if err == nil {
+			for _, account := range accounts {
+				if account == awsCreator.AccountID {
+					billingAccount = account
+				}
+			}
+		}
+		if !interactive.Enabled() {
+			reporter.Infof("Using billing account '%s'", billingAccount)
+		}
+	}
+	if interactive.Enabled() {
+		billingAccount, err = interactive.GetOption(interactive.Input{
+			Question: "Billing account",
+			Help:     cmd.Flags().Lookup("billing-account").Usage,
+			Options:  accounts,
+			Default:  billingAccount,
+			Required: true,
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid billing account: %s", err)
+			exit.Fail()
+		}
+	}
+	for _, account := range accounts {
+		if account == billingAccount {
+			return billingAccount
+		}
+	}
+	reporter.Errorf("Billing account '%s' isn't linked to your organization, valid accounts are: %s",
+		billingAccount, strings.Join(accounts, ", "))
+	exit.Fail()
+	return ""
+}
+
+// computeNodesValidators returns the validators of the number of compute nodes, which depend on
+// the cluster being deployed to multiple availability zones.
+// warnAutoscalingQuota warns if the quotas don't allow the autoscaler to reach the maximum number
+// of replicas, so that users don't find out when scaling up fails.
+func warnAutoscalingQuota(reporter *rprtr.Object, connection *sdk.Connection, awsClient aws.Client,
+	machineType string, multiAZ bool, current int, maxReplicas int) {
+	warnings, err := machines.CheckAutoscalingQuota(connection, awsClient, machineType, multiAZ,
+		current, maxReplicas)
+	for _, warning := range warnings {
+		reporter.Warnf("%s", warning)
+	}
+	if err != nil {
+		reporter.Debugf("Failed to check quota for autoscaling: %v", err)
+	}
+}
+
+func computeNodesValidators(multiAZ bool) []interactive.Validator {
+	if multiAZ {
+		return []interactive.Validator{validation.MinInt(3), validation.MultipleOf(3)}
+	}
+	return []interactive.Validator{validation.MinInt(2)}
+}
+
 // parseRFC3339 parses an RFC3339 date in either RFC3339Nano or RFC3339 format.
 func parseRFC3339(s string) (time.Time, error) {
 	if t, timeErr := time.Parse(time.RFC3339Nano, s); timeErr == nil {
@@ -968,6 +1792,9 @@ func parseSubnet(subnetOption string) string {
 func buildCommand(spec clusterprovider.Spec) string {
 	command := "rosa create cluster"
 	command += fmt.Sprintf(" --cluster-name %s", spec.Name)
+	if spec.BaseDomain != "" {
+		command += fmt.Sprintf(" --base-domain %s", spec.BaseDomain)
+	}
 	if spec.MultiAZ {
 		command += " --multi-az"
 	}
@@ -984,6 +1811,10 @@ func buildCommand(spec clusterprovider.Spec) string {
 		command += fmt.Sprintf(" --version %s", strings.TrimPrefix(spec.Version, "openshift-v"))
 	}
 
+	if spec.Flavour != "" && spec.Flavour != flavours.DefaultFlavour {
+		command += fmt.Sprintf(" --flavour-id %s", spec.Flavour)
+	}
+
 	// Only account for expiration duration, as a fixed date may be obsolete if command is re-run later
 	if args.expirationDuration != 0 {
 		command += fmt.Sprintf(" --expiration %s", args.expirationDuration)
@@ -1005,6 +1836,9 @@ func buildCommand(spec clusterprovider.Spec) string {
 	if spec.ComputeMachineType != "" {
 		command += fmt.Sprintf(" --compute-machine-type %s", spec.ComputeMachineType)
 	}
+	if len(spec.ComputeLabels) > 0 {
+		command += fmt.Sprintf(" --default-mp-labels %s", machines.FormatLabels(spec.ComputeLabels))
+	}
 
 	if !clusterprovider.IsEmptyCIDR(spec.MachineCIDR) {
 		command += fmt.Sprintf(" --machine-cidr %s", spec.MachineCIDR.String())
@@ -1021,8 +1855,57 @@ func buildCommand(spec clusterprovider.Spec) string {
 	if spec.Private != nil && *spec.Private {
 		command += " --private"
 	}
+	if spec.DefaultIngressLBType != "" {
+		command += fmt.Sprintf(" --lb-type %s", spec.DefaultIngressLBType)
+	}
+	if spec.BillingModel != "" && spec.BillingModel != ocm.BillingModelStandard {
+		command += fmt.Sprintf(" --billing-model %s", spec.BillingModel)
+	}
+	if spec.BillingAccount != "" {
+		command += fmt.Sprintf(" --billing-account %s", spec.BillingAccount)
+	}
 	if len(spec.SubnetIds) > 0 {
 		command += fmt.Sprintf(" --subnet-ids %s", strings.Join(spec.SubnetIds, ","))
 	}
+	if spec.NodeDrainGracePeriod != nil {
+		command += fmt.Sprintf(" --node-drain-grace-period %s", *spec.NodeDrainGracePeriod)
+	}
+	if spec.DisableWorkloadMonitoring != nil && *spec.DisableWorkloadMonitoring {
+		command += " --disable-workload-monitoring"
+	}
+	if len(spec.AllowedRegistries) > 0 {
+		command += fmt.Sprintf(" --registry-config-allowed-registries %s",
+			strings.Join(spec.AllowedRegistries, ","))
+	}
+	if len(spec.BlockedRegistries) > 0 {
+		command += fmt.Sprintf(" --registry-config-blocked-registries %s",
+			strings.Join(spec.BlockedRegistries, ","))
+	}
+	if len(spec.InsecureRegistries) > 0 {
+		command += fmt.Sprintf(" --registry-config-insecure-registries %s",
+			strings.Join(spec.InsecureRegistries, ","))
+	}
+	if len(spec.AllowedRegistriesForImport) > 0 {
+		items := make([]string, len(spec.AllowedRegistriesForImport))
+		for i, registry := range spec.AllowedRegistriesForImport {
+			items[i] = fmt.Sprintf("%s:%t", registry.DomainName, registry.Insecure)
+		}
+		command += fmt.Sprintf(" --registry-config-allowed-registries-for-import %s",
+			strings.Join(items, ","))
+	}
+	for _, machinePool := range spec.MachinePools {
+		command += fmt.Sprintf(" --machine-pool %s", machinePool)
+	}
+	if spec.KMSKeyARN != "" {
+		command += fmt.Sprintf(" --kms-key-arn %s", spec.KMSKeyARN)
+	} else if spec.EtcdEncryption {
+		command += " --etcd-encryption"
+	}
+	if spec.AssumeRole != "" {
+		if args.awsAccountID != "" {
+			command += fmt.Sprintf(" --aws-account-id %s", args.awsAccountID)
+		}
+		command += fmt.Sprintf(" --assume-role %s", spec.AssumeRole)
+	}
 	return command
 }
@@ -21,9 +21,14 @@ import (
 
 	"github.com/openshift/rosa/cmd/create/admin"
 	"github.com/openshift/rosa/cmd/create/cluster"
+	"github.com/openshift/rosa/cmd/create/dnsdomain"
 	"github.com/openshift/rosa/cmd/create/idp"
 	"github.com/openshift/rosa/cmd/create/ingress"
+	"github.com/openshift/rosa/cmd/create/kubeletconfig"
+	"github.com/openshift/rosa/cmd/create/label"
 	"github.com/openshift/rosa/cmd/create/machinepool"
+	"github.com/openshift/rosa/cmd/create/schedule"
+	"github.com/openshift/rosa/cmd/create/upgrade"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/confirm"
 )
@@ -38,11 +43,17 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(admin.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(kubeletconfig.Cmd)
+	Cmd.AddCommand(label.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(schedule.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
+	arguments.AddSTSRegionEndpointFlag(flags)
 	confirm.AddFlag(flags)
 }
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/dnsdomains"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domain",
+	Aliases: []string{"dnsdomain"},
+	Short:   "Reserve a base DNS domain",
+	Long: "Reserve a new base DNS domain for your organization. The name of the domain is " +
+		"generated by the service, and it can be used as the base domain of a new cluster with " +
+		"'rosa create cluster --base-domain'.",
+	Example: `  # Reserve a base DNS domain
+  rosa create dns-domain`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd.Flags(), output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Reserving DNS domain")
+	domain, err := dnsdomains.CreateDNSDomain(ocmConnection)
+	if err != nil {
+		reporter.Errorf("Failed to reserve DNS domain: %v", err)
+		exit.Fail()
+	}
+
+	err = output.WriteFile(domain)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(domain)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+	if output.NameOnly() {
+		fmt.Println(domain.ID)
+		return
+	}
+
+	reporter.Successf("Reserved DNS domain '%s'", domain.ID)
+	reporter.Infof("To use it for a new cluster, run 'rosa create cluster --base-domain=%s'", domain.ID)
+}
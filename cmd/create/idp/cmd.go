@@ -18,7 +18,6 @@ package idp
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -28,9 +27,12 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ifexists"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	"github.com/openshift/rosa/pkg/reporter"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
@@ -61,6 +63,9 @@ var args struct {
 	// Google
 	googleHostedDomain string
 
+	// HTPasswd
+	htpasswdFile string
+
 	// LDAP
 	ldapURL          string
 	ldapInsecure     bool
@@ -79,7 +84,7 @@ var args struct {
 	openidScopes    string
 }
 
-var validIdps []string = []string{"github", "gitlab", "google", "ldap", "openid"}
+var validIdps []string = []string{"github", "gitlab", "google", "htpasswd", "ldap", "openid"}
 var validMappingMethods []string = []string{"add", "claim", "generate", "lookup"}
 
 var idRE = regexp.MustCompile(`(?i)^[0-9a-z]+([-_][0-9a-z]+)*$`)
@@ -91,6 +96,13 @@ var Cmd = &cobra.Command{
 	Example: `  # Add a GitHub identity provider to a cluster named "mycluster"
   rosa create idp --type=github --cluster=mycluster
 
+  # Add an HTPasswd identity provider with the users of an existing htpasswd file
+  rosa create idp --type=htpasswd --from-file=users.htpasswd --cluster=mycluster
+
+  # Add a GitHub identity provider, or update it if it already exists
+  rosa create idp --type=github --name=github-1 --client-id=... --client-secret=... \
+	--organizations=myorg --cluster=mycluster --update-if-exists
+
   # Add an identity provider following interactive prompts
   rosa create idp --cluster=mycluster --interactive`,
 	Run: run,
@@ -107,7 +119,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to add the IdP to (required).",
+		"Name, ID or external ID of the cluster to add the IdP to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -187,6 +199,14 @@ func init() {
 		"Google: Restrict users to a Google Apps domain.\n",
 	)
 
+	// HTPasswd
+	flags.StringVar(
+		&args.htpasswdFile,
+		"from-file",
+		"",
+		"HTPasswd: Path to an htpasswd file with the users to add. Passwords must be hashed with bcrypt or SHA.\n",
+	)
+
 	// LDAP
 	flags.StringVar(
 		&args.ldapURL,
@@ -270,6 +290,7 @@ func init() {
 		"OpenID: List of scopes to request, in addition to the 'openid' scope, during the authorization token request.\n",
 	)
 
+	ifexists.AddFlags(flags)
 	interactive.AddFlag(flags)
 }
 
@@ -286,14 +307,24 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	err := ifexists.Validate()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if ifexists.Enabled() && !cmd.Flags().Changed("name") && !interactive.Enabled() {
+		reporter.Errorf("Option '--name' is required with '--if-not-exists' and '--update-if-exists'")
+		exit.Fail()
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -303,13 +334,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -318,7 +349,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -332,15 +363,15 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if interactive.Enabled() {
@@ -362,12 +393,12 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid IdP type: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if idpType == "" {
 		reporter.Errorf("Expected a valid IDP type. Options are: %s", strings.Join(validIdps, ","))
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if idpType != "" {
@@ -379,7 +410,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		if !isValidIdp {
 			reporter.Errorf("Expected a valid IDP type. Options are %s", validIdps)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -393,7 +424,7 @@ func run(cmd *cobra.Command, _ []string) {
 		isValidIdpName := idRE.MatchString(idpName)
 		if !isValidIdpName {
 			reporter.Errorf("Invalid identifier '%s' for 'name'", idpName)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if interactive.Enabled() {
@@ -405,12 +436,23 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid name for the identity provider: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	idpName = strings.Trim(idpName, " \t")
 
+	// Check if an identity provider with the same name already exists:
+	var existing *cmv1.IdentityProvider
+	if ifexists.Enabled() {
+		existing = findIdp(reporter, clustersCollection, cluster, idpName)
+		if existing != nil && ifexists.Skip() {
+			reporter.Infof("Identity provider '%s' already exists on cluster '%s'", idpName, clusterKey)
+			return
+		}
+	}
+
 	var idpBuilder cmv1.IdentityProviderBuilder
+	var idpAttributes map[string]interface{}
 	switch idpType {
 	case "github":
 		idpBuilder, err = buildGithubIdp(cmd, cluster, idpName)
@@ -418,6 +460,8 @@ func run(cmd *cobra.Command, _ []string) {
 		idpBuilder, err = buildGitlabIdp(cmd, cluster, idpName)
 	case "google":
 		idpBuilder, err = buildGoogleIdp(cmd, cluster, idpName)
+	case "htpasswd":
+		idpBuilder, idpAttributes, err = buildHTPasswdIdp(cmd, cluster, idpName)
 	case "ldap":
 		idpBuilder, err = buildLdapIdp(cmd, cluster, idpName)
 	case "openid":
@@ -425,7 +469,7 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if err != nil {
 		reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Infof("Configuring IDP for cluster '%s'", clusterKey)
@@ -433,18 +477,31 @@ func run(cmd *cobra.Command, _ []string) {
 	idp, err := idpBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
-	res, err := clustersCollection.Cluster(cluster.ID()).
-		IdentityProviders().
-		Add().
-		Body(idp).
-		Send()
-	if err != nil {
-		reporter.Debugf(err.Error())
-		reporter.Errorf("Failed to add IDP to cluster '%s': %s", clusterKey, res.Error().Reason())
-		os.Exit(1)
+	if existing != nil {
+		updateIdp(reporter, ocmConnection, cluster, existing, idp)
+		return
+	}
+
+	if idpAttributes != nil {
+		_, err = ocm.AddIdentityProvider(ocmConnection, cluster.ID(), idp, idpAttributes)
+		if err != nil {
+			reporter.Errorf("Failed to add IDP to cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+	} else {
+		res, err := clustersCollection.Cluster(cluster.ID()).
+			IdentityProviders().
+			Add().
+			Body(idp).
+			Send()
+		if err != nil {
+			reporter.Debugf(err.Error())
+			reporter.Errorf("Failed to add IDP to cluster '%s': %s", clusterKey, ocm.ErrorReason(res.Error()))
+			exit.Fail()
+		}
 	}
 
 	reporter.Infof(
@@ -502,6 +559,24 @@ func getMappingMethod(cmd *cobra.Command, mappingMethod string) (string, error)
 	return mappingMethod, err
 }
 
+// findIdp returns the identity provider of the cluster with the given name, or nil if it doesn't
+// exist.
+func findIdp(reporter *reporter.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	name string) *cmv1.IdentityProvider {
+	reporter.Debugf("Loading identity providers for cluster '%s'", cluster.ID())
+	ocmIdps, err := ocm.GetIdentityProviders(clusters, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", cluster.ID(), err)
+		exit.Fail()
+	}
+	for _, idp := range ocmIdps {
+		if idp.Name() == name {
+			return idp
+		}
+	}
+	return nil
+}
+
 func getIdps(reporter *reporter.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster) []IdentityProvider {
 	// Load any existing IDPs for this cluster
 	reporter.Debugf("Loading identity providers for cluster '%s'", cluster.ID())
@@ -509,7 +584,7 @@ func getIdps(reporter *reporter.Object, clusters *cmv1.ClustersClient, cluster *
 	ocmIdps, err := ocm.GetIdentityProviders(clusters, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	idps := []IdentityProvider{}
 	for _, idp := range ocmIdps {
@@ -0,0 +1,183 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+// bcryptRE matches the modular crypt format used by htpasswd for bcrypt hashes, for example
+// '$2y$05$' followed by the 22 characters of the salt and the 31 characters of the hash.
+var bcryptRE = regexp.MustCompile(`^\$2[aby]\$([0-9]{2})\$[./A-Za-z0-9]{53}$`)
+
+const shaPrefix = "{SHA}"
+
+type htpasswdUser struct {
+	username       string
+	hashedPassword string
+}
+
+func buildHTPasswdIdp(cmd *cobra.Command,
+	_ *cmv1.Cluster,
+	idpName string) (idpBuilder cmv1.IdentityProviderBuilder, attributes map[string]interface{}, err error) {
+	fromFile := args.htpasswdFile
+
+	if interactive.Enabled() || fromFile == "" {
+		fromFile, err = interactive.GetString(interactive.Input{
+			Question: "HTPasswd file",
+			Help:     cmd.Flags().Lookup("from-file").Usage,
+			Default:  fromFile,
+			Required: true,
+		})
+		if err != nil {
+			return idpBuilder, nil, errors.New("Expected a valid path to an htpasswd file")
+		}
+	}
+
+	users, err := parseHTPasswdFile(fromFile)
+	if err != nil {
+		return idpBuilder, nil, err
+	}
+
+	mappingMethod, err := getMappingMethod(cmd, args.mappingMethod)
+	if err != nil {
+		return idpBuilder, nil, err
+	}
+
+	// The version of the SDK used by the tool only supports a single user with a clear text
+	// password, so the list of users is added to the body of the request as raw attributes:
+	items := make([]interface{}, len(users))
+	for i, user := range users {
+		items[i] = map[string]interface{}{
+			"username":        user.username,
+			"hashed_password": user.hashedPassword,
+		}
+	}
+	attributes = map[string]interface{}{
+		"htpasswd": map[string]interface{}{
+			"users": map[string]interface{}{
+				"items": items,
+			},
+		},
+	}
+
+	// Create new IDP with HTPasswd provider
+	idpBuilder.
+		Type("HTPasswdIdentityProvider"). // FIXME: ocm-api-model has the wrong enum values
+		Name(idpName).
+		MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod)).
+		Htpasswd(cmv1.NewHTPasswdIdentityProvider())
+
+	return
+}
+
+// parseHTPasswdFile reads the users from the given htpasswd file. Only bcrypt and SHA hashes are
+// accepted, as the other formats supported by the htpasswd tool are not supported by the cluster.
+func parseHTPasswdFile(path string) ([]*htpasswdUser, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open htpasswd file: %v", err)
+	}
+	defer file.Close()
+
+	var users []*htpasswdUser
+	seen := map[string]int{}
+	scanner := bufio.NewScanner(file)
+	number := 0
+	for scanner.Scan() {
+		number++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		user, err := parseHTPasswdLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid entry in line %d of htpasswd file '%s': %v", number, path, err)
+		}
+		if previous, ok := seen[user.username]; ok {
+			return nil, fmt.Errorf("User '%s' in line %d of htpasswd file '%s' is already defined in line %d",
+				user.username, number, path, previous)
+		}
+		seen[user.username] = number
+		users = append(users, user)
+	}
+	err = scanner.Err()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read htpasswd file: %v", err)
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("Htpasswd file '%s' doesn't contain any user", path)
+	}
+	return users, nil
+}
+
+func parseHTPasswdLine(line string) (*htpasswdUser, error) {
+	parts := strings.SplitN(line, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("expected 'username:hash'")
+	}
+	username := parts[0]
+	hash := parts[1]
+	if username == "" {
+		return nil, errors.New("username is empty")
+	}
+	if strings.ContainsAny(username, " \t/%") || username == "." || username == ".." {
+		return nil, fmt.Errorf("username '%s' isn't valid: it must not contain spaces, '/' or '%%'", username)
+	}
+	err := validateHTPasswdHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("password of user '%s' %v", username, err)
+	}
+	return &htpasswdUser{
+		username:       username,
+		hashedPassword: hash,
+	}, nil
+}
+
+func validateHTPasswdHash(hash string) error {
+	if strings.HasPrefix(hash, shaPrefix) {
+		digest, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(hash, shaPrefix))
+		if err != nil || len(digest) != 20 {
+			return errors.New("isn't a valid SHA hash")
+		}
+		return nil
+	}
+	if strings.HasPrefix(hash, "$2") {
+		matches := bcryptRE.FindStringSubmatch(hash)
+		if matches == nil {
+			return errors.New("isn't a valid bcrypt hash")
+		}
+		cost, _ := strconv.Atoi(matches[1])
+		if cost < 4 || cost > 31 {
+			return fmt.Errorf("has an invalid bcrypt cost %d", cost)
+		}
+		return nil
+	}
+	return errors.New("must be hashed with bcrypt ('htpasswd -B') or SHA ('htpasswd -s')")
+}
@@ -0,0 +1,131 @@
+package idp
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const (
+	bcryptHash = "$2y$05$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu"
+	shaHash    = "{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g="
+)
+
+var _ = Describe("HTPasswd", func() {
+	Context("validateHTPasswdHash", func() {
+		valid := map[string]string{
+			"bcrypt with the '2y' prefix": bcryptHash,
+			"bcrypt with the '2a' prefix": "$2a$05$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu",
+			"bcrypt with the '2b' prefix": "$2b$10$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu",
+			"SHA":                         shaHash,
+		}
+		for name, hash := range valid {
+			name, hash := name, hash
+			It("accepts "+name, func() {
+				Expect(validateHTPasswdHash(hash)).To(Succeed())
+			})
+		}
+
+		invalid := map[string]string{
+			"bcrypt with a short hash":     "$2y$05$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEy",
+			"bcrypt with invalid chars":    "$2y$05$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIE!u",
+			"bcrypt with an unknown minor": "$2x$05$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu",
+			"bcrypt with a low cost":       "$2y$03$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu",
+			"bcrypt with a high cost":      "$2y$32$6Ke0KhNDbd/.S5j.5j1FWu0SmBkr8MBG6uxsPsf4QO5wEBadIEyCu",
+			"SHA that isn't base64":        "{SHA}not base64",
+			"SHA with a short digest":      "{SHA}W6ph5Mm5Pz8GgiULbPgz",
+			"MD5":                          "$apr1$r31.....$HqJZimcKQFAMYayBlzkrA/",
+			"crypt":                        "rqXexS6ZhobKA",
+			"clear text":                   "password",
+			"empty":                        "",
+		}
+		for name, hash := range invalid {
+			name, hash := name, hash
+			It("rejects "+name, func() {
+				Expect(validateHTPasswdHash(hash)).ToNot(Succeed())
+			})
+		}
+	})
+
+	Context("parseHTPasswdLine", func() {
+		It("returns the user and the hash", func() {
+			user, err := parseHTPasswdLine("alice:" + bcryptHash)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(user.username).To(Equal("alice"))
+			Expect(user.hashedPassword).To(Equal(bcryptHash))
+		})
+
+		invalid := map[string]string{
+			"a line without separator": "alice",
+			"an empty user name":       ":" + shaHash,
+			"a user name with spaces":  "alice smith:" + shaHash,
+			"a user name with a slash": "alice/smith:" + shaHash,
+			"a user name with percent": "alice%:" + shaHash,
+			"the '..' user name":       "..:" + shaHash,
+			"an invalid hash":          "alice:password",
+		}
+		for name, line := range invalid {
+			name, line := name, line
+			It("rejects "+name, func() {
+				_, err := parseHTPasswdLine(line)
+				Expect(err).To(HaveOccurred())
+			})
+		}
+	})
+
+	Context("parseHTPasswdFile", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "htpasswd")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		write := func(content string) string {
+			path := filepath.Join(dir, "htpasswd")
+			Expect(ioutil.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		It("skips comments and empty lines", func() {
+			path := write("# Users of the cluster\n\nalice:" + bcryptHash + "\n  # bob:" +
+				shaHash + "\n\ncarol:" + shaHash + "\n")
+			users, err := parseHTPasswdFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(users).To(HaveLen(2))
+			Expect(users[0].username).To(Equal("alice"))
+			Expect(users[1].username).To(Equal("carol"))
+		})
+
+		It("rejects duplicated users", func() {
+			path := write("alice:" + bcryptHash + "\nbob:" + shaHash + "\nalice:" + shaHash + "\n")
+			_, err := parseHTPasswdFile(path)
+			Expect(err).To(MatchError(ContainSubstring("line 3")))
+			Expect(err).To(MatchError(ContainSubstring("already defined in line 1")))
+		})
+
+		It("reports the line of invalid entries", func() {
+			path := write("# Comment\nalice:" + bcryptHash + "\nbob:password\n")
+			_, err := parseHTPasswdFile(path)
+			Expect(err).To(MatchError(ContainSubstring("line 3")))
+		})
+
+		It("rejects files without users", func() {
+			path := write("# Nothing here\n\n")
+			_, err := parseHTPasswdFile(path)
+			Expect(err).To(MatchError(ContainSubstring("doesn't contain any user")))
+		})
+
+		It("rejects files that don't exist", func() {
+			_, err := parseHTPasswdFile(filepath.Join(dir, "missing"))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
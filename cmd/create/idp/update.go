@@ -0,0 +1,163 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm/idps"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// updateIdp converges the existing identity provider to the configuration requested by the user.
+// Only the mutable fields can be changed, the rest must match or the identity provider has to be
+// deleted and created again.
+func updateIdp(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster,
+	current, desired *cmv1.IdentityProvider) {
+	clusterKey := args.clusterKey
+	if current.Type() != desired.Type() {
+		reporter.Errorf("Identity provider '%s' is of type '%s' instead of '%s'", current.Name(),
+			current.Type(), desired.Type())
+		exit.Fail()
+	}
+	if desired.Type() == "HTPasswdIdentityProvider" {
+		reporter.Errorf("HTPasswd identity providers can't be updated")
+		exit.Fail()
+	}
+
+	conflicts := immutableChanges(current, desired)
+	if len(conflicts) > 0 {
+		reporter.Errorf("Identity provider '%s' can't be updated because the %s would change. "+
+			"To change them, delete the identity provider and create it again",
+			current.Name(), strings.Join(conflicts, ", "))
+		exit.Fail()
+	}
+
+	raw, err := idps.GetIdentityProvider(connection, cluster.ID(), current.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get identity provider '%s' of cluster '%s': %v",
+			current.Name(), clusterKey, err)
+		exit.Fail()
+	}
+	plan, err := idps.NewPlan(raw, mutableChanges(desired))
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if plan.Empty() {
+		reporter.Infof("Identity provider '%s' on cluster '%s' is up to date", current.Name(), clusterKey)
+		return
+	}
+	for _, change := range plan.Changes {
+		reporter.Debugf("Changing %s of identity provider '%s' from '%s' to '%s'", change.Field,
+			current.Name(), change.Old, change.New)
+	}
+	err = plan.Apply(connection, cluster.ID(), current.ID())
+	if err != nil {
+		reporter.Errorf("Failed to update identity provider '%s' on cluster '%s': %v",
+			current.Name(), clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Infof("Identity provider '%s' has been updated on cluster '%s'", current.Name(), clusterKey)
+}
+
+// mutableChanges returns the values of the mutable fields of the requested identity provider.
+// Secrets can't be read back, so they are always sent.
+func mutableChanges(desired *cmv1.IdentityProvider) *idps.Changes {
+	mappingMethod := string(desired.MappingMethod())
+	changes := &idps.Changes{
+		MappingMethod: &mappingMethod,
+	}
+	var clientSecret, ca string
+	switch desired.Type() {
+	case "GithubIdentityProvider":
+		clientSecret = desired.Github().ClientSecret()
+		ca = desired.Github().CA()
+		changes.CA = &ca
+	case "GitlabIdentityProvider":
+		clientSecret = desired.Gitlab().ClientSecret()
+		ca = desired.Gitlab().CA()
+		changes.CA = &ca
+	case "GoogleIdentityProvider":
+		clientSecret = desired.Google().ClientSecret()
+	case "LDAPIdentityProvider":
+		ca = desired.LDAP().CA()
+		changes.CA = &ca
+		attributes := desired.LDAP().Attributes()
+		changes.EmailClaims = stringsOrEmpty(attributes.Email())
+		changes.NameClaims = stringsOrEmpty(attributes.Name())
+		changes.UsernameClaims = stringsOrEmpty(attributes.PreferredUsername())
+	case "OpenIDIdentityProvider":
+		clientSecret = desired.OpenID().ClientSecret()
+		ca = desired.OpenID().CA()
+		changes.CA = &ca
+		claims := desired.OpenID().Claims()
+		changes.EmailClaims = stringsOrEmpty(claims.Email())
+		changes.NameClaims = stringsOrEmpty(claims.Name())
+		changes.UsernameClaims = stringsOrEmpty(claims.PreferredUsername())
+	}
+	if clientSecret != "" {
+		changes.ClientSecret = &clientSecret
+	}
+	return changes
+}
+
+// immutableChanges returns the descriptions of the fields that can't be changed and that are
+// different in the requested identity provider.
+func immutableChanges(current, desired *cmv1.IdentityProvider) []string {
+	var fields []string
+	check := func(field string, old, new interface{}) {
+		if fmt.Sprint(old) != fmt.Sprint(new) {
+			fields = append(fields, field)
+		}
+	}
+	switch desired.Type() {
+	case "GithubIdentityProvider":
+		check("client ID", current.Github().ClientID(), desired.Github().ClientID())
+		check("hostname", current.Github().Hostname(), desired.Github().Hostname())
+		check("organizations", current.Github().Organizations(), desired.Github().Organizations())
+		check("teams", current.Github().Teams(), desired.Github().Teams())
+	case "GitlabIdentityProvider":
+		check("client ID", current.Gitlab().ClientID(), desired.Gitlab().ClientID())
+		check("host URL", current.Gitlab().URL(), desired.Gitlab().URL())
+	case "GoogleIdentityProvider":
+		check("client ID", current.Google().ClientID(), desired.Google().ClientID())
+		check("hosted domain", current.Google().HostedDomain(), desired.Google().HostedDomain())
+	case "LDAPIdentityProvider":
+		check("URL", current.LDAP().URL(), desired.LDAP().URL())
+		check("insecure setting", current.LDAP().Insecure(), desired.LDAP().Insecure())
+		check("bind DN", current.LDAP().BindDN(), desired.LDAP().BindDN())
+		check("ID attributes", current.LDAP().Attributes().ID(), desired.LDAP().Attributes().ID())
+	case "OpenIDIdentityProvider":
+		check("client ID", current.OpenID().ClientID(), desired.OpenID().ClientID())
+		check("issuer URL", current.OpenID().Issuer(), desired.OpenID().Issuer())
+		check("extra scopes", current.OpenID().ExtraScopes(), desired.OpenID().ExtraScopes())
+	}
+	return fields
+}
+
+func stringsOrEmpty(values []string) *[]string {
+	if values == nil {
+		values = []string{}
+	}
+	return &values
+}
@@ -18,16 +18,19 @@ package ingress
 
 import (
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -61,7 +64,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to add the ingress to (required).",
+		"Name, ID or external ID of the cluster to add the ingress to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -81,12 +84,18 @@ func init() {
 	)
 
 	interactive.AddFlag(flags)
+	output.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 	logger := logging.CreateLoggerOrExit(reporter)
 
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
 	clusterKey := args.clusterKey
@@ -96,7 +105,7 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	labelMatch := args.labelMatch
@@ -110,14 +119,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if labelMatch != "" {
 		routeSelectors, err = getRouteSelector(labelMatch)
 		if err != nil {
 			reporter.Errorf("%s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -127,13 +136,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -142,7 +151,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -156,20 +165,20 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.AWS().PrivateLink() {
 		reporter.Errorf("Cluster '%s' is PrivateLink and does not support creating new ingresses", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	ingressBuilder := cmv1.NewIngress()
@@ -188,7 +197,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		if private {
 			ingressBuilder = ingressBuilder.Listening(cmv1.ListeningMethodInternal)
@@ -203,7 +212,7 @@ func run(cmd *cobra.Command, _ []string) {
 	ingress, err := ingressBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to create ingress for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	res, err := clustersCollection.Cluster(cluster.ID()).
@@ -213,8 +222,21 @@ func run(cmd *cobra.Command, _ []string) {
 		Send()
 	if err != nil {
 		reporter.Debugf(err.Error())
-		reporter.Errorf("Failed to add ingress to cluster '%s': %s", clusterKey, res.Error().Reason())
-		os.Exit(1)
+		reporter.Errorf("Failed to add ingress to cluster '%s': %s", clusterKey, ocm.ErrorReason(res.Error()))
+		exit.Fail()
+	}
+
+	err = output.WriteFile(output.Marshaller(func(writer io.Writer) error {
+		return cmv1.MarshalIngress(res.Body(), writer)
+	}))
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.NameOnly() {
+		fmt.Println(res.Body().ID())
+		return
 	}
 
 	reporter.Infof("Ingress has been created on cluster '%s'.", clusterKey)
@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeletconfig
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/kubeletconfigs"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey   string
+	name         string
+	podPidsLimit int
+	maxPods      int
+}
+
+var Cmd = &cobra.Command{
+	Use:     "kubeletconfig",
+	Aliases: []string{"kubeletconfigs", "kubelet-config", "kubelet-configs"},
+	Short:   "Add a kubelet configuration to a cluster",
+	Long: "Add a kubelet configuration to a cluster. Kubelet configurations are applied to the " +
+		"nodes of the machine pools that reference them with the '--kubelet-configs' flag.",
+	Example: `  # Add a kubelet configuration that raises the PIDs limit of pods
+  rosa create kubeletconfig --cluster=mycluster --name=high-pids --pod-pids-limit=8192
+
+  # Use it in a new machine pool
+  rosa create machinepool --cluster=mycluster --name=mp-1 --replicas=3 --kubelet-configs=high-pids`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to add the kubelet configuration to (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the kubelet configuration (required).",
+	)
+	Cmd.MarkFlagRequired("name")
+
+	flags.IntVar(
+		&args.podPidsLimit,
+		"pod-pids-limit",
+		0,
+		fmt.Sprintf("Maximum number of processes in each pod, between %d and %d.",
+			kubeletconfigs.MinPodPidsLimit, kubeletconfigs.MaxPodPidsLimit),
+	)
+
+	flags.IntVar(
+		&args.maxPods,
+		"max-pods",
+		0,
+		fmt.Sprintf("Maximum number of pods in each node, between %d and %d.",
+			kubeletconfigs.MinMaxPods, kubeletconfigs.MaxMaxPods),
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	config := &kubeletconfigs.KubeletConfig{
+		Name:         args.name,
+		PodPidsLimit: args.podPidsLimit,
+		MaxPods:      args.maxPods,
+	}
+	if !kubeletconfigs.IsValidName(config.Name) {
+		reporter.Errorf("Kubelet configuration name '%s' isn't valid: it must contain only "+
+			"lowercase letters, digits and dashes", config.Name)
+		exit.Fail()
+	}
+	err := config.Validate()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	existing, err := kubeletconfigs.GetKubeletConfig(ocmConnection, cluster.ID(), config.Name)
+	if err != nil {
+		reporter.Errorf("Failed to get kubelet configurations of cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	if existing != nil {
+		reporter.Errorf("Cluster '%s' already has a kubelet configuration named '%s'",
+			clusterKey, config.Name)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Adding kubelet configuration '%s' to cluster '%s'", config.Name, clusterKey)
+	_, err = kubeletconfigs.CreateKubeletConfig(ocmConnection, cluster.ID(), config)
+	if err != nil {
+		reporter.Errorf("Failed to add kubelet configuration to cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Kubelet configuration '%s' added to cluster '%s'", config.Name, clusterKey)
+	reporter.Infof("To use it, run 'rosa create machinepool -c %s --kubelet-configs=%s' or "+
+		"'rosa edit machinepool -c %s --kubelet-configs=%s <id>'",
+		clusterKey, config.Name, clusterKey, config.Name)
+}
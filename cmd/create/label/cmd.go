@@ -0,0 +1,184 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	key        string
+	value      string
+	scope      string
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "label",
+	Aliases: []string{"labels"},
+	Short:   "Add a label to an organization or subscription",
+	Long: "Add a label to the organization of the current user or to the subscription of a " +
+		"cluster. Labels are usually requested by support to enable capabilities.",
+	Example: `  # Add a label to the organization
+  rosa create label --key capability.organization.example --value true
+
+  # Add a label to the subscription of the cluster named "mycluster"
+  rosa create label --scope subscription --cluster mycluster --key mykey --value myvalue`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.key,
+		"key",
+		"",
+		"Key of the label (required).",
+	)
+	Cmd.MarkFlagRequired("key")
+
+	flags.StringVar(
+		&args.value,
+		"value",
+		"",
+		"Value of the label (required).",
+	)
+	Cmd.MarkFlagRequired("value")
+
+	flags.StringVar(
+		&args.scope,
+		"scope",
+		ocm.LabelScopeOrganization,
+		fmt.Sprintf("Scope of the label, one of: %s.", strings.Join(ocm.LabelScopes, ", ")),
+	)
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster whose subscription will be labeled. Required for the "+
+			"'subscription' scope.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if !ocm.IsValidLabelKey(args.key) {
+		reporter.Errorf("Label key '%s' isn't valid: it must contain only letters, digits, "+
+			"dashes, underscores, dots and slashes", args.key)
+		exit.Fail()
+	}
+	if !ocm.IsValidLabelScope(args.scope) {
+		reporter.Errorf("Expected a valid scope, one of: %s", strings.Join(ocm.LabelScopes, ", "))
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	cluster := getCluster(reporter, logger, ocmConnection.ClustersMgmt().V1().Clusters())
+	labels, err := ocm.GetLabelsClient(ocmConnection, args.scope, cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get labels: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Adding label '%s' with scope '%s'", args.key, args.scope)
+	_, err = ocm.AddLabel(labels, args.key, args.value)
+	if err != nil {
+		reporter.Errorf("Failed to add label '%s': %v", args.key, err)
+		exit.Fail()
+	}
+	reporter.Successf("Added label '%s' with scope '%s'", args.key, args.scope)
+}
+
+// getCluster returns the cluster given in the command line, or nil if the scope of the label
+// isn't a subscription.
+func getCluster(reporter *rprtr.Object, logger *logrus.Logger, client *cmv1.ClustersClient) *cmv1.Cluster {
+	if args.scope != ocm.LabelScopeSubscription {
+		if args.clusterKey != "" {
+			reporter.Errorf("The '--cluster' flag can only be used with the '%s' scope",
+				ocm.LabelScopeSubscription)
+			exit.Fail()
+		}
+		return nil
+	}
+	if args.clusterKey == "" {
+		reporter.Errorf("The '--cluster' flag is required for the '%s' scope", ocm.LabelScopeSubscription)
+		exit.Fail()
+	}
+	if !ocm.IsValidClusterKey(args.clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			args.clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading cluster '%s'", args.clusterKey)
+	cluster, err := clusters.GetCluster(client, args.clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", args.clusterKey, err)
+		exit.Fail()
+	}
+	return cluster
+}
@@ -18,26 +18,30 @@ package machinepool
 
 import (
 	"fmt"
-	"os"
-	"regexp"
+	"io"
+	"sort"
 	"strings"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
 	c "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ifexists"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/kubeletconfigs"
 	"github.com/openshift/rosa/pkg/ocm/machines"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/validation"
 )
 
-// Regular expression to used to make sure that the identifier given by the
-// user is safe and that it there is no risk of SQL injection:
-var machinePoolKeyRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
-
 var args struct {
 	clusterKey         string
 	name               string
@@ -48,6 +52,8 @@ var args struct {
 	maxReplicas        int
 	labels             string
 	taints             string
+	kubeletConfigs     []string
+	maxPodsPerNode     int
 }
 
 var Cmd = &cobra.Command{
@@ -66,7 +72,13 @@ var Cmd = &cobra.Command{
 	--min-replicas=3 --max-replicas=6 --instance-type=m5.xlarge
 
   # Add a machine pool with labels to a cluster
-  rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --labels=foo=bar,bar=baz`,
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=2 --instance-type=r5.2xlarge --labels=foo=bar,bar=baz
+
+  # Add a machine pool that allows up to 350 pods in each node
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=3 --max-pods-per-node=350
+
+  # Add a machine pool mp-1, or update it to 3 replicas if it already exists
+  rosa create machinepool -c mycluster --name=mp-1 --replicas=3 --update-if-exists`,
 	Run: run,
 }
 
@@ -78,7 +90,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to add the machine pool to (required).",
+		"Name, ID or external ID of the cluster to add the machine pool to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -120,7 +132,7 @@ func init() {
 	flags.StringVar(
 		&args.instanceType,
 		"instance-type",
-		"m5.xlarge",
+		machines.DefaultMachineType,
 		"Instance type that should be used.",
 	)
 
@@ -140,7 +152,26 @@ func init() {
 			"This list will overwrite any modifications made to Node taints on an ongoing basis.",
 	)
 
+	flags.StringSliceVar(
+		&args.kubeletConfigs,
+		"kubelet-configs",
+		nil,
+		"Names of the kubelet configurations, created with 'rosa create kubeletconfig', to "+
+			"apply to the nodes of the machine pool.",
+	)
+
+	flags.IntVar(
+		&args.maxPodsPerNode,
+		"max-pods-per-node",
+		0,
+		fmt.Sprintf("Maximum number of pods in each node of the machine pool, between %d and %d. "+
+			"A kubelet configuration with this value is created for the machine pool.",
+			kubeletconfigs.MinMaxPods, kubeletconfigs.MaxMaxPods),
+	)
+
+	ifexists.AddFlags(flags)
 	interactive.AddFlag(flags)
+	output.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -156,7 +187,25 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if cmd.Flags().Changed("max-pods-per-node") {
+		err := (&kubeletconfigs.KubeletConfig{MaxPods: args.maxPodsPerNode}).Validate()
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	if err := ifexists.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -166,13 +215,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -181,7 +230,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -195,15 +244,15 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Machine pool name:
@@ -214,19 +263,34 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if name == "" || interactive.Enabled() {
 		name, err = interactive.GetString(interactive.Input{
-			Question: "Machine pool name",
-			Default:  name,
-			Required: true,
+			Question:   "Machine pool name",
+			Default:    name,
+			Required:   true,
+			Validators: []interactive.Validator{validation.MachinePoolName},
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid name for the machine pool: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	name = strings.Trim(name, " \t")
-	if !machinePoolKeyRE.MatchString(name) {
-		reporter.Errorf("Expected a valid name for the machine pool")
-		os.Exit(1)
+	err = validation.MachinePoolName(name)
+	if err != nil {
+		reporter.Errorf("Expected a valid name for the machine pool: %s", err)
+		exit.Fail()
+	}
+
+	// Check if a machine pool with the same name already exists:
+	var existing *cmv1.MachinePool
+	if ifexists.Enabled() {
+		existing = findMachinePool(reporter, ocmClient.Clusters(), cluster, name)
+		if existing != nil && ifexists.Skip() {
+			reporter.Infof("Machine pool '%s' already exists on cluster '%s'", name, clusterKey)
+			if output.NameOnly() {
+				fmt.Println(name)
+			}
+			return
+		}
 	}
 
 	isMinReplicasSet := cmd.Flags().Changed("min-replicas")
@@ -249,7 +313,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid value for enable-autoscaling: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -257,7 +321,7 @@ func run(cmd *cobra.Command, _ []string) {
 		// if the user set replicas and enabled autoscaling
 		if isReplicasSet {
 			reporter.Errorf("Replicas can't be set when autoscaling is enabled")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
@@ -265,19 +329,21 @@ func run(cmd *cobra.Command, _ []string) {
 				Help:     cmd.Flags().Lookup("min-replicas").Usage,
 				Default:  minReplicas,
 				Required: true,
+				Validators: append(replicasValidators(cluster.MultiAZ()),
+					validation.MinInt(1)),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of min replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		if minReplicas < 1 {
 			reporter.Errorf("min-replicas must be greater or equal to the number of zones")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if cluster.MultiAZ() && minReplicas%3 != 0 {
 			reporter.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if interactive.Enabled() || !isMaxReplicasSet {
@@ -286,41 +352,44 @@ func run(cmd *cobra.Command, _ []string) {
 				Help:     cmd.Flags().Lookup("max-replicas").Usage,
 				Default:  maxReplicas,
 				Required: true,
+				Validators: append(replicasValidators(cluster.MultiAZ()),
+					validation.MinInt(minReplicas)),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of max replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		if minReplicas > maxReplicas {
 			reporter.Errorf("max-replicas must be greater or equal to min-replicas")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if cluster.MultiAZ() && maxReplicas%3 != 0 {
 			reporter.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
-			os.Exit(1)
+			exit.Fail()
 		}
 	} else {
 		// if the user set min/max replicas and hasn't enabled autoscaling
 		if isMinReplicasSet || isMaxReplicasSet {
 			reporter.Errorf("Autoscaling must be enabled in order to set min and max replicas")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if interactive.Enabled() || !isReplicasSet {
 			replicas, err = interactive.GetInt(interactive.Input{
-				Question: "Replicas",
-				Help:     cmd.Flags().Lookup("replicas").Usage,
-				Default:  replicas,
-				Required: true,
+				Question:   "Replicas",
+				Help:       cmd.Flags().Lookup("replicas").Usage,
+				Default:    replicas,
+				Required:   true,
+				Validators: replicasValidators(cluster.MultiAZ()),
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		if cluster.MultiAZ() && replicas%3 != 0 {
 			reporter.Errorf("Multi AZ clusters require that the replicas be a multiple of 3")
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	// Machine pool instance type:
@@ -328,7 +397,7 @@ func run(cmd *cobra.Command, _ []string) {
 	instanceTypeList, err := machines.GetMachineTypeList(ocmClient)
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		exit.Fail()
 	}
 	if interactive.Enabled() {
 		if instanceType == "" {
@@ -343,41 +412,47 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid machine type: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if instanceType == "" {
 		reporter.Errorf("Expected a valid machine type")
-		os.Exit(1)
+		exit.Fail()
 	}
 	instanceType, err = machines.ValidateMachineType(instanceType, instanceTypeList)
 	if err != nil {
 		reporter.Errorf("Expected a valid machine type: %s", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if autoscaling {
+		current := 0
+		if existing != nil && existing.InstanceType() == instanceType {
+			current = existing.Replicas()
+			if existing.Autoscaling() != nil {
+				current = existing.Autoscaling().MinReplicas()
+			}
+		}
+		warnAutoscalingQuota(reporter, logger, ocmConnection, cluster, instanceType, current, maxReplicas)
 	}
 
 	labels := args.labels
-	labelMap := make(map[string]string)
 	if interactive.Enabled() {
 		labels, err = interactive.GetString(interactive.Input{
-			Question: "Labels",
-			Help:     cmd.Flags().Lookup("labels").Usage,
-			Default:  labels,
+			Question:   "Labels",
+			Help:       cmd.Flags().Lookup("labels").Usage,
+			Default:    labels,
+			Validators: []interactive.Validator{validation.Labels},
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
-	if labels != "" {
-		for _, label := range strings.Split(labels, ",") {
-			if !strings.Contains(label, "=") {
-				reporter.Errorf("Expected key=value format for labels")
-				os.Exit(1)
-			}
-			tokens := strings.Split(label, "=")
-			labelMap[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
-		}
+	labelMap, err := machines.ParseLabels(labels)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
 	}
 
 	taints := args.taints
@@ -390,14 +465,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if taints != "" {
 		for _, taint := range strings.Split(taints, ",") {
 			if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
 				reporter.Errorf("Expected key=value:scheduleType format for taints")
-				os.Exit(1)
+				exit.Fail()
 			}
 			tokens := strings.FieldsFunc(taint, Split)
 			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(tokens[2]))
@@ -422,24 +497,247 @@ func run(cmd *cobra.Command, _ []string) {
 	machinePool, err := mpBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	attributes := map[string]interface{}{}
+	configs := getKubeletConfigs(reporter, ocmConnection, cluster, name)
+	if len(configs) > 0 {
+		attributes[kubeletconfigs.MachinePoolAttribute] = configs
+	}
+
+	if existing != nil {
+		machinePool = updateMachinePool(reporter, ocmConnection, cluster, existing, machinePool, configs)
+	} else {
+		machinePool, err = ocm.AddMachinePool(ocmConnection, cluster.ID(), machinePool, attributes)
+		if err != nil {
+			reporter.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
 	}
 
-	_, err = ocmClient.Clusters().
-		Cluster(cluster.ID()).
-		MachinePools().
-		Add().
-		Body(machinePool).
-		Send()
+	err = output.WriteFile(output.Marshaller(func(writer io.Writer) error {
+		return cmv1.MarshalMachinePool(machinePool, writer)
+	}))
 	if err != nil {
-		reporter.Errorf("Failed to add machine pool to cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		reporter.Errorf("%v", err)
+		exit.Fail()
 	}
 
-	reporter.Infof("Machine pool '%s' created successfully on cluster '%s'", name, clusterKey)
+	if output.NameOnly() {
+		fmt.Println(name)
+		return
+	}
+
+	if existing == nil {
+		reporter.Successf("Machine pool '%s' created successfully on cluster '%s'", name, clusterKey)
+	}
 	reporter.Infof("To view all machine pools, run 'rosa list machinepools -c %s'", clusterKey)
 }
 
+// findMachinePool returns the machine pool of the cluster with the given name, or nil if it
+// doesn't exist.
+func findMachinePool(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	name string) *cmv1.MachinePool {
+	reporter.Debugf("Loading machine pools for cluster '%s'", args.clusterKey)
+	machinePools, err := ocm.GetMachinePools(clusters, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get machine pools for cluster '%s': %v", args.clusterKey, err)
+		exit.Fail()
+	}
+	for _, machinePool := range machinePools {
+		if machinePool.ID() == name {
+			return machinePool
+		}
+	}
+	return nil
+}
+
+// updateMachinePool converges the existing machine pool to the configuration requested by the
+// user. The instance type can't be changed this way, as that requires replacing the nodes.
+func updateMachinePool(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster,
+	current, desired *cmv1.MachinePool, configs []string) *cmv1.MachinePool {
+	clusterKey := args.clusterKey
+	if current.InstanceType() != desired.InstanceType() {
+		reporter.Errorf("Machine pool '%s' uses instance type '%s' instead of '%s'. To replace "+
+			"its nodes, run 'rosa edit machinepool --instance-type=%s -c %s %s'", current.ID(),
+			current.InstanceType(), desired.InstanceType(), desired.InstanceType(), clusterKey,
+			current.ID())
+		exit.Fail()
+	}
+
+	currentConfigs, err := kubeletconfigs.GetMachinePoolConfigs(connection, cluster.ID(), current.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get kubelet configurations of machine pool '%s': %v",
+			current.ID(), err)
+		exit.Fail()
+	}
+	poolChanged := !sameMachinePool(current, desired)
+	configsChanged := !sameStrings(currentConfigs, configs)
+	if !poolChanged && !configsChanged {
+		reporter.Infof("Machine pool '%s' on cluster '%s' is up to date", current.ID(), clusterKey)
+		return current
+	}
+
+	updated := current
+	if poolChanged {
+		mpBuilder := cmv1.NewMachinePool().
+			ID(current.ID()).
+			Labels(desired.Labels())
+		taintBuilders := []*cmv1.TaintBuilder{}
+		for _, taint := range desired.Taints() {
+			taintBuilders = append(taintBuilders,
+				cmv1.NewTaint().Key(taint.Key()).Value(taint.Value()).Effect(taint.Effect()))
+		}
+		mpBuilder = mpBuilder.Taints(taintBuilders...)
+		if autoscaling, ok := desired.GetAutoscaling(); ok {
+			mpBuilder = mpBuilder.Autoscaling(cmv1.NewMachinePoolAutoscaling().
+				MinReplicas(autoscaling.MinReplicas()).
+				MaxReplicas(autoscaling.MaxReplicas()))
+		} else {
+			mpBuilder = mpBuilder.Replicas(desired.Replicas())
+		}
+		body, err := mpBuilder.Build()
+		if err != nil {
+			reporter.Errorf("Failed to update machine pool for cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		reporter.Debugf("Updating machine pool '%s' on cluster '%s'", current.ID(), clusterKey)
+		response, err := connection.ClustersMgmt().V1().Clusters().
+			Cluster(cluster.ID()).
+			MachinePools().
+			MachinePool(current.ID()).
+			Update().
+			Body(body).
+			Send()
+		if err != nil {
+			reporter.Debugf(err.Error())
+			reporter.Errorf("Failed to update machine pool '%s' on cluster '%s': %s",
+				current.ID(), clusterKey, ocm.ErrorReason(response.Error()))
+			exit.Fail()
+		}
+		updated = response.Body()
+	}
+	if configsChanged {
+		if configs == nil {
+			configs = []string{}
+		}
+		reporter.Debugf("Setting kubelet configurations of machine pool '%s' on cluster '%s' to %v",
+			current.ID(), clusterKey, configs)
+		err = kubeletconfigs.AttachToMachinePool(connection, cluster.ID(), current.ID(), configs)
+		if err != nil {
+			reporter.Errorf("Failed to update kubelet configurations of machine pool '%s' on "+
+				"cluster '%s': %v", current.ID(), clusterKey, err)
+			exit.Fail()
+		}
+	}
+	reporter.Successf("Machine pool '%s' updated successfully on cluster '%s'", current.ID(), clusterKey)
+	return updated
+}
+
+// sameMachinePool checks if the replicas, labels and taints of the machine pools are the same.
+func sameMachinePool(a, b *cmv1.MachinePool) bool {
+	aAutoscaling, aOK := a.GetAutoscaling()
+	bAutoscaling, bOK := b.GetAutoscaling()
+	if aOK != bOK {
+		return false
+	}
+	if aOK {
+		if aAutoscaling.MinReplicas() != bAutoscaling.MinReplicas() ||
+			aAutoscaling.MaxReplicas() != bAutoscaling.MaxReplicas() {
+			return false
+		}
+	} else if a.Replicas() != b.Replicas() {
+		return false
+	}
+	if len(a.Labels()) != len(b.Labels()) {
+		return false
+	}
+	for key, value := range a.Labels() {
+		if other, ok := b.Labels()[key]; !ok || other != value {
+			return false
+		}
+	}
+	aTaints := []string{}
+	for _, taint := range a.Taints() {
+		aTaints = append(aTaints, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+	}
+	bTaints := []string{}
+	for _, taint := range b.Taints() {
+		bTaints = append(bTaints, fmt.Sprintf("%s=%s:%s", taint.Key(), taint.Value(), taint.Effect()))
+	}
+	return sameStrings(aTaints, bTaints)
+}
+
+// sameStrings checks if the lists contain the same strings, regardless of the order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a = append([]string{}, a...)
+	b = append([]string{}, b...)
+	sort.Strings(a)
+	sort.Strings(b)
+	return strings.Join(a, "\n") == strings.Join(b, "\n")
+}
+
+// getKubeletConfigs returns the names of the kubelet configurations requested for the machine
+// pool, creating the one that sets the maximum number of pods per node if needed.
+func getKubeletConfigs(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster,
+	name string) []string {
+	configs := args.kubeletConfigs
+	if len(configs) > 0 {
+		err := kubeletconfigs.CheckExist(connection, cluster.ID(), configs)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+	if args.maxPodsPerNode != 0 {
+		config, err := kubeletconfigs.EnsureMaxPods(connection, cluster.ID(), name,
+			args.maxPodsPerNode)
+		if err != nil {
+			reporter.Errorf("Failed to configure the maximum number of pods per node: %v", err)
+			exit.Fail()
+		}
+		configs = append(configs, config)
+	}
+	return configs
+}
+
 func Split(r rune) bool {
 	return r == '=' || r == ':'
 }
+
+// replicasValidators returns the validators of the number of replicas of a machine pool, which
+// must be a multiple of 3 in clusters deployed to multiple availability zones.
+// warnAutoscalingQuota warns if the quotas don't allow the autoscaler to reach the maximum number
+// of replicas of the machine pool, so that users don't find out when scaling up fails.
+func warnAutoscalingQuota(reporter *rprtr.Object, logger *logrus.Logger, connection *sdk.Connection,
+	cluster *cmv1.Cluster, machineType string, current int, maxReplicas int) {
+	// The AWS quotas are per region:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Region(cluster.Region().ID()).
+		Build()
+	if err != nil {
+		reporter.Debugf("Failed to check quota for autoscaling: %v", err)
+		return
+	}
+	warnings, err := machines.CheckAutoscalingQuota(connection, awsClient, machineType,
+		cluster.MultiAZ(), current, maxReplicas)
+	for _, warning := range warnings {
+		reporter.Warnf("%s", warning)
+	}
+	if err != nil {
+		reporter.Debugf("Failed to check quota for autoscaling: %v", err)
+	}
+}
+
+func replicasValidators(multiAZ bool) []interactive.Validator {
+	validators := []interactive.Validator{validation.MinInt(0)}
+	if multiAZ {
+		validators = append(validators, validation.MultipleOf(3))
+	}
+	return validators
+}
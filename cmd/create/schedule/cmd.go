@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey  string
+	id          string
+	machinePool string
+	cron        string
+	replicas    int
+}
+
+var Cmd = &cobra.Command{
+	Use:     "schedule",
+	Aliases: []string{"schedules"},
+	Short:   "Add a scaling schedule to a machine pool",
+	Long: "Add a schedule that sets the number of replicas of a machine pool at the times given " +
+		"by a cron expression. Times are evaluated in the local time zone of the machine " +
+		"running 'rosa serve --apply-schedules', which is what applies the schedules.",
+	Example: `  # Scale down the "workers" machine pool of "mycluster" at 19:00 on weekdays
+  rosa create schedule --cluster=mycluster --machinepool=workers --cron="0 19 * * 1-5" --replicas=2
+
+  # Scale it up again at 07:00 on weekdays
+  rosa create schedule --cluster=mycluster --machinepool=workers --cron="0 7 * * 1-5" --replicas=6`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to add the schedule to (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.machinePool,
+		"machinepool",
+		"",
+		"ID of the machine pool to scale (required).",
+	)
+	Cmd.MarkFlagRequired("machinepool")
+
+	flags.StringVar(
+		&args.cron,
+		"cron",
+		"",
+		"Cron expression with the minute, hour, day of month, month and day of week when the "+
+			"machine pool will be scaled (required).",
+	)
+	Cmd.MarkFlagRequired("cron")
+
+	flags.IntVar(
+		&args.replicas,
+		"replicas",
+		0,
+		"Number of replicas that the machine pool will be scaled to (required).",
+	)
+	Cmd.MarkFlagRequired("replicas")
+
+	flags.StringVar(
+		&args.id,
+		"id",
+		"",
+		"Identifier of the schedule. Defaults to the machine pool ID followed by a number.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	cron, err := schedules.ParseCron(args.cron)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if args.replicas < 0 {
+		reporter.Errorf("The number of replicas can't be negative")
+		exit.Fail()
+	}
+	if args.id != "" && !schedules.IsValidScheduleID(args.id) {
+		reporter.Errorf("Expected a valid identifier for the schedule")
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	// Autoscaling machine pools are scaled by the cluster itself, so a schedule would be
+	// overridden immediately:
+	if args.machinePool == schedules.DefaultMachinePool {
+		if cluster.Nodes().AutoscaleCompute() != nil {
+			reporter.Errorf("Machine pool '%s' has autoscaling enabled and can't be scheduled",
+				args.machinePool)
+			exit.Fail()
+		}
+		if cluster.MultiAZ() && args.replicas%3 != 0 {
+			reporter.Errorf("Default machine pool for AZ cluster requires multiple of 3 compute nodes")
+			exit.Fail()
+		}
+	} else {
+		reporter.Debugf("Loading machine pools for cluster '%s'", clusterKey)
+		machinePools, err := ocm.GetMachinePools(clustersCollection, cluster.ID())
+		if err != nil {
+			reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		found := false
+		for _, machinePool := range machinePools {
+			if machinePool.ID() != args.machinePool {
+				continue
+			}
+			found = true
+			if machinePool.Autoscaling() != nil {
+				reporter.Errorf("Machine pool '%s' has autoscaling enabled and can't be scheduled",
+					args.machinePool)
+				exit.Fail()
+			}
+		}
+		if !found {
+			reporter.Errorf("Failed to get machine pool '%s' for cluster '%s'", args.machinePool, clusterKey)
+			exit.Fail()
+		}
+	}
+
+	existing, err := schedules.GetSchedules(cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get schedules for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	id := args.id
+	if id == "" {
+		id = schedules.NextScheduleID(existing, args.machinePool)
+	}
+	for _, schedule := range existing {
+		if schedule.ID == id {
+			reporter.Errorf("Schedule '%s' already exists on cluster '%s'", id, clusterKey)
+			exit.Fail()
+		}
+	}
+
+	schedule := &schedules.Schedule{
+		ID:          id,
+		MachinePool: args.machinePool,
+		Cron:        cron.String(),
+		Replicas:    args.replicas,
+	}
+	reporter.Debugf("Adding schedule '%s' to cluster '%s'", id, clusterKey)
+	err = schedules.AddSchedule(clustersCollection, cluster, schedule)
+	if err != nil {
+		reporter.Errorf("Failed to add schedule to cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Added schedule '%s' to cluster '%s'", id, clusterKey)
+	reporter.Infof("Schedules are applied while 'rosa serve --apply-schedules' is running")
+}
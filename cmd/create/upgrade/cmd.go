@@ -0,0 +1,306 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+	"github.com/openshift/rosa/pkg/ocm/upgrades"
+	"github.com/openshift/rosa/pkg/ocm/versions"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey   string
+	version      string
+	schedule     string
+	scheduleDate string
+	scheduleTime string
+	notifyBefore time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:     "upgrade",
+	Aliases: []string{"upgrades"},
+	Short:   "Add an upgrade policy to a cluster",
+	Long: "Add a policy that upgrades the cluster once to the given version, or automatically " +
+		"following a cron expression. With '--notify-before' a service log notification is " +
+		"generated that long before each upgrade.",
+	Example: `  # Upgrade "mycluster" to version 4.7.2 tomorrow, notifying 2 hours before
+  rosa create upgrade --cluster=mycluster --version=4.7.2 --schedule-date=2021-06-02 \
+    --schedule-time=10:00 --notify-before=2h
+
+  # Upgrade "mycluster" automatically every Sunday at 03:00 UTC, notifying a day before
+  rosa create upgrade --cluster=mycluster --schedule="0 3 * * 0" --notify-before=24h`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to add the upgrade policy to (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of OpenShift that the cluster will be upgraded to. Defaults to the latest "+
+			"available version. Can't be used with '--schedule'.",
+	)
+
+	flags.StringVar(
+		&args.schedule,
+		"schedule",
+		"",
+		"Cron expression, in UTC, of the automatic upgrades to the latest available version.",
+	)
+
+	flags.StringVar(
+		&args.scheduleDate,
+		"schedule-date",
+		"",
+		"Date of the upgrade at the specified UTC time. Format should be 'yyyy-mm-dd'.",
+	)
+
+	flags.StringVar(
+		&args.scheduleTime,
+		"schedule-time",
+		"",
+		"UTC time of the upgrade on the specified date. Format should be 'HH:mm'.",
+	)
+
+	flags.DurationVar(
+		&args.notifyBefore,
+		"notify-before",
+		0,
+		"Generate a service log notification this long before each upgrade, like '2h' or '30m'.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	automatic := args.schedule != ""
+	if automatic {
+		if args.version != "" || args.scheduleDate != "" || args.scheduleTime != "" {
+			reporter.Errorf("The '--schedule' flag can't be combined with '--version', " +
+				"'--schedule-date' or '--schedule-time'")
+			exit.Fail()
+		}
+		_, err := schedules.ParseCron(args.schedule)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+	if cmd.Flags().Changed("notify-before") {
+		err := upgrades.ValidateNotifyBefore(args.notifyBefore)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	ocmClient := ocmConnection.ClustersMgmt().V1()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		exit.Fail()
+	}
+
+	policies, err := upgrades.GetUpgradePolicies(ocmClient, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get upgrade policies for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	for _, policy := range policies {
+		if policy.UpgradeType() != "OSD" {
+			continue
+		}
+		if policy.ScheduleType() == "automatic" {
+			reporter.Errorf("Cluster '%s' already has automatic upgrades with schedule '%s'",
+				clusterKey, policy.Schedule())
+			exit.Fail()
+		}
+		if !automatic && policy.ScheduleType() == "manual" {
+			reporter.Errorf("Cluster '%s' already has an upgrade to version %s on %s",
+				clusterKey, policy.Version(), policy.NextRun().Format("2006-01-02 15:04 MST"))
+			exit.Fail()
+		}
+	}
+
+	policyBuilder := cmv1.NewUpgradePolicy().
+		UpgradeType("OSD")
+	if automatic {
+		policyBuilder.
+			ScheduleType("automatic").
+			Schedule(args.schedule)
+	} else {
+		version, nextRun := manualUpgrade(reporter, ocmClient, cluster)
+		policyBuilder.
+			ScheduleType("manual").
+			Version(version).
+			NextRun(nextRun)
+		if args.notifyBefore > 0 && time.Until(nextRun) < args.notifyBefore {
+			reporter.Warn(rprtr.WarnUpgradeNotification,
+				"The upgrade is in less than %s, the notification will be generated "+
+					"immediately", args.notifyBefore)
+		}
+	}
+	policy, err := policyBuilder.Build()
+	if err != nil {
+		reporter.Errorf("Failed to create upgrade policy for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Adding upgrade policy to cluster '%s'", clusterKey)
+	policy, err = upgrades.AddUpgradePolicy(ocmConnection, cluster.ID(), policy, args.notifyBefore)
+	if err != nil {
+		reporter.Errorf("Failed to add upgrade policy to cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if automatic {
+		reporter.Successf("Automatic upgrades scheduled for cluster '%s'", clusterKey)
+	} else {
+		reporter.Successf("Upgrade to version %s scheduled for cluster '%s'", policy.Version(), clusterKey)
+	}
+	if args.notifyBefore > 0 {
+		reporter.Infof("A service log notification will be generated %s before each upgrade",
+			args.notifyBefore)
+	}
+}
+
+// manualUpgrade returns the version and the time of a single upgrade, checking that the version
+// is one of the available upgrades of the cluster.
+func manualUpgrade(reporter *rprtr.Object, client *cmv1.Client, cluster *cmv1.Cluster) (string, time.Time) {
+	availableUpgrades, err := versions.GetAvailableUpgrades(client, versions.GetVersionID(cluster))
+	if err != nil {
+		reporter.Errorf("Failed to find available upgrades: %v", err)
+		exit.Fail()
+	}
+	if len(availableUpgrades) == 0 {
+		reporter.Warnf("There are no available upgrades")
+		os.Exit(0)
+	}
+
+	version := args.version
+	if version == "" {
+		version = availableUpgrades[0]
+	}
+	validVersion := false
+	for _, v := range availableUpgrades {
+		if v == version {
+			validVersion = true
+			break
+		}
+	}
+	if !validVersion {
+		reporter.Errorf("Expected a valid version to upgrade to")
+		exit.Fail()
+	}
+
+	// Set the default next run within the next 10 minutes:
+	now := time.Now().UTC().Add(time.Minute * 10)
+	scheduleDate := args.scheduleDate
+	if scheduleDate == "" {
+		scheduleDate = now.Format("2006-01-02")
+	}
+	scheduleTime := args.scheduleTime
+	if scheduleTime == "" {
+		scheduleTime = now.Format("15:04")
+	}
+	nextRun, err := time.Parse("2006-01-02 15:04", fmt.Sprintf("%s %s", scheduleDate, scheduleTime))
+	if err != nil {
+		reporter.Errorf("Schedule date should use the format 'yyyy-mm-dd'\n" +
+			"   Schedule time should use the format 'HH:mm'")
+		exit.Fail()
+	}
+	return version, nextRun
+}
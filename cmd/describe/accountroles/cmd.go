@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package accountroles
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm/versions"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/update"
+)
+
+var args struct {
+	prefix  string
+	version string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "account-roles",
+	Aliases: []string{"accountroles", "account-role"},
+	Short:   "Show details of the account roles with a prefix",
+	Long: "Show the installer, support, control plane and worker roles whose names start with the " +
+		"given prefix, with the OpenShift version they were created for, their attached policies, " +
+		"their permissions boundary and their creation time. When a target OpenShift version is " +
+		"given, also check if the roles can be used to create clusters with that version.",
+	Example: `  # Describe the account roles with the "ManagedOpenShift" prefix
+  rosa describe account-roles --prefix=ManagedOpenShift
+
+  # Check if the account roles can be used to create clusters with OpenShift 4.10
+  rosa describe account-roles --prefix=ManagedOpenShift --version=4.10`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		"",
+		"Prefix of the names of the account roles (required).",
+	)
+	Cmd.MarkFlagRequired("prefix")
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"OpenShift version to check the compatibility of the roles with, like '4.10' or '4.10.15'.",
+	)
+
+	output.AddFlag(flags, output.JSON)
+}
+
+// accountRoleTypes are the types of the roles that are shared by the clusters of the account.
+var accountRoleTypes = map[string]bool{
+	aws.RoleTypeInstaller:    true,
+	aws.RoleTypeSupport:      true,
+	aws.RoleTypeControlPlane: true,
+	aws.RoleTypeWorker:       true,
+}
+
+// accountRole is an account role together with its policies and its compatibility with the target
+// version.
+type accountRole struct {
+	*aws.Role
+	*aws.RolePolicies
+	Compatible *bool `json:"compatible,omitempty"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	targetVersion := ""
+	if args.version != "" {
+		var err error
+		targetVersion, err = versions.MinorVersion(args.version)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Fetching IAM roles")
+	roles, err := awsClient.ListClusterRoles()
+	if err != nil {
+		reporter.Errorf("Failed to get IAM roles: %v", err)
+		exit.Fail()
+	}
+
+	items := []*accountRole{}
+	for _, role := range roles {
+		if !accountRoleTypes[role.Type] || role.Prefix != args.prefix {
+			continue
+		}
+		reporter.Debugf("Fetching policies of role '%s'", role.Name)
+		policies, err := awsClient.GetRolePolicies(role.Name)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		item := &accountRole{
+			Role:         role,
+			RolePolicies: policies,
+		}
+		if targetVersion != "" {
+			compatible := isCompatible(role.Version, targetVersion)
+			item.Compatible = &compatible
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Type < items[j].Type
+	})
+
+	err = output.WriteFile(items)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(items)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(items) == 0 {
+		reporter.Errorf("There are no account roles with prefix '%s' in the AWS account", args.prefix)
+		exit.Fail()
+	}
+
+	writer := output.NewTable(os.Stdout)
+	header := "TYPE\tNAME\tVERSION\tCREATED\tPOLICIES\tPERMISSIONS BOUNDARY"
+	if targetVersion != "" {
+		header += "\tCOMPATIBLE WITH " + targetVersion
+	}
+	fmt.Fprintf(writer, "%s\n", header)
+	for _, item := range items {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s",
+			item.Type,
+			item.Name,
+			printValue(item.Version),
+			item.Created.UTC().Format("2006-01-02 15:04:05 MST"),
+			printValue(strings.Join(policyNames(item.Attached), ", ")),
+			printValue(policyName(item.PermissionsBoundary)),
+		)
+		if item.Compatible != nil {
+			fmt.Fprintf(writer, "\t%s", printCompatible(*item.Compatible))
+		}
+		fmt.Fprintf(writer, "\n")
+	}
+	writer.Flush()
+
+	for _, item := range items {
+		if item.Compatible != nil && !*item.Compatible {
+			reporter.Warnf("Some account roles with prefix '%s' weren't created for OpenShift %s or "+
+				"newer, they may lack the permissions that clusters with that version need",
+				args.prefix, targetVersion)
+			break
+		}
+	}
+}
+
+// isCompatible checks if a role created for the given minor version can be used by clusters with
+// the target minor version. Roles without a version tag are considered incompatible, as there is no
+// way to know which permissions they have.
+func isCompatible(roleVersion string, targetVersion string) bool {
+	if roleVersion == "" {
+		return false
+	}
+	roleMinor, err := versions.MinorVersion(roleVersion)
+	if err != nil {
+		return false
+	}
+	return update.Compare(roleMinor, targetVersion) >= 0
+}
+
+// policyNames returns the names of the policies with the given ARNs.
+func policyNames(arns []string) []string {
+	names := make([]string, len(arns))
+	for i, arn := range arns {
+		names[i] = policyName(arn)
+	}
+	return names
+}
+
+// policyName returns the name of the policy with the given ARN, which is the last part of its
+// path.
+func policyName(arn string) string {
+	return arn[strings.LastIndex(arn, "/")+1:]
+}
+
+func printValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func printCompatible(compatible bool) string {
+	if compatible {
+		return "yes"
+	}
+	return "no"
+}
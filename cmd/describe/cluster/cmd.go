@@ -18,7 +18,7 @@ package cluster
 
 import (
 	"fmt"
-	"os"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/spf13/cobra"
@@ -27,8 +27,12 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/kubeconfig"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/limitedsupport"
 	"github.com/openshift/rosa/pkg/ocm/properties"
 	"github.com/openshift/rosa/pkg/ocm/upgrades"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
@@ -42,7 +46,10 @@ const (
 )
 
 var args struct {
-	clusterKey string
+	clusterKey       string
+	credentials      bool
+	mergeKubeconfig  bool
+	showSTSResources bool
 }
 
 var Cmd = &cobra.Command{
@@ -50,7 +57,16 @@ var Cmd = &cobra.Command{
 	Short: "Show details of a cluster",
 	Long:  "Show details of a cluster",
 	Example: `  # Describe a cluster named "mycluster"
-  rosa describe cluster --cluster=mycluster`,
+  rosa describe cluster --cluster=mycluster
+
+  # Print the admin kubeconfig of a cluster named "mycluster"
+  rosa describe cluster --cluster=mycluster --credentials
+
+  # Add a "rosa/mycluster" context to ~/.kube/config and make it the current context
+  rosa describe cluster --cluster=mycluster --credentials --merge-kubeconfig
+
+  # List the IAM roles and the OpenID Connect provider of an AWS STS cluster named "mycluster"
+  rosa describe cluster --cluster=mycluster --show-sts-resources`,
 	Run: run,
 }
 
@@ -64,9 +80,33 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to describe.",
+		"Name, ID or external ID of the cluster to describe.",
 	)
 	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.credentials,
+		"credentials",
+		false,
+		"Print the admin kubeconfig of the cluster instead of its details, when the cluster "+
+			"exposes its credentials.",
+	)
+
+	flags.BoolVar(
+		&args.mergeKubeconfig,
+		"merge-kubeconfig",
+		false,
+		"Used with '--credentials', add the kubeconfig of the cluster as a 'rosa/<cluster-name>' "+
+			"context to the file used by 'oc' and 'kubectl' and make it the current context.",
+	)
+
+	flags.BoolVar(
+		&args.showSTSResources,
+		"show-sts-resources",
+		false,
+		"List the installer, support, instance and operator roles and the OpenID Connect "+
+			"provider of an AWS STS cluster instead of its details.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -78,6 +118,15 @@ func run(cmd *cobra.Command, argv []string) {
 		args.clusterKey = argv[0]
 	}
 
+	if args.mergeKubeconfig && !args.credentials {
+		reporter.Errorf("The '--merge-kubeconfig' flag can only be used with '--credentials'")
+		exit.Fail()
+	}
+	if args.showSTSResources && args.credentials {
+		reporter.Errorf("The '--show-sts-resources' and '--credentials' flags can't be used together")
+		exit.Fail()
+	}
+
 	clusterKey := args.clusterKey
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
@@ -87,14 +136,14 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -104,13 +153,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -120,7 +169,7 @@ func run(cmd *cobra.Command, argv []string) {
 
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -134,16 +183,25 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := clusterprovider.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("Failed to get cluster '%s': %v", clusterKey, err))
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if args.credentials {
+		showCredentials(reporter, ocmClient.Clusters(), cluster)
+		return
+	}
+	if args.showSTSResources {
+		showSTSResources(reporter, ocmConnection, awsClient, cluster)
+		return
 	}
 
 	creatorARN, err := arn.Parse(cluster.Properties()[properties.CreatorARN])
 	if err != nil {
 		reporter.Errorf("Failed to parse creator ARN for cluster '%s'", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 	phase := ""
 
@@ -177,7 +235,7 @@ func run(cmd *cobra.Command, argv []string) {
 	scheduledUpgrade, upgradeState, err := upgrades.GetScheduledUpgrade(ocmClient, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	detailsPage := getDetailsLink(ocmConnection.URL())
@@ -189,7 +247,7 @@ func run(cmd *cobra.Command, argv []string) {
 	machinePools, err := ocm.GetMachinePools(ocmClient.Clusters(), cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	// Accumulate all replicas across machine pools
 	for _, machinePool := range machinePools {
@@ -275,6 +333,22 @@ func run(cmd *cobra.Command, argv []string) {
 		cluster.CreationTimestamp().Format("Jan _2 2006 15:04:05 MST"),
 	)
 
+	workloadMonitoringDisabled, err := clusterprovider.IsWorkloadMonitoringDisabled(ocmConnection, cluster.ID())
+	if err != nil {
+		reporter.Debugf("Failed to get workload monitoring of cluster '%s': %v", clusterKey, err)
+	} else {
+		workloadMonitoring := "Enabled"
+		if workloadMonitoringDisabled {
+			workloadMonitoring = "Disabled"
+		}
+		str = fmt.Sprintf("%s"+
+			"Workload Monitoring:        %s\n", str, workloadMonitoring)
+	}
+	if value, ok := cluster.GetNodeDrainGracePeriod(); ok {
+		str = fmt.Sprintf("%s"+
+			"Node Drain Grace Period:    %s\n", str,
+			clusterprovider.NodeDrainGracePeriod(value))
+	}
 	if detailsPage != "" {
 		str = fmt.Sprintf("%s"+
 			"Details Page:               %s%s\n", str,
@@ -298,9 +372,72 @@ func run(cmd *cobra.Command, argv []string) {
 			cluster.Status().ProvisionErrorMessage(),
 		)
 	}
+	if expiration := cluster.ExpirationTimestamp(); !expiration.IsZero() {
+		str = fmt.Sprintf("%s"+
+			"Expiration:                 %s\n", str,
+			expiration.Format("Jan _2 2006 15:04:05 MST"))
+	}
+	reporter.Debugf("Loading limited support reasons for cluster '%s'", clusterKey)
+	limitedSupportReasons, err := limitedsupport.GetReasons(ocmConnection, cluster.ID())
+	if err != nil {
+		reporter.Debugf("Failed to get limited support reasons for cluster '%s': %v", clusterKey, err)
+	}
+	if len(limitedSupportReasons) > 0 {
+		str = fmt.Sprintf("%s"+
+			"Limited Support:\n", str)
+		for _, reason := range limitedSupportReasons {
+			str = fmt.Sprintf("%s"+
+				" - %s\n", str, reason.Summary)
+		}
+	}
 	// Print short cluster description:
 	fmt.Print(str)
 	fmt.Println()
+
+	if len(limitedSupportReasons) > 0 {
+		reporter.Warnf("Cluster '%s' is in limited support, run 'rosa list limited-support-reasons "+
+			"-c %s' to see how to fix it", clusterKey, clusterKey)
+	}
+	if expiration := cluster.ExpirationTimestamp(); !expiration.IsZero() &&
+		time.Until(expiration) < expirationWarning {
+		reporter.Warnf("Cluster '%s' will be deleted automatically on %s", clusterKey,
+			expiration.Format("Jan _2 2006 15:04:05 MST"))
+	}
+}
+
+// expirationWarning is how long before the expiration of a cluster users are warned about it.
+const expirationWarning = 7 * 24 * time.Hour
+
+// showCredentials prints the kubeconfig of the cluster, or merges it into the kubeconfig file used
+// by 'oc' and 'kubectl'.
+func showCredentials(reporter *rprtr.Object, client *cmv1.ClustersClient, cluster *cmv1.Cluster) {
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", args.clusterKey)
+		exit.Fail()
+	}
+	reporter.Debugf("Loading credentials of cluster '%s'", args.clusterKey)
+	credentials, err := clusterprovider.GetClusterCredentials(client, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get credentials of cluster '%s': %v", args.clusterKey, err)
+		exit.Fail()
+	}
+	if !args.mergeKubeconfig {
+		fmt.Print(credentials.Kubeconfig())
+		return
+	}
+
+	path, err := kubeconfig.Location()
+	if err != nil {
+		reporter.Errorf("Failed to find kubeconfig file: %v", err)
+		exit.Fail()
+	}
+	contextName := kubeconfig.ContextName(cluster.Name())
+	err = kubeconfig.Merge(path, []byte(credentials.Kubeconfig()), contextName)
+	if err != nil {
+		reporter.Errorf("Failed to update kubeconfig file '%s': %v", path, err)
+		exit.Fail()
+	}
+	reporter.Infof("Added context '%s' to '%s' and made it the current context", contextName, path)
 }
 
 func getDetailsLink(environment string) string {
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// Sources of the STS resources of a cluster:
+const (
+	sourceCluster = "cluster"
+	sourceTags    = "tags"
+)
+
+// stsResource is an IAM artifact that belongs to an AWS STS cluster.
+type stsResource struct {
+	Type   string
+	ARN    string
+	Source string
+}
+
+// showSTSResources prints the IAM roles and the OpenID Connect provider of an AWS STS cluster. They
+// are taken from the STS configuration of the cluster, and completed with the roles of the account
+// that are linked to the cluster by their tags.
+func showSTSResources(reporter *rprtr.Object, connection *sdk.Connection, awsClient aws.Client,
+	cluster *cmv1.Cluster) {
+	reporter.Debugf("Loading STS configuration of cluster '%s'", args.clusterKey)
+	stsCluster, err := ocm.GetSTSCluster(connection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get STS configuration of cluster '%s': %v", args.clusterKey, err)
+		exit.Fail()
+	}
+	if stsCluster == nil {
+		reporter.Errorf("Cluster '%s' doesn't use AWS STS", args.clusterKey)
+		exit.Fail()
+	}
+
+	var resources []*stsResource
+	known := map[string]bool{}
+	add := func(resourceType, arn, source string) {
+		if arn == "" || known[arn] {
+			return
+		}
+		known[arn] = true
+		resources = append(resources, &stsResource{
+			Type:   resourceType,
+			ARN:    arn,
+			Source: source,
+		})
+	}
+	add(aws.RoleTypeInstaller, stsCluster.RoleARN, sourceCluster)
+	add(aws.RoleTypeSupport, stsCluster.SupportRoleARN, sourceCluster)
+	add(aws.RoleTypeControlPlane, stsCluster.ControlPlaneRoleARN, sourceCluster)
+	add(aws.RoleTypeWorker, stsCluster.WorkerRoleARN, sourceCluster)
+	for _, roleARN := range stsCluster.OperatorRoleARNs {
+		add(aws.RoleTypeOperator, roleARN, sourceCluster)
+	}
+	if stsCluster.OIDCEndpointURL != "" {
+		reporter.Debugf("Loading OpenID Connect provider for issuer '%s'", stsCluster.OIDCEndpointURL)
+		providerARN, err := awsClient.FindOIDCProvider(stsCluster.OIDCEndpointURL)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		if providerARN == "" {
+			reporter.Warnf("There is no OpenID Connect provider for issuer '%s' of cluster '%s' in "+
+				"the AWS account", stsCluster.OIDCEndpointURL, args.clusterKey)
+		}
+		add("oidc-provider", providerARN, sourceCluster)
+	}
+
+	// Roles that the cluster doesn't reference, like the roles of the nodes created by the
+	// installer, can only be found by their tags:
+	reporter.Debugf("Loading IAM roles tagged for cluster '%s'", args.clusterKey)
+	roles, err := awsClient.ListClusterRoles()
+	if err != nil {
+		reporter.Errorf("Failed to list IAM roles: %v", err)
+		exit.Fail()
+	}
+	for _, role := range roles {
+		if role.ClusterID == cluster.ID() ||
+			(stsCluster.InfraID != "" && role.InfraID == stsCluster.InfraID) {
+			add(role.Type, role.ARN, sourceTags)
+		}
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TYPE\tARN\tSOURCE\n")
+	for _, resource := range resources {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", resource.Type, resource.ARN, resource.Source)
+	}
+	writer.Flush()
+}
@@ -19,23 +19,45 @@ package describe
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/describe/accountroles"
 	"github.com/openshift/rosa/cmd/describe/addon"
 	"github.com/openshift/rosa/cmd/describe/admin"
 	"github.com/openshift/rosa/cmd/describe/cluster"
+	"github.com/openshift/rosa/cmd/describe/flavour"
+	"github.com/openshift/rosa/cmd/describe/ingress"
+	"github.com/openshift/rosa/cmd/describe/installation"
+	"github.com/openshift/rosa/cmd/describe/oidcprovider"
+	"github.com/openshift/rosa/cmd/describe/upgrade"
+	"github.com/openshift/rosa/cmd/describe/version"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws/checks"
 )
 
 var Cmd = &cobra.Command{
 	Use:   "describe",
 	Short: "Show details of a specific resource",
 	Long:  "Show details of a specific resource",
+
+	// These commands don't change resources, so they can skip the validations that need AWS
+	// permissions that the user doesn't have:
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		checks.AllowDegraded()
+	},
 }
 
 func init() {
+	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(addon.Cmd)
 	Cmd.AddCommand(admin.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(flavour.Cmd)
+	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(installation.Cmd)
+	Cmd.AddCommand(oidcprovider.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
+	Cmd.AddCommand(version.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
+	arguments.AddSkipAWSChecksFlag(flags)
 }
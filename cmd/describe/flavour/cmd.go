@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavour
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/flavours"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "flavour ID",
+	Aliases: []string{"flavor"},
+	Short:   "Show details of a flavour",
+	Long: "Show details of a flavour, including the instance types and volumes of the nodes " +
+		"and the default network configuration of the clusters created with it.",
+	Example: `  # Describe the default flavour
+  rosa describe flavour osd-4`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the identifier of the flavour")
+		}
+		return nil
+	},
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	flavourID := argv[0]
+	if !ocm.IsValidClusterKey(flavourID) {
+		reporter.Errorf("Flavour identifier '%s' isn't valid: it must contain only letters, "+
+			"digits, dashes and underscores", flavourID)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Loading flavour '%s'", flavourID)
+	flavour, err := flavours.GetFlavour(ocmConnection.ClustersMgmt().V1(), flavourID)
+	if err != nil {
+		reporter.Errorf("Failed to get flavour '%s': %v", flavourID, err)
+		exit.Fail()
+	}
+
+	aws := flavour.AWS()
+	network := flavour.Network()
+	fmt.Printf(""+
+		"ID:                     %s\n"+
+		"Name:                   %s\n"+
+		"Default:                %s\n"+
+		"Master Nodes:           %d\n"+
+		"Master Instance Type:   %s\n"+
+		"Master Volume:          %s\n"+
+		"Infra Instance Type:    %s\n"+
+		"Infra Volume:           %s\n"+
+		"Compute Instance Type:  %s\n"+
+		"Compute Volume:         %s\n"+
+		"Machine CIDR:           %s\n"+
+		"Service CIDR:           %s\n"+
+		"Pod CIDR:               %s\n"+
+		"Host Prefix:            %s\n",
+		flavour.ID(),
+		flavour.Name(),
+		printBool(flavour.ID() == flavours.DefaultFlavour),
+		flavour.Nodes().Master(),
+		aws.MasterInstanceType(),
+		printVolume(aws.MasterVolume()),
+		aws.InfraInstanceType(),
+		printVolume(aws.InfraVolume()),
+		aws.ComputeInstanceType(),
+		printVolume(aws.WorkerVolume()),
+		network.MachineCIDR(),
+		network.ServiceCIDR(),
+		network.PodCIDR(),
+		printHostPrefix(network.HostPrefix()),
+	)
+}
+
+func printVolume(volume *cmv1.AWSVolume) string {
+	if volume == nil || volume.Size() == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d GiB, %d IOPS", volume.Size(), volume.IOPS())
+}
+
+func printHostPrefix(hostPrefix int) string {
+	if hostPrefix == 0 {
+		return ""
+	}
+	return fmt.Sprintf("/%d", hostPrefix)
+}
+
+func printBool(val bool) string {
+	if val {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,352 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/checks"
+	"github.com/openshift/rosa/pkg/exit"
+	healthcheck "github.com/openshift/rosa/pkg/ingress"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// Regular expression to used to make sure that the identifier given by the
+// user is safe and that it there is no risk of SQL injection:
+var ingressKeyRE = regexp.MustCompile(`^[a-z0-9]{3,5}$`)
+
+// certificateExpiryWarning is how long before the expiration of the certificate of the router a
+// warning is reported.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+var args struct {
+	clusterKey string
+	check      bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "ingress ID",
+	Aliases: []string{"route"},
+	Short:   "Show details of a cluster ingress",
+	Long: "Show details of a cluster ingress. With '--check' the wildcard DNS record of the " +
+		"router is resolved from this host, checking that it points to the load balancer of " +
+		"the router, and the certificate served by the router is verified.",
+	Example: `  # Describe the default ingress of a cluster named 'mycluster'
+  rosa describe ingress --cluster=mycluster apps
+
+  # Check the DNS record and the certificate of the ingress with ID 'a1b2'
+  rosa describe ingress --cluster=mycluster --check a1b2`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the ingress",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to describe the ingress of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.check,
+		"check",
+		false,
+		"Check the wildcard DNS record and the serving certificate of the router from this host.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	ingressID := argv[0]
+	if !ingressKeyRE.MatchString(ingressID) {
+		reporter.Errorf(
+			"Ingress identifier '%s' isn't valid: it must contain only letters or digits",
+			ingressID,
+		)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		exit.Fail()
+	}
+
+	// Try to find the ingress:
+	reporter.Debugf("Loading ingresses for cluster '%s'", clusterKey)
+	ingresses, err := ocm.GetIngresses(clustersCollection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	var ingress *cmv1.Ingress
+	for _, item := range ingresses {
+		if ingressID == "apps" && item.Default() {
+			ingress = item
+		}
+		if ingressID == "apps2" && !item.Default() {
+			ingress = item
+		}
+		if item.ID() == ingressID {
+			ingress = item
+		}
+	}
+	if ingress == nil {
+		reporter.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
+		exit.Fail()
+	}
+
+	// The type of load balancer isn't supported yet by the SDK, so it is read as a raw attribute:
+	lbType := ocm.LoadBalancerTypeClassic
+	attributes, err := ocm.GetAttributes(
+		ocmConnection,
+		fmt.Sprintf("%s/ingresses/%s", ocm.ClusterPath(cluster.ID()), ingress.ID()),
+	)
+	if err != nil {
+		reporter.Debugf("Failed to get attributes of ingress '%s': %v", ingress.ID(), err)
+	} else if value, ok := attributes["load_balancer_type"].(string); ok && value != "" {
+		lbType = value
+	}
+
+	fmt.Printf(""+
+		"ID:                         %s\n"+
+		"Application Router:         https://%s\n"+
+		"Private:                    %s\n"+
+		"Default:                    %s\n"+
+		"Load Balancer Type:         %s\n"+
+		"Route Selectors:            %s\n",
+		ingress.ID(),
+		ingress.DNSName(),
+		yesNo(ingress.Listening() == cmv1.ListeningMethodInternal),
+		yesNo(ingress.Default()),
+		lbType,
+		routeSelectors(ingress.RouteSelectors()),
+	)
+
+	if !args.check {
+		return
+	}
+	fmt.Println()
+
+	healthy := checkDNS(reporter, awsClient, ingress)
+	healthy = checkCertificate(reporter, ingress) && healthy
+	if !healthy {
+		exit.Fail()
+	}
+	reporter.Infof("DNS and certificate of ingress '%s' look healthy", ingress.ID())
+}
+
+// checkDNS resolves the wildcard record of the router and checks that it points to the load
+// balancer of the router. Returns false if the record doesn't resolve.
+func checkDNS(reporter *rprtr.Object, awsClient aws.Client, ingress *cmv1.Ingress) bool {
+	host := healthcheck.WildcardHost(ingress.DNSName())
+	reporter.Debugf("Resolving '%s'", host)
+	result, err := healthcheck.ResolveDNS(host)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		if ingress.Listening() == cmv1.ListeningMethodInternal {
+			reporter.Infof("The ingress is private, its domain only resolves from networks " +
+				"connected to the VPC of the cluster")
+		}
+		return false
+	}
+	fmt.Printf(""+
+		"DNS Name:                   %s\n"+
+		"DNS Target:                 %s\n"+
+		"DNS Addresses:              %s\n",
+		result.Host,
+		valueOrNone(result.CNAME),
+		strings.Join(result.IPs, ", "),
+	)
+
+	if result.CNAME == "" {
+		reporter.Warn(rprtr.WarnIngressDNS,
+			"Domain '%s' doesn't point to a load balancer, it resolves directly to addresses", host)
+		return true
+	}
+	lb, err := awsClient.FindLoadBalancerByDNSName(result.CNAME)
+	if err != nil {
+		if checks.CanSkip(err) {
+			checks.Skipped("load balancer of the ingress", err)
+			return true
+		}
+		reporter.Warnf("Failed to find load balancer '%s': %v", result.CNAME, err)
+		return true
+	}
+	if lb == nil {
+		reporter.Warn(rprtr.WarnIngressDNS,
+			"Domain '%s' points to '%s', which isn't a load balancer of the current AWS "+
+				"account and region", host, result.CNAME)
+		return true
+	}
+	fmt.Printf(""+
+		"Load Balancer:              %s (%s, %s)\n"+
+		"Load Balancer Service:      %s\n",
+		lb.Name, lb.Type, lb.Scheme,
+		valueOrNone(lb.Service),
+	)
+	if !strings.HasPrefix(lb.Service, "openshift-ingress/") {
+		reporter.Warn(rprtr.WarnIngressDNS,
+			"Domain '%s' points to load balancer '%s', which doesn't belong to a router",
+			host, lb.Name)
+	}
+	return true
+}
+
+// checkCertificate checks the certificate served by the router. Returns false if the router can't
+// be reached or the certificate doesn't match the domain of the router.
+func checkCertificate(reporter *rprtr.Object, ingress *cmv1.Ingress) bool {
+	host := healthcheck.WildcardHost(ingress.DNSName())
+	reporter.Debugf("Checking certificate of '%s'", host)
+	result, err := healthcheck.CheckCertificate(host)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		return false
+	}
+	expiresIn := result.ExpiresIn(time.Now())
+	fmt.Printf(""+
+		"Certificate Subject:        %s\n"+
+		"Certificate Issuer:         %s\n"+
+		"Certificate Names:          %s\n"+
+		"Certificate Expires:        %s (%d days)\n",
+		result.Subject,
+		result.Issuer,
+		strings.Join(result.DNSNames, ", "),
+		result.NotAfter.Format("2006-01-02 15:04 MST"),
+		int(expiresIn.Hours()/24),
+	)
+
+	if !result.MatchesDN {
+		reporter.Errorf("Certificate of the router isn't valid for '*.%s'", ingress.DNSName())
+		return false
+	}
+	if !result.Trusted {
+		reporter.Warn(rprtr.WarnIngressCertificate,
+			"Certificate of the router isn't trusted by this host: %v", result.TrustErr)
+	}
+	if expiresIn <= 0 {
+		reporter.Warn(rprtr.WarnIngressCertificate, "Certificate of the router has expired")
+	} else if expiresIn < certificateExpiryWarning {
+		reporter.Warn(rprtr.WarnIngressCertificate,
+			"Certificate of the router expires in %d days", int(expiresIn.Hours()/24))
+	}
+	return true
+}
+
+func routeSelectors(selectors map[string]string) string {
+	if len(selectors) == 0 {
+		return "None"
+	}
+	pairs := make([]string, 0, len(selectors))
+	for key, value := range selectors {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
@@ -0,0 +1,201 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package installation
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/addons"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "addon-installation ID",
+	Aliases: []string{"addon-installations", "add-on-installation", "add-on-installations"},
+	Short:   "Show details of an add-on installation",
+	Long:    "Show the state and parameter values of an add-on installed on a cluster.",
+	Example: `  # Describe the "cluster-logging-operator" add-on installation on a cluster named "mycluster"
+  rosa describe addon-installation --cluster=mycluster cluster-logging-operator`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the identifier of the add-on")
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster the add-on is installed on (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	addOnID := argv[0]
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	ocmClient := ocmConnection.ClustersMgmt().V1()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading add-on installation '%s' on cluster '%s'", addOnID, clusterKey)
+	installation, err := addons.GetAddOnInstallation(ocmClient, cluster.ID(), addOnID)
+	if err != nil {
+		reporter.Errorf("Failed to get add-on installation '%s' on cluster '%s': %v\n"+
+			"Try running 'rosa list addons -c %s' to see all installed add-ons.",
+			addOnID, clusterKey, err, clusterKey)
+		exit.Fail()
+	}
+
+	state := string(installation.State())
+	if state == "" {
+		state = string(cmv1.AddOnInstallationStateInstalling)
+	}
+
+	// Print add-on installation description:
+	fmt.Printf("ADD-ON INSTALLATION\n"+
+		"ID:               %s\n"+
+		"Name:             %s\n"+
+		"Cluster:          %s\n"+
+		"State:            %s\n",
+		installation.AddOn.ID(),
+		installation.AddOn.Name(),
+		cluster.Name(),
+		state,
+	)
+	if installation.StateDescription() != "" {
+		fmt.Printf("State details:    %s\n", installation.StateDescription())
+	}
+	if installation.OperatorVersion() != "" {
+		fmt.Printf("Operator version: %s\n", installation.OperatorVersion())
+	}
+	fmt.Println()
+
+	fmt.Printf("STATE HISTORY\n")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "EVENT\tTIMESTAMP\n")
+	if !installation.CreationTimestamp().IsZero() {
+		fmt.Fprintf(writer, "%s\t%s\n", "Installation requested", installation.CreationTimestamp())
+	}
+	if !installation.UpdatedTimestamp().IsZero() {
+		fmt.Fprintf(writer, "%s\t%s\n", "Last transition to "+state, installation.UpdatedTimestamp())
+	}
+	writer.Flush()
+	fmt.Println()
+
+	params := installation.ParameterValues()
+	if len(params) > 0 {
+		fmt.Printf("PARAMETER VALUES\n")
+		writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "ID\tNAME\tVALUE\n")
+		for _, param := range params {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", param.ID, param.Name, param.Value)
+		}
+		writer.Flush()
+		fmt.Println()
+	}
+
+	if installation.State() == cmv1.AddOnInstallationStateFailed {
+		requirements := installation.Requirements()
+		if len(requirements) > 0 {
+			fmt.Printf("REQUIREMENTS\n")
+			for _, requirement := range requirements {
+				fmt.Printf("- ID:             %s\n"+
+					"  Resource:       %s\n",
+					requirement.ID(),
+					requirement.Resource(),
+				)
+			}
+			fmt.Println()
+		}
+		reporter.Warnf("Add-on installation '%s' has failed, check that the cluster meets the "+
+			"requirements of the add-on", addOnID)
+	}
+}
@@ -0,0 +1,255 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oidcprovider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// Results of the verification of the thumbprint:
+const (
+	statusValid   = "valid"
+	statusStale   = "stale"
+	statusUnknown = "unknown"
+)
+
+var args struct {
+	clusterKey string
+	fix        bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "oidc-provider",
+	Aliases: []string{"oidcprovider"},
+	Short:   "Show details of the OpenID Connect provider of a cluster",
+	Long: "Show details of the OpenID Connect provider that the operators of an AWS STS cluster " +
+		"use to authenticate, and verify that its thumbprint matches the certificate chain that " +
+		"the issuer currently presents. A stale thumbprint makes IAM reject the tokens of the " +
+		"operators.",
+	Example: `  # Describe the OpenID Connect provider of a cluster named "mycluster"
+  rosa describe oidc-provider --cluster=mycluster
+
+  # Update the thumbprint of the provider if it doesn't match the issuer
+  rosa describe oidc-provider --cluster=mycluster --fix`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to describe the OpenID Connect provider of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.fix,
+		"fix",
+		false,
+		"Replace the thumbprint of the provider with the one of the certificate chain of the "+
+			"issuer when they don't match.",
+	)
+
+	confirm.AddFlag(flags)
+	output.AddFlag(flags, output.JSON)
+}
+
+// description is the description of the OpenID Connect provider of a cluster.
+type description struct {
+	*aws.OIDCProvider
+	IssuerThumbprint string `json:"issuer_thumbprint,omitempty"`
+	Status           string `json:"status"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	stsCluster, err := ocm.GetSTSCluster(ocmConnection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get STS configuration of cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	if stsCluster == nil {
+		reporter.Errorf("Cluster '%s' doesn't use AWS STS", clusterKey)
+		exit.Fail()
+	}
+	issuerURL := stsCluster.OIDCEndpointURL
+	if issuerURL == "" {
+		reporter.Errorf("Cluster '%s' doesn't have an OpenID Connect issuer yet", clusterKey)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading OpenID Connect provider for issuer '%s'", issuerURL)
+	providerARN, err := awsClient.FindOIDCProvider(issuerURL)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if providerARN == "" {
+		reporter.Errorf("There is no OpenID Connect provider for issuer '%s' of cluster '%s' in "+
+			"the AWS account", issuerURL, clusterKey)
+		exit.Fail()
+	}
+	provider, err := awsClient.GetOIDCProvider(providerARN)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	result := &description{
+		OIDCProvider: provider,
+		Status:       statusUnknown,
+	}
+	reporter.Debugf("Getting certificate chain of issuer '%s'", issuerURL)
+	thumbprint, err := aws.IssuerThumbprint(issuerURL)
+	if err != nil {
+		reporter.Warnf("Can't verify the thumbprint of the OpenID Connect provider: %v", err)
+	} else {
+		result.IssuerThumbprint = thumbprint
+		result.Status = statusStale
+		for _, item := range provider.Thumbprints {
+			if strings.EqualFold(item, thumbprint) {
+				result.Status = statusValid
+				break
+			}
+		}
+	}
+
+	if result.Status == statusStale && args.fix {
+		if !confirm.Confirm("replace the thumbprint of OpenID Connect provider '%s'", providerARN) {
+			os.Exit(0)
+		}
+		err = awsClient.UpdateOIDCProviderThumbprint(providerARN, thumbprint)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		provider.Thumbprints = []string{thumbprint}
+		result.Status = statusValid
+		reporter.Infof("Updated thumbprint of OpenID Connect provider '%s'", providerARN)
+	}
+
+	err = output.WriteFile(result)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(result)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ARN:\t%s\n", provider.ARN)
+	fmt.Fprintf(writer, "URL:\t%s\n", provider.URL)
+	fmt.Fprintf(writer, "Audiences:\t%s\n", strings.Join(provider.Audiences, ", "))
+	fmt.Fprintf(writer, "Thumbprints:\t%s\n", strings.Join(provider.Thumbprints, ", "))
+	if result.IssuerThumbprint != "" {
+		fmt.Fprintf(writer, "Issuer thumbprint:\t%s\n", result.IssuerThumbprint)
+	}
+	if provider.Created != nil {
+		fmt.Fprintf(writer, "Created:\t%s\n", provider.Created.UTC().Format("2006-01-02 15:04:05 MST"))
+	}
+	fmt.Fprintf(writer, "Thumbprint status:\t%s\n", result.Status)
+	writer.Flush()
+
+	if result.Status == statusStale {
+		reporter.Warnf("The thumbprint of the OpenID Connect provider doesn't match the certificate "+
+			"chain of the issuer, so IAM will reject the tokens of the operators of cluster '%s'. "+
+			"Run 'rosa describe oidc-provider --cluster=%s --fix' to update it", clusterKey, clusterKey)
+	}
+}
@@ -0,0 +1,297 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/upgrades"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	history    bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Show details of the upgrades of a cluster",
+	Long: "Show details of the upgrade scheduled for a cluster or, with '--history', of the " +
+		"upgrades that the cluster went through.",
+	Example: `  # Describe the upgrade scheduled for a cluster named "mycluster"
+  rosa describe upgrade --cluster=mycluster
+
+  # Show the upgrade history of a cluster named "mycluster"
+  rosa describe upgrade --cluster=mycluster --history`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to describe the upgrades of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.history,
+		"history",
+		false,
+		"Show the upgrades that the cluster went through, with the versions, the times they "+
+			"started and finished and their outcome.",
+	)
+
+	output.AddFlag(flags, output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if args.history {
+		describeHistory(reporter, ocmConnection, cluster)
+	} else {
+		describeScheduled(reporter, ocmConnection, cluster)
+	}
+}
+
+// scheduledUpgrade is the description of the upgrade scheduled for a cluster.
+type scheduledUpgrade struct {
+	ID               string     `json:"id"`
+	Version          string     `json:"version,omitempty"`
+	ScheduleType     string     `json:"schedule_type"`
+	Schedule         string     `json:"schedule,omitempty"`
+	NextRun          *time.Time `json:"next_run,omitempty"`
+	State            string     `json:"state,omitempty"`
+	StateDescription string     `json:"state_description,omitempty"`
+}
+
+func describeScheduled(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster) {
+	client := connection.ClustersMgmt().V1()
+	reporter.Debugf("Loading upgrade policies for cluster '%s'", cluster.ID())
+	policies, err := upgrades.GetUpgradePolicies(client, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get upgrade policies for cluster '%s': %v", cluster.ID(), err)
+		exit.Fail()
+	}
+	scheduled := []*scheduledUpgrade{}
+	for _, policy := range policies {
+		if policy.UpgradeType() != "OSD" {
+			continue
+		}
+		item := &scheduledUpgrade{
+			ID:           policy.ID(),
+			Version:      policy.Version(),
+			ScheduleType: policy.ScheduleType(),
+			Schedule:     policy.Schedule(),
+		}
+		if nextRun := policy.NextRun(); !nextRun.IsZero() {
+			item.NextRun = &nextRun
+		}
+		response, err := client.Clusters().Cluster(cluster.ID()).UpgradePolicies().
+			UpgradePolicy(policy.ID()).State().Get().Send()
+		if err != nil {
+			reporter.Warnf("Failed to get state of upgrade policy '%s': %v", policy.ID(), err)
+		} else {
+			item.State = string(response.Body().Value())
+			item.StateDescription = response.Body().Description()
+		}
+		scheduled = append(scheduled, item)
+	}
+
+	err = output.WriteFile(scheduled)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(scheduled)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(scheduled) == 0 {
+		reporter.Infof("There are no upgrades scheduled for cluster '%s'", cluster.Name())
+		return
+	}
+	for i, item := range scheduled {
+		if i > 0 {
+			fmt.Println()
+		}
+		version := item.Version
+		if version == "" {
+			version = "latest available"
+		}
+		nextRun := "not scheduled"
+		if item.NextRun != nil {
+			nextRun = item.NextRun.Format("2006-01-02 15:04 MST")
+		}
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(writer, "ID:\t%s\n", item.ID)
+		fmt.Fprintf(writer, "Version:\t%s\n", version)
+		fmt.Fprintf(writer, "Schedule type:\t%s\n", item.ScheduleType)
+		if item.Schedule != "" {
+			fmt.Fprintf(writer, "Schedule:\t%s\n", item.Schedule)
+		}
+		fmt.Fprintf(writer, "Next run:\t%s\n", nextRun)
+		if item.State != "" {
+			fmt.Fprintf(writer, "State:\t%s\n", item.State)
+		}
+		if item.StateDescription != "" {
+			fmt.Fprintf(writer, "State description:\t%s\n", item.StateDescription)
+		}
+		writer.Flush()
+	}
+}
+
+func describeHistory(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster) {
+	reporter.Debugf("Loading upgrade history for cluster '%s'", cluster.ID())
+	history, err := upgrades.GetHistory(connection, cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get upgrade history for cluster '%s': %v", cluster.ID(), err)
+		exit.Fail()
+	}
+	if history == nil {
+		history = []*upgrades.Upgrade{}
+	}
+
+	err = output.WriteFile(history)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(history)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(history) == 0 {
+		reporter.Infof("There are no upgrades in the history of cluster '%s'", cluster.Name())
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "FROM\tTO\tSTARTED\tFINISHED\tOUTCOME\n")
+	for _, upgrade := range history {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			printValue(upgrade.From),
+			printValue(upgrade.To),
+			printTime(upgrade.Started),
+			printTime(upgrade.Finished),
+			upgrade.Outcome,
+		)
+	}
+	writer.Flush()
+}
+
+func printValue(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
+
+func printTime(value *time.Time) string {
+	if value == nil {
+		return "unknown"
+	}
+	return value.UTC().Format("2006-01-02 15:04:05 MST")
+}
@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/versions"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// endOfLifeWarning is how long before the end of life of a version a warning is reported.
+const endOfLifeWarning = 60 * 24 * time.Hour
+
+var args struct {
+	channelGroup string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "version VERSION",
+	Short: "Show details of a version",
+	Long:  "Show details of a version of OpenShift, including its end of life and version gates.",
+	Example: `  # Describe version 4.10.15
+  rosa describe version 4.10.15
+
+  # Describe version 4.11.0-rc.2 of the candidate channel group
+  rosa describe version 4.11.0-rc.2 --channel-group candidate`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the version")
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.StringVar(
+		&args.channelGroup,
+		"channel-group",
+		versions.DefaultChannelGroup,
+		"Channel group of the version",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	rawID := strings.TrimPrefix(argv[0], "openshift-v")
+	if _, err := versions.MinorVersion(rawID); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Loading version '%s'", rawID)
+	version, err := versions.GetVersion(ocmConnection, rawID, args.channelGroup)
+	if err != nil {
+		reporter.Errorf("Failed to get version '%s': %v\n"+
+			"Try running 'rosa list versions' to see all available versions.", rawID, err)
+		exit.Fail()
+	}
+
+	endOfLife := "Not set"
+	if !version.EndOfLife.IsZero() {
+		endOfLife = version.EndOfLife.Format("2006-01-02")
+	}
+	availableUpgrades := "None"
+	if len(version.AvailableUpgrades()) > 0 {
+		availableUpgrades = strings.Join(version.AvailableUpgrades(), ", ")
+	}
+
+	fmt.Printf(""+
+		"ID:                  %s\n"+
+		"Version:             %s\n"+
+		"Channel Group:       %s\n"+
+		"Enabled:             %s\n"+
+		"ROSA Enabled:        %s\n"+
+		"Default:             %s\n"+
+		"End of Life:         %s\n"+
+		"Available Upgrades:  %s\n",
+		version.ID(),
+		version.RawID(),
+		version.ChannelGroup(),
+		printBool(version.Enabled()),
+		printBool(version.ROSAEnabled()),
+		printBool(version.Default()),
+		endOfLife,
+		availableUpgrades,
+	)
+	if version.ReleaseImage != "" {
+		fmt.Printf("Release Image:       %s\n", version.ReleaseImage)
+	}
+	if !version.EndOfLife.IsZero() && time.Until(version.EndOfLife) < endOfLifeWarning {
+		reporter.Warn(rprtr.WarnVersionEndOfLife,
+			"Version '%s' reaches its end of life on %s, consider using a newer version",
+			version.RawID(), version.EndOfLife.Format("2006-01-02"))
+	}
+
+	// Version gates are only known by recent versions of the API, so failing to get them
+	// shouldn't prevent showing the rest of the details:
+	gates, err := versions.GetGates(ocmConnection, rawID)
+	if err != nil {
+		reporter.Debugf("Failed to get version gates of version '%s': %v", rawID, err)
+		return
+	}
+	if len(gates) == 0 {
+		return
+	}
+	fmt.Printf("\nVERSION GATES\n")
+	for _, gate := range gates {
+		fmt.Printf(""+
+			"- ID:                %s\n"+
+			"  Label:             %s\n"+
+			"  Description:       %s\n"+
+			"  STS Only:          %s\n",
+			gate.ID,
+			gate.Label,
+			gate.Description,
+			printBool(gate.STSOnly),
+		)
+		if gate.WarningMessage != "" {
+			fmt.Printf("  Warning:           %s\n", gate.WarningMessage)
+		}
+		if gate.DocumentationURL != "" {
+			fmt.Printf("  Documentation:     %s\n", gate.DocumentationURL)
+		}
+	}
+}
+
+func printBool(val bool) string {
+	if val {
+		return "yes"
+	}
+	return "no"
+}
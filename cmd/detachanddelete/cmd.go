@@ -0,0 +1,40 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package detachanddelete
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/detachanddelete/operatorroles"
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/confirm"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "detach-and-delete",
+	Short: "Detach the policies of a resource and delete it",
+	Long: "Detach the policies of AWS resources that are left behind when a cluster is deleted and " +
+		"delete them, in the order needed to not break anything that still uses them.",
+}
+
+func init() {
+	Cmd.AddCommand(operatorroles.Cmd)
+
+	flags := Cmd.PersistentFlags()
+	arguments.AddProfileFlag(flags)
+	confirm.AddFlag(flags)
+}
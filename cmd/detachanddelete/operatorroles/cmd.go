@@ -0,0 +1,314 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorroles
+
+import (
+	"fmt"
+	"os"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	prefix     string
+	watch      bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "operator-roles",
+	Aliases: []string{"operator-role"},
+	Short:   "Delete the operator roles and the OIDC provider of a deleted STS cluster",
+	Long: "Delete the operator roles and the OpenID Connect provider of an STS cluster that is being " +
+		"deleted. The operators of the cluster use them until the deprovision of the cluster " +
+		"finishes, so the roles are deleted only after that, and the OIDC provider only after the " +
+		"roles. Roles and providers that are also used by other clusters are kept.",
+	Example: `  # Delete cluster "mycluster" and then its operator roles and OIDC provider
+  rosa delete cluster --cluster=mycluster
+  rosa detach-and-delete operator-roles --cluster=mycluster --watch
+
+  # Delete the operator roles left by a cluster that has already been uninstalled
+  rosa detach-and-delete operator-roles --prefix=mycluster-a1b2`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster being deleted to delete the operator roles of.",
+	)
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		"",
+		"Prefix of the names of the operator roles to delete, for clusters that have already "+
+			"been uninstalled.",
+	)
+
+	flags.BoolVar(
+		&args.watch,
+		"watch",
+		false,
+		"Wait for the cluster to finish uninstalling and then delete the roles.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if (args.clusterKey == "") == (args.prefix == "") {
+		reporter.Errorf("Exactly one of '--cluster' and '--prefix' is required")
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if clusterKey != "" && !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Use the shared OCM connection, as the other clusters that may still use the roles are
+	// checked too:
+	ocmConnection, err := ocm.AcquireConnection(logger)
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocm.ReleaseConnection()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	var cluster *cmv1.Cluster
+	var roleARNs []string
+	var providerARN string
+	if clusterKey != "" {
+		// Once the deprovision finishes the cluster is no longer available, and neither is the
+		// information about its roles, so it needs to be loaded before waiting:
+		reporter.Debugf("Loading cluster '%s'", clusterKey)
+		cluster, err = clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+		if err != nil {
+			reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		if cluster.State() != cmv1.ClusterStateUninstalling {
+			reporter.Errorf("Cluster '%s' is in '%s' state, its operator roles can only be deleted "+
+				"after deleting it with 'rosa delete cluster -c %s'", clusterKey, cluster.State(),
+				clusterKey)
+			exit.Fail()
+		}
+		if !args.watch {
+			reporter.Errorf("Cluster '%s' is still uninstalling and its operators use the roles, "+
+				"use '--watch' to wait for it to finish", clusterKey)
+			exit.Fail()
+		}
+		roleARNs, providerARN = getClusterResources(reporter, ocmConnection, awsClient, cluster)
+	} else {
+		roleARNs, providerARN = getPrefixResources(reporter, awsClient, args.prefix)
+	}
+
+	// Roles and providers shared with other clusters, usually because they were created with the
+	// same operator role prefix, are still in use and can't be deleted:
+	reporter.Debugf("Loading STS clusters")
+	others, err := ocm.ListSTSClusters(ocmConnection)
+	if err != nil {
+		reporter.Errorf("Failed to get STS clusters: %v", err)
+		exit.Fail()
+	}
+	roleUsers := map[string]string{}
+	providerUser := ""
+	for _, other := range others {
+		if cluster != nil && other.ID == cluster.ID() {
+			continue
+		}
+		for _, roleARN := range other.OperatorRoleARNs {
+			roleUsers[roleARN] = other.Name
+		}
+		if providerARN != "" && aws.IsOIDCProviderFor(providerARN, other.OIDCEndpointURL) {
+			providerUser = other.Name
+		}
+	}
+	var unused []string
+	for _, roleARN := range roleARNs {
+		if user, ok := roleUsers[roleARN]; ok {
+			reporter.Warnf("Role '%s' is also used by cluster '%s', it will be kept", roleARN, user)
+			continue
+		}
+		unused = append(unused, roleARN)
+	}
+	roleARNs = unused
+	if providerARN != "" && providerUser != "" {
+		reporter.Warnf("OIDC provider '%s' is also used by cluster '%s', it will be kept", providerARN,
+			providerUser)
+		providerARN = ""
+	}
+
+	if len(roleARNs) == 0 && providerARN == "" {
+		reporter.Infof("There are no operator roles or OIDC provider to delete")
+		return
+	}
+
+	if !output.Machine() {
+		fmt.Printf("The following resources will be deleted:\n")
+		for _, roleARN := range roleARNs {
+			fmt.Printf("  Operator role: %s\n", roleARN)
+		}
+		if providerARN != "" {
+			fmt.Printf("  OIDC provider: %s\n", providerARN)
+		}
+	}
+	if !confirm.Confirm("delete these operator roles") {
+		os.Exit(0)
+	}
+
+	if cluster != nil {
+		reporter.Infof("Waiting for cluster '%s' to finish uninstalling", clusterKey)
+		err = clusterprovider.WaitForClusterDeleted(clustersCollection, cluster.ID())
+		if err != nil {
+			reporter.Errorf("Failed to wait for cluster '%s' to be deleted: %v", clusterKey, err)
+			exit.Fail()
+		}
+	}
+
+	// The OIDC provider is deleted last, and only if all the roles that trust it are gone:
+	failed := false
+	for _, roleARN := range roleARNs {
+		reporter.Debugf("Deleting operator role '%s'", roleARN)
+		err = awsClient.DeleteOperatorRole(roleARN)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			failed = true
+			continue
+		}
+		reporter.Infof("Deleted operator role '%s'", roleARN)
+	}
+	if failed {
+		if providerARN != "" {
+			reporter.Warnf("OIDC provider '%s' wasn't deleted because some roles failed, use "+
+				"'--prefix' to retry", providerARN)
+		}
+		exit.Fail()
+	}
+	if providerARN != "" {
+		reporter.Debugf("Deleting OIDC provider '%s'", providerARN)
+		err = awsClient.DeleteOIDCProvider(providerARN)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		reporter.Infof("Deleted OIDC provider '%s'", providerARN)
+	}
+	reporter.Successf("Deleted the operator roles")
+}
+
+// getClusterResources returns the ARNs of the operator roles and of the OIDC provider of the given
+// cluster, as stored in its STS configuration.
+func getClusterResources(reporter *rprtr.Object, connection *sdk.Connection, awsClient aws.Client,
+	cluster *cmv1.Cluster) ([]string, string) {
+	reporter.Debugf("Loading STS configuration of cluster '%s'", cluster.ID())
+	stsCluster, err := ocm.GetSTSCluster(connection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get STS configuration of cluster '%s': %v", cluster.ID(), err)
+		exit.Fail()
+	}
+	if stsCluster == nil {
+		reporter.Errorf("Cluster '%s' doesn't use STS, it has no operator roles", cluster.Name())
+		exit.Fail()
+	}
+	providerARN := ""
+	if stsCluster.OIDCEndpointURL != "" {
+		providerARN, err = awsClient.FindOIDCProvider(stsCluster.OIDCEndpointURL)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+	return stsCluster.OperatorRoleARNs, providerARN
+}
+
+// getPrefixResources returns the ARNs of the operator roles with the given prefix and of the OIDC
+// provider that they trust.
+func getPrefixResources(reporter *rprtr.Object, awsClient aws.Client, prefix string) ([]string, string) {
+	reporter.Debugf("Loading roles with prefix '%s'", prefix)
+	roles, err := awsClient.ListClusterRoles()
+	if err != nil {
+		reporter.Errorf("Failed to get roles: %v", err)
+		exit.Fail()
+	}
+	var roleARNs []string
+	providerARN := ""
+	for _, role := range roles {
+		if role.Type != aws.RoleTypeOperator || role.Prefix != prefix {
+			continue
+		}
+		roleARNs = append(roleARNs, role.ARN)
+		if providerARN == "" {
+			providerARN, err = awsClient.GetRoleOIDCProvider(role.ARN)
+			if err != nil {
+				reporter.Errorf("%v", err)
+				exit.Fail()
+			}
+		}
+	}
+	return roleARNs, providerARN
+}
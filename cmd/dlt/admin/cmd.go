@@ -17,15 +17,15 @@ limitations under the License.
 package admin
 
 import (
-	"os"
-
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -55,7 +55,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete the admin user from (required).",
+		"Name, ID or external ID of the cluster to delete the admin user from (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -73,7 +73,7 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -82,13 +82,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -97,7 +97,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -111,15 +111,15 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the htpasswd identity provider:
@@ -127,7 +127,7 @@ func run(cmd *cobra.Command, _ []string) {
 	idps, err := ocm.GetIdentityProviders(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get '%s' identity provider for cluster '%s': %v", idpName, clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var idp *cmv1.IdentityProvider
@@ -138,7 +138,7 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if idp == nil {
 		reporter.Errorf("Failed to get '%s' identity provider for cluster '%s'", idpName, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if confirm.Confirm("delete %s user on cluster %s", username, clusterKey) {
@@ -152,8 +152,8 @@ func run(cmd *cobra.Command, _ []string) {
 			Send()
 		if err != nil {
 			reporter.Errorf("Failed to delete '%s' identity provider on cluster '%s': %s",
-				idpName, clusterKey, idpResp.Error().Reason())
-			os.Exit(1)
+				idpName, clusterKey, ocm.ErrorReason(idpResp.Error()))
+			exit.Fail()
 		}
 
 		// Delete admin user from the cluster-admins group:
@@ -167,8 +167,8 @@ func run(cmd *cobra.Command, _ []string) {
 			Send()
 		if err != nil {
 			reporter.Errorf("Failed to delete '%s' user from cluster '%s': %s",
-				username, clusterKey, userResp.Error().Reason())
-			os.Exit(1)
+				username, clusterKey, ocm.ErrorReason(userResp.Error()))
+			exit.Fail()
 		}
 
 		reporter.Infof("Admin user '%s' has been deleted from cluster '%s'", username, clusterKey)
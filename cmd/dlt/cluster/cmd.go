@@ -17,8 +17,15 @@ limitations under the License.
 package cluster
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"os"
+	"strings"
+	"text/tabwriter"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	uninstallLogs "github.com/openshift/rosa/cmd/logs/uninstall"
@@ -27,8 +34,12 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/notify"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/idps"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -57,7 +68,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete.",
+		"Name, ID or external ID of the cluster to delete.",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -67,6 +78,8 @@ func init() {
 		false,
 		"Watch cluster uninstallation logs.",
 	)
+
+	notify.AddFlags(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -82,7 +95,7 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -92,13 +105,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -107,7 +120,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -119,6 +132,24 @@ func run(cmd *cobra.Command, _ []string) {
 	// Get the client for the OCM collection of clusters:
 	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
 
+	// Show what will be destroyed, so that there is a last chance to notice that it is the wrong
+	// cluster, even when the confirmation is skipped with '--yes':
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	showImpact(reporter, clustersCollection, cluster)
+
+	// The OAuth applications of the identity providers live outside of the cluster, so their
+	// callback URLs need to be collected now, as they can't be loaded once the cluster is gone:
+	reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	oauthApps, err := getOAuthApps(ocmConnection, cluster)
+	if err != nil {
+		reporter.Warnf("Failed to get identity providers: %v", err)
+	}
+
 	if !confirm.Confirm("delete cluster %s", clusterKey) {
 		os.Exit(0)
 	}
@@ -127,16 +158,189 @@ func run(cmd *cobra.Command, _ []string) {
 	_, err = clusterprovider.DeleteCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to delete cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		notify.Failed(reporter, "delete cluster", clusterKey, cluster.ID(), err)
+		exit.Fail()
 	}
 	reporter.Infof("Cluster '%s' will start uninstalling now", clusterKey)
+	showOAuthApps(reporter, oauthApps)
 
 	if args.watch {
-		uninstallLogs.Cmd.Run(uninstallLogs.Cmd, []string{clusterKey})
+		watchUninstallation(reporter, clustersCollection, cluster, clusterKey)
 	} else {
+		notify.Accepted(reporter, "delete cluster", clusterKey, cluster.ID(), "Cluster will start uninstalling now")
 		reporter.Infof(
 			"To watch your cluster uninstallation logs, run 'rosa logs uninstall -c %s --watch'",
 			clusterKey,
 		)
 	}
 }
+
+// watchUninstallation prints the uninstallation logs of the cluster until the cluster is gone,
+// and then notifies the webhooks of the result.
+func watchUninstallation(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	clusterKey string) {
+	// The logs command exits the process when watching the logs fails:
+	watching := true
+	exit.OnExit(func(_ int, message string) {
+		if watching {
+			notify.Failed(reporter, "delete cluster", clusterKey, cluster.ID(), errors.New(message))
+		}
+	})
+	uninstallLogs.Cmd.Run(uninstallLogs.Cmd, []string{clusterKey})
+	watching = false
+
+	response, err := clusters.Cluster(cluster.ID()).Status().Get().Send()
+	switch {
+	case response.Status() == http.StatusNotFound:
+		notify.Succeeded(reporter, "delete cluster", clusterKey, cluster.ID(), "Cluster has been uninstalled")
+	case err != nil:
+		notify.Failed(reporter, "delete cluster", clusterKey, cluster.ID(), err)
+	default:
+		notify.Failed(reporter, "delete cluster", clusterKey, cluster.ID(),
+			fmt.Errorf("Cluster is in state '%s'", response.Body().State()))
+	}
+}
+
+// showImpact prints a summary of the resources that will be destroyed together with the cluster.
+// Failures to load any of them are reported as warnings, as the summary is only informative.
+func showImpact(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster) {
+	if rprtr.Quiet() {
+		return
+	}
+	resource := clusters.Cluster(cluster.ID())
+
+	// Nodes:
+	nodes := cluster.Nodes()
+	if metrics := cluster.Metrics().Nodes(); metrics.Total() > 0 {
+		nodes = metrics
+	}
+	nodesText := fmt.Sprintf("%d control plane, %d infra, %d compute",
+		nodes.Master(), nodes.Infra(), nodes.Compute())
+
+	// Machine pools, including the default one:
+	machinePoolsText := []string{fmt.Sprintf("Default (%s)", printNodes(cluster.Nodes().AutoscaleCompute(),
+		cluster.Nodes().Compute()))}
+	machinePools, err := ocm.GetMachinePools(clusters, cluster.ID())
+	if err != nil {
+		reporter.Warnf("Failed to get machine pools: %v", err)
+	}
+	for _, machinePool := range machinePools {
+		machinePoolsText = append(machinePoolsText, fmt.Sprintf("%s (%s)", machinePool.ID(),
+			printNodes(machinePool.Autoscaling(), machinePool.Replicas())))
+	}
+
+	// Add-ons installed:
+	var addOnsText []string
+	addOns, err := resource.Addons().List().Page(1).Size(-1).Send()
+	if err != nil {
+		reporter.Warnf("Failed to get add-ons: %v", err)
+	} else {
+		addOns.Items().Each(func(addOn *cmv1.AddOnInstallation) bool {
+			addOnsText = append(addOnsText, addOn.Addon().ID())
+			return true
+		})
+	}
+
+	// Identity providers:
+	var idpsText []string
+	idps, err := ocm.GetIdentityProviders(clusters, cluster.ID())
+	if err != nil {
+		reporter.Warnf("Failed to get identity providers: %v", err)
+	}
+	for _, idp := range idps {
+		idpsText = append(idpsText, fmt.Sprintf("%s (%s)", idp.Name(), ocm.IdentityProviderType(idp)))
+	}
+
+	// Every ingress has its own load balancer, and the API has an internal one and, unless the
+	// cluster is private, also an external one:
+	ingresses, err := ocm.GetIngresses(clusters, cluster.ID())
+	if err != nil {
+		reporter.Warnf("Failed to get ingresses: %v", err)
+	}
+	apiLoadBalancers := 2
+	if cluster.API().Listening() == cmv1.ListeningMethodInternal {
+		apiLoadBalancers = 1
+	}
+	loadBalancersText := fmt.Sprintf("%d (%d for the API, %d for ingresses)",
+		apiLoadBalancers+len(ingresses), apiLoadBalancers, len(ingresses))
+
+	// Persistent data, as reported by the metrics of the cluster:
+	storageText := "unknown"
+	if used := cluster.Metrics().Storage().Used(); used != nil && used.Unit() != "" {
+		storageText = printValue(used) + " (all persistent volumes will be deleted)"
+	}
+
+	reporter.Warnf("Deleting cluster '%s' will permanently destroy:", cluster.Name())
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "  Nodes:\t%s\n", nodesText)
+	fmt.Fprintf(writer, "  Machine pools:\t%s\n", strings.Join(machinePoolsText, ", "))
+	fmt.Fprintf(writer, "  Add-ons:\t%s\n", printList(addOnsText))
+	fmt.Fprintf(writer, "  Identity providers:\t%s\n", printList(idpsText))
+	fmt.Fprintf(writer, "  Load balancers:\t%s\n", loadBalancersText)
+	fmt.Fprintf(writer, "  Storage in use:\t%s\n", storageText)
+	writer.Flush()
+}
+
+// getOAuthApps returns the identity providers of the cluster that authenticate users through an
+// OAuth application registered with an external provider.
+func getOAuthApps(connection *sdk.Connection, cluster *cmv1.Cluster) ([]*idps.Details, error) {
+	details, err := idps.GetDetails(connection, cluster)
+	if err != nil {
+		return nil, err
+	}
+	var apps []*idps.Details
+	for _, detail := range details {
+		switch detail.Type {
+		case "GitHub", "GitLab", "Google", "OpenID":
+			if detail.AuthURL != "" {
+				apps = append(apps, detail)
+			}
+		}
+	}
+	return apps, nil
+}
+
+// showOAuthApps reminds the user to remove the OAuth applications that were created for the
+// identity providers of the deleted cluster, as they aren't deleted together with it.
+func showOAuthApps(reporter *rprtr.Object, apps []*idps.Details) {
+	if len(apps) == 0 {
+		return
+	}
+	reporter.Infof("The following OAuth applications were configured for the identity providers " +
+		"of the cluster and can now be removed from their providers:")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "  NAME\tTYPE\tCLIENT ID\tCALLBACK URL\n")
+	for _, app := range apps {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\n", app.Name, app.Type, app.ClientID, app.AuthURL)
+	}
+	writer.Flush()
+}
+
+func printNodes(autoscaling *cmv1.MachinePoolAutoscaling, replicas int) string {
+	if autoscaling != nil {
+		return fmt.Sprintf("%d-%d replicas", autoscaling.MinReplicas(), autoscaling.MaxReplicas())
+	}
+	return fmt.Sprintf("%d replicas", replicas)
+}
+
+func printList(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+	return strings.Join(items, ", ")
+}
+
+// printValue formats a value of the metrics, converting bytes to a human readable unit.
+func printValue(value *cmv1.Value) string {
+	if value.Unit() != "B" {
+		return fmt.Sprintf("%.1f %s", value.Value(), value.Unit())
+	}
+	amount := value.Value()
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	unit := 0
+	for amount >= 1024 && unit < len(units)-1 {
+		amount /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", amount, units[unit])
+}
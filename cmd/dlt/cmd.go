@@ -21,9 +21,12 @@ import (
 
 	"github.com/openshift/rosa/cmd/dlt/admin"
 	"github.com/openshift/rosa/cmd/dlt/cluster"
+	"github.com/openshift/rosa/cmd/dlt/dnsdomain"
 	"github.com/openshift/rosa/cmd/dlt/idp"
 	"github.com/openshift/rosa/cmd/dlt/ingress"
+	"github.com/openshift/rosa/cmd/dlt/label"
 	"github.com/openshift/rosa/cmd/dlt/machinepool"
+	"github.com/openshift/rosa/cmd/dlt/schedule"
 	"github.com/openshift/rosa/cmd/dlt/upgrade"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/confirm"
@@ -39,9 +42,12 @@ var Cmd = &cobra.Command{
 func init() {
 	Cmd.AddCommand(admin.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
 	Cmd.AddCommand(idp.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(label.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(schedule.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
 
 	flags := Cmd.PersistentFlags()
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/dnsdomains"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domain ID",
+	Aliases: []string{"dns-domains", "dnsdomain", "dnsdomains"},
+	Short:   "Release a base DNS domain",
+	Long:    "Release a base DNS domain reserved by your organization. Domains in use can't be released.",
+	Example: `  # Release the base DNS domain "abcd.s1.devshift.org"
+  rosa delete dns-domain abcd.s1.devshift.org`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the id of the DNS domain",
+			)
+		}
+		return nil
+	},
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	domainID := argv[0]
+	if !dnsdomains.IsValidDomain(domainID) {
+		reporter.Errorf("Expected a valid DNS domain")
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Loading DNS domain '%s'", domainID)
+	domain, err := dnsdomains.GetDNSDomain(ocmConnection, domainID)
+	if err != nil {
+		reporter.Errorf("Failed to get DNS domain '%s': %v", domainID, err)
+		exit.Fail()
+	}
+	if domain == nil {
+		reporter.Errorf("DNS domain '%s' isn't reserved by your organization", domainID)
+		exit.Fail()
+	}
+	if domain.InUse() {
+		reporter.Errorf("DNS domain '%s' is used by cluster '%s' and can't be released", domainID,
+			domain.ClusterID)
+		exit.Fail()
+	}
+
+	if !confirm.Confirm("release DNS domain '%s'", domainID) {
+		os.Exit(0)
+	}
+
+	reporter.Debugf("Releasing DNS domain '%s'", domainID)
+	err = dnsdomains.DeleteDNSDomain(ocmConnection, domainID)
+	if err != nil {
+		reporter.Errorf("Failed to release DNS domain '%s': %v", domainID, err)
+		exit.Fail()
+	}
+	reporter.Successf("Released DNS domain '%s'", domainID)
+}
@@ -18,15 +18,16 @@ package idp
 
 import (
 	"fmt"
-	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -60,7 +61,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete the IdP from (required).",
+		"Name, ID or external ID of the cluster to delete the IdP from (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -80,7 +81,7 @@ func run(_ *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -89,13 +90,13 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -104,7 +105,7 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -118,10 +119,10 @@ func run(_ *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the identity provider:
@@ -129,7 +130,7 @@ func run(_ *cobra.Command, argv []string) {
 	idps, err := ocm.GetIdentityProviders(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var idp *cmv1.IdentityProvider
@@ -140,7 +141,7 @@ func run(_ *cobra.Command, argv []string) {
 	}
 	if idp == nil {
 		reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if confirm.Confirm("delete identity provider %s on cluster %s", idpName, clusterKey) {
@@ -154,9 +155,9 @@ func run(_ *cobra.Command, argv []string) {
 		if err != nil {
 			reporter.Debugf(err.Error())
 			reporter.Errorf("Failed to delete identity provider '%s' on cluster '%s': %s",
-				idpName, clusterKey, res.Error().Reason())
-			os.Exit(1)
+				idpName, clusterKey, ocm.ErrorReason(res.Error()))
+			exit.Fail()
 		}
-		reporter.Infof("Successfully deleted identity provider '%s' from cluster '%s'", idpName, clusterKey)
+		reporter.Successf("Successfully deleted identity provider '%s' from cluster '%s'", idpName, clusterKey)
 	}
 }
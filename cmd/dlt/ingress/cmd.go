@@ -18,7 +18,6 @@ package ingress
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -26,8 +25,10 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -68,7 +69,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete the ingress from (required).",
+		"Name, ID or external ID of the cluster to delete the ingress from (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -83,7 +84,7 @@ func run(_ *cobra.Command, argv []string) {
 			"Ingress  identifier '%s' isn't valid: it must contain only four letters or digits",
 			ingressID,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
@@ -95,7 +96,7 @@ func run(_ *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -104,13 +105,13 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -119,7 +120,7 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -133,10 +134,10 @@ func run(_ *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the ingress:
@@ -144,7 +145,7 @@ func run(_ *cobra.Command, argv []string) {
 	ingresses, err := ocm.GetIngresses(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var ingress *cmv1.Ingress
@@ -161,7 +162,7 @@ func run(_ *cobra.Command, argv []string) {
 	}
 	if ingress == nil {
 		reporter.Errorf("Ingress '%s' does not exist on cluster '%s'", ingressID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if confirm.Confirm("delete ingress %s on cluster %s", ingressID, clusterKey) {
@@ -175,9 +176,9 @@ func run(_ *cobra.Command, argv []string) {
 		if err != nil {
 			reporter.Debugf(err.Error())
 			reporter.Errorf("Failed to delete ingress '%s' on cluster '%s': %s",
-				ingress.ID(), clusterKey, res.Error().Reason())
-			os.Exit(1)
+				ingress.ID(), clusterKey, ocm.ErrorReason(res.Error()))
+			exit.Fail()
 		}
-		reporter.Infof("Successfully deleted ingress '%s' from cluster '%s'", ingressID, clusterKey)
+		reporter.Successf("Successfully deleted ingress '%s' from cluster '%s'", ingressID, clusterKey)
 	}
 }
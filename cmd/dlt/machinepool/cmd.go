@@ -18,7 +18,6 @@ package machinepool
 
 import (
 	"fmt"
-	"os"
 	"regexp"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -26,8 +25,10 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -65,7 +66,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete the machine pool from (required).",
+		"Name, ID or external ID of the cluster to delete the machine pool from (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -77,7 +78,7 @@ func run(_ *cobra.Command, argv []string) {
 	machinePoolID := argv[0]
 	if machinePoolID != "Default" && !machinePoolKeyRE.MatchString(machinePoolID) {
 		reporter.Errorf("Expected a valid identifier for the machine pool")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
@@ -89,12 +90,12 @@ func run(_ *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if machinePoolID == "Default" {
 		reporter.Errorf("Machine pool '%s' cannot be deleted from cluster '%s'", machinePoolID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -103,13 +104,13 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -118,7 +119,7 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -132,10 +133,10 @@ func run(_ *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the machine pool:
@@ -143,7 +144,7 @@ func run(_ *cobra.Command, argv []string) {
 	machinePools, err := ocm.GetMachinePools(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var machinePool *cmv1.MachinePool
@@ -154,7 +155,7 @@ func run(_ *cobra.Command, argv []string) {
 	}
 	if machinePool == nil {
 		reporter.Errorf("Failed to get machine pool '%s' for cluster '%s'", machinePoolID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if confirm.Confirm("delete machine pool '%s' on cluster '%s'", machinePoolID, clusterKey) {
@@ -168,9 +169,9 @@ func run(_ *cobra.Command, argv []string) {
 		if err != nil {
 			reporter.Debugf(err.Error())
 			reporter.Errorf("Failed to delete machine pool '%s' on cluster '%s': %s",
-				machinePool.ID(), clusterKey, res.Error().Reason())
-			os.Exit(1)
+				machinePool.ID(), clusterKey, ocm.ErrorReason(res.Error()))
+			exit.Fail()
 		}
-		reporter.Infof("Successfully deleted machine pool '%s' from cluster '%s'", machinePoolID, clusterKey)
+		reporter.Successf("Successfully deleted machine pool '%s' from cluster '%s'", machinePoolID, clusterKey)
 	}
 }
@@ -25,8 +25,10 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	c "github.com/openshift/rosa/pkg/cluster"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	"github.com/openshift/rosa/pkg/ocm/upgrades"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
@@ -52,7 +54,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to cancel the upgrade for (required)",
+		"Name, ID or external ID of the cluster to cancel the upgrade for (required)",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -70,7 +72,7 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -80,13 +82,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -95,7 +97,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -109,21 +111,21 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	scheduledUpgrade, _, err := upgrades.GetScheduledUpgrade(ocmClient, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if scheduledUpgrade == nil {
 		reporter.Warnf("There are no scheduled upgrades on cluster '%s'", clusterKey)
@@ -135,7 +137,7 @@ func run(cmd *cobra.Command, _ []string) {
 		canceled, err := upgrades.CancelUpgrade(ocmClient, cluster.ID())
 		if err != nil {
 			reporter.Errorf("Failed to cancel scheduled upgrade on cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if !canceled {
@@ -143,6 +145,6 @@ func run(cmd *cobra.Command, _ []string) {
 			os.Exit(0)
 		}
 
-		reporter.Infof("Successfully canceled scheduled upgrade on cluster '%s'", clusterKey)
+		reporter.Successf("Successfully canceled scheduled upgrade on cluster '%s'", clusterKey)
 	}
 }
@@ -18,110 +18,129 @@ package oc
 
 import (
 	"fmt"
-	"io"
 	"net/http"
 	"os"
-	"runtime"
+	"path/filepath"
 	"strings"
 
 	"github.com/dustin/go-humanize"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/clients"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm/proxy"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 
 	"github.com/openshift/rosa/cmd/verify/oc"
 )
 
+var args struct {
+	version    string
+	clusterKey string
+	installDir string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "openshift-client",
 	Aliases: []string{"oc", "openshift"},
 	Short:   "Download OpenShift client tools",
-	Long:    "Downloads to latest compatible version of the OpenShift client tools.",
+	Long: "Downloads to latest compatible version of the OpenShift client tools, or the version " +
+		"that matches a cluster. The download is verified against the checksums published in the " +
+		"mirror, and uses the proxy configured in the HTTPS_PROXY and NO_PROXY environment variables.",
 	Example: `  # Download oc client tools
-  rosa download oc`,
+  rosa download oc
+
+  # Download the oc client tools that match the cluster named "mycluster" and install them
+  rosa download oc --cluster=mycluster --install-dir=$HOME/bin`,
 	Run: run,
 }
 
-func run(cmd *cobra.Command, argv []string) {
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Version of the client tools to download, like '4.7.13'. Defaults to the latest version.",
+	)
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster. The client tools that match the version of the "+
+			"cluster will be downloaded.",
+	)
+
+	flags.StringVar(
+		&args.installDir,
+		"install-dir",
+		"",
+		"Directory where the 'oc' binary will be extracted from the downloaded archive. By "+
+			"default the archive is only saved in the current directory.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 
-	// Verify whether `oc` is installed
-	oc.Cmd.Run(cmd, argv)
+	if args.version != "" && args.clusterKey != "" {
+		reporter.Errorf("Flags '--version' and '--cluster' can't be used together")
+		exit.Fail()
+	}
 
-	platform := getPlatform()
-	extension := getExtension()
+	version := args.version
+	if args.clusterKey != "" {
+		var err error
+		version, err = oc.ClusterVersion(reporter, args.clusterKey)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		reporter.Infof("Cluster '%s' runs version %s", args.clusterKey, version)
+	}
 
-	filename := fmt.Sprintf("openshift-client-%s.%s", platform, extension)
-	downloadURL := fmt.Sprintf("https://mirror.openshift.com/pub/openshift-v4/clients/ocp/latest/%s", filename)
+	// Verify whether `oc` is installed
+	oc.Verify(reporter, version)
 
-	reporter.Infof("Downloading %s", downloadURL)
+	url := clients.OCURL(version) + "/" + clients.OCArchive()
+	reporter.Debugf("Using proxy '%s' for '%s'", proxy.Resolve(url, http.ProxyFromEnvironment), url)
+	reporter.Infof("Downloading %s", url)
 
-	err := download(downloadURL, filename)
+	client := clients.NewHTTPClient(http.ProxyFromEnvironment)
+	archive, err := clients.DownloadOC(client, version, ".", &WriteCounter{})
+	// The progress use the same line so print a new line once it's finished downloading
+	fmt.Print("\n")
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		exit.Fail()
 	}
+	reporter.Successf("Successfully downloaded %s", archive)
 
-	reporter.Infof("Successfully downloaded %s", filename)
-}
-
-// Get the platform name used on the oc tarball filename
-func getPlatform() string {
-	if runtime.GOOS == "darwin" {
-		return "mac"
+	if args.installDir == "" {
+		return
 	}
-	return runtime.GOOS
-}
-
-// Get the extension used for the compressed oc file
-func getExtension() string {
-	if runtime.GOOS == "windows" {
-		return "zip"
-	}
-	return "tar.gz"
-}
-
-// download will download a url to a local file. It's efficient because it will
-// write as it downloads and not load the whole file into memory. We pass an io.TeeReader
-// into Copy() to report progress on the download.
-func download(url string, filename string) error {
-	// Create the file, but give it a tmp file extension, this means we won't overwrite a
-	// file until it's downloaded, but we'll remove the tmp extension once downloaded.
-	out, err := os.Create(filename + ".tmp")
+	installDir, err := filepath.Abs(args.installDir)
 	if err != nil {
-		return err
+		reporter.Errorf("Invalid install directory '%s': %v", args.installDir, err)
+		exit.Fail()
 	}
-
-	// Get the data
-	// nolint:gosec
-	resp, err := http.Get(url)
+	binary, err := clients.InstallOC(archive, installDir)
 	if err != nil {
-		out.Close()
-		return err
-	}
-	defer resp.Body.Close()
-
-	// Create our progress reporter and pass it to be used alongside our writer
-	counter := &WriteCounter{}
-	if _, err = io.Copy(out, io.TeeReader(resp.Body, counter)); err != nil {
-		out.Close()
-		return err
+		reporter.Errorf("%s", err)
+		exit.Fail()
 	}
-
-	// The progress use the same line so print a new line once it's finished downloading
-	fmt.Print("\n")
-
-	// Close the file without defer so it can happen before Rename()
-	out.Close()
-
-	if err = os.Rename(filename+".tmp", filename); err != nil {
-		return err
+	reporter.Successf("Installed OpenShift command-line tool to '%s'", binary)
+	if !clients.InPath(installDir) {
+		reporter.Infof("Directory '%s' isn't in your PATH. Add it with 'export PATH=\"%s%c$PATH\"' "+
+			"or the equivalent command for your shell", installDir, installDir, os.PathListSeparator)
 	}
-	return nil
 }
 
 // WriteCounter counts the number of bytes written to it. It implements to the io.Writer interface
-// and we can pass this into io.TeeReader() which will report progress on each write cycle.
+// and it is passed as the progress writer of the download, which will report progress on each write cycle.
 type WriteCounter struct {
 	Total uint64
 }
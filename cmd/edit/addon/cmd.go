@@ -19,7 +19,6 @@ package addon
 import (
 	"fmt"
 	"net"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
@@ -30,9 +29,11 @@ import (
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -70,7 +71,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to edit the addon parameters of (required).",
+		"Name, ID or external ID of the cluster to edit the addon parameters of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -93,7 +94,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -102,13 +103,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -117,7 +118,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -131,37 +132,37 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := clusterprovider.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	parameters, err := clusterprovider.GetAddOnParameters(ocmClient.Addons(), addOnID)
 	if err != nil {
 		reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	addOnInstallation, err := clusterprovider.GetAddOnInstallation(ocmClient.Clusters(),
 		clusterKey, awsCreator.ARN, addOnID)
 	if err != nil {
 		reporter.Errorf("Failed to get add-on '%s' installation: %v", addOnID, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if addOnInstallation.State() != cmv1.AddOnInstallationStateReady {
 		reporter.Errorf("Add-on '%s' is not yet ready on cluster '%s'", addOnID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if parameters.Len() == 0 {
 		reporter.Errorf("Add-on '%s' has no parameters to edit", addOnID)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Determine if all required parameters have already been set as flags and ensure
@@ -173,7 +174,7 @@ func run(cmd *cobra.Command, argv []string) {
 			flag := cmd.Flags().Lookup(param.ID())
 			if flag != nil && !param.Editable() {
 				reporter.Errorf("Parameter '%s' on addon '%s' cannot be modified", param.ID(), addOnID)
-				os.Exit(1)
+				exit.Fail()
 			}
 			return true
 		})
@@ -250,7 +251,7 @@ func run(cmd *cobra.Command, argv []string) {
 			}
 			if err != nil {
 				reporter.Errorf("Expected a valid value for '%s': %v", param.ID(), err)
-				os.Exit(1)
+				exit.Fail()
 			}
 			hasVal = true
 		}
@@ -261,7 +262,7 @@ func run(cmd *cobra.Command, argv []string) {
 				isValid, err := regexp.MatchString(param.Validation(), val)
 				if err != nil || !isValid {
 					reporter.Errorf("Expected %v to match /%s/", val, param.Validation())
-					os.Exit(1)
+					exit.Fail()
 				}
 			}
 			params = append(params, clusterprovider.AddOnParam{Key: param.ID(), Val: val})
@@ -274,7 +275,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = clusterprovider.UpdateAddOnInstallation(ocmClient.Clusters(), clusterKey, awsCreator.ARN, addOnID, params)
 	if err != nil {
 		reporter.Errorf("Failed to update add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Infof("Add-on '%s' is now updating. To check the status run 'rosa list addons -c %s'", addOnID, clusterKey)
 }
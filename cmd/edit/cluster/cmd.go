@@ -19,7 +19,9 @@ package cluster
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -28,9 +30,14 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/machinepools/labels"
+	"github.com/openshift/rosa/pkg/ocm/machines"
+	"github.com/openshift/rosa/pkg/ocm/upgrades"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -43,6 +50,22 @@ var args struct {
 
 	// Networking options
 	private bool
+
+	// Time to wait for pods to be drained when removing nodes
+	nodeDrainGracePeriod time.Duration
+
+	// Disable the monitoring of user workloads
+	disableWorkloadMonitoring bool
+
+	// Times when the cluster shouldn't be upgraded
+	maintenanceExclusion string
+
+	// Labels added to all the machine pools
+	workerLabels         string
+	excludedMachinePools []string
+
+	// Raw partial cluster document
+	patch string
 }
 
 var Cmd = &cobra.Command{
@@ -52,6 +75,12 @@ var Cmd = &cobra.Command{
 	Example: `  # Edit a cluster named "mycluster" to make it private
   rosa edit cluster mycluster --private
 
+  # Add a label to all the machine pools of a cluster named "mycluster", except "gpu"
+  rosa edit cluster -c mycluster --worker-labels tier=backend --exclude-machinepools gpu
+
+  # Change the display name of a cluster named "mycluster" with a raw partial update
+  rosa edit cluster -c mycluster --patch '{"display_name":"new"}'
+
   # Edit all options interactively
   rosa edit cluster -c mycluster --interactive`,
 	Run: run,
@@ -66,7 +95,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to edit.",
+		"Name, ID or external ID of the cluster to edit.",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -94,6 +123,55 @@ func init() {
 		false,
 		"Restrict master API endpoint to direct, private connectivity.",
 	)
+
+	flags.DurationVar(
+		&args.nodeDrainGracePeriod,
+		"node-drain-grace-period",
+		0,
+		"Time to wait for pods protected by pod disruption budgets to be drained when removing "+
+			"nodes, like 30m or 1h. Must be a whole number of minutes, up to one week.",
+	)
+
+	flags.BoolVar(
+		&args.disableWorkloadMonitoring,
+		"disable-workload-monitoring",
+		false,
+		"Disable the monitoring of user-defined projects. Use "+
+			"'--disable-workload-monitoring=false' to enable it again.",
+	)
+
+	flags.StringVar(
+		&args.maintenanceExclusion,
+		"maintenance-exclusion",
+		"",
+		"Cron expression, in UTC, of the times when the cluster shouldn't be upgraded, like "+
+			"'* * * * 1-5' to exclude week days. 'rosa upgrade cluster' refuses to start "+
+			"immediate upgrades inside the window. Use an empty value to remove it.",
+	)
+
+	flags.StringVar(
+		&args.workerLabels,
+		"worker-labels",
+		"",
+		"Labels to add to the nodes of all the machine pools of the cluster, as a comma-separated "+
+			"list of 'key=value'. Existing labels with other keys are kept.",
+	)
+
+	flags.StringSliceVar(
+		&args.excludedMachinePools,
+		"exclude-machinepools",
+		nil,
+		"Machine pools that '--worker-labels' doesn't apply to.",
+	)
+
+	flags.StringVar(
+		&args.patch,
+		"patch",
+		"",
+		"Raw partial cluster document in JSON to send as the update, or '@' followed by the name "+
+			"of a file containing it. Only mutable fields are accepted. Can't be combined with "+
+			"other options.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -108,13 +186,54 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	editFlags := []string{"expiration-time", "expiration", "private", "node-drain-grace-period",
+		"disable-workload-monitoring", "maintenance-exclusion", "worker-labels"}
+
+	if cmd.Flags().Changed("exclude-machinepools") && !cmd.Flags().Changed("worker-labels") {
+		reporter.Errorf("The '--exclude-machinepools' option can only be used with '--worker-labels'")
+		exit.Fail()
+	}
+	var workerLabels map[string]string
+	if cmd.Flags().Changed("worker-labels") {
+		var err error
+		workerLabels, err = machines.ParseLabels(args.workerLabels)
+		if err != nil {
+			reporter.Errorf("Expected valid worker labels: %v", err)
+			exit.Fail()
+		}
+		if len(workerLabels) == 0 {
+			reporter.Errorf("Expected at least one worker label")
+			exit.Fail()
+		}
+	}
+
+	var patch map[string]interface{}
+	if cmd.Flags().Changed("patch") {
+		for _, flag := range editFlags {
+			if cmd.Flags().Changed(flag) {
+				reporter.Errorf("The '--patch' option can't be combined with '--%s'", flag)
+				exit.Fail()
+			}
+		}
+		if interactive.Enabled() {
+			reporter.Errorf("The '--patch' option can't be used in interactive mode")
+			exit.Fail()
+		}
+		var err error
+		patch, err = readPatch(args.patch)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
 	}
 
 	// Enable interactive mode if no flags have been set
-	if !interactive.Enabled() {
+	if !interactive.Enabled() && patch == nil {
 		changedFlags := false
-		for _, flag := range []string{"expiration-time", "expiration", "private"} {
+		for _, flag := range editFlags {
 			if cmd.Flags().Changed(flag) {
 				changedFlags = true
 			}
@@ -132,7 +251,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -148,27 +267,38 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := clusterprovider.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if patch != nil {
+		reporter.Debugf("Patching cluster '%s' with %s", clusterKey, args.patch)
+		err = ocm.PatchAttributes(ocmConnection, ocm.ClusterPath(cluster.ID()), patch)
+		if err != nil {
+			reporter.Errorf("Failed to patch cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		reporter.Infof("Updated cluster '%s'", clusterKey)
+		return
 	}
 
 	// Validate flags:
 	expiration, err := validateExpiration()
 	if err != nil {
 		reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if interactive.Enabled() {
@@ -196,26 +326,106 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		private = &privateValue
 	} else if privateValue {
-		reporter.Warnf("You are choosing to make your cluster API private. %s", privateWarning)
+		reporter.Warn(rprtr.WarnPrivateCluster,
+			"You are choosing to make your cluster API private. %s", privateWarning)
 		if !confirm.Confirm("set cluster '%s' as private", clusterKey) {
 			os.Exit(0)
 		}
 	}
 
+	var nodeDrainGracePeriod *time.Duration
+	if cmd.Flags().Changed("node-drain-grace-period") {
+		err = clusterprovider.ValidateNodeDrainGracePeriod(args.nodeDrainGracePeriod)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		nodeDrainGracePeriod = &args.nodeDrainGracePeriod
+	}
+
+	var disableWorkloadMonitoring *bool
+	if cmd.Flags().Changed("disable-workload-monitoring") {
+		disableWorkloadMonitoring = &args.disableWorkloadMonitoring
+	} else if interactive.Enabled() {
+		disabled, err := clusterprovider.IsWorkloadMonitoringDisabled(ocmConnection, cluster.ID())
+		if err != nil {
+			reporter.Errorf("Failed to get workload monitoring of cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		disabled, err = interactive.GetBool(interactive.Input{
+			Question: "Disable workload monitoring",
+			Help:     cmd.Flags().Lookup("disable-workload-monitoring").Usage,
+			Default:  disabled,
+		})
+		if err != nil {
+			reporter.Errorf("Expected a valid disable-workload-monitoring value: %s", err)
+			exit.Fail()
+		}
+		disableWorkloadMonitoring = &disabled
+	}
+
 	clusterConfig := clusterprovider.Spec{
-		Expiration: expiration,
-		Private:    private,
+		Expiration:           expiration,
+		Private:              private,
+		NodeDrainGracePeriod: nodeDrainGracePeriod,
 	}
 
 	reporter.Debugf("Updating cluster '%s'", clusterKey)
 	err = clusterprovider.UpdateCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN, clusterConfig)
 	if err != nil {
 		reporter.Errorf("Failed to update cluster: %v", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// The SDK doesn't support the workload monitoring setting yet, so it is updated separately:
+	if disableWorkloadMonitoring != nil {
+		reporter.Debugf("Setting workload monitoring of cluster '%s'", clusterKey)
+		err = clusterprovider.SetWorkloadMonitoring(ocmConnection, cluster.ID(), *disableWorkloadMonitoring)
+		if err != nil {
+			reporter.Errorf("Failed to update workload monitoring of cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+	}
+
+	if cmd.Flags().Changed("maintenance-exclusion") {
+		reporter.Debugf("Setting maintenance exclusion window of cluster '%s'", clusterKey)
+		err = upgrades.SetMaintenanceExclusion(ocmClient.Clusters(), cluster,
+			strings.TrimSpace(args.maintenanceExclusion))
+		if err != nil {
+			reporter.Errorf("Failed to update maintenance exclusion window of cluster '%s': %v",
+				clusterKey, err)
+			exit.Fail()
+		}
+	}
+
+	if workerLabels != nil {
+		reporter.Debugf("Adding worker labels to machine pools of cluster '%s'", clusterKey)
+		results, err := labels.Converge(ocmClient.Clusters(), cluster, workerLabels,
+			args.excludedMachinePools)
+		if err != nil {
+			reporter.Errorf("Failed to get machine pools of cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		failed := false
+		for _, result := range results {
+			switch result.Outcome {
+			case labels.OutcomeFailed:
+				failed = true
+				reporter.Errorf("Failed to update labels of machine pool '%s': %v",
+					result.MachinePool, result.Error)
+			case labels.OutcomeSkipped:
+				reporter.Warnf("Skipped machine pool '%s': %v", result.MachinePool, result.Error)
+			default:
+				reporter.Infof("Machine pool '%s': %s", result.MachinePool, result.Outcome)
+			}
+		}
+		if failed {
+			exit.Fail()
+		}
 	}
 	reporter.Infof("Updated cluster '%s'", clusterKey)
 }
@@ -245,6 +455,20 @@ func validateExpiration() (expiration time.Time, err error) {
 	return
 }
 
+// readPatch reads the raw partial cluster document given with the '--patch' flag, either directly
+// or from the file named after the '@' prefix, and checks that it only changes mutable fields.
+func readPatch(value string) (map[string]interface{}, error) {
+	data := []byte(value)
+	if strings.HasPrefix(value, "@") {
+		var err error
+		data, err = ioutil.ReadFile(strings.TrimPrefix(value, "@"))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read patch file: %v", err)
+		}
+	}
+	return clusterprovider.ParsePatch(data)
+}
+
 // parseRFC3339 parses an RFC3339 date in either RFC3339Nano or RFC3339 format.
 func parseRFC3339(s string) (time.Time, error) {
 	if t, timeErr := time.Parse(time.RFC3339Nano, s); timeErr == nil {
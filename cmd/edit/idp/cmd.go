@@ -0,0 +1,311 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/idps"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey     string
+	clientSecret   string
+	caPath         string
+	mappingMethod  string
+	emailClaims    []string
+	nameClaims     []string
+	usernameClaims []string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "idp NAME",
+	Aliases: []string{"idps"},
+	Short:   "Edit cluster IDP",
+	Long: "Edit the mutable fields of an identity provider of a cluster, without deleting it, so " +
+		"that its users aren't logged out. The differences are shown before they are applied.",
+	Example: `  # Rotate the client secret of an identity provider named "github-1"
+  rosa edit idp github-1 --cluster=mycluster --client-secret=...
+
+  # Change the CA bundle and the claims of an OpenID identity provider
+  rosa edit idp openid-1 --cluster=mycluster --ca=ca.pem --username-claims=preferred_username,email
+
+  # Remove the CA bundle of an identity provider
+  rosa edit idp gitlab-1 --cluster=mycluster --ca=""`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line parameter containing the name of the identity provider",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster of the IdP (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.clientSecret,
+		"client-secret",
+		"",
+		"New client secret from the registered application.",
+	)
+	flags.StringVar(
+		&args.caPath,
+		"ca",
+		"",
+		"Path to the new PEM-encoded certificate file to use when making requests to the server. "+
+			"An empty value removes the current certificates.",
+	)
+	flags.StringVar(
+		&args.mappingMethod,
+		"mapping-method",
+		"",
+		fmt.Sprintf("Specifies how new identities are mapped to users when they log in. "+
+			"Options are %s.", idps.MappingMethods),
+	)
+	flags.StringSliceVar(
+		&args.emailClaims,
+		"email-claims",
+		nil,
+		"OpenID: List of claims to use as the email address. LDAP: list of attributes to use "+
+			"as the email address.",
+	)
+	flags.StringSliceVar(
+		&args.nameClaims,
+		"name-claims",
+		nil,
+		"OpenID: List of claims to use as the display name. LDAP: list of attributes to use "+
+			"as the display name.",
+	)
+	flags.StringSliceVar(
+		&args.usernameClaims,
+		"username-claims",
+		nil,
+		"OpenID: List of claims to use as the preferred username. LDAP: list of attributes to "+
+			"use as the preferred username.",
+	)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	idpName := argv[0]
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	changes, err := getChanges(cmd)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	// Try to find the identity provider:
+	reporter.Debugf("Loading identity provider '%s'", idpName)
+	items, err := ocm.GetIdentityProviders(clustersCollection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	var idp *cmv1.IdentityProvider
+	for _, item := range items {
+		if item.Name() == idpName {
+			idp = item
+		}
+	}
+	if idp == nil {
+		reporter.Errorf("Failed to get identity provider '%s' for cluster '%s'", idpName, clusterKey)
+		exit.Fail()
+	}
+
+	attributes, err := idps.GetIdentityProvider(ocmConnection, cluster.ID(), idp.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get identity provider '%s' for cluster '%s': %v",
+			idpName, clusterKey, err)
+		exit.Fail()
+	}
+	plan, err := idps.NewPlan(attributes, changes)
+	if err != nil {
+		reporter.Errorf("Can't edit identity provider '%s': %v", idpName, err)
+		exit.Fail()
+	}
+	if plan.Empty() {
+		reporter.Infof("Identity provider '%s' already has the requested configuration", idpName)
+		os.Exit(0)
+	}
+
+	// Show the differences before applying them:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "FIELD\tCURRENT\tNEW\n")
+	for _, change := range plan.Changes {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", change.Field, valueOrNone(change.Old),
+			valueOrNone(change.New))
+	}
+	writer.Flush()
+
+	if !confirm.Confirm("edit identity provider %s on cluster %s", idpName, clusterKey) {
+		os.Exit(0)
+	}
+
+	reporter.Debugf("Updating identity provider '%s' on cluster '%s'", idpName, clusterKey)
+	err = plan.Apply(ocmConnection, cluster.ID(), idp.ID())
+	if err != nil {
+		reporter.Errorf("Failed to update identity provider '%s' on cluster '%s': %v",
+			idpName, clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Updated identity provider '%s' on cluster '%s'", idpName, clusterKey)
+}
+
+// getChanges returns the changes requested with the command line flags.
+func getChanges(cmd *cobra.Command) (*idps.Changes, error) {
+	flags := cmd.Flags()
+	changes := &idps.Changes{}
+
+	if flags.Changed("client-secret") {
+		changes.ClientSecret = &args.clientSecret
+	}
+
+	if flags.Changed("ca") {
+		ca := ""
+		if args.caPath != "" {
+			data, err := ioutil.ReadFile(args.caPath)
+			if err != nil {
+				return nil, fmt.Errorf("Expected a valid certificate bundle: %s", err)
+			}
+			ca = string(data)
+		}
+		changes.CA = &ca
+	}
+
+	if flags.Changed("mapping-method") {
+		changes.MappingMethod = &args.mappingMethod
+	}
+
+	if flags.Changed("email-claims") {
+		changes.EmailClaims = trim(args.emailClaims)
+	}
+	if flags.Changed("name-claims") {
+		changes.NameClaims = trim(args.nameClaims)
+	}
+	if flags.Changed("username-claims") {
+		changes.UsernameClaims = trim(args.usernameClaims)
+	}
+
+	if changes.ClientSecret == nil && changes.CA == nil && changes.MappingMethod == nil &&
+		changes.EmailClaims == nil && changes.NameClaims == nil && changes.UsernameClaims == nil {
+		return nil, fmt.Errorf("Expected at least one of '--client-secret', '--ca', " +
+			"'--mapping-method', '--email-claims', '--name-claims' or '--username-claims'")
+	}
+	return changes, nil
+}
+
+func trim(values []string) *[]string {
+	result := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+	return &result
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
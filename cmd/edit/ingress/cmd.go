@@ -27,9 +27,12 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -41,6 +44,7 @@ var args struct {
 	clusterKey string
 	private    bool
 	labelMatch string
+	lbType     string
 }
 
 var Cmd = &cobra.Command{
@@ -55,7 +59,10 @@ var Cmd = &cobra.Command{
   rosa edit ingress --label-match=foo=bar --cluster=mycluster a1b2
 
   # Update the default ingress using the sub-domain identifier
-  rosa edit ingress --private=false --cluster=mycluster apps`,
+  rosa edit ingress --private=false --cluster=mycluster apps
+
+  # Use a network load balancer for the default ingress
+  rosa edit ingress --lb-type=nlb --cluster=mycluster apps`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -75,7 +82,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to edit the ingress of (required).",
+		"Name, ID or external ID of the cluster to edit the ingress of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -93,6 +100,13 @@ func init() {
 		"Label match for ingress. Format should be a comma-separated list of 'key=value'. "+
 			"If no label is specified, all routes will be exposed on both routers.",
 	)
+
+	flags.StringVar(
+		&args.lbType,
+		"lb-type",
+		"",
+		"Type of load balancer of the default ingress, either 'classic' or 'nlb'.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -105,7 +119,7 @@ func run(cmd *cobra.Command, argv []string) {
 			"Ingress  identifier '%s' isn't valid: it must contain only letters or digits",
 			ingressID,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
@@ -117,7 +131,18 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	lbType := args.lbType
+	if lbType != "" && !ocm.IsValidLoadBalancerType(lbType) {
+		reporter.Errorf("Expected a valid load balancer type, either '%s' or '%s'",
+			ocm.LoadBalancerTypeClassic, ocm.LoadBalancerTypeNLB)
+		exit.Fail()
+	}
+	if lbType != "" && ingressID == "api" {
+		reporter.Errorf("The load balancer type can't be changed for the API, only for the default ingress")
+		exit.Fail()
 	}
 
 	labelMatch := args.labelMatch
@@ -131,14 +156,14 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	if labelMatch != "" {
 		routeSelectors, err = getRouteSelector(labelMatch)
 		if err != nil {
 			reporter.Errorf("%s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -153,7 +178,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		private = &privArg
 	}
@@ -164,13 +189,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -179,7 +204,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -193,15 +218,15 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.AWS().PrivateLink() {
 		reporter.Errorf("Cluster '%s' is PrivateLink and does not support updating ingresses", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Edit API endpoint instead of ingresses
@@ -213,7 +238,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err = clusterprovider.UpdateCluster(clustersCollection, clusterKey, awsCreator.ARN, clusterConfig)
 		if err != nil {
 			reporter.Errorf("Failed to update cluster API on cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		os.Exit(0)
@@ -224,7 +249,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ingresses, err := ocm.GetIngresses(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get ingresses for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var ingress *cmv1.Ingress
@@ -241,7 +266,23 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	if ingress == nil {
 		reporter.Errorf("Failed to get ingress '%s' for cluster '%s'", ingressID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if lbType != "" && !ingress.Default() {
+		reporter.Errorf("The load balancer type can only be changed for the default ingress")
+		exit.Fail()
+	}
+
+	// Replacing the load balancer interrupts connections, so the user needs to confirm it before
+	// any change is sent:
+	if lbType != "" {
+		reporter.Warn(rprtr.WarnLoadBalancerReplaced,
+			"Changing the load balancer type replaces the load balancer of the router. "+
+				"Connections will be drained and may be interrupted until DNS points to the new load balancer.")
+		if !confirm.Confirm("replace the load balancer of ingress '%s' on cluster '%s'", ingress.ID(), clusterKey) {
+			os.Exit(0)
+		}
 	}
 
 	ingressBuilder := cmv1.NewIngress().ID(ingress.ID())
@@ -263,7 +304,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ingress, err = ingressBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to create ingress for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Debugf("Updating ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
@@ -277,8 +318,25 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Debugf(err.Error())
 		reporter.Errorf("Failed to update ingress '%s' on cluster '%s': %s",
-			ingress.ID(), clusterKey, res.Error().Reason())
-		os.Exit(1)
+			ingress.ID(), clusterKey, ocm.ErrorReason(res.Error()))
+		exit.Fail()
+	}
+
+	if lbType != "" {
+		reporter.Debugf("Setting load balancer type of ingress '%s' on cluster '%s' to '%s'",
+			ingress.ID(), clusterKey, lbType)
+		err = ocm.PatchAttributes(
+			ocmConnection,
+			fmt.Sprintf("%s/ingresses/%s", ocm.ClusterPath(cluster.ID()), ingress.ID()),
+			map[string]interface{}{
+				"load_balancer_type": lbType,
+			},
+		)
+		if err != nil {
+			reporter.Errorf("Failed to update load balancer type of ingress '%s' on cluster '%s': %v",
+				ingress.ID(), clusterKey, err)
+			exit.Fail()
+		}
 	}
 	reporter.Infof("Updated ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
 }
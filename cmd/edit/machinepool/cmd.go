@@ -21,16 +21,26 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"time"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
 	c "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/kubeletconfigs"
+	"github.com/openshift/rosa/pkg/ocm/machinepools/replace"
+	"github.com/openshift/rosa/pkg/ocm/machines"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/validation"
 )
 
 // Regular expression to used to make sure that the identifier given by the
@@ -45,6 +55,12 @@ var args struct {
 	maxReplicas        int
 	labels             string
 	taints             string
+	instanceType       string
+	surge              int
+	wait               bool
+	waitTimeout        time.Duration
+	kubeletConfigs     []string
+	maxPodsPerNode     int
 }
 
 var Cmd = &cobra.Command{
@@ -55,7 +71,13 @@ var Cmd = &cobra.Command{
 	Example: `  # Set 4 replicas on machine pool 'mp1' on cluster 'mycluster'
   rosa edit machinepool --replicas=4 --cluster=mycluster mp1
   # Enable autoscaling and Set 3-5 replicas on machine pool 'mp1' on cluster 'mycluster'
-  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1`,
+  rosa edit machinepool --enable-autoscaling --min-replicas=3 --max-replicas=5 --cluster=mycluster mp1
+  # Set 6 replicas on machine pool 'mp1' and wait until the cluster reports the new nodes
+  rosa edit machinepool --replicas=6 --wait --cluster=mycluster mp1
+  # Move machine pool 'mp1' to m5.2xlarge instances, replacing two nodes at a time
+  rosa edit machinepool --instance-type=m5.2xlarge --surge=2 --cluster=mycluster mp1
+  # Allow up to 350 pods in each node of machine pool 'mp1'
+  rosa edit machinepool --max-pods-per-node=350 --cluster=mycluster mp1`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
 		if len(argv) != 1 {
@@ -75,7 +97,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to edit the machine pool of (required).",
+		"Name, ID or external ID of the cluster to edit the machine pool of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -122,6 +144,56 @@ func init() {
 		"Taints for machine pool. Format should be a comma-separated list of 'key=value:ScheduleType'. "+
 			"This list will overwrite any modifications made to node taints on an ongoing basis.",
 	)
+
+	flags.StringVar(
+		&args.instanceType,
+		"instance-type",
+		"",
+		"Instance type of the machine pool. The instance type of a machine pool can't be changed "+
+			"in place, so a new machine pool is created with the new instance type and the old "+
+			"one is drained and deleted.",
+	)
+
+	flags.IntVar(
+		&args.surge,
+		"surge",
+		1,
+		"Number of nodes added above the requested replicas at each step when changing the "+
+			"instance type.",
+	)
+
+	flags.BoolVar(
+		&args.wait,
+		"wait",
+		false,
+		"Wait until the cluster reports the number of compute nodes requested. Node counts "+
+			"come from the cluster metrics, which can take several minutes to be updated.",
+	)
+
+	flags.DurationVar(
+		&args.waitTimeout,
+		"wait-timeout",
+		30*time.Minute,
+		"Maximum time to wait when using '--wait', or for each step when changing the "+
+			"instance type.",
+	)
+
+	flags.StringSliceVar(
+		&args.kubeletConfigs,
+		"kubelet-configs",
+		nil,
+		"Names of the kubelet configurations, created with 'rosa create kubeletconfig', to "+
+			"apply to the nodes of the machine pool. An empty value removes them.",
+	)
+
+	flags.IntVar(
+		&args.maxPodsPerNode,
+		"max-pods-per-node",
+		0,
+		fmt.Sprintf("Maximum number of pods in each node of the machine pool, between %d and %d. "+
+			"A kubelet configuration with this value is created or updated for the machine pool.",
+			kubeletconfigs.MinMaxPods, kubeletconfigs.MaxMaxPods),
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -131,7 +203,7 @@ func run(cmd *cobra.Command, argv []string) {
 	machinePoolID := argv[0]
 	if machinePoolID != "Default" && !machinePoolKeyRE.MatchString(machinePoolID) {
 		reporter.Errorf("Expected a valid identifier for the machine pool")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Check that the cluster key (name, identifier or external identifier) given by the user
@@ -143,7 +215,15 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if cmd.Flags().Changed("max-pods-per-node") {
+		err := (&kubeletconfigs.KubeletConfig{MaxPods: args.maxPodsPerNode}).Validate()
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
 	}
 
 	// Create the AWS client:
@@ -153,13 +233,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -168,7 +248,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -182,21 +262,29 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Editing the default machine pool is a different process
 	if machinePoolID == "Default" {
 		if cmd.Flags().Changed("labels") {
 			reporter.Errorf("Labels cannot be updated on the Default machine pool")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if cmd.Flags().Changed("taints") {
 			reporter.Errorf("Taints are not supported on the Default machine pool")
-			os.Exit(1)
+			exit.Fail()
+		}
+		if cmd.Flags().Changed("instance-type") {
+			reporter.Errorf("The instance type of the Default machine pool can't be changed")
+			exit.Fail()
+		}
+		if kubeletConfigsChanged(cmd) {
+			reporter.Errorf("Kubelet configurations are not supported on the Default machine pool")
+			exit.Fail()
 		}
 
 		autoscaling, replicas, minReplicas, maxReplicas := getReplicas(cmd, reporter, machinePoolID,
@@ -206,18 +294,27 @@ func run(cmd *cobra.Command, argv []string) {
 			if !autoscaling && replicas < 3 ||
 				(autoscaling && cmd.Flags().Changed("min-replicas") && minReplicas < 3) {
 				reporter.Errorf("Default machine pool for AZ cluster requires at least 3 compute nodes")
-				os.Exit(1)
+				exit.Fail()
 			}
 
 			if !autoscaling && replicas%3 != 0 ||
 				(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0)) {
 				reporter.Errorf("Multi AZ clusters require that the number of compute nodes be a multiple of 3")
-				os.Exit(1)
+				exit.Fail()
 			}
 		} else if !autoscaling && replicas < 2 ||
 			(autoscaling && cmd.Flags().Changed("min-replicas") && minReplicas < 2) {
 			reporter.Errorf("Default machine pool requires at least 2 compute nodes")
-			os.Exit(1)
+			exit.Fail()
+		}
+
+		if autoscaling {
+			current := cluster.Nodes().Compute()
+			if cluster.Nodes().AutoscaleCompute() != nil {
+				current = cluster.Nodes().AutoscaleCompute().MinReplicas()
+			}
+			warnAutoscalingQuota(reporter, logger, ocmConnection, cluster,
+				cluster.Nodes().ComputeMachineType().ID(), current, maxReplicas)
 		}
 
 		clusterConfig := c.Spec{
@@ -232,7 +329,10 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			reporter.Errorf("Failed to update machine pool '%s' on cluster '%s': %s",
 				machinePoolID, clusterKey, err)
-			os.Exit(1)
+			exit.Fail()
+		}
+		if args.wait {
+			waitForNodes(reporter, clustersCollection, cluster, machinePoolID, clusterKey)
 		}
 
 		os.Exit(0)
@@ -243,7 +343,7 @@ func run(cmd *cobra.Command, argv []string) {
 	machinePools, err := ocm.GetMachinePools(clustersCollection, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var machinePool *cmv1.MachinePool
@@ -254,7 +354,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	if machinePool == nil {
 		reporter.Errorf("Failed to get machine pool '%s' for cluster '%s'", machinePoolID, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	autoscaling, replicas, minReplicas, maxReplicas := getReplicas(cmd, reporter, machinePoolID,
@@ -263,47 +363,36 @@ func run(cmd *cobra.Command, argv []string) {
 	if !autoscaling && replicas < 0 ||
 		(autoscaling && cmd.Flags().Changed("min-replicas") && minReplicas < 1) {
 		reporter.Errorf("The number of machine pool replicas needs to be a positive integer")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.MultiAZ() &&
 		(!autoscaling && replicas%3 != 0 ||
 			(autoscaling && (minReplicas%3 != 0 || maxReplicas%3 != 0))) {
 		reporter.Errorf("Multi AZ clusters require that the number of MachinePool replicas be a multiple of 3")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	labels := args.labels
-	labelMap := make(map[string]string)
 	if interactive.Enabled() {
 		if labels == "" {
-			for lk, lv := range machinePool.Labels() {
-				if labels != "" {
-					labels += ","
-				}
-				labels += fmt.Sprintf("%s=%s", lk, lv)
-			}
+			labels = machines.FormatLabels(machinePool.Labels())
 		}
 		labels, err = interactive.GetString(interactive.Input{
-			Question: "Labels",
-			Help:     cmd.Flags().Lookup("labels").Usage,
-			Default:  labels,
+			Question:   "Labels",
+			Help:       cmd.Flags().Lookup("labels").Usage,
+			Default:    labels,
+			Validators: []interactive.Validator{validation.Labels},
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
-	labels = strings.Trim(labels, " ")
-	if labels != "" {
-		for _, label := range strings.Split(labels, ",") {
-			if !strings.Contains(label, "=") {
-				reporter.Errorf("Expected key=value format for labels")
-				os.Exit(1)
-			}
-			tokens := strings.Split(label, "=")
-			labelMap[strings.TrimSpace(tokens[0])] = strings.TrimSpace(tokens[1])
-		}
+	labelMap, err := machines.ParseLabels(labels)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
 	}
 
 	taints := args.taints
@@ -324,7 +413,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	taints = strings.Trim(taints, " ")
@@ -332,13 +421,37 @@ func run(cmd *cobra.Command, argv []string) {
 		for _, taint := range strings.Split(taints, ",") {
 			if !strings.Contains(taint, "=") || !strings.Contains(taint, ":") {
 				reporter.Errorf("Expected key=value:scheduleType format for taints")
-				os.Exit(1)
+				exit.Fail()
 			}
 			tokens := strings.FieldsFunc(taint, Split)
 			taintBuilders = append(taintBuilders, cmv1.NewTaint().Key(tokens[0]).Value(tokens[1]).Effect(tokens[2]))
 		}
 	}
 
+	if autoscaling {
+		// Changing the instance type replaces the nodes of the machine pool:
+		machineType := machinePool.InstanceType()
+		current := machinePool.Replicas()
+		if machinePool.Autoscaling() != nil {
+			current = machinePool.Autoscaling().MinReplicas()
+		}
+		if args.instanceType != "" && args.instanceType != machineType {
+			machineType = args.instanceType
+			current = 0
+		}
+		warnAutoscalingQuota(reporter, logger, ocmConnection, cluster, machineType, current, maxReplicas)
+	}
+
+	if args.instanceType != "" && args.instanceType != machinePool.InstanceType() {
+		if kubeletConfigsChanged(cmd) {
+			reporter.Errorf("Kubelet configurations can't be changed together with the instance type")
+			exit.Fail()
+		}
+		replaceMachinePool(cmd, reporter, ocmConnection.ClustersMgmt().V1(), cluster, machinePool,
+			autoscaling, replicas, minReplicas, maxReplicas, labelMap, taintBuilders)
+		return
+	}
+
 	mpBuilder := cmv1.NewMachinePool().
 		ID(machinePool.ID())
 
@@ -370,7 +483,7 @@ func run(cmd *cobra.Command, argv []string) {
 	machinePool, err = mpBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Debugf("Updating machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
@@ -384,10 +497,171 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Debugf(err.Error())
 		reporter.Errorf("Failed to update machine pool '%s' on cluster '%s': %s",
-			machinePool.ID(), clusterKey, res.Error().Reason())
-		os.Exit(1)
+			machinePool.ID(), clusterKey, ocm.ErrorReason(res.Error()))
+		exit.Fail()
+	}
+	if kubeletConfigsChanged(cmd) {
+		updateKubeletConfigs(cmd, reporter, ocmConnection, cluster, machinePool.ID())
 	}
 	reporter.Infof("Updated machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
+	if args.wait {
+		waitForNodes(reporter, clustersCollection, cluster, machinePool.ID(), clusterKey)
+	}
+}
+
+// kubeletConfigsChanged checks if the user requested to change the kubelet configurations of the
+// machine pool.
+func kubeletConfigsChanged(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("kubelet-configs") || cmd.Flags().Changed("max-pods-per-node")
+}
+
+// updateKubeletConfigs sets the kubelet configurations of the machine pool requested by the user,
+// creating or updating the one that sets the maximum number of pods per node if needed.
+func updateKubeletConfigs(cmd *cobra.Command, reporter *rprtr.Object, connection *sdk.Connection,
+	cluster *cmv1.Cluster, machinePoolID string) {
+	clusterKey := args.clusterKey
+	var configs []string
+	if cmd.Flags().Changed("kubelet-configs") {
+		for _, config := range args.kubeletConfigs {
+			if config != "" {
+				configs = append(configs, config)
+			}
+		}
+		err := kubeletconfigs.CheckExist(connection, cluster.ID(), configs)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	} else {
+		current, err := kubeletconfigs.GetMachinePoolConfigs(connection, cluster.ID(), machinePoolID)
+		if err != nil {
+			reporter.Errorf("Failed to get kubelet configurations of machine pool '%s': %v",
+				machinePoolID, err)
+			exit.Fail()
+		}
+		configs = current
+	}
+	if cmd.Flags().Changed("max-pods-per-node") {
+		config, err := kubeletconfigs.EnsureMaxPods(connection, cluster.ID(), machinePoolID,
+			args.maxPodsPerNode)
+		if err != nil {
+			reporter.Errorf("Failed to configure the maximum number of pods per node: %v", err)
+			exit.Fail()
+		}
+		found := false
+		for _, name := range configs {
+			found = found || name == config
+		}
+		if !found {
+			configs = append(configs, config)
+		}
+	}
+	if configs == nil {
+		configs = []string{}
+	}
+	reporter.Debugf("Setting kubelet configurations of machine pool '%s' on cluster '%s' to %v",
+		machinePoolID, clusterKey, configs)
+	err := kubeletconfigs.AttachToMachinePool(connection, cluster.ID(), machinePoolID, configs)
+	if err != nil {
+		reporter.Errorf("Failed to update kubelet configurations of machine pool '%s' on "+
+			"cluster '%s': %v", machinePoolID, clusterKey, err)
+		exit.Fail()
+	}
+}
+
+// replaceMachinePool replaces the machine pool with a new one that uses the instance type given by
+// the user, keeping the rest of the configuration and applying the other changes requested.
+func replaceMachinePool(cmd *cobra.Command, reporter *rprtr.Object, client *cmv1.Client,
+	cluster *cmv1.Cluster, machinePool *cmv1.MachinePool, autoscaling bool,
+	replicas, minReplicas, maxReplicas int, labels map[string]string, taints []*cmv1.TaintBuilder) {
+	clusterKey := args.clusterKey
+
+	instanceTypeList, err := machines.GetMachineTypeList(client)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+	instanceType, err := machines.ValidateMachineType(args.instanceType, instanceTypeList)
+	if err != nil {
+		reporter.Errorf("Expected a valid machine type: %s", err)
+		exit.Fail()
+	}
+	if args.surge < 1 {
+		reporter.Errorf("The surge needs to be a positive integer")
+		exit.Fail()
+	}
+	if cluster.MultiAZ() && args.surge%3 != 0 {
+		reporter.Errorf("Multi AZ clusters require that the surge be a multiple of 3")
+		exit.Fail()
+	}
+
+	targetBuilder := cmv1.NewMachinePool().
+		ID(machinePool.ID()).
+		InstanceType(instanceType).
+		AvailabilityZones(machinePool.AvailabilityZones()...)
+	if cmd.Flags().Changed("labels") || interactive.Enabled() {
+		targetBuilder = targetBuilder.Labels(labels)
+	} else {
+		targetBuilder = targetBuilder.Labels(machinePool.Labels())
+	}
+	if !cmd.Flags().Changed("taints") && !interactive.Enabled() {
+		taints = []*cmv1.TaintBuilder{}
+		for _, taint := range machinePool.Taints() {
+			taints = append(taints, cmv1.NewTaint().
+				Key(taint.Key()).
+				Value(taint.Value()).
+				Effect(taint.Effect()))
+		}
+	}
+	targetBuilder = targetBuilder.Taints(taints...)
+	if autoscaling {
+		targetBuilder = targetBuilder.Autoscaling(cmv1.NewMachinePoolAutoscaling().
+			MinReplicas(minReplicas).
+			MaxReplicas(maxReplicas))
+	} else {
+		targetBuilder = targetBuilder.Replicas(replicas)
+	}
+	target, err := targetBuilder.Build()
+	if err != nil {
+		reporter.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	newID := replace.PoolID(machinePool, instanceType)
+	reporter.Infof("The instance type of a machine pool can't be changed in place. Machine pool '%s' "+
+		"will be created with instance type '%s', and machine pool '%s' will be drained and deleted.",
+		newID, instanceType, machinePool.ID())
+	if !confirm.Confirm("replace machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey) {
+		os.Exit(0)
+	}
+
+	_, err = replace.Replace(client.Clusters(), cluster.ID(), machinePool, target, &replace.Options{
+		Surge:    args.surge,
+		Timeout:  args.waitTimeout,
+		Progress: reporter.Infof,
+	})
+	if err != nil {
+		reporter.Errorf("Failed to replace machine pool '%s' on cluster '%s': %v",
+			machinePool.ID(), clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Replaced machine pool '%s' with machine pool '%s' on cluster '%s'",
+		machinePool.ID(), newID, clusterKey)
+}
+
+// waitForNodes waits until the cluster reports the compute nodes requested, so that pipelines can
+// wait for the capacity to be available before deploying workloads.
+func waitForNodes(reporter *rprtr.Object, clusters *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	machinePoolID string, clusterKey string) {
+	reporter.Infof("Waiting up to %s for the compute nodes of cluster '%s' to be ready",
+		args.waitTimeout, clusterKey)
+	nodes, err := c.WaitForComputeNodes(clusters, cluster.ID(), args.waitTimeout)
+	if err != nil {
+		reporter.Errorf("Failed to wait for machine pool '%s' on cluster '%s': %v",
+			machinePoolID, clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Cluster '%s' reports %d compute nodes", clusterKey, nodes)
 }
 
 func getReplicas(cmd *cobra.Command,
@@ -412,14 +686,14 @@ func getReplicas(cmd *cobra.Command,
 	if (isMinReplicasSet || isMaxReplicasSet) && !autoscaling && existingAutoscaling == nil {
 		reporter.Errorf("Autoscaling is not enabled on machine pool '%s'. can't set min or max replicas",
 			machinePoolID)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// if the user set replicas but enabled autoscaling or hasn't disabled existing autoscaling
 	if isReplicasSet && existingAutoscaling != nil && (!isAutoscalingSet || autoscaling) {
 		reporter.Errorf("Autoscaling enabled on machine pool '%s'. can't set replicas",
 			machinePoolID)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if !isAutoscalingSet {
@@ -433,7 +707,7 @@ func getReplicas(cmd *cobra.Command,
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid value for enable-autoscaling: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 	}
@@ -449,7 +723,7 @@ func getReplicas(cmd *cobra.Command,
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of min replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 
@@ -463,7 +737,7 @@ func getReplicas(cmd *cobra.Command,
 			})
 			if err != nil {
 				reporter.Errorf("Expected a valid number of max replicas: %s", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 	} else if interactive.Enabled() || !isReplicasSet {
@@ -478,12 +752,35 @@ func getReplicas(cmd *cobra.Command,
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid number of replicas: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	return
 }
 
+// warnAutoscalingQuota warns if the quotas don't allow the autoscaler to reach the maximum number
+// of replicas of the machine pool, so that users don't find out when scaling up fails.
+func warnAutoscalingQuota(reporter *rprtr.Object, logger *logrus.Logger, connection *sdk.Connection,
+	cluster *cmv1.Cluster, machineType string, current int, maxReplicas int) {
+	// The AWS quotas are per region:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Region(cluster.Region().ID()).
+		Build()
+	if err != nil {
+		reporter.Debugf("Failed to check quota for autoscaling: %v", err)
+		return
+	}
+	warnings, err := machines.CheckAutoscalingQuota(connection, awsClient, machineType,
+		cluster.MultiAZ(), current, maxReplicas)
+	for _, warning := range warnings {
+		reporter.Warnf("%s", warning)
+	}
+	if err != nil {
+		reporter.Debugf("Failed to check quota for autoscaling: %v", err)
+	}
+}
+
 func Split(r rune) bool {
 	return r == '=' || r == ':'
 }
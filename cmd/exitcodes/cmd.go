@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exitcodes
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "List the exit codes of the tool",
+	Long: "List the exit codes used by the tool when a command fails, so that scripts and wrappers " +
+		"can decide what to do, for example if it is worth retrying the command.",
+	Example: `  # List the exit codes
+  rosa exit-codes
+
+  # List the exit codes in JSON format
+  rosa exit-codes --output json`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd.Flags(), output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	err := output.WriteFile(exit.Codes)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(exit.Codes)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "CODE\tNAME\tRETRYABLE\tDESCRIPTION\n")
+	for _, code := range exit.Codes {
+		retryable := "no"
+		if code.Retryable {
+			retryable = "yes"
+		}
+		fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", code.Code, code.Name, retryable, code.Description)
+	}
+	writer.Flush()
+}
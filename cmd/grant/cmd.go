@@ -19,6 +19,7 @@ package grant
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/grant/infraaccess"
 	"github.com/openshift/rosa/cmd/grant/user"
 	"github.com/openshift/rosa/pkg/arguments"
 )
@@ -30,6 +31,7 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(infraaccess.Cmd)
 	Cmd.AddCommand(user.Cmd)
 
 	flags := Cmd.PersistentFlags()
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infraaccess
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	userARN    string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "aws-infrastructure-access ROLE",
+	Aliases: []string{"aws-infra-access"},
+	Short:   "Grant access to the AWS infrastructure of a cluster",
+	Long: "Grant an AWS IAM user or role access to the AWS console of the account of the cluster " +
+		"under a specific role.",
+	Example: `  # Grant read-only access to the infrastructure of a cluster to an IAM user
+  rosa grant aws-infrastructure-access read-only --cluster=mycluster \
+    --user-arn=arn:aws:iam::123456789012:user/myuser
+
+  # Grant network management access to an IAM role
+  rosa grant aws-infrastructure-access network-mgmt --cluster=mycluster \
+    --user-arn=arn:aws:iam::123456789012:role/myrole`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the identifier of the role " +
+					"to grant. Run 'rosa list aws-infrastructure-access --roles' to see the roles.",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to grant access to (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.userARN,
+		"user-arn",
+		"",
+		"ARN of the AWS IAM user or role to grant the role to (required).",
+	)
+	Cmd.MarkFlagRequired("user-arn")
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	userARN := args.userARN
+	parsedARN, err := arn.Parse(userARN)
+	if err != nil || parsedARN.Service != "iam" {
+		reporter.Errorf("Expected a valid ARN of an AWS IAM user or role, got '%s'", userARN)
+		exit.Fail()
+	}
+
+	roleID := argv[0]
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	ocmClient := ocmConnection.ClustersMgmt().V1()
+	clustersCollection := ocmClient.Clusters()
+
+	// Check that the role exists:
+	roles, err := ocm.GetAWSInfrastructureAccessRoles(ocmClient)
+	if err != nil {
+		reporter.Errorf("Failed to get AWS infrastructure access roles: %v", err)
+		exit.Fail()
+	}
+	validRoles := []string{}
+	isRoleValid := false
+	for _, role := range roles {
+		validRoles = append(validRoles, role.ID())
+		if role.ID() == roleID {
+			isRoleValid = true
+		}
+	}
+	if !isRoleValid {
+		reporter.Errorf("Expected one of %s", validRoles)
+		exit.Fail()
+	}
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Granting role '%s' to '%s' on cluster '%s'", roleID, userARN, clusterKey)
+	grant, err := ocm.AddAWSInfrastructureAccessRoleGrant(clustersCollection, cluster.ID(), roleID, userARN)
+	if err != nil {
+		reporter.Errorf("Failed to grant role '%s' to '%s' on cluster '%s': %v",
+			roleID, userARN, clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Infof("Granted role '%s' to '%s' on cluster '%s'", roleID, userARN, clusterKey)
+	if grant.State() != cmv1.AWSInfrastructureAccessRoleGrantStateReady {
+		reporter.Infof("The grant is in '%s' state. To check when it is ready, run "+
+			"'rosa list aws-infrastructure-access -c %s'", grant.State(), clusterKey)
+	}
+	if grant.ConsoleURL() != "" {
+		reporter.Infof("Console URL: %s", grant.ConsoleURL())
+	}
+}
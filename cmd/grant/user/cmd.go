@@ -18,15 +18,16 @@ package user
 
 import (
 	"fmt"
-	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -70,7 +71,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to add the user to (required).",
+		"Name, ID or external ID of the cluster to add the user to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -97,7 +98,7 @@ func run(_ *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	username := args.username
@@ -106,11 +107,11 @@ func run(_ *cobra.Command, argv []string) {
 			"Username '%s' isn't valid: it must contain only letters, digits, dashes and underscores",
 			username,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if username == "cluster-admin" {
 		reporter.Errorf("Username 'cluster-admin' is not allowed")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	role := argv[0]
@@ -129,14 +130,14 @@ func run(_ *cobra.Command, argv []string) {
 	}
 	if !isRoleValid {
 		reporter.Errorf("Expected at least one of %s", validRoles)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -146,13 +147,13 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -161,7 +162,7 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -175,21 +176,21 @@ func run(_ *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	user, err := cmv1.NewUser().ID(username).Build()
 	if err != nil {
 		reporter.Errorf("Failed to create user '%s' for cluster '%s'", username, clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Debugf("Adding user '%s' to group '%s' in cluster '%s'", username, role, clusterKey)
@@ -197,8 +198,8 @@ func run(_ *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Debugf(err.Error())
 		reporter.Errorf("Failed to grant '%s' to user '%s' to cluster '%s': %s",
-			role, username, clusterKey, res.Error().Reason())
-		os.Exit(1)
+			role, username, clusterKey, ocm.ErrorReason(res.Error()))
+		exit.Fail()
 	}
 
 	reporter.Infof("Granted role '%s' to user '%s' on cluster '%s'", role, username, clusterKey)
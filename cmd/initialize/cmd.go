@@ -21,7 +21,7 @@ import (
 	"os"
 	"strings"
 
-	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/login"
@@ -32,7 +32,9 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/aws/stsendpoint"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/ocm/config"
@@ -43,6 +45,8 @@ var args struct {
 	deleteStack      bool
 	disableSCPChecks bool
 	region           string
+	mode             string
+	bundleDir        string
 }
 
 var Cmd = &cobra.Command{
@@ -54,7 +58,10 @@ var Cmd = &cobra.Command{
   rosa init
 
   # Configure a new AWS account using pre-existing OCM credentials
-  rosa init --token=$OFFLINE_ACCESS_TOKEN`,
+  rosa init --token=$OFFLINE_ACCESS_TOKEN
+
+  # Write the files needed to configure the AWS account manually
+  rosa init --mode manual --bundle-dir ./rosa-setup`,
 	Run: run,
 }
 
@@ -76,17 +83,52 @@ func init() {
 		"Indicates if cloud permission checks are disabled when attempting installation of the cluster.",
 	)
 
+	flags.StringVar(
+		&args.mode,
+		"mode",
+		aws.ModeAuto,
+		"How to create the AWS resources. In 'auto' mode they are created directly. In 'manual' "+
+			"mode the templates, policies and a script to create them are written to the bundle "+
+			"directory, so that they can be reviewed and applied elsewhere.",
+	)
+
+	flags.StringVar(
+		&args.bundleDir,
+		"bundle-dir",
+		"rosa-setup",
+		"Directory where the files are written in 'manual' mode.",
+	)
+
 	// Force-load all flags from `login` into `init`
 	flags.AddFlagSet(login.Cmd.Flags())
 
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
+	arguments.AddSTSRegionEndpointFlag(flags)
 }
 
 func run(cmd *cobra.Command, argv []string) {
 	reporter := rprtr.CreateReporterOrExit()
 	logger := logging.CreateLoggerOrExit(reporter)
 
+	// In manual mode only write the bundle, as the AWS account may not be reachable from here:
+	switch args.mode {
+	case aws.ModeAuto:
+	case aws.ModeManual:
+		paths, err := aws.WriteManualBundle(args.bundleDir, arguments.GetRegion())
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+		reporter.Infof("Wrote the following files:\n  %s", strings.Join(paths, "\n  "))
+		reporter.Infof("Review them and run '%s' with credentials of the AWS account, then run "+
+			"'rosa init' to verify the account", paths[len(paths)-1])
+		os.Exit(0)
+	default:
+		reporter.Errorf("Invalid mode '%s', expected '%s' or '%s'", args.mode, aws.ModeAuto, aws.ModeManual)
+		exit.Fail()
+	}
+
 	// If necessary, call `login` as part of `init`. We do this before
 	// other validations to get the prompt out of the way before performing
 	// longer checks.
@@ -108,14 +150,14 @@ func run(cmd *cobra.Command, argv []string) {
 		cfg, err := config.Load()
 		if err != nil {
 			reporter.Errorf("Failed to load config file: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		if cfg != nil {
 			// Check that credentials in the config file are valid
 			isLoggedIn, err = cfg.Armed()
 			if err != nil {
 				reporter.Errorf("Failed to determine if user is logged in: %v", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 
@@ -123,7 +165,7 @@ func run(cmd *cobra.Command, argv []string) {
 			username, err := cfg.GetData("username")
 			if err != nil {
 				reporter.Errorf("Failed to get username: %v", err)
-				os.Exit(1)
+				exit.Fail()
 			}
 
 			reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
@@ -136,7 +178,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ocmConnection, err := ocm.NewConnection().Logger(logger).Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer ocmConnection.Close()
 	ocmClient := ocmConnection.ClustersMgmt().V1()
@@ -152,7 +194,7 @@ func run(cmd *cobra.Command, argv []string) {
 			ocm.LogEvent(ocmClient, "ROSAInitCredentialsSTS")
 		}
 		reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Validate AWS credentials for current user
@@ -164,14 +206,24 @@ func run(cmd *cobra.Command, argv []string) {
 			ocm.LogEvent(ocmClient, "ROSAInitCredentialsSTS")
 		}
 		reporter.Errorf("Error validating AWS credentials: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if !ok {
 		ocm.LogEvent(ocmClient, "ROSAInitCredentialsInvalid")
 		reporter.Errorf("AWS credentials are invalid")
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Infof("AWS credentials are valid!")
+
+	// Check the STS endpoint only when the user selected one explicitly:
+	if stsendpoint.Endpoint() != "" {
+		reporter.Infof("Validating STS endpoint...")
+		err = client.ValidateSTSEndpoint("")
+		if err != nil {
+			reporter.Errorf("Error validating STS endpoint: %v", err)
+			exit.Fail()
+		}
+	}
 	clustersCollection := ocmClient.Clusters()
 
 	// Delete CloudFormation stack and exit
@@ -183,21 +235,21 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			ocm.LogEvent(ocmClient, "ROSAInitGetCreatorFailed")
 			reporter.Errorf("Failed to get AWS creator: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		// Check whether the account has clusters:
 		hasClusters, err := ocm.HasClusters(clustersCollection, awsCreator.ARN)
 		if err != nil {
 			reporter.Errorf("Failed to check for clusters: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		if hasClusters {
 			reporter.Errorf(
 				"Failed to delete '%s': User still has clusters.",
 				aws.AdminUserName)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		// Delete the CloudFormation stack
@@ -205,10 +257,10 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			ocm.LogEvent(ocmClient, "ROSAInitDeleteStackFailed")
 			reporter.Errorf("Failed to delete user '%s': %v", aws.AdminUserName, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
-		reporter.Infof("Admin user '%s' deleted successfully!", aws.AdminUserName)
+		reporter.Successf("Admin user '%s' deleted successfully!", aws.AdminUserName)
 		os.Exit(0)
 	}
 
@@ -228,34 +280,38 @@ func run(cmd *cobra.Command, argv []string) {
 	// Call `verify quota` as part of init
 	quota.Cmd.Run(cmd, argv)
 
+	// The remaining steps take a while each, mostly waiting for CloudFormation, so show the
+	// progress:
+	progress := reporter.NewProgress(3)
+
 	// Ensure that there is an AWS user to create all the resources needed by the cluster:
-	reporter.Infof("Ensuring cluster administrator user '%s'...", aws.AdminUserName)
+	progress.Step("Ensuring cluster administrator user '%s'...", aws.AdminUserName)
 	created, err := client.EnsureOsdCcsAdminUser(aws.OsdCcsAdminStackName, aws.AdminUserName)
 	if err != nil {
 		ocm.LogEvent(ocmClient, "ROSAInitCreateStackFailed")
 		reporter.Errorf("Failed to create user '%s': %v", aws.AdminUserName, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if created {
-		reporter.Infof("Admin user '%s' created successfully!", aws.AdminUserName)
+		reporter.Successf("Admin user '%s' created successfully!", aws.AdminUserName)
 	} else {
 		reporter.Infof("Admin user '%s' already exists!", aws.AdminUserName)
 	}
 
 	// Check if osdCcsAdmin has right permissions
-	reporter.Infof("Validating SCP policies for '%s'...", aws.AdminUserName)
+	progress.Step("Validating SCP policies for '%s'...", aws.AdminUserName)
 	target := aws.AdminUserName
 	isValid, err := client.ValidateSCP(&target)
 	if !isValid {
 		ocm.LogEvent(ocmClient, "ROSAInitSCPPoliciesFailed")
 		reporter.Errorf("Failed to verify permissions for user '%s': %v", target, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Infof("AWS SCP policies ok")
 
 	// Check whether the user can create a basic cluster
-	reporter.Infof("Validating cluster creation...")
-	err = simulateCluster(clustersCollection, region.Region())
+	progress.Step("Validating cluster creation...")
+	err = simulateCluster(ocmConnection, region.Region())
 	if err != nil {
 		ocm.LogEvent(ocmClient, "ROSAInitDryRunFailed")
 		reporter.Warnf("Cluster creation failed. "+
@@ -263,11 +319,12 @@ func run(cmd *cobra.Command, argv []string) {
 	} else {
 		reporter.Infof("Cluster creation valid")
 	}
+	progress.Done()
 
 	oc.Cmd.Run(cmd, argv)
 }
 
-func simulateCluster(client *cmv1.ClustersClient, region string) error {
+func simulateCluster(connection *sdk.Connection, region string) error {
 	dryRun := true
 	if region == "" {
 		region = aws.DefaultRegion
@@ -278,7 +335,7 @@ func simulateCluster(client *cmv1.ClustersClient, region string) error {
 		DryRun: &dryRun,
 	}
 
-	_, err := clusterprovider.CreateCluster(client, spec)
+	_, err := clusterprovider.CreateCluster(connection, spec)
 	if err != nil {
 		return err
 	}
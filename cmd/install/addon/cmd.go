@@ -31,9 +31,11 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -65,14 +67,13 @@ var Cmd = &cobra.Command{
 
 func init() {
 	flags := Cmd.Flags()
-	confirm.AddFlag(flags)
 
 	flags.StringVarP(
 		&args.clusterKey,
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to install the addon to (required).",
+		"Name, ID or external ID of the cluster to install the addon to (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -95,7 +96,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -104,13 +105,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -119,7 +120,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -133,15 +134,15 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	addOn, err := clusterprovider.GetAddOnInstallation(ocmClient.Clusters(), clusterKey, awsCreator.ARN, addOnID)
@@ -157,7 +158,7 @@ func run(cmd *cobra.Command, argv []string) {
 	parameters, err := clusterprovider.GetAddOnParameters(ocmClient.Addons(), addOnID)
 	if err != nil {
 		reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var params []clusterprovider.AddOnParam
@@ -225,7 +226,7 @@ func run(cmd *cobra.Command, argv []string) {
 				}
 				if err != nil {
 					reporter.Errorf("Expected a valid value for '%s': %v", param.Name(), err)
-					os.Exit(1)
+					exit.Fail()
 				}
 				hasVal = true
 			}
@@ -236,7 +237,7 @@ func run(cmd *cobra.Command, argv []string) {
 					isValid, err := regexp.MatchString(param.Validation(), val)
 					if err != nil || !isValid {
 						reporter.Errorf("Expected %v to match /%s/", val, param.Validation())
-						os.Exit(1)
+						exit.Fail()
 					}
 				}
 				params = append(params, clusterprovider.AddOnParam{Key: param.ID(), Val: val})
@@ -250,7 +251,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = clusterprovider.InstallAddOn(ocmClient.Clusters(), clusterKey, awsCreator.ARN, addOnID, params)
 	if err != nil {
 		reporter.Errorf("Failed to add add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Infof("Add-on '%s' is now installing. To check the status run 'rosa list addons -c %s'", addOnID, clusterKey)
 }
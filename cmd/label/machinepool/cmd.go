@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/nodechanges"
+	"github.com/openshift/rosa/pkg/ocm/machines"
+)
+
+var args struct {
+	clusterKey string
+	overwrite  bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID KEY=VALUE|KEY-...",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Add or remove labels of a machine pool",
+	Long: "Add or remove labels of the nodes of a machine pool, keeping the rest of the labels. " +
+		"Labels are added with 'key=value' and removed with 'key-'.",
+	Example: `  # Add a label to machine pool 'mp1' on cluster 'mycluster'
+  rosa label machinepool --cluster=mycluster mp1 tier=frontend
+
+  # Change the value of the label
+  rosa label machinepool --cluster=mycluster mp1 tier=backend --overwrite
+
+  # Remove the label
+  rosa label machinepool --cluster=mycluster mp1 tier-`,
+	Run:  run,
+	Args: nodechanges.Args("label"),
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to change the labels of the machine pool of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.overwrite,
+		"overwrite",
+		false,
+		"Replace the value of labels that already exist.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	var changes []*machines.LabelChange
+	nodechanges.Run(&nodechanges.Attribute{
+		Name:             "labels",
+		DefaultPoolError: "Labels cannot be updated on the Default machine pool",
+		Parse: func(text string) error {
+			change, err := machines.ParseLabelChange(text)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change)
+			return nil
+		},
+		Apply: func(current *cmv1.MachinePool, builder *cmv1.MachinePoolBuilder) error {
+			labels, err := machines.ApplyLabelChanges(current.Labels(), changes, args.overwrite)
+			if err != nil {
+				return err
+			}
+			builder.Labels(labels)
+			return nil
+		},
+	}, args.clusterKey, argv)
+}
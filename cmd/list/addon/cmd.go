@@ -19,14 +19,16 @@ package addon
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -52,7 +54,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to list the add-ons of (required).",
+		"Name, ID or external ID of the cluster to list the add-ons of (required).",
 	)
 }
 
@@ -69,7 +71,7 @@ func run(_ *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -78,7 +80,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -92,7 +94,7 @@ func run(_ *cobra.Command, _ []string) {
 		addOnResources, err := ocm.GetAvailableAddOns(ocmConnection)
 		if err != nil {
 			reporter.Errorf("Failed to fetch add-ons: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		if len(addOnResources) == 0 {
 			reporter.Infof("There are no add-ons available")
@@ -100,14 +102,14 @@ func run(_ *cobra.Command, _ []string) {
 		}
 
 		// Create the writer that will be used to print the tabulated results:
-		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-		fmt.Fprintf(writer, "ID\t\tNAME\t\tAVAILABILITY\n")
+		writer := output.NewTable(os.Stdout)
+		fmt.Fprintf(writer, "ID\tNAME\tAVAILABILITY\n")
 		for _, addOnResource := range addOnResources {
 			availability := "unavailable"
 			if addOnResource.Available {
 				availability = "available"
 			}
-			fmt.Fprintf(writer, "%s\t\t%s\t\t%s\n", addOnResource.AddOn.ID(), addOnResource.AddOn.Name(), availability)
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", addOnResource.AddOn.ID(), addOnResource.AddOn.Name(), availability)
 		}
 		writer.Flush()
 
@@ -120,26 +122,26 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Load any existing Add-Ons for this cluster
@@ -147,7 +149,7 @@ func run(_ *cobra.Command, _ []string) {
 	clusterAddOns, err := ocm.GetClusterAddOns(ocmConnection, cluster)
 	if err != nil {
 		reporter.Errorf("Failed to get add-ons for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if len(clusterAddOns) == 0 {
@@ -156,10 +158,10 @@ func run(_ *cobra.Command, _ []string) {
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\t\tNAME\t\tSTATE\n")
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tNAME\tSTATE\n")
 	for _, clusterAddOn := range clusterAddOns {
-		fmt.Fprintf(writer, "%s\t\t%s\t\t%s\n", clusterAddOn.ID, clusterAddOn.Name, clusterAddOn.State)
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", clusterAddOn.ID, clusterAddOn.Name, clusterAddOn.State)
 	}
 	writer.Flush()
 }
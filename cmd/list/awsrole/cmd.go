@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package awsrole
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "aws-roles",
+	Aliases: []string{"aws-role"},
+	Short:   "List the IAM roles of the AWS account related to clusters",
+	Long: "List the IAM roles of the AWS account whose names follow the conventions of the roles " +
+		"used by clusters, like the OCM, user, account, operator and node roles, grouped by " +
+		"cluster or by the prefix of their names. Roles that belong to clusters that are no " +
+		"longer visible to the current OCM user and roles that aren't linked to the current OCM " +
+		"organization or user are reported too, to help cleaning up the account.",
+	Example: `  # List the IAM roles related to clusters
+  rosa list aws-roles`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd.Flags(), output.JSON)
+}
+
+// awsRole is an IAM role together with the cluster and the OCM organization or user that it is
+// related to.
+type awsRole struct {
+	*aws.Role
+	Group       string `json:"group"`
+	ClusterName string `json:"cluster_name,omitempty"`
+	LinkedTo    string `json:"linked_to,omitempty"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Fetching IAM roles")
+	roles, err := awsClient.ListClusterRoles()
+	if err != nil {
+		reporter.Errorf("Failed to get IAM roles: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Fetching clusters")
+	clusters, err := clusterprovider.GetAWSClusters(ocmConnection.ClustersMgmt().V1().Clusters())
+	if err != nil {
+		reporter.Errorf("Failed to get clusters: %v", err)
+		exit.Fail()
+	}
+	names := map[string]string{}
+	for _, cluster := range clusters {
+		names[cluster.ID()] = cluster.Name()
+	}
+	infraIDs, err := ocm.GetClusterInfraIDs(ocmConnection)
+	if err != nil {
+		reporter.Warnf("Failed to get infrastructure identifiers of clusters: %v", err)
+	}
+	linked, err := ocm.GetLinkedRoles(ocmConnection)
+	if err != nil {
+		reporter.Warnf("Failed to get roles linked to the OCM organization and user: %v", err)
+	}
+
+	items := []*awsRole{}
+	for _, role := range roles {
+		item := &awsRole{
+			Role:     role,
+			Group:    role.Prefix,
+			LinkedTo: linked[role.ARN],
+		}
+		if item.ClusterID == "" && item.InfraID != "" {
+			item.ClusterID = infraIDs[item.InfraID]
+		}
+		if item.ClusterID == "" && item.Type == aws.RoleTypeNode {
+			item.ClusterID = infraIDs[item.Prefix]
+		}
+		if name, ok := names[item.ClusterID]; ok {
+			item.ClusterName = name
+			item.Group = name
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Group != items[j].Group {
+			return items[i].Group < items[j].Group
+		}
+		if items[i].Type != items[j].Type {
+			return items[i].Type < items[j].Type
+		}
+		return items[i].Name < items[j].Name
+	})
+
+	err = output.WriteFile(items)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(items)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(items) == 0 {
+		reporter.Infof("There are no IAM roles related to clusters in the AWS account")
+		os.Exit(0)
+	}
+
+	if output.NameOnly() {
+		for _, item := range items {
+			fmt.Println(item.Name)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "GROUP\tTYPE\tNAME\tVERSION\tCLUSTER\tLINKED TO\n")
+	for _, item := range items {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			item.Group,
+			item.Type,
+			item.Name,
+			printValue(item.Version),
+			printCluster(item),
+			printValue(item.LinkedTo),
+		)
+	}
+	writer.Flush()
+}
+
+func printValue(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// printCluster returns the name of the cluster of the role, or an indication that the cluster
+// is no longer visible if the role belongs to a cluster that OCM doesn't know.
+func printCluster(item *awsRole) string {
+	switch {
+	case item.ClusterName != "":
+		return item.ClusterName
+	case item.ClusterID != "" || item.InfraID != "" || item.Type == aws.RoleTypeNode:
+		return "not found"
+	default:
+		return "-"
+	}
+}
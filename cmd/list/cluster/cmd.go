@@ -18,26 +18,44 @@ package cluster
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"text/tabwriter"
+	"strings"
 
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
+var args struct {
+	registered bool
+	mine       bool
+	status     string
+	properties []string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "clusters",
 	Aliases: []string{"cluster"},
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List all clusters, including the registered self-managed ones
+  rosa list clusters --registered
+
+  # List the active clusters created by the current user that have the property 'team=payments'
+  rosa list clusters --mine --status active --property team=payments`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
@@ -47,12 +65,46 @@ func init() {
 	flags.SortFlags = false
 
 	arguments.AddRegionFlag(flags)
+	output.AddFlag(flags)
+
+	flags.BoolVar(
+		&args.registered,
+		"registered",
+		false,
+		"Also list the self-managed clusters registered with 'rosa register cluster'.",
+	)
+
+	flags.BoolVar(
+		&args.mine,
+		"mine",
+		false,
+		"List only the clusters created by the current OCM user.",
+	)
+
+	flags.StringVar(
+		&args.status,
+		"status",
+		"",
+		"List only the clusters whose subscription has the given status, like 'active' or 'reserved'.",
+	)
+
+	flags.StringArrayVar(
+		&args.properties,
+		"property",
+		nil,
+		"List only the clusters that have the given property, like 'key=value'. Can be repeated.",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 	logger := logging.CreateLoggerOrExit(reporter)
 
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	// Create the AWS client:
 	awsClient, err := aws.NewClient().
 		Region(arguments.GetRegion()).
@@ -60,13 +112,13 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -75,7 +127,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -84,30 +136,113 @@ func run(_ *cobra.Command, _ []string) {
 		}
 	}()
 
+	filter, err := clusterprovider.PropertiesFilter(args.properties)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	// Retrieve the list of clusters:
 	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
-	clusters, err := clusterprovider.GetClusters(clustersCollection, awsCreator.ARN, 1000)
+	clusters, err := clusterprovider.GetClustersWithFilter(clustersCollection, awsCreator.ARN, filter, 1000)
 	if err != nil {
 		reporter.Errorf("Failed to get clusters: %v", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// The creator and the status are only known by the subscriptions, so they are used to search
+	// the subscriptions of the clusters:
+	creatorID := ""
+	if args.mine {
+		account, err := ocm.GetCurrentAccount(ocmConnection)
+		if err != nil {
+			reporter.Errorf("Failed to get current account: %v", err)
+			exit.Fail()
+		}
+		creatorID = account.ID()
+	}
+	subscriptionsFilter, err := ocm.SubscriptionsFilter(creatorID, args.status)
+	if err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+	subscriptions, err := ocm.GetClusterSubscriptions(ocmConnection, clusters, subscriptionsFilter)
+	if err != nil {
+		reporter.Errorf("Failed to get subscriptions of clusters: %v", err)
+		exit.Fail()
+	}
+	if subscriptionsFilter != "" {
+		selected := clusters[:0]
+		for _, cluster := range clusters {
+			if _, ok := subscriptions[cluster.ID()]; ok {
+				selected = append(selected, cluster)
+			}
+		}
+		clusters = selected
 	}
 
-	if len(clusters) == 0 {
+	var registered []*amsv1.Subscription
+	if args.registered {
+		registered, err = ocm.GetRegisteredClusters(ocmConnection, subscriptionsFilter)
+		if err != nil {
+			reporter.Errorf("Failed to get registered clusters: %v", err)
+			exit.Fail()
+		}
+	}
+
+	err = output.WriteFile(output.Marshaller(func(writer io.Writer) error {
+		return cmv1.MarshalClusterList(clusters, writer)
+	}))
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if len(clusters) == 0 && len(registered) == 0 {
 		reporter.Infof("No clusters available")
 		os.Exit(0)
 	}
 
+	if output.NameOnly() {
+		for _, cluster := range clusters {
+			fmt.Println(cluster.ID())
+		}
+		for _, subscription := range registered {
+			fmt.Println(subscription.ExternalClusterID())
+		}
+		return
+	}
+
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tNAME\tSTATE\n")
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tCREATED BY\n")
 	for _, cluster := range clusters {
 		fmt.Fprintf(
 			writer,
-			"%s\t%s\t%s\n",
+			"%s\t%s\t%s\t%s\n",
 			cluster.ID(),
 			cluster.Name(),
 			cluster.State(),
+			creator(subscriptions[cluster.ID()]),
+		)
+	}
+	for _, subscription := range registered {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\n",
+			subscription.ExternalClusterID(),
+			subscription.DisplayName(),
+			strings.ToLower(subscription.Status()),
+			creator(subscription),
 		)
 	}
 	writer.Flush()
 }
+
+// creator returns the user name of the creator of the given subscription.
+func creator(subscription *amsv1.Subscription) string {
+	if subscription == nil || subscription.Creator().Username() == "" {
+		return "N/A"
+	}
+	return subscription.Creator().Username()
+}
@@ -20,34 +20,66 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/list/addon"
+	"github.com/openshift/rosa/cmd/list/awsrole"
 	"github.com/openshift/rosa/cmd/list/cluster"
+	"github.com/openshift/rosa/cmd/list/dnsdomain"
+	"github.com/openshift/rosa/cmd/list/event"
+	"github.com/openshift/rosa/cmd/list/flavour"
+	"github.com/openshift/rosa/cmd/list/gate"
 	"github.com/openshift/rosa/cmd/list/idp"
+	"github.com/openshift/rosa/cmd/list/infraaccess"
 	"github.com/openshift/rosa/cmd/list/ingress"
+	"github.com/openshift/rosa/cmd/list/label"
+	"github.com/openshift/rosa/cmd/list/limitedsupportreason"
 	"github.com/openshift/rosa/cmd/list/machinepool"
+	"github.com/openshift/rosa/cmd/list/orphanedresource"
+	"github.com/openshift/rosa/cmd/list/policy"
 	"github.com/openshift/rosa/cmd/list/region"
+	"github.com/openshift/rosa/cmd/list/schedule"
 	"github.com/openshift/rosa/cmd/list/upgrade"
 	"github.com/openshift/rosa/cmd/list/user"
 	"github.com/openshift/rosa/cmd/list/version"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws/checks"
+	"github.com/openshift/rosa/pkg/output"
 )
 
 var Cmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all resources of a specific type",
 	Long:  "List all resources of a specific type",
+
+	// These commands don't change resources, so they can skip the validations that need AWS
+	// permissions that the user doesn't have:
+	PersistentPreRun: func(_ *cobra.Command, _ []string) {
+		checks.AllowDegraded()
+	},
 }
 
 func init() {
 	Cmd.AddCommand(addon.Cmd)
+	Cmd.AddCommand(awsrole.Cmd)
 	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(dnsdomain.Cmd)
+	Cmd.AddCommand(event.Cmd)
+	Cmd.AddCommand(flavour.Cmd)
+	Cmd.AddCommand(gate.Cmd)
 	Cmd.AddCommand(idp.Cmd)
+	Cmd.AddCommand(infraaccess.Cmd)
 	Cmd.AddCommand(ingress.Cmd)
+	Cmd.AddCommand(label.Cmd)
+	Cmd.AddCommand(limitedsupportreason.Cmd)
 	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(orphanedresource.Cmd)
+	Cmd.AddCommand(policy.Cmd)
 	Cmd.AddCommand(region.Cmd)
+	Cmd.AddCommand(schedule.Cmd)
 	Cmd.AddCommand(upgrade.Cmd)
 	Cmd.AddCommand(user.Cmd)
 	Cmd.AddCommand(version.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
+	arguments.AddSkipAWSChecksFlag(flags)
+	output.AddTableFlags(flags)
 }
@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dnsdomain
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/dnsdomains"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "dns-domains",
+	Aliases: []string{"dns-domain", "dnsdomains", "dnsdomain"},
+	Short:   "List base DNS domains",
+	Long:    "List the base DNS domains reserved by your organization and the clusters using them.",
+	Example: `  # List the reserved base DNS domains
+  rosa list dns-domains`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd.Flags(), output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Loading DNS domains")
+	domains, err := dnsdomains.GetDNSDomains(ocmConnection)
+	if err != nil {
+		reporter.Errorf("Failed to get DNS domains: %v", err)
+		exit.Fail()
+	}
+
+	err = output.WriteFile(domains)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if output.JSONOnly() {
+		err = output.Print(domains)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(domains) == 0 {
+		reporter.Infof("There are no DNS domains reserved by your organization")
+		return
+	}
+
+	if output.NameOnly() {
+		for _, domain := range domains {
+			fmt.Println(domain.ID)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tCLUSTER ID\tRESERVED AT\n")
+	for _, domain := range domains {
+		reservedAt := ""
+		if domain.ReservedAt != nil {
+			reservedAt = domain.ReservedAt.UTC().Format("2006-01-02 15:04:05 MST")
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", domain.ID, domain.ClusterID, reservedAt)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,198 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package event
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/events"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	since      string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "events",
+	Aliases: []string{"event"},
+	Short:   "List cluster events",
+	Long: "List the events of a cluster in chronological order, like state changes, provisioning " +
+		"errors and limited support notices, to get a timeline of what happened to the cluster.",
+	Example: `  # List the events of a cluster named "mycluster"
+  rosa list events --cluster=mycluster
+
+  # List the events of the last day as JSON
+  rosa list events --cluster=mycluster --since=24h --output json`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to list the events of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Only list the events since this time. Either a duration like '24h', a date like "+
+			"'2021-06-01' or a time like '2021-06-01T10:00:00Z'.",
+	)
+
+	output.AddFlag(flags, output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	var since time.Time
+	if args.since != "" {
+		var err error
+		since, err = events.ParseSince(args.since, time.Now())
+		if err != nil {
+			reporter.Errorf("Invalid value for '--since': %v", err)
+			exit.Fail()
+		}
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading events for cluster '%s'", clusterKey)
+	clusterEvents, err := events.GetEvents(ocmConnection, cluster, since)
+	if err != nil {
+		reporter.Errorf("Failed to get events for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	if clusterEvents == nil {
+		clusterEvents = []*events.Event{}
+	}
+
+	err = output.WriteFile(clusterEvents)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.JSONOnly() {
+		err = output.Print(clusterEvents)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(clusterEvents) == 0 {
+		reporter.Infof("There are no events for cluster '%s'", clusterKey)
+		return
+	}
+
+	if output.NameOnly() {
+		for _, event := range clusterEvents {
+			fmt.Println(event.Summary)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "TIME\tSEVERITY\tSOURCE\tSUMMARY\n")
+	for _, event := range clusterEvents {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			event.Timestamp.UTC().Format("2006-01-02 15:04:05 MST"),
+			event.Severity,
+			event.Source,
+			event.Summary,
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,135 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flavour
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/flavours"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "flavours",
+	Aliases: []string{"flavour", "flavors", "flavor"},
+	Short:   "List available flavours",
+	Long: "List the flavours available to your organization. A flavour is a set of predefined " +
+		"properties of a cluster, like the instance types of its nodes and its default network " +
+		"configuration.",
+	Example: `  # List all flavours
+  rosa list flavours`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd.Flags(), output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	reporter.Debugf("Fetching flavours")
+	items, err := flavours.GetFlavours(ocmConnection.ClustersMgmt().V1())
+	if err != nil {
+		reporter.Errorf("Failed to fetch flavours: %v", err)
+		exit.Fail()
+	}
+
+	marshaller := output.Marshaller(func(writer io.Writer) error {
+		return cmv1.MarshalFlavourList(items, writer)
+	})
+	err = output.WriteFile(marshaller)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.JSONOnly() {
+		err = output.Print(marshaller)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if output.NameOnly() {
+		for _, flavour := range items {
+			fmt.Println(flavour.ID())
+		}
+		return
+	}
+
+	if len(items) == 0 {
+		reporter.Infof("There are no flavours available")
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tNAME\tMASTER NODES\tMASTER TYPE\tINFRA TYPE\tCOMPUTE TYPE\tDEFAULT\n")
+	for _, flavour := range items {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			flavour.ID(),
+			flavour.Name(),
+			flavour.Nodes().Master(),
+			flavour.AWS().MasterInstanceType(),
+			flavour.AWS().InfraInstanceType(),
+			flavour.AWS().ComputeInstanceType(),
+			printBool(flavour.ID() == flavours.DefaultFlavour),
+		)
+	}
+	writer.Flush()
+}
+
+func printBool(val bool) string {
+	if val {
+		return "yes"
+	}
+	return "no"
+}
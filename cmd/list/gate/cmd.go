@@ -0,0 +1,259 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gate
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/upgrades"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	version    string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "gates",
+	Aliases: []string{"gate", "version-gates"},
+	Short:   "List version gates",
+	Long: "List the version gates, like API removals, that need to be acknowledged before " +
+		"upgrading to a minor version. With '--cluster' only the gates that apply to the " +
+		"cluster are listed, showing which ones are already acknowledged.",
+	Example: `  # List the version gates of OpenShift 4.12
+  rosa list gates --version 4.12
+
+  # List the version gates of the next minor version of a cluster named "mycluster"
+  rosa list gates --cluster mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.version,
+		"version",
+		"",
+		"Minor version of OpenShift to list the gates of, like '4.12'. Defaults to the next "+
+			"minor version of the cluster when '--cluster' is used.",
+	)
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to evaluate the gates for.",
+	)
+
+	output.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	if args.version == "" && args.clusterKey == "" {
+		reporter.Errorf("Either '--version' or '--cluster' is required")
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if clusterKey != "" && !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	version := args.version
+	if version != "" {
+		var err error
+		version, err = upgrades.MinorVersion(version)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	var clusterID string
+	var agreements map[string]time.Time
+	sts := false
+	if clusterKey != "" {
+		// Create the AWS client:
+		awsClient, err := aws.NewClient().
+			Logger(logger).
+			Build()
+		if err != nil {
+			reporter.Errorf("Failed to create AWS client: %v", err)
+			exit.Fail()
+		}
+
+		awsCreator, err := awsClient.GetCreator()
+		if err != nil {
+			reporter.Errorf("Failed to get AWS creator: %v", err)
+			exit.Fail()
+		}
+
+		// Try to find the cluster:
+		reporter.Debugf("Loading cluster '%s'", clusterKey)
+		cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+			awsCreator.ARN)
+		if err != nil {
+			reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		clusterID = cluster.ID()
+
+		if version == "" {
+			version, err = upgrades.NextMinorVersion(cluster.OpenshiftVersion())
+			if err != nil {
+				reporter.Errorf("Failed to get next minor version of cluster '%s': %v", clusterKey, err)
+				exit.Fail()
+			}
+			reporter.Debugf("Using next minor version %s of cluster '%s'", version, clusterKey)
+		}
+
+		agreements, err = upgrades.GetGateAgreements(ocmConnection, clusterID)
+		if err != nil {
+			reporter.Errorf("Failed to get acknowledged gates of cluster '%s': %v", clusterKey, err)
+			exit.Fail()
+		}
+		sts, err = upgrades.IsSTSCluster(ocmConnection, clusterID)
+		if err != nil {
+			reporter.Debugf("Failed to check if cluster '%s' uses STS: %v", clusterKey, err)
+		}
+	}
+
+	reporter.Debugf("Loading version gates of version %s", version)
+	gates, err := upgrades.GetVersionGates(ocmConnection, version)
+	if err != nil {
+		reporter.Errorf("Failed to get version gates of version %s: %v", version, err)
+		exit.Fail()
+	}
+	if clusterID != "" && !sts {
+		applicable := gates[:0]
+		for _, gate := range gates {
+			if !gate.STSOnly {
+				applicable = append(applicable, gate)
+			}
+		}
+		gates = applicable
+	}
+
+	err = output.WriteFile(gates)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if len(gates) == 0 {
+		reporter.Infof("There are no version gates for version %s", version)
+		os.Exit(0)
+	}
+
+	if output.NameOnly() {
+		for _, gate := range gates {
+			fmt.Println(gate.ID)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	if clusterID != "" {
+		fmt.Fprintf(writer, "ID\tVERSION\tDESCRIPTION\tACKNOWLEDGED\n")
+	} else {
+		fmt.Fprintf(writer, "ID\tVERSION\tDESCRIPTION\tSTS ONLY\n")
+	}
+	pending := 0
+	for _, gate := range gates {
+		description := gate.Label
+		if description == "" {
+			description = gate.Description
+		}
+		if clusterID != "" {
+			acknowledged := "No"
+			if agreed, ok := agreements[gate.ID]; ok {
+				acknowledged = "Yes"
+				if !agreed.IsZero() {
+					acknowledged = agreed.Format("2006-01-02")
+				}
+			} else {
+				pending++
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", gate.ID, gate.Version, description, acknowledged)
+		} else {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", gate.ID, gate.Version, description, yesNo(gate.STSOnly))
+		}
+	}
+	writer.Flush()
+
+	for _, gate := range gates {
+		if gate.DocumentationURL != "" {
+			reporter.Debugf("Gate '%s' is documented at %s", gate.ID, gate.DocumentationURL)
+		}
+	}
+	if pending > 0 {
+		reporter.Infof("%d gate(s) need to be acknowledged before upgrading cluster '%s' to %s",
+			pending, clusterKey, version)
+	}
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "Yes"
+	}
+	return "No"
+}
@@ -19,15 +19,17 @@ package idp
 import (
 	"fmt"
 	"os"
-	"strings"
-	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/idps"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -39,9 +41,14 @@ var Cmd = &cobra.Command{
 	Use:     "idps",
 	Aliases: []string{"idp"},
 	Short:   "List cluster IDPs",
-	Long:    "List identity providers for a cluster.",
+	Long: "List identity providers for a cluster. The JSON output includes the mapping method, " +
+		"the endpoints specific to each type of provider and, when available, when and by whom " +
+		"they were created. Secrets are never included.",
 	Example: `  # List all identity providers on a cluster named "mycluster"
-  rosa list idps --cluster=mycluster`,
+  rosa list idps --cluster=mycluster
+
+  # Print the details of the identity providers as JSON
+  rosa list idps --cluster=mycluster --output json`,
 	Run: run,
 }
 
@@ -53,15 +60,22 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to list the IdPs of (required).",
+		"Name, ID or external ID of the cluster to list the IdPs of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
+
+	output.AddFlag(flags, output.JSON)
 }
 
 func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 	logger := logging.CreateLoggerOrExit(reporter)
 
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
 	clusterKey := args.clusterKey
@@ -71,7 +85,7 @@ func run(_ *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -80,13 +94,13 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -95,7 +109,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -109,43 +123,56 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Load any existing IDPs for this cluster
 	reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
-	idps, err := ocm.GetIdentityProviders(clustersCollection, cluster.ID())
+	details, err := idps.GetDetails(ocmConnection, cluster)
 	if err != nil {
 		reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
-	if len(idps) == 0 {
+	err = output.WriteFile(details)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.JSONOnly() {
+		err = output.Print(details)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(details) == 0 {
 		reporter.Infof("There are no identity providers configured for cluster '%s'", clusterKey)
 	}
 
-	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "NAME\t\tTYPE\t\tAUTH URL\n")
-	for _, idp := range idps {
-		idpType := ocm.IdentityProviderType(idp)
-		if idpType == "htpasswd" {
-			continue
+	if output.NameOnly() {
+		for _, idp := range details {
+			fmt.Println(idp.Name)
 		}
-		fmt.Fprintf(writer, "%s\t\t%s\t\t%s\n", idp.Name(), idpType, getAuthURL(cluster, idp.Name()))
+		return
 	}
-	writer.Flush()
-}
 
-func getAuthURL(cluster *cmv1.Cluster, idpName string) string {
-	oauthURL := strings.Replace(cluster.Console().URL(), "console-openshift-console", "oauth-openshift", 1)
-	return fmt.Sprintf("%s/oauth2callback/%s", oauthURL, idpName)
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "NAME\t\tTYPE\t\tMAPPING METHOD\t\tAUTH URL\n")
+	for _, idp := range details {
+		fmt.Fprintf(writer, "%s\t\t%s\t\t%s\t\t%s\n", idp.Name, idp.Type, idp.MappingMethod, idp.AuthURL)
+	}
+	writer.Flush()
 }
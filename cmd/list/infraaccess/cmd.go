@@ -0,0 +1,173 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infraaccess
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	roles      bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "aws-infrastructure-access",
+	Aliases: []string{"aws-infra-access"},
+	Short:   "List grants of access to the AWS infrastructure of a cluster",
+	Long:    "List the AWS IAM users and roles that have been granted access to the AWS infrastructure of a cluster.",
+	Example: `  # List all grants on a cluster named "mycluster"
+  rosa list aws-infrastructure-access --cluster=mycluster
+
+  # List the roles that can be granted
+  rosa list aws-infrastructure-access --roles`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to list the grants of.",
+	)
+
+	flags.BoolVar(
+		&args.roles,
+		"roles",
+		false,
+		"List the roles that can be granted instead of the grants of a cluster.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	clusterKey := args.clusterKey
+	if !args.roles {
+		if clusterKey == "" {
+			reporter.Errorf("Option '--cluster' is required unless '--roles' is used")
+			exit.Fail()
+		}
+
+		// Check that the cluster key (name, identifier or external identifier) given by the user
+		// is reasonably safe so that there is no risk of SQL injection:
+		if !ocm.IsValidClusterKey(clusterKey) {
+			reporter.Errorf(
+				"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+					"must contain only letters, digits, dashes and underscores",
+				clusterKey,
+			)
+			exit.Fail()
+		}
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+	ocmClient := ocmConnection.ClustersMgmt().V1()
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+
+	if args.roles {
+		roles, err := ocm.GetAWSInfrastructureAccessRoles(ocmClient)
+		if err != nil {
+			reporter.Errorf("Failed to get AWS infrastructure access roles: %v", err)
+			exit.Fail()
+		}
+		fmt.Fprintf(writer, "ID\tNAME\tDESCRIPTION\n")
+		for _, role := range roles {
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", role.ID(), role.DisplayName(), role.Description())
+		}
+		writer.Flush()
+		return
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading AWS infrastructure access grants for cluster '%s'", clusterKey)
+	grants, err := ocm.GetAWSInfrastructureAccessRoleGrants(ocmClient.Clusters(), cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get AWS infrastructure access grants for cluster '%s': %v",
+			clusterKey, err)
+		exit.Fail()
+	}
+
+	if len(grants) == 0 {
+		reporter.Infof("There are no AWS infrastructure access grants for cluster '%s'", clusterKey)
+		os.Exit(0)
+	}
+
+	fmt.Fprintf(writer, "ROLE\tUSER ARN\tSTATE\tCONSOLE URL\n")
+	for _, grant := range grants {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			grant.Role().ID(),
+			grant.UserARN(),
+			grant.State(),
+			grant.ConsoleURL(),
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package label
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	scope      string
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "labels",
+	Aliases: []string{"label"},
+	Short:   "List labels",
+	Long:    "List the labels of the organization of the current user or of the subscription of a cluster.",
+	Example: `  # List the labels of the organization
+  rosa list labels
+
+  # List the labels of the subscription of the cluster named "mycluster"
+  rosa list labels --scope subscription --cluster mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.scope,
+		"scope",
+		ocm.LabelScopeOrganization,
+		fmt.Sprintf("Scope of the labels, one of: %s.", strings.Join(ocm.LabelScopes, ", ")),
+	)
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster whose subscription labels will be listed. Required for the "+
+			"'subscription' scope.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if !ocm.IsValidLabelScope(args.scope) {
+		reporter.Errorf("Expected a valid scope, one of: %s", strings.Join(ocm.LabelScopes, ", "))
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	cluster := getCluster(reporter, logger, ocmConnection.ClustersMgmt().V1().Clusters())
+	client, err := ocm.GetLabelsClient(ocmConnection, args.scope, cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get labels: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading labels with scope '%s'", args.scope)
+	labels, err := ocm.GetLabels(client)
+	if err != nil {
+		reporter.Errorf("Failed to get labels: %v", err)
+		exit.Fail()
+	}
+
+	if len(labels) == 0 {
+		reporter.Infof("There are no labels with scope '%s'", args.scope)
+		os.Exit(0)
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "KEY\tVALUE\tINTERNAL\n")
+	for _, label := range labels {
+		internal := "no"
+		if label.Internal() {
+			internal = "yes"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", label.Key(), label.Value(), internal)
+	}
+	writer.Flush()
+}
+
+// getCluster returns the cluster given in the command line, or nil if the scope of the label
+// isn't a subscription.
+func getCluster(reporter *rprtr.Object, logger *logrus.Logger, client *cmv1.ClustersClient) *cmv1.Cluster {
+	if args.scope != ocm.LabelScopeSubscription {
+		if args.clusterKey != "" {
+			reporter.Errorf("The '--cluster' flag can only be used with the '%s' scope",
+				ocm.LabelScopeSubscription)
+			exit.Fail()
+		}
+		return nil
+	}
+	if args.clusterKey == "" {
+		reporter.Errorf("The '--cluster' flag is required for the '%s' scope", ocm.LabelScopeSubscription)
+		exit.Fail()
+	}
+	if !ocm.IsValidClusterKey(args.clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			args.clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading cluster '%s'", args.clusterKey)
+	cluster, err := clusters.GetCluster(client, args.clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", args.clusterKey, err)
+		exit.Fail()
+	}
+	return cluster
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package limitedsupportreason
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/limitedsupport"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "limited-support-reasons",
+	Aliases: []string{"limited-support-reason", "limited-support"},
+	Short:   "List the reasons why a cluster is in limited support",
+	Long: "List the reasons why a cluster is in limited support, like expired credentials or " +
+		"certificates, with the details about how to fix them so that SRE can fully support " +
+		"the cluster again.",
+	Example: `  # List the limited support reasons of a cluster named "mycluster"
+  rosa list limited-support-reasons --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to list the limited support reasons of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	output.AddFlag(flags, output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Loading limited support reasons for cluster '%s'", clusterKey)
+	reasons, err := limitedsupport.GetReasons(ocmConnection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+	if reasons == nil {
+		reasons = []*limitedsupport.Reason{}
+	}
+
+	err = output.WriteFile(reasons)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.JSONOnly() {
+		err = output.Print(reasons)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if len(reasons) == 0 {
+		reporter.Infof("Cluster '%s' isn't in limited support", clusterKey)
+		return
+	}
+
+	if output.NameOnly() {
+		for _, reason := range reasons {
+			fmt.Println(reason.ID)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tCREATED\tDETECTION\tSUMMARY\tDETAILS\n")
+	for _, reason := range reasons {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			reason.ID,
+			reason.Created.UTC().Format("2006-01-02 15:04:05 MST"),
+			reason.DetectionType,
+			reason.Summary,
+			strings.Join(strings.Fields(reason.Details), " "),
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphanedresource
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/cleanup"
+	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// allRegions is the value of the '--region' flag that selects all the enabled regions.
+const allRegions = "all"
+
+var args struct {
+	regions []string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "orphaned-resources",
+	Aliases: []string{"orphaned-resource"},
+	Short:   "List AWS resources left behind by deleted clusters",
+	Long: "List load balancers, volumes, snapshots and NAT gateways tagged for clusters that " +
+		"no longer exist, with an estimation of their monthly cost. Resources of clusters that " +
+		"aren't visible to the current OCM user are also reported.",
+	Example: `  # List the orphaned resources in the current region
+  rosa list orphaned-resources
+
+  # List the orphaned resources in two regions
+  rosa list orphaned-resources --region us-east-1,eu-west-1
+
+  # List the orphaned resources in all the enabled regions
+  rosa list orphaned-resources --region all`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringSliceVar(
+		&args.regions,
+		"region",
+		nil,
+		fmt.Sprintf("Regions to scan, or '%s' to scan all the regions enabled for the account. "+
+			"Defaults to the region of the AWS_REGION environment variable.", allRegions),
+	)
+	output.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// The client needs a region even when scanning several of them:
+	clientRegion := arguments.GetRegion()
+	if clientRegion == "" && len(args.regions) > 0 && args.regions[0] != allRegions {
+		clientRegion = args.regions[0]
+	}
+	if clientRegion == "" {
+		clientRegion = aws.DefaultRegion
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Region(clientRegion).
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	regions := args.regions
+	switch {
+	case len(regions) == 0:
+		regions = []string{awsClient.GetRegion()}
+	case len(regions) == 1 && regions[0] == allRegions:
+		regions, err = awsClient.GetEnabledRegions()
+		if err != nil {
+			reporter.Errorf("Failed to get enabled regions: %v", err)
+			exit.Fail()
+		}
+	default:
+		for _, region := range regions {
+			if region == allRegions {
+				reporter.Errorf("Region '%s' can't be combined with other regions", allRegions)
+				exit.Fail()
+			}
+		}
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Resources are orphaned when their cluster isn't known by OCM:
+	reporter.Debugf("Fetching clusters")
+	clusters, err := clusterprovider.GetAWSClusters(ocmConnection.ClustersMgmt().V1().Clusters())
+	if err != nil {
+		reporter.Errorf("Failed to get clusters: %v", err)
+		exit.Fail()
+	}
+	live := map[string]string{}
+	for _, cluster := range clusters {
+		live[cluster.ID()] = cluster.Name()
+	}
+
+	reporter.Infof("Scanning %d region(s) for orphaned resources", len(regions))
+	resources, failures, err := awsClient.FindOrphanedResources(regions, live)
+	if err != nil {
+		reporter.Errorf("Failed to scan regions: %v", err)
+		exit.Fail()
+	}
+	failed := make([]string, 0, len(failures))
+	for region := range failures {
+		failed = append(failed, region)
+	}
+	sort.Strings(failed)
+	for _, region := range failed {
+		reporter.Warnf("Failed to scan region '%s': %v", region, failures[region])
+	}
+
+	if resources == nil {
+		resources = []*cleanup.Resource{}
+	}
+	err = output.WriteFile(resources)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if len(resources) == 0 {
+		reporter.Infof("No orphaned resources found")
+		if len(failures) > 0 {
+			exit.Fail()
+		}
+		os.Exit(0)
+	}
+
+	if output.NameOnly() {
+		for _, resource := range resources {
+			fmt.Println(resource.ID)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "REGION\tTYPE\tID\tCLUSTER\tMONTHLY COST\n")
+	var total float64
+	for _, resource := range resources {
+		fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s\t%s\t$%.2f\n",
+			resource.Region,
+			resource.Type,
+			resource.ID,
+			owner(resource.ClusterID, resource.InfraID),
+			resource.MonthlyCost,
+		)
+		total += resource.MonthlyCost
+	}
+	writer.Flush()
+
+	reporter.Infof("Estimated monthly cost of %d orphaned resource(s): $%.2f", len(resources), total)
+}
+
+// owner returns the identifier of the cluster that created the resource, or the infrastructure
+// identifier if the resource doesn't have the cluster identifier tag.
+func owner(clusterID, infraID string) string {
+	if clusterID != "" {
+		return clusterID
+	}
+	return infraID
+}
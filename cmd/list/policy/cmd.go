@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	policy string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "policies",
+	Aliases: []string{"policy"},
+	Short:   "List the AWS policies bundled with the tool",
+	Long: "List the AWS policy documents and templates bundled with this version of the tool, " +
+		"with their checksums, or show the content of one of them as it would be used in a " +
+		"region. Nothing is created in the AWS account.",
+	Example: `  # List the bundled policies
+  rosa list policies
+
+  # Show the policy that the admin user needs, as it would be used in a GovCloud region
+  rosa list policies --policy=osd-scp-policy --region=us-gov-west-1`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.policy,
+		"policy",
+		"",
+		"Name of a bundled policy to show the content of.",
+	)
+
+	arguments.AddRegionFlag(flags)
+	output.AddFlag(flags, output.JSON)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	if args.policy != "" {
+		content, err := aws.RenderPolicy(args.policy, arguments.GetRegion())
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		fmt.Print(content)
+		return
+	}
+
+	policies, err := aws.ListPolicies()
+	if err != nil {
+		reporter.Errorf("Failed to list policies: %v", err)
+		exit.Fail()
+	}
+
+	err = output.WriteFile(policies)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.JSONOnly() {
+		err = output.Print(policies)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+		return
+	}
+
+	if output.NameOnly() {
+		for _, policy := range policies {
+			fmt.Println(policy.Name)
+		}
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "NAME\tTYPE\tVERSION\tCHECKSUM\tDESCRIPTION\n")
+	for _, policy := range policies {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			policy.Name,
+			policy.Type,
+			policy.Version,
+			policy.Checksum,
+			policy.Description,
+		)
+	}
+	writer.Flush()
+}
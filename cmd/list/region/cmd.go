@@ -19,18 +19,23 @@ package region
 import (
 	"fmt"
 	"os"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/ocm/regions"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
 var args struct {
-	multiAZ bool
+	multiAZ    bool
+	hostedCP   bool
+	localZones bool
+	wavelength bool
 }
 
 var Cmd = &cobra.Command{
@@ -39,7 +44,13 @@ var Cmd = &cobra.Command{
 	Short:   "List available regions",
 	Long:    "List regions that are available for the current AWS account.",
 	Example: `  # List all available regions
-  rosa list regions`,
+  rosa list regions
+
+  # List regions that support hosted control planes
+  rosa list regions --hosted-cp
+
+  # List regions that have local zones
+  rosa list regions --local-zones`,
 	Run: run,
 }
 
@@ -51,6 +62,24 @@ func init() {
 		false,
 		"List only regions with support for multiple availability zones",
 	)
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"List only regions with support for hosted control planes",
+	)
+	flags.BoolVar(
+		&args.localZones,
+		"local-zones",
+		false,
+		"List only regions with local zones",
+	)
+	flags.BoolVar(
+		&args.wavelength,
+		"wavelength",
+		false,
+		"List only regions with wavelength zones",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -63,7 +92,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -77,22 +106,85 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Fetching regions")
-	regions, err := regions.GetRegions(ocmClient)
+	availableRegions, err := regions.GetRegions(ocmClient)
 	if err != nil {
 		reporter.Errorf("Failed to fetch regions: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
-	if len(regions) == 0 {
+	if len(availableRegions) == 0 {
 		reporter.Warnf("There are no regions available for this AWS account")
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// The support for hosted control planes and the zones of the regions are only loaded when
+	// requested, as they need additional requests:
+	filters := []*capability{}
+	if cmd.Flags().Changed("hosted-cp") {
+		reporter.Debugf("Fetching hosted control plane support")
+		support, err := regions.GetHostedCPSupport(ocmConnection)
+		if err != nil {
+			reporter.Errorf("Failed to fetch hosted control plane support: %v", err)
+			exit.Fail()
+		}
+		filters = append(filters, &capability{
+			title:  "HOSTED-CP SUPPORT",
+			wanted: args.hostedCP,
+			check: func(regionID string) bool {
+				return support[regionID]
+			},
+		})
+	}
+	if cmd.Flags().Changed("local-zones") || cmd.Flags().Changed("wavelength") {
+		awsClient, err := aws.NewClient().
+			Logger(logger).
+			Build()
+		if err != nil {
+			reporter.Errorf("Failed to create AWS client: %v", err)
+			exit.Fail()
+		}
+		zoneTypes := map[string]map[string]bool{}
+		getZoneTypes := func(regionID string) map[string]bool {
+			types, ok := zoneTypes[regionID]
+			if !ok {
+				reporter.Debugf("Fetching zones of region '%s'", regionID)
+				types, err = awsClient.GetZoneTypes(regionID)
+				if err != nil {
+					reporter.Debugf("Failed to fetch zones of region '%s': %v", regionID, err)
+				}
+				zoneTypes[regionID] = types
+			}
+			return types
+		}
+		if cmd.Flags().Changed("local-zones") {
+			filters = append(filters, &capability{
+				title:  "LOCAL ZONES",
+				wanted: args.localZones,
+				check: func(regionID string) bool {
+					return getZoneTypes(regionID)[aws.ZoneTypeLocalZone]
+				},
+			})
+		}
+		if cmd.Flags().Changed("wavelength") {
+			filters = append(filters, &capability{
+				title:  "WAVELENGTH ZONES",
+				wanted: args.wavelength,
+				check: func(regionID string) bool {
+					return getZoneTypes(regionID)[aws.ZoneTypeWavelengthZone]
+				},
+			})
+		}
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\t\tNAME\t\tMULTI-AZ SUPPORT\n")
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tNAME\tMULTI-AZ SUPPORT")
+	for _, filter := range filters {
+		fmt.Fprintf(writer, "\t%s", filter.title)
+	}
+	fmt.Fprintf(writer, "\n")
 
-	for _, region := range regions {
+	for _, region := range availableRegions {
 		if !region.Enabled() {
 			continue
 		}
@@ -101,12 +193,33 @@ func run(cmd *cobra.Command, _ []string) {
 				continue
 			}
 		}
+		matches := true
+		for _, filter := range filters {
+			if filter.check(region.ID()) != filter.wanted {
+				matches = false
+				break
+			}
+		}
+		if !matches {
+			continue
+		}
 		fmt.Fprintf(writer,
-			"%s\t\t%s\t\t%t\n",
+			"%s\t%s\t%t",
 			region.ID(),
 			region.DisplayName(),
 			region.SupportsMultiAZ(),
 		)
+		for _, filter := range filters {
+			fmt.Fprintf(writer, "\t%t", filter.wanted)
+		}
+		fmt.Fprintf(writer, "\n")
 	}
 	writer.Flush()
 }
+
+// capability is a feature of regions that can be used to filter them.
+type capability struct {
+	title  string
+	wanted bool
+	check  func(regionID string) bool
+}
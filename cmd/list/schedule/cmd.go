@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+	"github.com/openshift/rosa/pkg/output"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "schedules",
+	Aliases: []string{"schedule"},
+	Short:   "List machine pool scaling schedules",
+	Long:    "List the machine pool scaling schedules of a cluster.",
+	Example: `  # List all scaling schedules of a cluster named "mycluster"
+  rosa list schedules --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to list the schedules of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	output.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if err := output.Validate(); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	items, err := schedules.GetSchedules(cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get schedules for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	// Schedules are written indexed by identifier, the same way that they are stored:
+	indexed := map[string]*schedules.Schedule{}
+	for _, schedule := range items {
+		indexed[schedule.ID] = schedule
+	}
+	err = output.WriteFile(indexed)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+
+	if output.NameOnly() {
+		for _, schedule := range items {
+			fmt.Println(schedule.ID)
+		}
+		return
+	}
+
+	if len(items) == 0 {
+		reporter.Infof("There are no schedules for cluster '%s'", clusterKey)
+		return
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := output.NewTable(os.Stdout)
+
+	now := time.Now()
+	fmt.Fprintf(writer, "ID\tMACHINE POOL\tCRON\tREPLICAS\tNEXT RUN\n")
+	for _, schedule := range items {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n",
+			schedule.ID,
+			schedule.MachinePool,
+			schedule.Cron,
+			schedule.Replicas,
+			nextRun(schedule, now),
+		)
+	}
+	writer.Flush()
+}
+
+func nextRun(schedule *schedules.Schedule, now time.Time) string {
+	cron, err := schedules.ParseCron(schedule.Cron)
+	if err != nil {
+		return "invalid"
+	}
+	next := cron.Next(now)
+	if next.IsZero() {
+		return "never"
+	}
+	return next.Format("2006-01-02 15:04 MST")
+}
@@ -21,21 +21,26 @@ import (
 	"os"
 	"strconv"
 	"strings"
-	"text/tabwriter"
+	"time"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	"github.com/openshift/rosa/pkg/ocm/upgrades"
 	"github.com/openshift/rosa/pkg/ocm/versions"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
 var args struct {
-	clusterKey string
+	clusterKey   string
+	machinePools bool
 }
 
 var Cmd = &cobra.Command{
@@ -43,7 +48,12 @@ var Cmd = &cobra.Command{
 	Aliases: []string{"upgrade"},
 	Short:   "List available cluster upgrades",
 	Long:    "List available and scheduled cluster version upgrades",
-	Run:     run,
+	Example: `  # List all available upgrades for a cluster named "mycluster"
+  rosa list upgrades --cluster=mycluster
+
+  # Compare the versions of the machine pools with the version of the control plane
+  rosa list upgrades --cluster=mycluster --machinepools`,
+	Run: run,
 }
 
 func init() {
@@ -54,9 +64,17 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to list the upgrades of (required).",
+		"Name, ID or external ID of the cluster to list the upgrades of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.machinePools,
+		"machinepools",
+		false,
+		"Show the version of each machine pool compared to the version of the control plane, "+
+			"and which machine pools need to be upgraded before the next minor version upgrade.",
+	)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -72,7 +90,7 @@ func run(_ *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -81,7 +99,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -97,26 +115,26 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Load available upgrades for this cluster
@@ -124,7 +142,12 @@ func run(_ *cobra.Command, _ []string) {
 	availableUpgrades, err := versions.GetAvailableUpgrades(ocmClient, versions.GetVersionID(cluster))
 	if err != nil {
 		reporter.Errorf("Failed to get available upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if args.machinePools {
+		printMachinePoolVersions(reporter, ocmConnection, cluster, availableUpgrades)
+		return
 	}
 
 	if len(availableUpgrades) == 0 {
@@ -138,11 +161,11 @@ func run(_ *cobra.Command, _ []string) {
 	scheduledUpgrade, upgradeState, err := upgrades.GetScheduledUpgrade(ocmClient, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	writer := output.NewTable(os.Stdout)
 	fmt.Fprintf(writer, "VERSION\tNOTES\n")
 	for i, availableUpgrade := range availableUpgrades {
 		notes := ""
@@ -156,6 +179,81 @@ func run(_ *cobra.Command, _ []string) {
 		fmt.Fprintf(writer, "%s\t%s\n", availableUpgrade, notes)
 	}
 	writer.Flush()
+
+	printNotificationWindows(reporter, ocmConnection, cluster)
+}
+
+// printNotificationWindows prints the service log notifications configured for the upgrades of the
+// cluster that haven't started yet.
+func printNotificationWindows(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster) {
+	reporter.Debugf("Loading upgrade notifications for cluster '%s'", cluster.ID())
+	windows, err := upgrades.GetNotificationWindows(connection, cluster.ID())
+	if err != nil {
+		reporter.Warnf("Failed to get upgrade notifications for cluster '%s': %v", cluster.ID(), err)
+		return
+	}
+	now := time.Now()
+	for _, window := range windows {
+		if !window.Pending(now) {
+			continue
+		}
+		target := "automatic upgrade"
+		if window.Version != "" {
+			target = fmt.Sprintf("upgrade to version %s", window.Version)
+		}
+		state := "will be notified on"
+		if window.Open(now) {
+			state = "was notified on"
+		}
+		reporter.Infof("The %s on %s %s %s", target,
+			window.NextRun.Format("2006-01-02 15:04 MST"), state,
+			window.NotifyAt.Format("2006-01-02 15:04 MST"))
+	}
+}
+
+func printMachinePoolVersions(reporter *rprtr.Object, connection *sdk.Connection, cluster *cmv1.Cluster,
+	availableUpgrades []string) {
+	reporter.Debugf("Loading machine pool versions for cluster '%s'", cluster.ID())
+	machinePoolVersions, err := upgrades.GetMachinePoolVersions(connection, cluster)
+	if err != nil {
+		reporter.Errorf("Failed to get machine pool versions for cluster '%s': %v", cluster.ID(), err)
+		exit.Fail()
+	}
+
+	// Find the next minor version the control plane can be upgraded to, if any:
+	nextMinor := ""
+	for _, availableUpgrade := range availableUpgrades {
+		if upgrades.IsYStreamUpgrade(cluster.OpenshiftVersion(), availableUpgrade) {
+			nextMinor = availableUpgrade
+		}
+	}
+
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "MACHINE POOL\tVERSION\tCONTROL PLANE\tMINOR SKEW\tBLOCKS MINOR UPGRADE\n")
+	blocked := false
+	for _, machinePool := range machinePoolVersions {
+		blocks := "no"
+		if machinePool.BlocksYStream {
+			blocks = "yes"
+			blocked = true
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%d\t%s\n",
+			machinePool.ID,
+			machinePool.Version,
+			machinePool.ControlPlaneVersion,
+			machinePool.MinorSkew,
+			blocks,
+		)
+	}
+	writer.Flush()
+
+	if nextMinor == "" {
+		reporter.Infof("There are no minor version upgrades available for cluster '%s'", cluster.Name())
+	} else if blocked {
+		reporter.Warn(rprtr.WarnMinorUpgradeBlocked,
+			"Machine pools marked above must be upgraded before the control plane of "+
+				"cluster '%s' can be upgraded to version '%s'", cluster.Name(), nextMinor)
+	}
 }
 
 func latestInCurrentMinor(current string, versions []string) string {
@@ -20,15 +20,17 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -56,7 +58,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to list the users of (required).",
+		"Name, ID or external ID of the cluster to list the users of (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 }
@@ -74,7 +76,7 @@ func run(_ *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -84,13 +86,13 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -99,7 +101,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -113,15 +115,15 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	var clusterAdmins []*cmv1.User
@@ -130,7 +132,7 @@ func run(_ *cobra.Command, _ []string) {
 	clusterAdmins, err = ocm.GetUsers(clustersCollection, cluster.ID(), "cluster-admins")
 	if err != nil {
 		reporter.Errorf("Failed to get cluster-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	// Remove cluster-admin user
 	for i, user := range clusterAdmins {
@@ -143,12 +145,12 @@ func run(_ *cobra.Command, _ []string) {
 	dedicatedAdmins, err := ocm.GetUsers(clustersCollection, cluster.ID(), "dedicated-admins")
 	if err != nil {
 		reporter.Errorf("Failed to get dedicated-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if len(clusterAdmins) == 0 && len(dedicatedAdmins) == 0 {
 		reporter.Warnf("There are no users configured for cluster '%s'", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	groups := make(map[string][]string)
@@ -164,11 +166,11 @@ func run(_ *cobra.Command, _ []string) {
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\t\tGROUPS\n")
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "ID\tGROUPS\n")
 
 	for u, r := range groups {
-		fmt.Fprintf(writer, "%s\t\t%s\n", u, strings.Join(r, ", "))
+		fmt.Fprintf(writer, "%s\t%s\n", u, strings.Join(r, ", "))
 		writer.Flush()
 	}
 }
@@ -20,13 +20,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/ocm/versions"
+	"github.com/openshift/rosa/pkg/output"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -64,7 +65,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -81,17 +82,17 @@ func run(cmd *cobra.Command, _ []string) {
 	versions, err := versions.GetVersions(ocmClient, args.channelGroup)
 	if err != nil {
 		reporter.Errorf("Failed to fetch versions: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if len(versions) == 0 {
 		reporter.Warnf("There are no OpenShift versions available")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the writer that will be used to print the tabulated results:
-	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "VERSION\t\tDEFAULT\t\tAVAILABLE UPGRADES\n")
+	writer := output.NewTable(os.Stdout)
+	fmt.Fprintf(writer, "VERSION\tDEFAULT\tAVAILABLE UPGRADES\n")
 
 	for _, version := range versions {
 		if !version.Enabled() {
@@ -102,7 +103,7 @@ func run(cmd *cobra.Command, _ []string) {
 			isDefault = "yes"
 		}
 		fmt.Fprintf(writer,
-			"%s\t\t%s\t\t%s\n",
+			"%s\t%s\t%s\n",
 			version.RawID(),
 			isDefault,
 			strings.Join(version.AvailableUpgrades(), ", "),
@@ -19,11 +19,14 @@ package login
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/dgrijalva/jwt-go"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
@@ -31,8 +34,7 @@ import (
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
-// #nosec G101
-const uiTokenPage = "https://cloud.redhat.com/openshift/token/rosa"
+const uiTokenPage = config.UITokenPage
 
 var args struct {
 	tokenURL     string
@@ -41,7 +43,9 @@ var args struct {
 	scopes       []string
 	env          string
 	token        string
+	tokenFile    string
 	insecure     bool
+	govcloud     bool
 }
 
 var Cmd = &cobra.Command{
@@ -54,10 +58,23 @@ var Cmd = &cobra.Command{
 		"\t2. Environment variable (ROSA_TOKEN)\n"+
 		"\t3. Environment variable (OCM_TOKEN)\n"+
 		"\t4. Configuration file\n"+
-		"\t5. Command-line prompt\n", uiTokenPage),
+		"\t5. Command-line prompt\n\n"+
+		"In CI environments a federated token, like the ones issued to GitHub Actions workflows "+
+		"or to Kubernetes service accounts, can be exchanged for the tokens instead using the "+
+		"'--federated-token-file' flag. The file is read again whenever new tokens are needed.\n\n"+
+		"To manage clusters in the AWS GovCloud regions log in to the FedRAMP environment with "+
+		"the '--govcloud' flag, using a token obtained at: %s\n",
+		uiTokenPage, fedramp.TokenPage),
 	Example: "  # Login to the OpenShift API with an existing token generated from " +
 		`https://cloud.redhat.com/openshift/token/rosa
-  rosa login --token=$OFFLINE_ACCESS_TOKEN`,
+  rosa login --token=$OFFLINE_ACCESS_TOKEN
+
+  # Login to the FedRAMP environment to manage clusters in the AWS GovCloud regions
+  rosa login --govcloud --token=$OFFLINE_ACCESS_TOKEN
+
+  # Login exchanging the token of a Kubernetes service account
+  rosa login --client-id=my-client \
+    --federated-token-file=/var/run/secrets/kubernetes.io/serviceaccount/token`,
 	Run: run,
 }
 
@@ -99,7 +116,8 @@ func init() {
 		"env",
 		sdk.DefaultURL,
 		"Environment of the API gateway. The value can be the complete URL or an alias. "+
-			"The valid aliases are 'production', 'staging' and 'integration'.",
+			"The valid aliases are 'production', 'staging' and 'integration'. When not given "+
+			"it is selected from the issuer of the token.",
 	)
 	flags.MarkHidden("env")
 	flags.StringVarP(
@@ -109,6 +127,13 @@ func init() {
 		"",
 		"Access or refresh token generated from https://cloud.redhat.com/openshift/token/rosa.",
 	)
+	flags.StringVar(
+		&args.tokenFile,
+		"federated-token-file",
+		"",
+		"File containing a federated token, like the ones issued to CI workflows or Kubernetes "+
+			"service accounts, to exchange for OCM tokens.",
+	)
 	flags.BoolVar(
 		&args.insecure,
 		"insecure",
@@ -116,6 +141,12 @@ func init() {
 		"Enables insecure communication with the server. This disables verification of TLS "+
 			"certificates and host names.",
 	)
+	flags.BoolVar(
+		&args.govcloud,
+		"govcloud",
+		false,
+		"Log in to the FedRAMP environment, used to manage clusters in the AWS GovCloud regions.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -125,21 +156,45 @@ func run(cmd *cobra.Command, argv []string) {
 	// Check mandatory options:
 	if args.env == "" {
 		reporter.Errorf("Option '--env' is mandatory")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Load the configuration file:
 	cfg, err := config.Load()
 	if err != nil {
 		reporter.Errorf("Failed to load config file: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if cfg == nil {
 		cfg = new(config.Config)
 	}
 
+	// Either option can come from an environment variable, and then the one given in the command
+	// line wins:
+	if args.tokenFile != "" && args.token != "" {
+		switch {
+		case cmd.Flags().Changed("token") && !cmd.Flags().Changed("federated-token-file"):
+			args.tokenFile = ""
+		case cmd.Flags().Changed("federated-token-file") && !cmd.Flags().Changed("token"):
+			args.token = ""
+		default:
+			reporter.Errorf("Options '--token' and '--federated-token-file' are mutually exclusive")
+			exit.Fail()
+		}
+	}
+
+	// Read the federated token, so that it fails early if the file can't be used:
+	var federatedToken string
+	if args.tokenFile != "" {
+		federatedToken, err = ocm.ReadFederatedToken(args.tokenFile)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
 	token := args.token
-	haveReqs := token != ""
+	haveReqs := token != "" || federatedToken != ""
 
 	// Verify environment variables:
 	if !haveReqs {
@@ -155,45 +210,113 @@ func run(cmd *cobra.Command, argv []string) {
 		armed, err := cfg.Armed()
 		if err != nil {
 			reporter.Errorf("Failed to verify configuration: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		haveReqs = armed
 	}
 
+	tokenPage := uiTokenPage
+	if args.govcloud {
+		tokenPage = fedramp.TokenPage
+	}
+
 	// Prompt the user for token:
 	if !haveReqs {
-		fmt.Println("To login to your Red Hat account, get an offline access token at", uiTokenPage)
+		fmt.Println("To login to your Red Hat account, get an offline access token at", tokenPage)
 		token, err = interactive.GetPassword(interactive.Input{
 			Question: "Copy the token and paste it here",
 			Required: true,
 		})
 		if err != nil {
 			reporter.Errorf("Failed to parse token: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		haveReqs = token != ""
 	}
 
 	if !haveReqs {
 		reporter.Errorf("Failed to login to OCM. See 'rosa login --help' for information.")
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// The FedRAMP environment has its own SSO server, so its tokens can't be used with the
+	// commercial one and the other way around:
+	detectToken := token
+	if detectToken == "" && federatedToken == "" {
+		detectToken = cfg.RefreshToken
+		if detectToken == "" {
+			detectToken = cfg.AccessToken
+		}
+	}
+	if detectToken != "" {
+		info, err := config.GetTokenInfo(detectToken)
+		if err != nil {
+			reporter.Debugf("Failed to get issuer of token: %v", err)
+		} else if fedramp.IsIssuer(info.Issuer) != args.govcloud {
+			if args.govcloud {
+				reporter.Errorf("The token wasn't issued by the FedRAMP environment, get a new one "+
+					"at %s", fedramp.TokenPage)
+			} else {
+				reporter.Errorf("The token was issued by the FedRAMP environment, use the " +
+					"'--govcloud' flag to log in to it")
+			}
+			exit.Fail()
+		}
 	}
 
 	// Apply the default OpenID details if not explicitly provided by the user:
 	tokenURL := sdk.DefaultTokenURL
+	clientID := sdk.DefaultClientID
+	urlAliases := config.URLAliases
+	if args.govcloud {
+		tokenURL = fedramp.TokenURL
+		clientID = fedramp.ClientID
+		urlAliases = fedramp.URLAliases
+	}
 	if args.tokenURL != "" {
 		tokenURL = args.tokenURL
 	}
-	clientID := sdk.DefaultClientID
 	if args.clientID != "" {
 		clientID = args.clientID
 	}
 
 	// If the value of the `--env` is any of the aliases then replace it with the corresponding
 	// real URL:
-	gatewayURL, ok := config.URLAliases[args.env]
+	env := args.env
+	if args.govcloud && !cmd.Flags().Changed("env") {
+		env = "production"
+	}
+	gatewayURL, ok := urlAliases[env]
 	if !ok {
-		gatewayURL = args.env
+		gatewayURL = env
+	}
+
+	// When the environment isn't explicitly given select it from the issuer of the token, so that
+	// for example tokens from the staging SSO server are used with the staging API:
+	if !cmd.Flags().Changed("env") && federatedToken == "" && !args.govcloud {
+		detectToken := token
+		if detectToken == "" {
+			detectToken = cfg.RefreshToken
+		}
+		if detectToken == "" {
+			detectToken = cfg.AccessToken
+		}
+		if detectToken != "" {
+			env, issuerTokenURL, err := config.TokenEnvironment(detectToken)
+			if err != nil {
+				reporter.Debugf("Failed to detect environment from token: %v", err)
+			} else if env != "" {
+				gatewayURL = config.URLAliases[env]
+				if args.tokenURL == "" {
+					tokenURL = issuerTokenURL
+				}
+				reporter.Debugf("Detected '%s' environment from the issuer of the token", env)
+				if env != "production" {
+					reporter.Infof("Using the '%s' environment '%s' that matches the token",
+						env, gatewayURL)
+				}
+			}
+		}
 	}
 
 	// Update the configuration with the values given in the command line:
@@ -203,21 +326,39 @@ func run(cmd *cobra.Command, argv []string) {
 	cfg.Scopes = args.scopes
 	cfg.URL = gatewayURL
 	cfg.Insecure = args.insecure
+	cfg.FedRAMP = args.govcloud
+
+	if federatedToken != "" {
+		// Exchange the federated token and remember the file, so that the exchange can be
+		// repeated when the tokens expire:
+		tokenFile, err := filepath.Abs(args.tokenFile)
+		if err != nil {
+			reporter.Errorf("Failed to get absolute path of '%s': %v", args.tokenFile, err)
+			exit.Fail()
+		}
+		cfg.AccessToken, cfg.RefreshToken, err = ocm.ExchangeFederatedToken(cfg, federatedToken)
+		if err != nil {
+			reporter.Errorf("Failed to exchange federated token: %v", err)
+			exit.Fail()
+		}
+		cfg.FederatedTokenFile = tokenFile
+	}
 
 	if token != "" {
+		cfg.FederatedTokenFile = ""
 		// If a token has been provided parse it:
 		parser := new(jwt.Parser)
 		jwtToken, _, err := parser.ParseUnverified(token, jwt.MapClaims{})
 		if err != nil {
 			reporter.Errorf("Failed to parse token '%s': %v", token, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		// Put the token in the place of the configuration that corresponds to its type:
 		typ, err := tokenType(jwtToken)
 		if err != nil {
 			reporter.Errorf("Failed to extract type from 'typ' claim of token '%s': %v", token, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		switch typ {
 		case "Bearer":
@@ -228,10 +369,10 @@ func run(cmd *cobra.Command, argv []string) {
 			cfg.RefreshToken = token
 		case "":
 			reporter.Errorf("Don't know how to handle empty type in token '%s'", token)
-			os.Exit(1)
+			exit.Fail()
 		default:
 			reporter.Errorf("Don't know how to handle token type '%s' in token '%s'", typ, token)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -242,7 +383,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = connection.Close()
@@ -253,8 +394,8 @@ func run(cmd *cobra.Command, argv []string) {
 	accessToken, refreshToken, err := connection.Tokens()
 	if err != nil {
 		reporter.Errorf("Failed to get token. Your session might be expired: %v", err)
-		reporter.Infof("Get a new offline access token at %s", uiTokenPage)
-		os.Exit(1)
+		reporter.Infof("Get a new offline access token at %s", tokenPage)
+		exit.Fail()
 	}
 
 	// Save the configuration:
@@ -263,13 +404,13 @@ func run(cmd *cobra.Command, argv []string) {
 	err = config.Save(cfg)
 	if err != nil {
 		reporter.Errorf("Failed to save config file: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	username, err := cfg.GetData("username")
 	if err != nil {
 		reporter.Errorf("Failed to get username: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
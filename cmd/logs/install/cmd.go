@@ -30,8 +30,11 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/logs"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -39,6 +42,8 @@ var args struct {
 	clusterKey string
 	tail       int
 	watch      bool
+	component  string
+	level      string
 }
 
 var Cmd = &cobra.Command{
@@ -49,7 +54,10 @@ var Cmd = &cobra.Command{
   rosa logs install mycluster --tail=100
 
   # Show install logs for a cluster using the --cluster flag
-  rosa logs install --cluster=mycluster`,
+  rosa logs install --cluster=mycluster
+
+  # Show only the warnings and errors related to the bootstrap node
+  rosa logs install --cluster=mycluster --component=bootstrap --level=warning`,
 	Run: run,
 }
 
@@ -61,7 +69,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to get logs for.",
+		"Name, ID or external ID of the cluster to get logs for.",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -79,6 +87,20 @@ func init() {
 		false,
 		"After getting the logs, watch for changes.",
 	)
+
+	flags.StringVar(
+		&args.component,
+		"component",
+		"",
+		fmt.Sprintf("Show only the log lines related to the given component, one of %v.", logs.Components),
+	)
+
+	flags.StringVar(
+		&args.level,
+		"level",
+		"",
+		fmt.Sprintf("Show only the log lines with the given level or a more severe one, one of %v.", logs.Levels),
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -95,6 +117,15 @@ func run(cmd *cobra.Command, argv []string) {
 		watch = true
 	}
 
+	filter = logs.Filter{
+		Component: args.component,
+		Level:     args.level,
+	}
+	if err := logs.ValidateFilter(filter); err != nil {
+		reporter.Errorf("%s", err)
+		exit.Fail()
+	}
+
 	clusterKey := args.clusterKey
 	// Check that the cluster key (name, identifier or external identifier) given by the user
 	// is reasonably safe so that there is no risk of SQL injection:
@@ -104,7 +135,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -113,13 +144,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -128,7 +159,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -142,14 +173,14 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := clusterprovider.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() == cmv1.ClusterStateReady {
-		reporter.Infof("Cluster '%s' has been successfully installed", clusterKey)
+		reporter.Successf("Cluster '%s' has been successfully installed", clusterKey)
 		os.Exit(0)
 	}
 
@@ -163,7 +194,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"Cluster '%s' has been in %s state for too long. Please contact support",
 				clusterKey, cluster.State(),
 			)
-			os.Exit(1)
+			exit.Fail()
 		}
 		reporter.Warnf(pendingMessage)
 		os.Exit(0)
@@ -173,24 +204,24 @@ func run(cmd *cobra.Command, argv []string) {
 		reporter.Errorf("Cluster '%s' is in '%s' state and no installation logs are available",
 			clusterKey, cluster.State(),
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get logs from Hive
-	logs, err := ocm.GetInstallLogs(clustersCollection, cluster.ID(), args.tail)
+	log, err := logs.GetInstallLogs(clustersCollection, cluster.ID(), args.tail)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			reporter.Infof(pendingMessage)
 		} else {
 			reporter.Errorf("Failed to get logs for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
-	printLog(logs, nil)
+	printLog(log, nil)
 
 	if watch {
 		if cluster.State() == cmv1.ClusterStateReady {
-			reporter.Infof("Cluster '%s' is successfully installed", clusterKey)
+			reporter.Successf("Cluster '%s' is successfully installed", clusterKey)
 			os.Exit(0)
 		}
 
@@ -198,11 +229,11 @@ func run(cmd *cobra.Command, argv []string) {
 		spin.Start()
 
 		// Poll for changing logs:
-		response, err := ocm.PollInstallLogs(clustersCollection, cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
+		response, err := logs.PollInstallLogs(clustersCollection, cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
 			state, _ := ocm.GetClusterState(clustersCollection, cluster.ID())
 			if state == cmv1.ClusterStateError {
 				reporter.Errorf("There was an error installing cluster '%s'", clusterKey)
-				os.Exit(1)
+				exit.Fail()
 			}
 			if state == cmv1.ClusterStateReady {
 				reporter.Infof("Cluster '%s' is now ready", clusterKey)
@@ -214,7 +245,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
 				reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		printLog(response, spin)
@@ -224,9 +255,18 @@ func run(cmd *cobra.Command, argv []string) {
 var lastLine string
 var redact = regexp.MustCompile(`(?s:.*)KUBECONFIG(?s:.*)`)
 
+// filter selects the log lines that are printed.
+var filter logs.Filter
+
+// Escape sequences used to highlight errors:
+const (
+	highlightStart = "\033[0;31m"
+	highlightEnd   = "\033[m"
+)
+
 // Print next log lines
-func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
+func printLog(log *cmv1.Log, spin *spinner.Spinner) {
+	lines := filterLines(findNextLines(log))
 	if lines != "" {
 		fmt.Printf("%s\n", lines)
 		if spin != nil {
@@ -237,9 +277,28 @@ func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
 	}
 }
 
+// Keep only the lines selected by the filter, highlighting errors when the terminal supports it
+func filterLines(text string) string {
+	if text == "" {
+		return ""
+	}
+	var result []string
+	for _, line := range logs.ParseLines(text) {
+		if !filter.Matches(line) {
+			continue
+		}
+		if rprtr.ColorsEnabled(os.Stdout) {
+			result = append(result, logs.HighlightErrors(line.Raw, highlightStart, highlightEnd))
+		} else {
+			result = append(result, line.Raw)
+		}
+	}
+	return strings.Join(result, "\n")
+}
+
 // Remove duplicate lines from the log poll response
-func findNextLines(logs *cmv1.Log) string {
-	lines := strings.Split(logs.Content(), "\n")
+func findNextLines(log *cmv1.Log) string {
+	lines := strings.Split(log.Content(), "\n")
 	// Last element is always empty, remove it
 	if len(lines) > 0 {
 		lines = lines[:len(lines)-1]
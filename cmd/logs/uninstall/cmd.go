@@ -18,7 +18,6 @@ package uninstall
 
 import (
 	"fmt"
-	"os"
 	"strings"
 	"time"
 
@@ -29,8 +28,11 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/logs"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -60,7 +62,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to get logs for.",
+		"Name, ID or external ID of the cluster to get logs for.",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -103,7 +105,7 @@ func run(cmd *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -112,13 +114,13 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -127,7 +129,7 @@ func run(cmd *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -141,15 +143,15 @@ func run(cmd *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := clusterprovider.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateUninstalling && !watch {
 		reporter.Warnf("Cluster '%s' is not currently uninstalling", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() == cmv1.ClusterStateInstalling ||
@@ -157,27 +159,27 @@ func run(cmd *cobra.Command, argv []string) {
 		reporter.Errorf("Cluster '%s' is in '%s' state and no uninstallation logs are available",
 			clusterKey, cluster.State(),
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get logs from Hive
-	logs, err := ocm.GetUninstallLogs(clustersCollection, cluster.ID(), args.tail)
+	log, err := logs.GetUninstallLogs(clustersCollection, cluster.ID(), args.tail)
 	if err != nil {
 		if errors.GetType(err) == errors.NotFound {
 			reporter.Warnf("Logs for cluster '%s' are not available", clusterKey)
 		} else {
 			reporter.Errorf("Failed to get logs for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
-	printLog(logs, nil)
+	printLog(log, nil)
 
 	if watch {
 		spin := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
 		spin.Start()
 
 		// Poll for changing logs:
-		response, err := ocm.PollUninstallLogs(clustersCollection, cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
+		response, err := logs.PollUninstallLogs(clustersCollection, cluster.ID(), func(logResponse *cmv1.LogGetResponse) bool {
 			state, err := ocm.GetClusterState(clustersCollection, cluster.ID())
 			if err != nil || state == cmv1.ClusterState("") {
 				return true
@@ -188,7 +190,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
 				reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
+				exit.Fail()
 			}
 		}
 		printLog(response, spin)
@@ -198,8 +200,8 @@ func run(cmd *cobra.Command, argv []string) {
 var lastLine string
 
 // Print next log lines
-func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
-	lines := findNextLines(logs)
+func printLog(log *cmv1.Log, spin *spinner.Spinner) {
+	lines := findNextLines(log)
 	if lines != "" {
 		fmt.Printf("%s\n", lines)
 		if spin != nil {
@@ -211,8 +213,8 @@ func printLog(logs *cmv1.Log, spin *spinner.Spinner) {
 }
 
 // Remove duplicate lines from the log poll response
-func findNextLines(logs *cmv1.Log) string {
-	lines := strings.Split(logs.Content(), "\n")
+func findNextLines(log *cmv1.Log) string {
+	lines := strings.Split(log.Content(), "\n")
 	// Last element is always empty, remove it
 	if len(lines) > 0 {
 		lines = lines[:len(lines)-1]
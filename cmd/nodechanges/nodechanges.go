@@ -0,0 +1,174 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the implementation shared by the commands that add or remove single
+// labels or taints of the nodes of a machine pool, like 'rosa label machinepool' and 'rosa taint
+// machinepool'.
+
+package nodechanges
+
+import (
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/validation"
+)
+
+// Attribute describes the attribute of the nodes of the machine pool changed by a command.
+type Attribute struct {
+	// Name is the plural name of the attribute, like 'labels', used in messages.
+	Name string
+
+	// DefaultPoolError is the error reported when the changes are for the Default machine pool.
+	DefaultPoolError string
+
+	// Parse checks the changes given in the command line. It is called before connecting to the
+	// OCM API, so that invalid changes are reported early.
+	Parse func(text string) error
+
+	// Apply adds to the builder the attribute that results of applying the changes to the
+	// current machine pool.
+	Apply func(current *cmv1.MachinePool, builder *cmv1.MachinePoolBuilder) error
+}
+
+// Args returns the function that checks that the command line contains the identifier of the
+// machine pool followed by at least one change. The name is the singular name of the attribute.
+func Args(name string) cobra.PositionalArgs {
+	return func(_ *cobra.Command, argv []string) error {
+		if len(argv) < 2 {
+			return fmt.Errorf(
+				"Expected the id of the machine pool followed by at least one %s", name,
+			)
+		}
+		return nil
+	}
+}
+
+// Run reads the machine pool given as the first argument of the command line, applies the
+// changes given in the rest of the arguments and writes it back.
+func Run(attribute *Attribute, clusterKey string, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	machinePoolID := argv[0]
+	if machinePoolID == "Default" {
+		reporter.Errorf("%s", attribute.DefaultPoolError)
+		exit.Fail()
+	}
+	err := validation.MachinePoolName(machinePoolID)
+	if err != nil {
+		reporter.Errorf("Expected a valid identifier for the machine pool: %s", err)
+		exit.Fail()
+	}
+
+	for _, arg := range argv[1:] {
+		err = attribute.Parse(arg)
+		if err != nil {
+			reporter.Errorf("%v", err)
+			exit.Fail()
+		}
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Use the shared OCM connection, so that reading and updating the machine pool reuse the same
+	// TLS session and tokens:
+	ocmConnection, err := ocm.AcquireConnection(logger)
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocm.ReleaseConnection()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	// The machine pool is read and written back with the changes:
+	reporter.Debugf("Loading machine pool '%s' for cluster '%s'", machinePoolID, clusterKey)
+	machinePoolClient := clustersCollection.Cluster(cluster.ID()).MachinePools().MachinePool(machinePoolID)
+	getResponse, err := machinePoolClient.Get().Send()
+	if err != nil {
+		reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %s",
+			machinePoolID, clusterKey, ocm.ErrorReason(getResponse.Error()))
+		exit.Fail()
+	}
+	builder := cmv1.NewMachinePool().ID(machinePoolID)
+	err = attribute.Apply(getResponse.Body(), builder)
+	if err != nil {
+		reporter.Errorf("Failed to change %s of machine pool '%s': %v", attribute.Name, machinePoolID, err)
+		exit.Fail()
+	}
+
+	machinePool, err := builder.Build()
+	if err != nil {
+		reporter.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	reporter.Debugf("Updating machine pool '%s' on cluster '%s'", machinePoolID, clusterKey)
+	updateResponse, err := machinePoolClient.Update().Body(machinePool).Send()
+	if err != nil {
+		reporter.Errorf("Failed to update machine pool '%s' on cluster '%s': %s",
+			machinePoolID, clusterKey, ocm.ErrorReason(updateResponse.Error()))
+		exit.Fail()
+	}
+	reporter.Infof("Updated %s of machine pool '%s' on cluster '%s'", attribute.Name, machinePoolID, clusterKey)
+}
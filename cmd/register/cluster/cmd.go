@@ -0,0 +1,197 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	externalID   string
+	name         string
+	apiURL       string
+	consoleURL   string
+	disconnected bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Register an existing OpenShift cluster",
+	Long: "Register an existing self-managed OpenShift cluster with OCM, so that it is listed " +
+		"together with the ROSA clusters. OCM only stores the console URL of disconnected " +
+		"clusters, so their API URL is used to compute the default console URL.",
+	Example: `  # Register a cluster that reports to OCM using its cluster ID
+  rosa register cluster --external-id 0c2ab5b1-2d34-4a6e-9d2c-6c3c7cbdd0c5
+
+  # Register a cluster that can't connect to OCM
+  rosa register cluster --disconnected --external-id 0c2ab5b1-2d34-4a6e-9d2c-6c3c7cbdd0c5 \
+    --name mycluster --api-url https://api.mycluster.example.com:6443`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVar(
+		&args.externalID,
+		"external-id",
+		"",
+		"Cluster ID of the existing cluster, as shown by 'oc get clusterversion -o "+
+			"jsonpath='{.items[].spec.clusterID}''.",
+	)
+	Cmd.MarkFlagRequired("external-id")
+
+	flags.BoolVar(
+		&args.disconnected,
+		"disconnected",
+		false,
+		"Register a cluster that can't report to OCM by itself. Its details need to be "+
+			"maintained manually.",
+	)
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name shown for a disconnected cluster. Defaults to the external ID.",
+	)
+
+	flags.StringVar(
+		&args.apiURL,
+		"api-url",
+		"",
+		"URL of the API server of a disconnected cluster, like 'https://api.mycluster.example.com:6443'. "+
+			"It isn't stored by OCM, it is used to compute the default console URL.",
+	)
+
+	flags.StringVar(
+		&args.consoleURL,
+		"console-url",
+		"",
+		"URL of the web console of a disconnected cluster. Defaults to the console URL that "+
+			"corresponds to the API URL.",
+	)
+}
+
+var externalIDRE = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func run(cmd *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if !externalIDRE.MatchString(args.externalID) {
+		reporter.Errorf("External ID '%s' isn't a valid cluster ID, it must be a lowercase UUID",
+			args.externalID)
+		exit.Fail()
+	}
+
+	if !args.disconnected && (cmd.Flags().Changed("name") || cmd.Flags().Changed("api-url") ||
+		cmd.Flags().Changed("console-url")) {
+		reporter.Errorf("The '--name', '--api-url' and '--console-url' flags can only be used with " +
+			"'--disconnected', connected clusters report their own details")
+		exit.Fail()
+	}
+
+	if args.apiURL != "" && !isHTTPSURL(args.apiURL) {
+		reporter.Errorf("API URL '%s' must be a valid HTTPS URL", args.apiURL)
+		exit.Fail()
+	}
+	consoleURL := args.consoleURL
+	if consoleURL == "" && args.apiURL != "" {
+		consoleURL = defaultConsoleURL(args.apiURL)
+		if consoleURL == "" {
+			reporter.Errorf("Can't compute the console URL from API URL '%s', use the '--console-url' flag",
+				args.apiURL)
+			exit.Fail()
+		}
+		reporter.Debugf("Using console URL '%s' for API URL '%s'", consoleURL, args.apiURL)
+	}
+	if consoleURL != "" && !isHTTPSURL(consoleURL) {
+		reporter.Errorf("Console URL '%s' must be a valid HTTPS URL", consoleURL)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	if args.disconnected {
+		name := args.name
+		if name == "" {
+			name = args.externalID
+		}
+		reporter.Debugf("Registering disconnected cluster '%s'", args.externalID)
+		subscription, err := ocm.RegisterDisconnectedCluster(ocmConnection, args.externalID, name,
+			consoleURL)
+		if err != nil {
+			reporter.Errorf("Failed to register cluster '%s': %v", args.externalID, err)
+			exit.Fail()
+		}
+		reporter.Infof("Registered disconnected cluster '%s' with subscription '%s'",
+			name, subscription.ID())
+		return
+	}
+
+	reporter.Debugf("Registering cluster '%s'", args.externalID)
+	registration, err := ocm.RegisterCluster(ocmConnection, args.externalID)
+	if err != nil {
+		reporter.Errorf("Failed to register cluster '%s': %v", args.externalID, err)
+		exit.Fail()
+	}
+	reporter.Infof("Registered cluster '%s'. It will show its details once it reports to OCM",
+		registration.ClusterID())
+}
+
+func isHTTPSURL(value string) bool {
+	parsed, err := url.ParseRequestURI(value)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
+
+// defaultConsoleURL returns the URL of the web console that OpenShift uses by default for the given
+// API URL, for example 'https://console-openshift-console.apps.mycluster.example.com' for
+// 'https://api.mycluster.example.com:6443'. It returns an empty string if the API URL doesn't
+// follow the default naming.
+func defaultConsoleURL(apiURL string) string {
+	parsed, err := url.Parse(apiURL)
+	if err != nil || !strings.HasPrefix(parsed.Hostname(), "api.") {
+		return ""
+	}
+	domain := strings.TrimPrefix(parsed.Hostname(), "api.")
+	return "https://console-openshift-console.apps." + domain
+}
@@ -19,6 +19,7 @@ package revoke
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/revoke/infraaccess"
 	"github.com/openshift/rosa/cmd/revoke/user"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/confirm"
@@ -31,6 +32,7 @@ var Cmd = &cobra.Command{
 }
 
 func init() {
+	Cmd.AddCommand(infraaccess.Cmd)
 	Cmd.AddCommand(user.Cmd)
 
 	flags := Cmd.PersistentFlags()
@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package infraaccess
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	clusterKey string
+	userARN    string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "aws-infrastructure-access ROLE",
+	Aliases: []string{"aws-infra-access"},
+	Short:   "Revoke access to the AWS infrastructure of a cluster",
+	Long:    "Revoke a role granted to an AWS IAM user or role to access the AWS console of the cluster.",
+	Example: `  # Revoke read-only access to the infrastructure of a cluster from an IAM user
+  rosa revoke aws-infrastructure-access read-only --cluster=mycluster \
+    --user-arn=arn:aws:iam::123456789012:user/myuser`,
+	Run: run,
+	Args: func(_ *cobra.Command, argv []string) error {
+		if len(argv) != 1 {
+			return fmt.Errorf(
+				"Expected exactly one command line argument containing the identifier of the role " +
+					"to revoke.",
+			)
+		}
+		return nil
+	},
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to revoke access to (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.userARN,
+		"user-arn",
+		"",
+		"ARN of the AWS IAM user or role to revoke the role from (required).",
+	)
+	Cmd.MarkFlagRequired("user-arn")
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	roleID := argv[0]
+	userARN := args.userARN
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	// Find the grant:
+	grants, err := ocm.GetAWSInfrastructureAccessRoleGrants(clustersCollection, cluster.ID())
+	if err != nil {
+		reporter.Errorf("Failed to get AWS infrastructure access grants for cluster '%s': %v",
+			clusterKey, err)
+		exit.Fail()
+	}
+	grantID := ""
+	for _, grant := range grants {
+		if grant.Role().ID() == roleID && grant.UserARN() == userARN {
+			grantID = grant.ID()
+			break
+		}
+	}
+	if grantID == "" {
+		reporter.Warnf("Cannot find grant of role '%s' to '%s' on cluster '%s'", roleID, userARN, clusterKey)
+		os.Exit(0)
+	}
+
+	if !confirm.Confirm("revoke role %s from %s in cluster %s", roleID, userARN, clusterKey) {
+		os.Exit(0)
+	}
+
+	reporter.Debugf("Deleting grant '%s' of cluster '%s'", grantID, clusterKey)
+	err = ocm.DeleteAWSInfrastructureAccessRoleGrant(clustersCollection, cluster.ID(), grantID)
+	if err != nil {
+		reporter.Errorf("Failed to revoke role '%s' from '%s' on cluster '%s': %v",
+			roleID, userARN, clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Infof("Revoked role '%s' from '%s' on cluster '%s'", roleID, userARN, clusterKey)
+}
@@ -24,8 +24,10 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -67,7 +69,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to delete the users from (required).",
+		"Name, ID or external ID of the cluster to delete the users from (required).",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -94,7 +96,7 @@ func run(_ *cobra.Command, argv []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	username := args.username
@@ -103,11 +105,11 @@ func run(_ *cobra.Command, argv []string) {
 			"Username '%s' isn't valid: it must contain only letters, digits, dashes and underscores",
 			username,
 		)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if username == "cluster-admin" {
 		reporter.Errorf("Username 'cluster-admin' is not allowed")
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	role := argv[0]
@@ -126,7 +128,7 @@ func run(_ *cobra.Command, argv []string) {
 	}
 	if !isRoleValid {
 		reporter.Errorf("Expected at least one of %s", validRoles)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -135,13 +137,13 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -150,7 +152,7 @@ func run(_ *cobra.Command, argv []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -164,10 +166,10 @@ func run(_ *cobra.Command, argv []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Try to find the user:
@@ -175,7 +177,7 @@ func run(_ *cobra.Command, argv []string) {
 	user, err := ocm.GetUser(clustersCollection, cluster.ID(), role, username)
 	if err != nil {
 		reporter.Errorf(err.Error())
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if user == nil {
@@ -192,8 +194,8 @@ func run(_ *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Debugf(err.Error())
 		reporter.Errorf("Failed to revoke '%s' from user '%s' in cluster '%s': %s",
-			role, username, clusterKey, res.Error().Reason())
-		os.Exit(1)
+			role, username, clusterKey, ocm.ErrorReason(res.Error()))
+		exit.Fail()
 	}
 	reporter.Infof("Revoked role '%s' from user '%s' on cluster '%s'", role, username, clusterKey)
 }
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Flags", func() {
+	It("doesn't shadow persistent flags with flags of subcommands", func() {
+		type persistent struct {
+			flag  *pflag.Flag
+			owner string
+		}
+		var clashes []string
+		var walk func(cmd *cobra.Command, inherited map[string]*persistent)
+		walk = func(cmd *cobra.Command, inherited map[string]*persistent) {
+			// Inherited flags may have been merged into the flags of the command, so only the
+			// flags that are different objects shadow them:
+			checked := map[*pflag.Flag]bool{}
+			check := func(flag *pflag.Flag) {
+				if checked[flag] {
+					return
+				}
+				checked[flag] = true
+				names := []string{"--" + flag.Name}
+				if flag.Shorthand != "" {
+					names = append(names, "-"+flag.Shorthand)
+				}
+				for _, name := range names {
+					if parent, ok := inherited[name]; ok && parent.flag != flag {
+						clashes = append(clashes, fmt.Sprintf(
+							"flag '%s' of '%s' shadows the persistent flag of '%s'",
+							name, cmd.CommandPath(), parent.owner))
+					}
+				}
+			}
+			cmd.Flags().VisitAll(check)
+			cmd.PersistentFlags().VisitAll(check)
+
+			// The persistent flags of the command are inherited by its subcommands:
+			children := map[string]*persistent{}
+			for name, parent := range inherited {
+				children[name] = parent
+			}
+			cmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
+				if _, ok := inherited["--"+flag.Name]; ok {
+					return
+				}
+				value := &persistent{
+					flag:  flag,
+					owner: cmd.CommandPath(),
+				}
+				children["--"+flag.Name] = value
+				if flag.Shorthand != "" {
+					children["-"+flag.Shorthand] = value
+				}
+			})
+			for _, child := range cmd.Commands() {
+				walk(child, children)
+			}
+		}
+		walk(root, map[string]*persistent{})
+		Expect(clashes).To(BeEmpty())
+	})
+})
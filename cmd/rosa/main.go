@@ -17,33 +17,52 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/apply"
+	"github.com/openshift/rosa/cmd/check"
 	"github.com/openshift/rosa/cmd/completion"
+	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
 	"github.com/openshift/rosa/cmd/describe"
+	"github.com/openshift/rosa/cmd/detachanddelete"
 	"github.com/openshift/rosa/cmd/dlt"
 	"github.com/openshift/rosa/cmd/docs"
 	"github.com/openshift/rosa/cmd/download"
 	"github.com/openshift/rosa/cmd/edit"
+	"github.com/openshift/rosa/cmd/exitcodes"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/initialize"
 	"github.com/openshift/rosa/cmd/install"
+	"github.com/openshift/rosa/cmd/label"
 	"github.com/openshift/rosa/cmd/list"
 	"github.com/openshift/rosa/cmd/login"
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
+	"github.com/openshift/rosa/cmd/register"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/scale"
+	"github.com/openshift/rosa/cmd/serve"
+	"github.com/openshift/rosa/cmd/taint"
+	"github.com/openshift/rosa/cmd/token"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/upgrade"
+	"github.com/openshift/rosa/cmd/validate"
 	"github.com/openshift/rosa/cmd/verify"
 	"github.com/openshift/rosa/cmd/version"
 	"github.com/openshift/rosa/cmd/whoami"
 
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/fedramp"
+	ocmconfig "github.com/openshift/rosa/pkg/ocm/config"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/tracing"
 )
 
 var root = &cobra.Command{
@@ -56,36 +75,110 @@ func init() {
 	// Add the command line flags:
 	fs := root.PersistentFlags()
 	arguments.AddDebugFlag(fs)
+	arguments.AddLogFlags(fs)
+	arguments.AddNoColorFlag(fs)
+	arguments.AddQuietFlag(fs)
+	arguments.AddSuppressWarningsFlag(fs)
+	arguments.AddWarningsOutputFlag(fs)
+	arguments.AddOCMProxyFlag(fs)
 
 	// Register the subcommands:
+	root.AddCommand(apply.Cmd)
+	root.AddCommand(check.Cmd)
 	root.AddCommand(completion.Cmd)
+	root.AddCommand(config.Cmd)
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
+	root.AddCommand(detachanddelete.Cmd)
 	root.AddCommand(dlt.Cmd)
 	root.AddCommand(docs.Cmd)
 	root.AddCommand(download.Cmd)
 	root.AddCommand(edit.Cmd)
+	root.AddCommand(exitcodes.Cmd)
 	root.AddCommand(grant.Cmd)
 	root.AddCommand(list.Cmd)
 	root.AddCommand(initialize.Cmd)
 	root.AddCommand(install.Cmd)
+	root.AddCommand(label.Cmd)
 	root.AddCommand(login.Cmd)
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
+	root.AddCommand(register.Cmd)
 	root.AddCommand(revoke.Cmd)
+	root.AddCommand(scale.Cmd)
+	root.AddCommand(serve.Cmd)
+	root.AddCommand(taint.Cmd)
+	root.AddCommand(token.Cmd)
 	root.AddCommand(uninstall.Cmd)
 	root.AddCommand(upgrade.Cmd)
+	root.AddCommand(validate.Cmd)
 	root.AddCommand(verify.Cmd)
 	root.AddCommand(version.Cmd)
 	root.AddCommand(whoami.Cmd)
+
+	// Apply the environment variables and the defaults of the configuration file before running
+	// the hooks of the commands:
+	arguments.RegisterDefaults(root)
+
+	// Check the warning flags once they have been parsed:
+	cobra.OnInitialize(func() {
+		err := rprtr.ValidateWarningFlags()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s\n", err)
+			os.Exit(exit.Usage)
+		}
+	})
 }
 
 func main() {
+	// Start the trace of the command, if tracing is enabled:
+	name := root.Name()
+	if cmd, _, err := root.Find(os.Args[1:]); err == nil {
+		name = cmd.CommandPath()
+	}
+	tracing.Start(name)
+
+	// Commands that fail exit the process directly, so the trace needs to be finished and the
+	// summaries printed before that happens:
+	exit.OnExit(func(code int, message string) {
+		if message == "" {
+			message = fmt.Sprintf("Command failed with exit code %d", code)
+		}
+		tracing.Shutdown(errors.New(message))
+		printSummaries()
+	})
+
+	// Some commands aren't available in the FedRAMP environment:
+	if cfg, err := ocmconfig.Load(); err == nil && cfg != nil && cfg.FedRAMP {
+		if reason := fedramp.Unsupported(name); reason != "" {
+			reporter := rprtr.CreateReporterOrExit()
+			reporter.Errorf("Command '%s' isn't supported in the FedRAMP environment: %s", name, reason)
+			exit.Fail()
+		}
+	}
+
 	// Execute the root command:
 	root.SetArgs(os.Args[1:])
 	err := root.Execute()
+	tracing.Shutdown(err)
 	if err != nil {
+		// Commands report their own failures and exit, so errors here are about the command
+		// line, like unknown commands or flags:
 		fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
-		os.Exit(1)
+		os.Exit(exit.Usage)
+	}
+	printSummaries()
+}
+
+// printSummaries prints the information collected while the command was running, after it
+// finishes, successfully or not.
+func printSummaries() {
+	// Tell the user if the command was slowed down by AWS API rate limits:
+	if summary := aws.ThrottleSummary(); summary != "" {
+		reporter, err := rprtr.New().Build()
+		if err == nil {
+			reporter.Warn(rprtr.WarnAWSThrottled, "%s", summary)
+		}
 	}
+	rprtr.FlushWarnings()
 }
@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scale
+
+import (
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/machinepools/scale"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/validation"
+)
+
+var args struct {
+	clusterKey    string
+	machinePoolID string
+	replicas      int
+	step          int
+	interval      time.Duration
+	waitTimeout   time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Scale a machine pool gradually",
+	Long: "Change the replicas of a machine pool in batches, waiting until the nodes of each batch " +
+		"are ready before starting the next one, so that a large number of nodes doesn't join " +
+		"the cluster at the same time.",
+	Example: `  # Scale the default machine pool of cluster "mycluster" to 12 replicas, 3 at a time
+  rosa scale --cluster=mycluster --replicas=12 --step=3
+
+  # Scale machine pool "workers" to 12 replicas, 3 at a time, pausing 5 minutes between batches
+  rosa scale --cluster=mycluster --machinepool=workers --replicas=12 --step=3 --interval=5m`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to scale the machine pool of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.StringVar(
+		&args.machinePoolID,
+		"machinepool",
+		scale.DefaultPoolID,
+		"Identifier of the machine pool to scale.",
+	)
+
+	flags.IntVar(
+		&args.replicas,
+		"replicas",
+		0,
+		"Number of replicas that the machine pool will have at the end (required).",
+	)
+	Cmd.MarkFlagRequired("replicas")
+
+	flags.IntVar(
+		&args.step,
+		"step",
+		0,
+		"Maximum number of replicas added or removed in each batch. By default all the "+
+			"replicas are changed in a single batch.",
+	)
+
+	flags.DurationVar(
+		&args.interval,
+		"interval",
+		0,
+		"Time to wait after the nodes of a batch are ready before starting the next batch.",
+	)
+
+	flags.DurationVar(
+		&args.waitTimeout,
+		"wait-timeout",
+		30*time.Minute,
+		"Maximum time to wait for the nodes of each batch to be ready.",
+	)
+
+	arguments.AddProfileFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	machinePoolID := args.machinePoolID
+	if machinePoolID != scale.DefaultPoolID {
+		err := validation.MachinePoolName(machinePoolID)
+		if err != nil {
+			reporter.Errorf("Expected a valid identifier for the machine pool: %s", err)
+			exit.Fail()
+		}
+	}
+	if args.replicas < 0 {
+		reporter.Errorf("The number of machine pool replicas needs to be a positive integer")
+		exit.Fail()
+	}
+	if args.step < 0 {
+		reporter.Errorf("The step needs to be a positive integer")
+		exit.Fail()
+	}
+	if args.interval < 0 {
+		reporter.Errorf("The interval can't be negative")
+		exit.Fail()
+	}
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	clusterKey := args.clusterKey
+	if !ocm.IsValidClusterKey(clusterKey) {
+		reporter.Errorf(
+			"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores",
+			clusterKey,
+		)
+		exit.Fail()
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// The shared OCM connection is kept for all the batches, so that its tokens are refreshed
+	// instead of requested again:
+	ocmConnection, err := ocm.AcquireConnection(logger)
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocm.ReleaseConnection()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Get the client for the OCM collection of clusters:
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(clustersCollection, clusterKey, awsCreator.ARN)
+	if err != nil {
+		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		exit.Fail()
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		exit.Fail()
+	}
+
+	// The replicas of every batch must be valid for the machine pool:
+	if cluster.MultiAZ() {
+		if args.replicas%3 != 0 || args.step%3 != 0 {
+			reporter.Errorf("Multi AZ clusters require that the number of replicas and the step " +
+				"be multiples of 3")
+			exit.Fail()
+		}
+	}
+	if machinePoolID == scale.DefaultPoolID {
+		minimum := 2
+		if cluster.MultiAZ() {
+			minimum = 3
+		}
+		if args.replicas < minimum {
+			reporter.Errorf("Default machine pool requires at least %d compute nodes", minimum)
+			exit.Fail()
+		}
+	}
+
+	current, err := scale.Replicas(clustersCollection, cluster, machinePoolID)
+	if err != nil {
+		reporter.Errorf("Failed to get machine pool '%s' for cluster '%s': %v", machinePoolID,
+			clusterKey, err)
+		exit.Fail()
+	}
+	if current == args.replicas {
+		reporter.Infof("Machine pool '%s' on cluster '%s' already has %d replicas", machinePoolID,
+			clusterKey, current)
+		return
+	}
+
+	batches := scale.Batches(current, args.replicas, args.step)
+	reporter.Infof("Scaling machine pool '%s' on cluster '%s' from %d to %d replicas in %d batch(es)",
+		machinePoolID, clusterKey, current, args.replicas, len(batches))
+	err = scale.Scale(clustersCollection, cluster, machinePoolID, args.replicas, &scale.Options{
+		Step:     args.step,
+		Interval: args.interval,
+		Timeout:  args.waitTimeout,
+		Progress: reporter.Infof,
+	})
+	if err != nil {
+		reporter.Errorf("Failed to scale machine pool '%s' on cluster '%s': %v", machinePoolID,
+			clusterKey, err)
+		exit.Fail()
+	}
+	reporter.Successf("Scaled machine pool '%s' on cluster '%s' to %d replicas", machinePoolID,
+		clusterKey, args.replicas)
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serve
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	c "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var args struct {
+	applySchedules bool
+	clusterKeys    []string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Run background tasks for clusters",
+	Long: "Run in the foreground and perform periodic tasks for the clusters of the current " +
+		"AWS user until interrupted. Currently the only task is applying the machine pool " +
+		"scaling schedules created with 'rosa create schedule'.",
+	Example: `  # Apply the scaling schedules of all clusters
+  rosa serve --apply-schedules
+
+  # Apply the scaling schedules of the cluster named "mycluster" only
+  rosa serve --apply-schedules --cluster=mycluster`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVar(
+		&args.applySchedules,
+		"apply-schedules",
+		false,
+		"Scale machine pools according to the schedules stored in the clusters.",
+	)
+
+	flags.StringSliceVarP(
+		&args.clusterKeys,
+		"cluster",
+		"c",
+		nil,
+		"Name, ID or external ID of a cluster to serve. Can be repeated. Defaults to all the clusters "+
+			"of the current AWS user.",
+	)
+
+	arguments.AddProfileFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	if !args.applySchedules {
+		reporter.Errorf("Nothing to do, use '--apply-schedules' to apply machine pool schedules")
+		exit.Fail()
+	}
+	for _, clusterKey := range args.clusterKeys {
+		if !ocm.IsValidClusterKey(clusterKey) {
+			reporter.Errorf(
+				"Cluster name, identifier or external identifier '%s' isn't valid: it "+
+					"must contain only letters, digits, dashes and underscores",
+				clusterKey,
+			)
+			exit.Fail()
+		}
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
+	if err != nil {
+		reporter.Errorf("Failed to create AWS client: %v", err)
+		exit.Fail()
+	}
+
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		reporter.Errorf("Failed to get AWS creator: %v", err)
+		exit.Fail()
+	}
+
+	// The connection is kept open for as long as the command runs, so that the tokens are
+	// refreshed instead of requested again every minute:
+	ocmConnection, err := ocm.AcquireConnection(logger)
+	if err != nil {
+		reporter.Errorf("Failed to create OCM connection: %v", err)
+		exit.Fail()
+	}
+	defer func() {
+		err = ocm.ReleaseConnection()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+	clustersCollection := ocmConnection.ClustersMgmt().V1().Clusters()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	reporter.Infof("Applying machine pool schedules, press Ctrl+C to stop")
+	for {
+		// Schedules have a resolution of one minute, so wake up at the start of each minute:
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		select {
+		case <-signals:
+			reporter.Infof("Stopped applying machine pool schedules")
+			return
+		case <-time.After(next.Sub(now)):
+		}
+
+		clusters, err := getClusters(reporter, clustersCollection, awsCreator.ARN)
+		if err != nil {
+			reporter.Errorf("Failed to get clusters: %v", err)
+			continue
+		}
+		for _, cluster := range clusters {
+			applySchedules(reporter, clustersCollection, cluster, next)
+		}
+	}
+}
+
+// getClusters loads the clusters given in the command line, or all the clusters of the current
+// user if none was given. The clusters are loaded again each time so that changes to the
+// schedules are picked up.
+func getClusters(reporter *rprtr.Object, client *cmv1.ClustersClient,
+	creatorARN string) ([]*cmv1.Cluster, error) {
+	if len(args.clusterKeys) == 0 {
+		return c.GetClusters(client, creatorARN, 1000)
+	}
+	var result []*cmv1.Cluster
+	for _, clusterKey := range args.clusterKeys {
+		reporter.Debugf("Loading cluster '%s'", clusterKey)
+		cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, cluster)
+	}
+	return result, nil
+}
+
+func applySchedules(reporter *rprtr.Object, client *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	now time.Time) {
+	items, err := schedules.GetSchedules(cluster)
+	if err != nil {
+		reporter.Warnf("Failed to get schedules for cluster '%s': %v", cluster.Name(), err)
+		return
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		if len(items) > 0 {
+			reporter.Debugf("Skipping schedules of cluster '%s' because it isn't ready", cluster.Name())
+		}
+		return
+	}
+	for _, schedule := range schedules.Due(items, now) {
+		reporter.Infof("Scaling machine pool '%s' of cluster '%s' to %d replicas (schedule '%s')",
+			schedule.MachinePool, cluster.Name(), schedule.Replicas, schedule.ID)
+		err = schedules.Apply(client, cluster.ID(), schedule)
+		if err != nil {
+			reporter.Errorf("Failed to apply schedule '%s' to cluster '%s': %v",
+				schedule.ID, cluster.Name(), err)
+		}
+	}
+}
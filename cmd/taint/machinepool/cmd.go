@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/nodechanges"
+	"github.com/openshift/rosa/pkg/ocm/machines"
+)
+
+var args struct {
+	clusterKey string
+	overwrite  bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID KEY=VALUE:EFFECT|KEY:EFFECT-|KEY-...",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Add or remove taints of a machine pool",
+	Long: "Add or remove taints of the nodes of a machine pool, keeping the rest of the taints. " +
+		"Taints are added with 'key=value:effect' and removed with 'key:effect-', or with 'key-' " +
+		"to remove the taints with the key and any effect.",
+	Example: `  # Add a taint to machine pool 'mp1' on cluster 'mycluster'
+  rosa taint machinepool --cluster=mycluster mp1 dedicated=gpu:NoSchedule
+
+  # Change the value of the taint
+  rosa taint machinepool --cluster=mycluster mp1 dedicated=ml:NoSchedule --overwrite
+
+  # Remove the taint
+  rosa taint machinepool --cluster=mycluster mp1 dedicated:NoSchedule-`,
+	Run:  run,
+	Args: nodechanges.Args("taint"),
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster to change the taints of the machine pool of (required).",
+	)
+	Cmd.MarkFlagRequired("cluster")
+
+	flags.BoolVar(
+		&args.overwrite,
+		"overwrite",
+		false,
+		"Replace the value of taints that already exist with the same key and effect.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	var changes []*machines.TaintChange
+	nodechanges.Run(&nodechanges.Attribute{
+		Name:             "taints",
+		DefaultPoolError: "Taints are not supported on the Default machine pool",
+		Parse: func(text string) error {
+			change, err := machines.ParseTaintChange(text)
+			if err != nil {
+				return err
+			}
+			changes = append(changes, change)
+			return nil
+		},
+		Apply: func(current *cmv1.MachinePool, builder *cmv1.MachinePoolBuilder) error {
+			taints, err := machines.ApplyTaintChanges(current.Taints(), changes, args.overwrite)
+			if err != nil {
+				return err
+			}
+			builder.Taints(taints...)
+			return nil
+		},
+	}, args.clusterKey, argv)
+}
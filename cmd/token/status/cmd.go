@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm/config"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the status of the stored tokens",
+	Long:  "Show the type, issuer and expiration time of the tokens stored in the configuration file.",
+	Example: `  # Check when the current session expires
+  rosa token status`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	// Load the configuration file:
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		exit.Fail()
+	}
+	if cfg == nil || (cfg.AccessToken == "" && cfg.RefreshToken == "") {
+		reporter.Errorf("There are no stored tokens, run the 'rosa login' command")
+		exit.Fail()
+	}
+
+	// Create the writer that will be used to print the tabulated results:
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "TOKEN\tTYPE\tISSUER\tEXPIRES\n")
+	tokens := []struct {
+		name  string
+		value string
+	}{
+		{"access", cfg.AccessToken},
+		{"refresh", cfg.RefreshToken},
+	}
+	for _, token := range tokens {
+		if token.value == "" {
+			continue
+		}
+		info, err := config.GetTokenInfo(token.value)
+		if err != nil {
+			reporter.Errorf("Failed to parse %s token: %v", token.name, err)
+			exit.Fail()
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			token.name,
+			info.Type,
+			info.Issuer,
+			printExpiry(info),
+		)
+	}
+	writer.Flush()
+
+	expires, left, err := cfg.RefreshTokenExpiry()
+	if err == nil && expires && left < config.TokenExpiryWarning {
+		reporter.Warnf("Get a new offline access token at %s and run 'rosa login' again", cfg.TokenPage())
+	}
+}
+
+func printExpiry(info *config.TokenInfo) string {
+	if !info.Expires {
+		return "never"
+	}
+	return fmt.Sprintf("%s (%s)",
+		info.ExpiresAt.Format("2006-01-02 15:04 MST"),
+		humanize.Time(info.ExpiresAt),
+	)
+}
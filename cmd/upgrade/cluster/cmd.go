@@ -28,9 +28,12 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	c "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/notify"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	"github.com/openshift/rosa/pkg/ocm/upgrades"
 	"github.com/openshift/rosa/pkg/ocm/versions"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
@@ -42,8 +45,14 @@ var args struct {
 	scheduleDate         string
 	scheduleTime         string
 	nodeDrainGracePeriod string
+	scheduleNow          bool
+	overrideWindow       bool
 }
 
+// scheduleNowDelay is the time added to the current time for upgrades that start immediately, as
+// the next run of the upgrade policy needs to be in the future when it is created.
+const scheduleNowDelay = 5 * time.Minute
+
 var nodeDrainOptions = []string{
 	"15 minutes",
 	"30 minutes",
@@ -62,7 +71,10 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgade cluster -c mycluster --version 4.5.20`,
+  rosa upgade cluster -c mycluster --version 4.5.20
+
+  # Upgrade a cluster right away, unless it is inside its maintenance exclusion window
+  rosa upgrade cluster -c mycluster --version 4.5.20 --schedule-now`,
 	Run: run,
 }
 
@@ -75,7 +87,7 @@ func init() {
 		"cluster",
 		"c",
 		"",
-		"Name or ID of the cluster to schedule the upgrade for (required)",
+		"Name, ID or external ID of the cluster to schedule the upgrade for (required)",
 	)
 	Cmd.MarkFlagRequired("cluster")
 
@@ -100,6 +112,22 @@ func init() {
 		"Next UTC time that the upgrade should run on the specified date. Format should be 'HH:mm'",
 	)
 
+	flags.BoolVar(
+		&args.scheduleNow,
+		"schedule-now",
+		false,
+		"Start the upgrade right away. It is refused when the current time is inside the "+
+			"maintenance exclusion window of the cluster, unless '--override-window' is used.",
+	)
+
+	flags.BoolVar(
+		&args.overrideWindow,
+		"override-window",
+		false,
+		"Upgrade the cluster even if the upgrade time is inside its maintenance exclusion window. "+
+			"Intended for emergencies.",
+	)
+
 	flags.StringVar(
 		&args.nodeDrainGracePeriod,
 		"node-drain-grace-period",
@@ -109,6 +137,8 @@ func init() {
 			"Budgets that have not been successfully drained from a node will be forcibly evicted.\nValid "+
 			"options are ['%s']", strings.Join(nodeDrainOptions, "','")),
 	)
+
+	notify.AddFlags(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -124,7 +154,13 @@ func run(cmd *cobra.Command, _ []string) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	if args.scheduleNow && (args.scheduleDate != "" || args.scheduleTime != "") {
+		reporter.Errorf("The '--schedule-now' option can't be combined with '--schedule-date' " +
+			"or '--schedule-time'")
+		exit.Fail()
 	}
 
 	// Create the AWS client:
@@ -134,13 +170,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -149,7 +185,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = ocmConnection.Close()
@@ -163,21 +199,21 @@ func run(cmd *cobra.Command, _ []string) {
 
 	// Try to find the cluster:
 	reporter.Debugf("Loading cluster '%s'", clusterKey)
-	cluster, err := ocm.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
+	cluster, err := clusters.GetCluster(ocmClient.Clusters(), clusterKey, awsCreator.ARN)
 	if err != nil {
 		reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	if cluster.State() != cmv1.ClusterStateReady {
 		reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	scheduledUpgrade, upgradeState, err := upgrades.GetScheduledUpgrade(ocmClient, cluster.ID())
 	if err != nil {
 		reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if scheduledUpgrade != nil {
 		reporter.Warnf("There is already a %s upgrade to version %s on %s",
@@ -195,7 +231,7 @@ func run(cmd *cobra.Command, _ []string) {
 	availableUpgrades, err := versions.GetAvailableUpgrades(ocmClient, versions.GetVersionID(cluster))
 	if err != nil {
 		reporter.Errorf("Failed to find available upgrades: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if len(availableUpgrades) == 0 {
 		reporter.Warnf("There are no available upgrades")
@@ -215,7 +251,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid version to upgrade to: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -229,15 +265,18 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if !validVersion {
 		reporter.Errorf("Expected a valid version to upgrade to")
-		os.Exit(1)
+		exit.Fail()
 	}
 
-	if scheduleDate == "" || scheduleTime == "" {
+	if !args.scheduleNow && (scheduleDate == "" || scheduleTime == "") {
 		interactive.Enable()
 	}
 
 	// Set the default next run within the next 10 minutes
 	now := time.Now().UTC().Add(time.Minute * 10)
+	if args.scheduleNow {
+		now = time.Now().UTC().Add(scheduleNowDelay)
+	}
 	if scheduleDate == "" {
 		scheduleDate = now.Format("2006-01-02")
 	}
@@ -245,13 +284,13 @@ func run(cmd *cobra.Command, _ []string) {
 		scheduleTime = now.Format("15:04")
 	}
 
-	if interactive.Enabled() {
+	if interactive.Enabled() && !args.scheduleNow {
 		// If datetimes are set, use them in the interactive form, otherwise fallback to 'now'
 		scheduleParsed, err := time.Parse("2006-01-02 15:04", fmt.Sprintf("%s %s", scheduleDate, scheduleTime))
 		if err != nil {
 			reporter.Errorf("Schedule date should use the format 'yyyy-mm-dd'\n" +
 				"   Schedule time should use the format 'HH:mm'")
-			os.Exit(1)
+			exit.Fail()
 		}
 		if scheduleParsed.IsZero() {
 			scheduleParsed = now
@@ -267,12 +306,12 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid date: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		_, err = time.Parse("2006-01-02", scheduleDate)
 		if err != nil {
 			reporter.Errorf("Date format '%s' invalid", scheduleDate)
-			os.Exit(1)
+			exit.Fail()
 		}
 
 		scheduleTime, err = interactive.GetString(interactive.Input{
@@ -283,12 +322,12 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid time: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 		_, err = time.Parse("15:04", scheduleTime)
 		if err != nil {
 			reporter.Errorf("Time format '%s' invalid", scheduleTime)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 
@@ -297,7 +336,34 @@ func run(cmd *cobra.Command, _ []string) {
 	if err != nil {
 		reporter.Errorf("Schedule date should use the format 'yyyy-mm-dd'\n" +
 			"   Schedule time should use the format 'HH:mm'")
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	// Check the maintenance exclusion window of the cluster:
+	window, err := upgrades.GetMaintenanceExclusion(cluster)
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if window != nil && window.Matches(nextRun) {
+		allowed := "There is no time outside of the window in the next year"
+		if next := upgrades.NextAllowed(window, nextRun); !next.IsZero() {
+			allowed = fmt.Sprintf("The window ends on %s", next.Format("2006-01-02 15:04 MST"))
+		}
+		switch {
+		case args.overrideWindow:
+			reporter.Warnf("Upgrade time %s is inside the maintenance exclusion window '%s' of "+
+				"cluster '%s', overriding it", nextRun.Format("2006-01-02 15:04 MST"), window, clusterKey)
+		case args.scheduleNow:
+			reporter.Errorf("The current time is inside the maintenance exclusion window '%s' of "+
+				"cluster '%s'. %s. Use '--override-window' to upgrade anyway", window, clusterKey,
+				allowed)
+			exit.Fail()
+		default:
+			reporter.Warnf("Upgrade time %s is inside the maintenance exclusion window '%s' of "+
+				"cluster '%s'. %s", nextRun.Format("2006-01-02 15:04 MST"), window, clusterKey,
+				allowed)
+		}
 	}
 
 	upgradePolicyBuilder := cmv1.NewUpgradePolicy().
@@ -336,7 +402,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			reporter.Errorf("Expected a valid node drain grace period: %s", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	}
 	isValidNodeDrainGracePeriod := false
@@ -349,13 +415,13 @@ func run(cmd *cobra.Command, _ []string) {
 	if !isValidNodeDrainGracePeriod {
 		reporter.Errorf("Expected a valid node drain grace period. Options are [%s]",
 			strings.Join(nodeDrainOptions, ", "))
-		os.Exit(1)
+		exit.Fail()
 	}
 	nodeDrainParsed := strings.Split(nodeDrainGracePeriod, " ")
 	nodeDrainValue, err := strconv.ParseFloat(nodeDrainParsed[0], 64)
 	if err != nil {
 		reporter.Errorf("Expected a valid node drain grace period: %s", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if nodeDrainParsed[1] == "hours" || nodeDrainParsed[1] == "hour" {
 		nodeDrainValue = nodeDrainValue * 60
@@ -368,13 +434,13 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	upgradePolicy, err := upgradePolicyBuilder.Build()
 	if err != nil {
 		reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	_, err = ocmClient.Clusters().
@@ -385,7 +451,8 @@ func run(cmd *cobra.Command, _ []string) {
 		Send()
 	if err != nil {
 		reporter.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		notify.Failed(reporter, "upgrade cluster", clusterKey, cluster.ID(), err)
+		exit.Fail()
 	}
 
 	_, err = ocmClient.Clusters().
@@ -395,8 +462,11 @@ func run(cmd *cobra.Command, _ []string) {
 		Send()
 	if err != nil {
 		reporter.Errorf("Failed to update cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		notify.Failed(reporter, "upgrade cluster", clusterKey, cluster.ID(), err)
+		exit.Fail()
 	}
 
-	reporter.Infof("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	reporter.Successf("Upgrade successfully scheduled for cluster '%s'", clusterKey)
+	notify.Accepted(reporter, "upgrade cluster", clusterKey, cluster.ID(),
+		fmt.Sprintf("Upgrade to version %s has been scheduled", version))
 }
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rosa
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/confirm"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/info"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/update"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "rosa",
+	Short: "Upgrade the rosa tool",
+	Long: "Replace the running rosa binary with the latest release, after verifying the " +
+		"checksum of the downloaded binary.",
+	Example: `  # Upgrade the rosa tool to the latest release
+  rosa upgrade rosa`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	confirm.AddFlag(flags)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	reporter.Debugf("Checking latest release")
+	release, err := update.Latest()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if !release.Newer(info.Version) {
+		reporter.Infof("Version %s is already the latest release", info.Version)
+		os.Exit(0)
+	}
+
+	path, err := os.Executable()
+	if err != nil {
+		reporter.Errorf("Failed to find the rosa binary: %v", err)
+		exit.Fail()
+	}
+	if !confirm.Confirm("upgrade '%s' from version %s to version %s", path, info.Version, release.Version) {
+		os.Exit(0)
+	}
+
+	reporter.Infof("Downloading version %s", release.Version)
+	err = update.Install(release, path)
+	if err != nil {
+		reporter.Errorf("Failed to upgrade rosa: %v", err)
+		if os.IsPermission(err) {
+			reporter.Infof("Run the command as a user that can write to '%s', or download the "+
+				"release from %s", path, release.URL)
+		}
+		exit.Fail()
+	}
+	reporter.Successf("Upgraded rosa to version %s", release.Version)
+}
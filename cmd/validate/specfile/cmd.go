@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package specfile
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/exit"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/spec"
+)
+
+var args struct {
+	schema bool
+}
+
+var Cmd = &cobra.Command{
+	Use:   "spec-file [FILE]",
+	Short: "Validate a cluster spec file",
+	Long: "Validate a cluster spec file used by 'rosa create cluster --spec-file' against its " +
+		"JSON schema, or print the schema.",
+	Example: `  # Validate the cluster spec file 'cluster.yaml'
+  rosa validate spec-file cluster.yaml
+
+  # Print the JSON schema of cluster spec files
+  rosa validate spec-file --schema > rosa-cluster-v1.json`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.schema,
+		"schema",
+		false,
+		"Print the JSON schema of cluster spec files instead of validating a file.",
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	reporter := rprtr.CreateReporterOrExit()
+
+	if args.schema {
+		fmt.Print(spec.Schema)
+		return
+	}
+
+	if len(argv) != 1 {
+		reporter.Errorf("Expected exactly one spec file")
+		exit.Fail()
+	}
+	path := argv[0]
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		reporter.Errorf("Failed to read spec file '%s': %v", path, err)
+		exit.Fail()
+	}
+	errs, err := spec.Validate(data)
+	if err != nil {
+		reporter.Errorf("Failed to parse spec file '%s': %v", path, err)
+		exit.Fail()
+	}
+	if len(errs) > 0 {
+		for _, e := range errs {
+			reporter.Errorf("%s", e)
+		}
+		exit.Fail()
+	}
+	reporter.Infof("Spec file '%s' is valid for schema version '%s'", path, spec.SchemaVersion)
+}
@@ -1,9 +1,8 @@
 package validations
 
 import (
-	"os"
-
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/spf13/cobra"
@@ -13,21 +12,28 @@ import (
 func Validations(cmd *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 	logger := logging.CreateLoggerOrExit(reporter)
+	// When provisioning into another account the stack needs to exist in that account:
+	assumeRole := ""
+	if flag := cmd.Flags().Lookup("assume-role"); flag != nil {
+		assumeRole = flag.Value.String()
+	}
+
 	// Create the AWS client:
 	client, err := aws.NewClient().
 		Logger(logger).
 		Region(aws.DefaultRegion).
+		AssumeRole(assumeRole).
 		Build()
 	if err != nil {
 		reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Debugf("Validating cloudformation stack exists")
 	stackExist, _, err := client.CheckStackReadyOrNotExisting(aws.OsdCcsAdminStackName)
 	if !stackExist || err != nil {
 		reporter.Errorf("Cloudformation stack does not exist. Run `rosa init` first")
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Debugf("cloudformation stack is valid!")
 }
@@ -17,52 +17,155 @@ limitations under the License.
 package oc
 
 import (
-	"os/exec"
-	"regexp"
+	"fmt"
 	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/clients"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
+var args struct {
+	clusterKey string
+}
+
 var Cmd = &cobra.Command{
 	Use:     "openshift-client",
 	Aliases: []string{"oc", "openshift"},
 	Short:   "Verify OpenShift client tools",
-	Long:    "Verify that the OpenShift client tools is installed and compatible.",
+	Long: "Verify that the OpenShift client tools is installed and compatible. When a cluster is " +
+		"given the version of the client is also compared with the version of the cluster.",
 	Example: `  # Verify oc client tools
-  rosa verify oc`,
+  rosa verify oc
+
+  # Verify that the oc client tools can be used with the cluster named "mycluster"
+  rosa verify oc --cluster=mycluster`,
 	Run: run,
 }
 
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVarP(
+		&args.clusterKey,
+		"cluster",
+		"c",
+		"",
+		"Name, ID or external ID of the cluster that the client tools will be used with.",
+	)
+}
+
 func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporterOrExit()
 
-	// Verify whether `oc` is installed
+	clusterVersion := ""
+	if args.clusterKey != "" {
+		var err error
+		clusterVersion, err = ClusterVersion(reporter, args.clusterKey)
+		if err != nil {
+			reporter.Errorf("%s", err)
+			exit.Fail()
+		}
+	}
+
+	Verify(reporter, clusterVersion)
+}
+
+// Verify checks that the OpenShift command-line tool is installed and that it is compatible with
+// the given version of a cluster, if any, and reports the result. It returns true if the tool can
+// be used.
+func Verify(reporter *rprtr.Object, clusterVersion string) bool {
 	reporter.Infof("Verifying whether OpenShift command-line tool is available...")
 
-	output, err := exec.Command("oc", "version").Output()
-	if output == nil && err != nil {
-		reporter.Warnf("OpenShift command-line tool is not installed.\n" +
+	version, err := clients.OCVersion()
+	if err == clients.ErrOCNotFound {
+		reporter.Warn(rprtr.WarnUnsupportedOC, "OpenShift command-line tool is not installed.\n"+
 			"Run 'rosa download oc' to download the latest version, then add it to your PATH.")
-		return
+		return false
+	}
+	if err != nil {
+		reporter.Errorf("Failed to get OpenShift Client version: %v", err)
+		return false
+	}
+
+	if !clients.OCCompatible(version, "") {
+		reporter.Warn(rprtr.WarnUnsupportedOC, "Current OpenShift Client Version: %s\n"+
+			"Your version of the OpenShift command-line tool is not supported.\n"+
+			"Run 'rosa download oc' to download the latest version, then add it to your PATH.", version)
+		return false
+	}
+
+	if !clients.OCCompatible(version, clusterVersion) {
+		reporter.Warn(rprtr.WarnUnsupportedOC, "Current OpenShift Client Version: %s\n"+
+			"Your version of the OpenShift command-line tool is more than one minor version away "+
+			"from the version %s of the cluster.\n"+
+			"Run 'rosa download oc --version=%s' to download a matching version, then add it to "+
+			"your PATH.", version, clusterVersion, clusterVersion)
+		return false
 	}
 
-	// Parse the version for the OpenShift Client
-	version := strings.Replace(strings.Split(string(output), "\n")[0], "\n", "", 1)
-	isCorrectVersion, err := regexp.Match(`\W4.\d*`, output)
+	reporter.Infof("Current OpenShift Client Version: %s", version)
+	return true
+}
+
+// ClusterVersion returns the OpenShift version of the given cluster.
+func ClusterVersion(reporter *rprtr.Object, clusterKey string) (string, error) {
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	// Check that the cluster key (name, identifier or external identifier) given by the user
+	// is reasonably safe so that there is no risk of SQL injection:
+	if !ocm.IsValidClusterKey(clusterKey) {
+		return "", fmt.Errorf("Cluster name, identifier or external identifier '%s' isn't valid: it "+
+			"must contain only letters, digits, dashes and underscores", clusterKey)
+	}
+
+	// Create the AWS client:
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Build()
 	if err != nil {
-		reporter.Errorf("Failed to parse OpenShift Client version: %v", err)
-		return
+		return "", fmt.Errorf("Failed to create AWS client: %v", err)
 	}
 
-	if !isCorrectVersion {
-		reporter.Warnf("Current OpenShift %s", version)
-		reporter.Warnf("Your version of the OpenShift command-line tool is not supported.\n" +
-			"Run 'rosa download oc' to download the latest version, then add it to your PATH.")
-		return
+	awsCreator, err := awsClient.GetCreator()
+	if err != nil {
+		return "", fmt.Errorf("Failed to get AWS creator: %v", err)
 	}
 
-	reporter.Infof("Current OpenShift %s", version)
+	// Create the client for the OCM API:
+	ocmConnection, err := ocm.NewConnection().
+		Logger(logger).
+		Build()
+	if err != nil {
+		return "", fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	defer func() {
+		err = ocmConnection.Close()
+		if err != nil {
+			reporter.Errorf("Failed to close OCM connection: %v", err)
+		}
+	}()
+
+	// Try to find the cluster:
+	reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := clusters.GetCluster(ocmConnection.ClustersMgmt().V1().Clusters(), clusterKey,
+		awsCreator.ARN)
+	if err != nil {
+		return "", fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+	}
+
+	version := cluster.OpenshiftVersion()
+	if version == "" {
+		version = strings.TrimPrefix(cluster.Version().RawID(), "openshift-v")
+	}
+	if version == "" {
+		return "", fmt.Errorf("Cluster '%s' doesn't report its version yet", clusterKey)
+	}
+	return version, nil
 }
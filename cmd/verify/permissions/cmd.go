@@ -18,18 +18,22 @@ package permissions
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
+var args struct {
+	instanceTypes []string
+}
+
 var Cmd = &cobra.Command{
 	Use:   "permissions",
 	Short: "Verify AWS permissions are ok for cluster install",
@@ -38,7 +42,10 @@ var Cmd = &cobra.Command{
   rosa verify permissions
 
   # Verify AWS permissions in a different region
-  rosa verify permissions --region=us-west-2`,
+  rosa verify permissions --region=us-west-2
+
+  # Verify that the organization allows launching the instance types of the cluster
+  rosa verify permissions --instance-type=m5.xlarge --instance-type=r5.2xlarge`,
 	Run: run,
 }
 
@@ -47,6 +54,14 @@ func init() {
 
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
+
+	flags.StringSliceVar(
+		&args.instanceTypes,
+		"instance-type",
+		[]string{"m5.xlarge"},
+		"Instance type to check against the service control policies of the organization. "+
+			"Can be repeated.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -57,7 +72,7 @@ func run(cmd *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -66,7 +81,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer ocmConnection.Close()
 	ocmClient := ocmConnection.ClustersMgmt().V1()
@@ -82,7 +97,18 @@ func run(cmd *cobra.Command, _ []string) {
 			ocm.LogEvent(ocmClient, "ROSAInitCredentialsSTS")
 		}
 		reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
+	}
+
+	reporter.Infof("Checking for organization SCP restrictions...")
+	restrictions, err := client.DetectSCPRestrictions(args.instanceTypes)
+	if err != nil {
+		reporter.Warnf("Unable to check for organization SCP restrictions: %v", err)
+	} else {
+		if !restrictions.Empty() {
+			ocm.LogEvent(ocmClient, "ROSAVerifyPermissionsSCPRestricted")
+		}
+		reportSCPRestrictions(reporter, client.GetRegion(), restrictions)
 	}
 
 	reporter.Infof("Validating SCP policies...")
@@ -92,10 +118,10 @@ func run(cmd *cobra.Command, _ []string) {
 		reporter.Errorf("Unable to validate SCP policies")
 		if strings.Contains(err.Error(), "Throttling: Rate exceeded") {
 			reporter.Errorf("Throttling: Rate exceeded. Please wait 3-5 minutes before retrying.")
-			os.Exit(1)
+			exit.Fail()
 		}
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if !ok {
 		ocm.LogEvent(ocmClient, "ROSAVerifyPermissionsSCPInvalid")
@@ -103,3 +129,37 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	reporter.Infof("AWS SCP policies ok")
 }
+
+// reportSCPRestrictions warns about the service control policies that would make the installation
+// fail, as otherwise those failures would only show up in the middle of the installation.
+func reportSCPRestrictions(reporter *rprtr.Object, region string, restrictions *aws.SCPRestrictions) {
+	if len(restrictions.DeniedActions) > 0 {
+		reporter.Warn(rprtr.WarnSCPRestrictions,
+			"The service control policies of the organization deny the following "+
+				"actions in region '%s': %s", region, strings.Join(restrictions.DeniedActions, ", "))
+	}
+	if len(restrictions.DeniedInstanceTypes) > 0 {
+		reporter.Warn(rprtr.WarnSCPRestrictions,
+			"The service control policies of the organization deny launching the "+
+				"following instance types in region '%s': %s",
+			region, strings.Join(restrictions.DeniedInstanceTypes, ", "))
+	}
+	for _, policy := range restrictions.Policies {
+		reporter.Warn(rprtr.WarnSCPRestrictions,
+			"Service control policy '%s' (%s) attached to '%s' denies:\n  - %s",
+			policy.Name, policy.ID, policy.Target, strings.Join(policy.Denies, "\n  - "))
+	}
+	if restrictions.OrganizationsError != nil {
+		reporter.Debugf("Unable to inspect the service control policies: %v",
+			restrictions.OrganizationsError)
+	}
+	if restrictions.Empty() {
+		reporter.Infof("No organization SCP restrictions found")
+		return
+	}
+	if len(restrictions.Policies) == 0 && restrictions.OrganizationsError != nil {
+		reporter.Warn(rprtr.WarnSCPRestrictions,
+			"Ask the administrator of the AWS organization to review the service "+
+				"control policies applied to this account")
+	}
+}
@@ -18,13 +18,13 @@ package quota
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
@@ -57,7 +57,7 @@ func run(cmd *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Create the client for the OCM API:
@@ -66,7 +66,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer ocmConnection.Close()
 	ocmClient := ocmConnection.ClustersMgmt().V1()
@@ -82,7 +82,7 @@ func run(cmd *cobra.Command, _ []string) {
 			ocm.LogEvent(ocmClient, "ROSAInitCredentialsSTS")
 		}
 		reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	reporter.Infof("Validating AWS quota...")
@@ -91,7 +91,7 @@ func run(cmd *cobra.Command, _ []string) {
 		ocm.LogEvent(ocmClient, "ROSAVerifyQuotaInsufficient")
 		reporter.Errorf("Insufficient AWS quotas")
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	reporter.Infof("AWS quota ok")
 }
@@ -22,16 +22,52 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/info"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/update"
 )
 
+var args struct {
+	check bool
+}
+
 var Cmd = &cobra.Command{
 	Use:   "version",
 	Short: "Prints the version of the tool",
 	Long:  "Prints the version number of the tool.",
-	Run:   run,
+	Example: `  # Print the version and check if there is a newer release
+  rosa version --check`,
+	Run: run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.BoolVar(
+		&args.check,
+		"check",
+		false,
+		"Check if there is a newer release of the tool.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
 	fmt.Fprintf(os.Stdout, "%s\n", info.Version)
+
+	if !args.check {
+		return
+	}
+	reporter := rprtr.CreateReporterOrExit()
+	release, err := update.Latest()
+	if err != nil {
+		reporter.Errorf("%v", err)
+		exit.Fail()
+	}
+	if !release.Newer(info.Version) {
+		reporter.Infof("Version %s is the latest release", info.Version)
+		return
+	}
+	reporter.Infof("A newer version of rosa is available: %s\n"+
+		"To upgrade run 'rosa upgrade rosa', or download it from %s",
+		release.Version, release.URL)
 }
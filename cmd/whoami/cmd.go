@@ -26,6 +26,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/ocm/config"
@@ -56,28 +57,28 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("failed to create AWS client: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get current AWS account information:
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("failed to get AWS creator: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Get default AWS region:
 	awsRegion, err := aws.GetRegion("")
 	if err != nil {
 		reporter.Errorf("Error getting AWS region: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 
 	// Load the configuration file:
 	cfg, err := config.Load()
 	if err != nil {
 		reporter.Errorf("Failed to load config file: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if cfg == nil {
 		reporter.Errorf("User is not logged in to OCM")
@@ -88,7 +89,7 @@ func run(_ *cobra.Command, _ []string) {
 	loggedIn, err := cfg.Armed()
 	if err != nil {
 		reporter.Errorf("Failed to verify configuration: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	if !loggedIn {
 		reporter.Errorf("User is not logged in to OCM")
@@ -102,7 +103,7 @@ func run(_ *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	defer func() {
 		err = connection.Close()
@@ -119,8 +120,8 @@ func run(_ *cobra.Command, _ []string) {
 		if response.Status() == http.StatusNotFound {
 			useTokenData = true
 		} else {
-			reporter.Errorf("Failed to get current account: %s", response.Error().Reason())
-			os.Exit(1)
+			reporter.Errorf("Failed to get current account: %s", ocm.ErrorReason(response.Error()))
+			exit.Fail()
 		}
 	}
 
@@ -129,7 +130,7 @@ func run(_ *cobra.Command, _ []string) {
 		account, err = getAccountDataFromToken(cfg)
 		if err != nil {
 			reporter.Errorf("Failed to get account data from token: %v", err)
-			os.Exit(1)
+			exit.Fail()
 		}
 	} else {
 		account = response.Body()
@@ -24,9 +24,14 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
+	"github.com/openshift/rosa/pkg/aws/checks"
 	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/aws/stsendpoint"
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm/proxy"
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 var hasUnknownFlags bool
@@ -111,11 +116,48 @@ func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
 
+// AddLogFlags adds the '--log-format', '--log-file' and '--log-level' flags to the given set of
+// command line flags.
+func AddLogFlags(fs *pflag.FlagSet) {
+	logging.AddFlags(fs)
+}
+
+// AddNoColorFlag adds the '--no-color' flag to the given set of command line flags.
+func AddNoColorFlag(fs *pflag.FlagSet) {
+	reporter.AddNoColorFlag(fs)
+}
+
+// AddQuietFlag adds the '--quiet' flag to the given set of command line flags.
+func AddQuietFlag(fs *pflag.FlagSet) {
+	reporter.AddQuietFlag(fs)
+}
+
+// AddSuppressWarningsFlag adds the '--suppress-warnings' flag to the given set of command line
+// flags.
+func AddSuppressWarningsFlag(fs *pflag.FlagSet) {
+	reporter.AddSuppressWarningsFlag(fs)
+}
+
+// AddWarningsOutputFlag adds the '--warnings-output' flag to the given set of command line flags.
+func AddWarningsOutputFlag(fs *pflag.FlagSet) {
+	reporter.AddWarningsOutputFlag(fs)
+}
+
+// AddOCMProxyFlag adds the '--ocm-proxy' flag to the given set of command line flags.
+func AddOCMProxyFlag(fs *pflag.FlagSet) {
+	proxy.AddFlag(fs)
+}
+
 // AddProfileFlag adds the '--profile' flag to the given set of command line flags.
 func AddProfileFlag(fs *pflag.FlagSet) {
 	profile.AddFlag(fs)
 }
 
+// AddSkipAWSChecksFlag adds the '--skip-aws-checks' flag to the given set of command line flags.
+func AddSkipAWSChecksFlag(fs *pflag.FlagSet) {
+	checks.AddFlag(fs)
+}
+
 func GetProfile() string {
 	return profile.Profile()
 }
@@ -128,3 +170,9 @@ func AddRegionFlag(fs *pflag.FlagSet) {
 func GetRegion() string {
 	return region.Region()
 }
+
+// AddSTSRegionEndpointFlag adds the '--sts-region-endpoint' flag to the given set of command line
+// flags.
+func AddSTSRegionEndpointFlag(fs *pflag.FlagSet) {
+	stsendpoint.AddFlag(fs)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that set the values of flags that weren't given in the command
+// line from environment variables and from the defaults of the configuration file.
+//
+// Values of flags can be given with environment variables named after the flag, like
+// 'ROSA_REGION' for the '--region' flag or 'ROSA_OUTPUT' for the '--output' flag.
+//
+// Flags given explicitly in the command line always take precedence, then the environment
+// variables, then the defaults of the cluster selected with the '--cluster' flag, and then the
+// global defaults.
+
+package arguments
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/defaults"
+	"github.com/openshift/rosa/pkg/exit"
+)
+
+// EnvPrefix is the prefix of the environment variables used to set the values of flags.
+const EnvPrefix = "ROSA_"
+
+// RegisterDefaults makes the commands of the tree apply the environment variables and the
+// defaults before any of their hooks run. Cobra only runs the persistent pre-run hook of the
+// nearest command that has one, so the hook of the root command applies the defaults, and the
+// hooks of other commands are changed to apply them before doing anything else.
+func RegisterDefaults(root *cobra.Command) {
+	persistentPreRun := root.PersistentPreRun
+	if persistentPreRun != nil || !root.HasParent() {
+		root.PersistentPreRun = func(cmd *cobra.Command, argv []string) {
+			applyDefaults(cmd)
+			if persistentPreRun != nil {
+				persistentPreRun(cmd, argv)
+			}
+		}
+	}
+	for _, child := range root.Commands() {
+		RegisterDefaults(child)
+	}
+}
+
+func applyDefaults(cmd *cobra.Command) {
+	err := ApplyDefaults(cmd)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to apply defaults: %v\n", err)
+		exit.Fail()
+	}
+}
+
+// EnvName returns the name of the environment variable that sets the value of the given flag, for
+// example 'ROSA_MULTI_AZ' for the 'multi-az' flag.
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// ApplyDefaults sets the values of the flags of the command that weren't given explicitly in the
+// command line to the values of the environment variables, or else to the defaults stored in the
+// configuration file. Defaults for flags that the command doesn't have, or with values that it
+// doesn't support, are ignored. Flags set from defaults are marked as changed.
+func ApplyDefaults(cmd *cobra.Command) error {
+	flags := cmd.Flags()
+	applied := map[string]bool{}
+	err := applyEnv(flags, applied)
+	if err != nil {
+		return err
+	}
+	cfg, err := defaults.Load()
+	if err != nil {
+		return err
+	}
+	if cluster := defaults.SelectedCluster(flags); cluster != "" {
+		if clusterConfig, ok := cfg.Clusters[cluster]; ok {
+			err = applyConfig(flags, clusterConfig.Defaults, applied)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return applyConfig(flags, cfg.Defaults, applied)
+}
+
+// applyEnv sets the flags that weren't given in the command line and have a non empty environment
+// variable with a value supported by the command, adding their names to the applied set.
+func applyEnv(flags *pflag.FlagSet, applied map[string]bool) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+		name := EnvName(flag.Name)
+		value := os.Getenv(name)
+		if value == "" || !allows(flag, value) {
+			return
+		}
+		// Set the value through the flag set, so that the flag is marked as changed and commands
+		// handle it exactly as if it had been given in the command line:
+		err = flags.Set(flag.Name, value)
+		if err != nil {
+			err = fmt.Errorf("Invalid value '%s' of environment variable '%s' for flag '%s': %v",
+				value, name, flag.Name, err)
+			return
+		}
+		applied[flag.Name] = true
+	})
+	return err
+}
+
+func applyConfig(flags *pflag.FlagSet, values map[string]string, applied map[string]bool) error {
+	for _, name := range defaults.Keys(values) {
+		flag := flags.Lookup(name)
+		if flag == nil || flag.Changed || applied[name] || flag.Value.String() != flag.DefValue {
+			continue
+		}
+		if !allows(flag, values[name]) {
+			continue
+		}
+		// Set the value through the flag set, so that the flag is marked as changed and commands
+		// handle it exactly as if it had been given in the command line:
+		err := flags.Set(name, values[name])
+		if err != nil {
+			return fmt.Errorf("Invalid default value '%s' for flag '%s': %v", values[name], name, err)
+		}
+		applied[name] = true
+	}
+	return nil
+}
+
+// restrictedValue is implemented by the values of flags that accept different values depending on
+// the command, like the '--output' flag.
+type restrictedValue interface {
+	Allows(value string) bool
+}
+
+// allows checks if the value can be used for the given flag. Defaults that the command doesn't
+// support, like an output format that it can't print, are ignored instead of failing, as they are
+// shared by all the commands.
+func allows(flag *pflag.Flag, value string) bool {
+	restricted, ok := flag.Value.(restrictedValue)
+	return !ok || restricted.Allows(value)
+}
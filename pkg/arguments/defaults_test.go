@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package arguments
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Defaults", func() {
+	var (
+		dir  string
+		root *cobra.Command
+		seen map[string]string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "rosa-defaults")
+		Expect(err).ToNot(HaveOccurred())
+		config := filepath.Join(dir, "config.yaml")
+		err = ioutil.WriteFile(config, []byte("defaults:\n  profile: fromconfig\n"), 0600)
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("ROSA_CONFIG", config)
+
+		// The hooks record the values of the flags when they run:
+		seen = map[string]string{}
+		root = &cobra.Command{Use: "root"}
+		middle := &cobra.Command{
+			Use: "middle",
+			PersistentPreRun: func(cmd *cobra.Command, _ []string) {
+				seen["pre-run"] = cmd.Flag("profile").Value.String()
+			},
+		}
+		leaf := &cobra.Command{
+			Use: "leaf",
+			Run: func(cmd *cobra.Command, _ []string) {
+				seen["run"] = cmd.Flag("profile").Value.String()
+			},
+		}
+		leaf.Flags().String("profile", "", "")
+		middle.AddCommand(leaf)
+		root.AddCommand(middle)
+		RegisterDefaults(root)
+	})
+
+	AfterEach(func() {
+		os.Unsetenv("ROSA_CONFIG")
+		os.Unsetenv("ROSA_PROFILE")
+		os.RemoveAll(dir)
+	})
+
+	It("Applies the defaults before the persistent pre-run hooks", func() {
+		root.SetArgs([]string{"middle", "leaf"})
+		Expect(root.Execute()).To(Succeed())
+		Expect(seen["pre-run"]).To(Equal("fromconfig"))
+		Expect(seen["run"]).To(Equal("fromconfig"))
+	})
+
+	It("Gives precedence to the environment over the configuration file", func() {
+		os.Setenv("ROSA_PROFILE", "fromenv")
+		root.SetArgs([]string{"middle", "leaf"})
+		Expect(root.Execute()).To(Succeed())
+		Expect(seen["pre-run"]).To(Equal("fromenv"))
+	})
+
+	It("Gives precedence to the command line over the environment", func() {
+		os.Setenv("ROSA_PROFILE", "fromenv")
+		root.SetArgs([]string{"middle", "leaf", "--profile", "fromline"})
+		Expect(root.Execute()).To(Succeed())
+		Expect(seen["pre-run"]).To(Equal("fromline"))
+	})
+
+	It("Applies the defaults from the root hook when no other command has one", func() {
+		other := &cobra.Command{
+			Use: "other",
+			Run: func(cmd *cobra.Command, _ []string) {
+				seen["run"] = cmd.Flag("profile").Value.String()
+			},
+		}
+		other.Flags().String("profile", "", "")
+		root = &cobra.Command{Use: "root"}
+		root.AddCommand(other)
+		RegisterDefaults(root)
+		root.SetArgs([]string{"other"})
+		Expect(root.Execute()).To(Succeed())
+		Expect(seen["run"]).To(Equal("fromconfig"))
+	})
+})
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to write the bundle of files that allows creating the
+// resources needed by the tool manually, without giving it access to the AWS account.
+
+package aws
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/rosa/assets"
+)
+
+const (
+	// ModeAuto is the mode where the tool creates the AWS resources itself.
+	ModeAuto = "auto"
+
+	// ModeManual is the mode where the tool writes the files needed to create the AWS resources,
+	// so that they can be reviewed and applied by someone else.
+	ModeManual = "manual"
+)
+
+// Names of the files written to the bundle directory:
+const (
+	bundleStackTemplateFile = "iam_user_osdCcsAdmin.json"
+	bundleSCPPolicyFile     = "osd_scp_policy.json"
+	bundleScriptFile        = "setup.sh"
+)
+
+// WriteManualBundle writes to the given directory the CloudFormation template that creates the
+// admin user, the policy that the user needs and a shell script that applies the template with
+// the AWS command line tool. It returns the paths of the files written.
+func WriteManualBundle(dir string, region string) ([]string, error) {
+	err := os.MkdirAll(dir, 0750)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create bundle directory '%s': %v", dir, err)
+	}
+
+	partition := PartitionAWS
+	if region != "" {
+		partition, err = GetPartition(region)
+		if err != nil {
+			return nil, err
+		}
+	}
+	stackTemplate, err := readCFTemplate(partition)
+	if err != nil {
+		return nil, err
+	}
+	scpPolicy, err := assets.Asset("templates/policies/osd_scp_policy.json")
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read SCP policy: %s", err)
+	}
+
+	regionOption := ""
+	if region != "" {
+		regionOption = fmt.Sprintf(" --region %s", region)
+	}
+	script := fmt.Sprintf(`#!/bin/sh
+#
+# Creates the '%[1]s' user needed to install clusters. Generated by 'rosa init --mode manual'.
+#
+# The user is created by the CloudFormation template in '%[2]s' and it must be allowed the
+# actions in '%[3]s'. Review both files before running this script.
+
+set -e
+cd "$(dirname "$0")"
+
+aws cloudformation create-stack%[4]s \
+  --stack-name %[5]s \
+  --template-body file://%[2]s \
+  --capabilities CAPABILITY_IAM CAPABILITY_NAMED_IAM
+
+aws cloudformation wait stack-create-complete%[4]s \
+  --stack-name %[5]s
+`, AdminUserName, bundleStackTemplateFile, bundleSCPPolicyFile, regionOption, OsdCcsAdminStackName)
+
+	files := []struct {
+		name string
+		data []byte
+		mode os.FileMode
+	}{
+		{bundleStackTemplateFile, []byte(stackTemplate), 0600},
+		{bundleSCPPolicyFile, scpPolicy, 0600},
+		{bundleScriptFile, []byte(script), 0700},
+	}
+	var paths []string
+	for _, file := range files {
+		path := filepath.Join(dir, file.name)
+		// The script needs to be executable:
+		err = ioutil.WriteFile(path, file.data, file.mode) // #nosec G306
+		if err != nil {
+			return nil, fmt.Errorf("Failed to write file '%s': %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
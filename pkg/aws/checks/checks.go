@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--skip-aws-checks' command line option and
+// the degraded mode of the commands that only read resources. In that mode validations that need
+// AWS permissions that the caller doesn't have are skipped, telling the user which ones, instead
+// of failing the command.
+
+package checks
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/spf13/pflag"
+
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// AddFlag adds the '--skip-aws-checks' flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&skip,
+		"skip-aws-checks",
+		false,
+		"Skip the validations that use the AWS API, like checking that the credentials don't "+
+			"belong to the root user. Validations are also skipped automatically when the AWS "+
+			"credentials don't have permission to run them.",
+	)
+}
+
+// Skip returns true if the user requested to skip the validations that use the AWS API.
+func Skip() bool {
+	return skip
+}
+
+// AllowDegraded enables the degraded mode, where validations that fail because of missing AWS
+// permissions are skipped. It should only be enabled for commands that don't change resources.
+func AllowDegraded() {
+	state.Lock()
+	state.degraded = true
+	state.Unlock()
+}
+
+// DegradedAllowed returns true if validations that fail because of missing AWS permissions can be
+// skipped.
+func DegradedAllowed() bool {
+	state.Lock()
+	defer state.Unlock()
+	return state.degraded || skip
+}
+
+// CanSkip checks if a validation that failed with the given error can be skipped, because the
+// degraded mode is enabled and the error was caused by missing AWS permissions.
+func CanSkip(err error) bool {
+	return DegradedAllowed() && IsAccessDenied(err)
+}
+
+// Skipped records that the validation with the given description was skipped, and warns the user
+// about it. The reason can be an error or a string.
+func Skipped(validation string, reason interface{}) {
+	state.Lock()
+	state.skipped = append(state.skipped, validation)
+	state.Unlock()
+	reporter, err := rprtr.New().Build()
+	if err != nil {
+		return
+	}
+	reporter.Warn(rprtr.WarnAWSChecksSkipped, "Skipped AWS validation '%s': %s", validation,
+		describe(reason))
+}
+
+// SkippedChecks returns the descriptions of the validations that were skipped.
+func SkippedChecks() []string {
+	state.Lock()
+	defer state.Unlock()
+	return append([]string{}, state.skipped...)
+}
+
+// IsAccessDenied checks if the given error was returned by the AWS API because the caller doesn't
+// have permission to run the operation.
+func IsAccessDenied(err error) bool {
+	typed, ok := err.(awserr.Error)
+	if !ok {
+		return false
+	}
+	switch typed.Code() {
+	case "AccessDenied",
+		"AccessDeniedException",
+		"UnauthorizedOperation",
+		"UnauthorizedAccess",
+		"AuthorizationError":
+		return true
+	}
+	return false
+}
+
+func describe(reason interface{}) string {
+	if typed, ok := reason.(awserr.Error); ok && IsAccessDenied(typed) {
+		return fmt.Sprintf("the AWS credentials don't have permission (%s)", typed.Code())
+	}
+	return fmt.Sprintf("%v", reason)
+}
+
+// skip is a boolean flag that indicates that the validations that use the AWS API should be
+// skipped.
+var skip bool
+
+// state contains the degraded mode and the validations that were skipped.
+var state struct {
+	sync.Mutex
+	degraded bool
+	skipped  []string
+}
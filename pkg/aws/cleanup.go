@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/openshift/rosa/pkg/aws/cleanup"
+)
+
+// GetEnabledRegions returns the names of the regions that are enabled for the account, sorted
+// alphabetically.
+func (c *awsClient) GetEnabledRegions() ([]string, error) {
+	output, err := c.ec2Client.DescribeRegions(&ec2.DescribeRegionsInput{})
+	if err != nil {
+		return nil, err
+	}
+	regions := make([]string, 0, len(output.Regions))
+	for _, region := range output.Regions {
+		regions = append(regions, aws.StringValue(region.RegionName))
+	}
+	sort.Strings(regions)
+	return regions, nil
+}
+
+// FindOrphanedResources scans the given regions for resources created for clusters that no longer
+// exist. The clusters map contains the names of the existing clusters indexed by identifier. The
+// errors of the regions that couldn't be scanned are returned indexed by region.
+func (c *awsClient) FindOrphanedResources(regions []string, clusters map[string]string) (
+	[]*cleanup.Resource, map[string]error, error) {
+	builder := cleanup.NewScanner().
+		Logger(c.logger).
+		Session(c.awsSession).
+		Regions(regions...)
+	for id, name := range clusters {
+		builder.Cluster(id, name)
+	}
+	scanner, err := builder.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	resources, failures := scanner.Scan()
+	return resources, failures, nil
+}
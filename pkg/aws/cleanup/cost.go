@@ -0,0 +1,65 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the estimation of the monthly cost of the orphaned resources. The prices are
+// the on-demand prices of the us-east-1 region, which is close enough to the rest of the regions
+// to decide if the leftovers are worth cleaning up. The pricing API isn't used because it isn't
+// available in all partitions and requires additional permissions.
+
+package cleanup
+
+// Number of hours used to convert hourly prices to monthly prices.
+const hoursPerMonth = 730
+
+// Monthly cost of the resources that are billed per hour, without the data processed.
+const (
+	classicLoadBalancerMonthlyCost = 0.025 * hoursPerMonth
+	loadBalancerMonthlyCost        = 0.0225 * hoursPerMonth
+	natGatewayMonthlyCost          = 0.045 * hoursPerMonth
+)
+
+// Monthly cost of a GiB of snapshot storage. Snapshots are incremental, so using the size of the
+// volume gives an upper bound.
+const snapshotGiBMonthlyCost = 0.05
+
+// Monthly cost of a GiB of each type of volume, and of each provisioned IOPS.
+var (
+	volumeGiBMonthlyCost = map[string]float64{
+		"gp2":      0.10,
+		"gp3":      0.08,
+		"io1":      0.125,
+		"io2":      0.125,
+		"st1":      0.045,
+		"sc1":      0.015,
+		"standard": 0.05,
+	}
+	volumeIOPSMonthlyCost = map[string]float64{
+		"io1": 0.065,
+		"io2": 0.065,
+	}
+)
+
+func volumeMonthlyCost(volumeType string, size int64, iops int64) float64 {
+	gibCost, ok := volumeGiBMonthlyCost[volumeType]
+	if !ok {
+		gibCost = volumeGiBMonthlyCost["gp2"]
+	}
+	return gibCost*float64(size) + volumeIOPSMonthlyCost[volumeType]*float64(iops)
+}
+
+func snapshotMonthlyCost(size int64) float64 {
+	return snapshotGiBMonthlyCost * float64(size)
+}
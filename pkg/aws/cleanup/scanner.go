@@ -0,0 +1,437 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the scanner that finds the AWS resources left behind by clusters that no
+// longer exist, like load balancers created by the ingress controller or volumes created for
+// persistent volume claims, which aren't removed when the cluster is deleted.
+
+package cleanup
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// Types of the resources that the scanner looks for:
+const (
+	ClassicLoadBalancer = "classic-load-balancer"
+	LoadBalancer        = "load-balancer"
+	Volume              = "volume"
+	Snapshot            = "snapshot"
+	NATGateway          = "nat-gateway"
+)
+
+// Tags that the installer and the cluster add to the resources that they create. The name of the
+// ownership tag is followed by the infrastructure identifier of the cluster.
+const (
+	clusterOwnerTagPrefix = "kubernetes.io/cluster/"
+	clusterIDTag          = "api.openshift.com/id"
+)
+
+// Maximum number of regions scanned at the same time, and maximum number of load balancers whose
+// tags can be requested in a single call.
+const (
+	maxParallelRegions = 8
+	maxTagsBatch       = 20
+)
+
+// The installer truncates the name of the cluster to this length before adding the random suffix
+// that forms the infrastructure identifier.
+const infraIDNameLength = 21
+
+// Resource is an AWS resource that belongs to a cluster that no longer exists.
+type Resource struct {
+	Region    string `json:"region"`
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	InfraID   string `json:"infra_id,omitempty"`
+	ClusterID string `json:"cluster_id,omitempty"`
+
+	// MonthlyCost is an estimation, in US dollars, of what the resource costs per month.
+	MonthlyCost float64 `json:"monthly_cost"`
+}
+
+// ScannerBuilder contains the information and logic needed to build a new scanner.
+type ScannerBuilder struct {
+	logger   *logrus.Logger
+	session  *session.Session
+	regions  []string
+	clusters map[string]bool
+	names    map[string]bool
+}
+
+// Scanner finds the orphaned resources in a set of regions.
+type Scanner struct {
+	logger   *logrus.Logger
+	session  *session.Session
+	regions  []string
+	clusters map[string]bool
+	names    map[string]bool
+}
+
+// NewScanner creates a builder that can then be used to configure and build a new scanner.
+func NewScanner() *ScannerBuilder {
+	return &ScannerBuilder{
+		clusters: map[string]bool{},
+		names:    map[string]bool{},
+	}
+}
+
+// Logger sets the logger that the scanner will use to send messages to the log.
+func (b *ScannerBuilder) Logger(value *logrus.Logger) *ScannerBuilder {
+	b.logger = value
+	return b
+}
+
+// Session sets the AWS session that will be used to create the clients for each region.
+func (b *ScannerBuilder) Session(value *session.Session) *ScannerBuilder {
+	b.session = value
+	return b
+}
+
+// Regions sets the regions that will be scanned.
+func (b *ScannerBuilder) Regions(values ...string) *ScannerBuilder {
+	b.regions = append(b.regions, values...)
+	return b
+}
+
+// Cluster adds a cluster that still exists, so that its resources aren't reported.
+func (b *ScannerBuilder) Cluster(id string, name string) *ScannerBuilder {
+	b.clusters[id] = true
+	b.names[truncate(name, infraIDNameLength)] = true
+	return b
+}
+
+// Build uses the information stored in the builder to build a new scanner.
+func (b *ScannerBuilder) Build() (*Scanner, error) {
+	// Check parameters:
+	if b.logger == nil {
+		return nil, fmt.Errorf("Logger is mandatory")
+	}
+	if b.session == nil {
+		return nil, fmt.Errorf("Session is mandatory")
+	}
+	if len(b.regions) == 0 {
+		return nil, fmt.Errorf("At least one region is mandatory")
+	}
+
+	return &Scanner{
+		logger:   b.logger,
+		session:  b.session,
+		regions:  b.regions,
+		clusters: b.clusters,
+		names:    b.names,
+	}, nil
+}
+
+// Scan looks for orphaned resources in all the regions in parallel. The resources are returned
+// sorted by region, type and identifier. Regions that can't be scanned don't stop the scan of
+// the rest, their errors are returned in the map indexed by region.
+func (s *Scanner) Scan() (resources []*Resource, failures map[string]error) {
+	failures = map[string]error{}
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, maxParallelRegions)
+	for _, region := range s.regions {
+		wg.Add(1)
+		go func(region string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			found, err := s.scanRegion(region)
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				failures[region] = err
+				return
+			}
+			resources = append(resources, found...)
+		}(region)
+	}
+	wg.Wait()
+
+	sort.Slice(resources, func(i, j int) bool {
+		a, b := resources[i], resources[j]
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		return a.ID < b.ID
+	})
+	return
+}
+
+func (s *Scanner) scanRegion(region string) (resources []*Resource, err error) {
+	s.logger.Debugf("Scanning region '%s' for orphaned resources", region)
+	sess := s.session.Copy(&aws.Config{
+		Region: aws.String(region),
+	})
+	scanners := []func(*session.Session, string) ([]*Resource, error){
+		s.scanClassicLoadBalancers,
+		s.scanLoadBalancers,
+		s.scanVolumes,
+		s.scanSnapshots,
+		s.scanNATGateways,
+	}
+	for _, scan := range scanners {
+		var found []*Resource
+		found, err = scan(sess, region)
+		if err != nil {
+			return
+		}
+		resources = append(resources, found...)
+	}
+	return
+}
+
+func (s *Scanner) scanClassicLoadBalancers(sess *session.Session, region string) ([]*Resource, error) {
+	client := elb.New(sess)
+	var names []*string
+	err := client.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{},
+		func(page *elb.DescribeLoadBalancersOutput, _ bool) bool {
+			for _, lb := range page.LoadBalancerDescriptions {
+				names = append(names, lb.LoadBalancerName)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe classic load balancers: %v", err)
+	}
+	var resources []*Resource
+	for len(names) > 0 {
+		batch := names
+		if len(batch) > maxTagsBatch {
+			batch = batch[:maxTagsBatch]
+		}
+		names = names[len(batch):]
+		output, err := client.DescribeTags(&elb.DescribeTagsInput{
+			LoadBalancerNames: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe tags of classic load balancers: %v", err)
+		}
+		for _, description := range output.TagDescriptions {
+			values := map[string]string{}
+			for _, tag := range description.Tags {
+				values[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			resource := s.orphan(region, ClassicLoadBalancer,
+				aws.StringValue(description.LoadBalancerName), values)
+			if resource != nil {
+				resource.MonthlyCost = classicLoadBalancerMonthlyCost
+				resources = append(resources, resource)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func (s *Scanner) scanLoadBalancers(sess *session.Session, region string) ([]*Resource, error) {
+	client := elbv2.New(sess)
+	var arns []*string
+	err := client.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+		func(page *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+			for _, lb := range page.LoadBalancers {
+				arns = append(arns, lb.LoadBalancerArn)
+			}
+			return true
+		})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe load balancers: %v", err)
+	}
+	var resources []*Resource
+	for len(arns) > 0 {
+		batch := arns
+		if len(batch) > maxTagsBatch {
+			batch = batch[:maxTagsBatch]
+		}
+		arns = arns[len(batch):]
+		output, err := client.DescribeTags(&elbv2.DescribeTagsInput{
+			ResourceArns: batch,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to describe tags of load balancers: %v", err)
+		}
+		for _, description := range output.TagDescriptions {
+			values := map[string]string{}
+			for _, tag := range description.Tags {
+				values[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+			}
+			resource := s.orphan(region, LoadBalancer,
+				aws.StringValue(description.ResourceArn), values)
+			if resource != nil {
+				resource.MonthlyCost = loadBalancerMonthlyCost
+				resources = append(resources, resource)
+			}
+		}
+	}
+	return resources, nil
+}
+
+func (s *Scanner) scanVolumes(sess *session.Session, region string) ([]*Resource, error) {
+	var resources []*Resource
+	err := ec2.New(sess).DescribeVolumesPages(&ec2.DescribeVolumesInput{
+		Filters: clusterTagFilters(),
+	}, func(page *ec2.DescribeVolumesOutput, _ bool) bool {
+		for _, volume := range page.Volumes {
+			resource := s.orphan(region, Volume, aws.StringValue(volume.VolumeId),
+				ec2Tags(volume.Tags))
+			if resource != nil {
+				resource.MonthlyCost = volumeMonthlyCost(aws.StringValue(volume.VolumeType),
+					aws.Int64Value(volume.Size), aws.Int64Value(volume.Iops))
+				resources = append(resources, resource)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe volumes: %v", err)
+	}
+	return resources, nil
+}
+
+func (s *Scanner) scanSnapshots(sess *session.Session, region string) ([]*Resource, error) {
+	var resources []*Resource
+	err := ec2.New(sess).DescribeSnapshotsPages(&ec2.DescribeSnapshotsInput{
+		OwnerIds: aws.StringSlice([]string{"self"}),
+		Filters:  clusterTagFilters(),
+	}, func(page *ec2.DescribeSnapshotsOutput, _ bool) bool {
+		for _, snapshot := range page.Snapshots {
+			resource := s.orphan(region, Snapshot, aws.StringValue(snapshot.SnapshotId),
+				ec2Tags(snapshot.Tags))
+			if resource != nil {
+				resource.MonthlyCost = snapshotMonthlyCost(aws.Int64Value(snapshot.VolumeSize))
+				resources = append(resources, resource)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe snapshots: %v", err)
+	}
+	return resources, nil
+}
+
+func (s *Scanner) scanNATGateways(sess *session.Session, region string) ([]*Resource, error) {
+	var resources []*Resource
+	filters := append(clusterTagFilters(), &ec2.Filter{
+		Name:   aws.String("state"),
+		Values: aws.StringSlice([]string{"pending", "available"}),
+	})
+	err := ec2.New(sess).DescribeNatGatewaysPages(&ec2.DescribeNatGatewaysInput{
+		Filter: filters,
+	}, func(page *ec2.DescribeNatGatewaysOutput, _ bool) bool {
+		for _, gateway := range page.NatGateways {
+			resource := s.orphan(region, NATGateway, aws.StringValue(gateway.NatGatewayId),
+				ec2Tags(gateway.Tags))
+			if resource != nil {
+				resource.MonthlyCost = natGatewayMonthlyCost
+				resources = append(resources, resource)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to describe NAT gateways: %v", err)
+	}
+	return resources, nil
+}
+
+// orphan returns the resource with the given tags if it was created for a cluster, and that
+// cluster no longer exists. Otherwise it returns nil.
+func (s *Scanner) orphan(region, kind, id string, values map[string]string) *Resource {
+	var infraID string
+	for key, value := range values {
+		if strings.HasPrefix(key, clusterOwnerTagPrefix) && value == "owned" {
+			infraID = strings.TrimPrefix(key, clusterOwnerTagPrefix)
+			break
+		}
+	}
+	clusterID := values[clusterIDTag]
+	if clusterID == "" {
+		clusterID = values[tags.ClusterID]
+	}
+
+	switch {
+	case clusterID != "":
+		if s.clusters[clusterID] {
+			return nil
+		}
+	case infraID != "":
+		// Without the identifier of the cluster the only link to it is the prefix of the
+		// infrastructure identifier, which is derived from the name:
+		prefix := infraID
+		if index := strings.LastIndex(prefix, "-"); index > 0 {
+			prefix = prefix[:index]
+		}
+		if s.names[prefix] {
+			return nil
+		}
+	default:
+		return nil
+	}
+
+	return &Resource{
+		Region:    region,
+		Type:      kind,
+		ID:        id,
+		InfraID:   infraID,
+		ClusterID: clusterID,
+	}
+}
+
+// clusterTagFilters returns the EC2 filters that select the resources that have any of the tags
+// that link them to a cluster.
+func clusterTagFilters() []*ec2.Filter {
+	return []*ec2.Filter{
+		{
+			Name: aws.String("tag-key"),
+			Values: aws.StringSlice([]string{
+				clusterOwnerTagPrefix + "*",
+				clusterIDTag,
+				tags.ClusterID,
+			}),
+		},
+	}
+}
+
+func ec2Tags(list []*ec2.Tag) map[string]string {
+	values := map[string]string{}
+	for _, tag := range list {
+		values[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+	return values
+}
+
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
@@ -25,8 +25,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/arn"
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -35,6 +35,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/kms/kmsiface"
 	"github.com/aws/aws-sdk-go/service/organizations"
 	"github.com/aws/aws-sdk-go/service/organizations/organizationsiface"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
@@ -43,7 +45,10 @@ import (
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/sirupsen/logrus"
 
+	"github.com/openshift/rosa/pkg/aws/checks"
+	"github.com/openshift/rosa/pkg/aws/cleanup"
 	"github.com/openshift/rosa/pkg/aws/profile"
+	"github.com/openshift/rosa/pkg/aws/stsendpoint"
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/logging"
 )
@@ -71,8 +76,27 @@ type Client interface {
 	GetCreator() (*Creator, error)
 	TagUser(username string, clusterID string, clusterName string) error
 	ValidateSCP(*string) (bool, error)
+	DetectSCPRestrictions(instanceTypes []string) (*SCPRestrictions, error)
 	GetSubnetIDs() ([]*ec2.Subnet, error)
 	ValidateQuota() (bool, error)
+	GetAvailableVCPUs() (int, error)
+	ListClusterRoles() ([]*Role, error)
+	GetRolePolicies(roleName string) (*RolePolicies, error)
+	DeleteOperatorRole(roleARN string) error
+	FindOIDCProvider(issuerURL string) (string, error)
+	GetRoleOIDCProvider(roleARN string) (string, error)
+	DeleteOIDCProvider(providerARN string) error
+	GetOIDCProvider(providerARN string) (*OIDCProvider, error)
+	UpdateOIDCProviderThumbprint(providerARN string, thumbprint string) error
+	ValidateSTSEndpoint(roleARN string) error
+	ValidateKMSKey(keyARN string) (bool, error)
+	AddKMSKeyInstallerStatement(keyARN string) error
+	GetEnabledRegions() ([]string, error)
+	GetZoneTypes(region string) (map[string]bool, error)
+	FindOrphanedResources(regions []string, clusters map[string]string) ([]*cleanup.Resource, map[string]error, error)
+	FindLoadBalancerByDNSName(dnsName string) (*LoadBalancer, error)
+	RunEgressProbe(subnetID string, script string, done func(string) bool,
+		timeout time.Duration) (string, error)
 }
 
 // ClientBuilder contains the information and logic needed to build a new AWS client.
@@ -80,6 +104,7 @@ type ClientBuilder struct {
 	logger      *logrus.Logger
 	region      *string
 	credentials *AccessKey
+	assumeRole  string
 }
 
 type awsClient struct {
@@ -90,8 +115,10 @@ type awsClient struct {
 	stsClient           stsiface.STSAPI
 	cfClient            cloudformationiface.CloudFormationAPI
 	servicequotasClient servicequotasiface.ServiceQuotasAPI
+	kmsClient           kmsiface.KMSAPI
 	awsSession          *session.Session
 	awsAccessKeys       *AccessKey
+	partition           string
 }
 
 // NewClient creates a builder that can then be used to configure and build a new AWS client.
@@ -107,6 +134,7 @@ func New(
 	stsClient stsiface.STSAPI,
 	cfClient cloudformationiface.CloudFormationAPI,
 	servicequotasClient servicequotasiface.ServiceQuotasAPI,
+	kmsClient kmsiface.KMSAPI,
 	awsSession *session.Session,
 	awsAccessKeys *AccessKey,
 
@@ -119,8 +147,10 @@ func New(
 		stsClient,
 		cfClient,
 		servicequotasClient,
+		kmsClient,
 		awsSession,
 		awsAccessKeys,
+		PartitionAWS,
 	}
 }
 
@@ -141,6 +171,13 @@ func (b *ClientBuilder) AccessKeys(value *AccessKey) *ClientBuilder {
 	return b
 }
 
+// AssumeRole sets the ARN of a role that the client will assume, so that all the requests are
+// performed with the credentials of that role, usually in a different AWS account.
+func (b *ClientBuilder) AssumeRole(value string) *ClientBuilder {
+	b.assumeRole = value
+	return b
+}
+
 // Create AWS session with a specific set of credentials
 func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey) (*session.Session, error) {
 	return session.NewSessionWithOptions(session.Options{
@@ -148,6 +185,7 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey) (*s
 			CredentialsChainVerboseErrors: aws.Bool(true),
 			Region:                        b.region,
 			Credentials:                   credentials.NewStaticCredentials(value.AccessKeyID, value.SecretAccessKey, ""),
+			STSRegionalEndpoint:           stsendpoint.Model(),
 		},
 	})
 }
@@ -159,6 +197,7 @@ func (b *ClientBuilder) BuildSessionWithOptions() (*session.Session, error) {
 		Config: aws.Config{
 			CredentialsChainVerboseErrors: aws.Bool(true),
 			Region:                        b.region,
+			STSRegionalEndpoint:           stsendpoint.Model(),
 		},
 	})
 }
@@ -194,6 +233,11 @@ func (b *ClientBuilder) Build() (Client, error) {
 		b.logger.Debugf("Using AWS profile: %s", profile.Profile())
 	}
 
+	err = stsendpoint.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	// Check that the AWS credentials are available:
 	// TODO: No need to do this twice, we're essentially doing the
 	// same thing in getClientDetails()
@@ -204,31 +248,64 @@ func (b *ClientBuilder) Build() (Client, error) {
 		return nil, fmt.Errorf("Failed to find credentials. Check your AWS configuration and try again")
 	}
 
+	// Switch to the credentials of the assumed role, if any:
+	if b.assumeRole != "" {
+		b.logger.Debugf("Assuming role '%s'", b.assumeRole)
+		sess = sess.Copy(&aws.Config{
+			Credentials: stscreds.NewCredentials(sess, b.assumeRole),
+		})
+		_, err = sess.Config.Credentials.Get()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to assume role '%s': %v", b.assumeRole, err)
+		}
+	}
+
 	// Check that the region is set:
 	region := aws.StringValue(sess.Config.Region)
 	if region == "" {
 		return nil, fmt.Errorf("Region is not set")
 	}
+	partition, err := GetPartition(region)
+	if err != nil {
+		return nil, err
+	}
+
+	// The AWS SDK uses the proxy configured in the HTTPS_PROXY and NO_PROXY environment
+	// variables, report it to simplify troubleshooting of connection problems:
+	if b.logger.IsLevelEnabled(logrus.DebugLevel) {
+		endpoint := stsEndpointURL(region, sess.Config.STSRegionalEndpoint)
+		request, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+		proxyURL, err := http.ProxyFromEnvironment(request)
+		switch {
+		case err != nil:
+			b.logger.Debugf("Failed to resolve proxy for '%s': %v", endpoint, err)
+		case proxyURL == nil:
+			b.logger.Debugf("Using no proxy for '%s'", endpoint)
+		default:
+			b.logger.Debugf("Using proxy '%s' for '%s'", proxyURL.Host, endpoint)
+		}
+	}
 
 	// Update session config
 	sess = sess.Copy(&aws.Config{
 		// MaxRetries to limit the number of attempts on failed API calls
 		MaxRetries: aws.Int(25),
-		// Set MinThrottleDelay to 1 second
-		Retryer: client.DefaultRetryer{
-			NumMaxRetries:    5,
-			MinThrottleDelay: 1 * time.Second,
-		},
-		Logger: logger,
+		// Retry throttled requests with a minimum delay of 1 second, counting them
+		Retryer: newThrottleRetryer(b.logger),
+		Logger:  logger,
 		HTTPClient: &http.Client{
 			Transport: http.DefaultTransport,
 		},
 	})
 
-	if b.logger.IsLevelEnabled(logrus.DebugLevel) {
+	// Generate a span for each request when tracing is enabled:
+	addTracingHandlers(sess)
+
+	awsLogger := logging.ForModule(b.logger, logging.ModuleAWS)
+	if awsLogger.IsLevelEnabled(logrus.DebugLevel) {
 		var dumper http.RoundTripper
 		dumper, err = logging.NewRoundTripper().
-			Logger(b.logger).
+			Logger(awsLogger).
 			Next(sess.Config.HTTPClient.Transport).
 			Build()
 		if err != nil {
@@ -246,11 +323,30 @@ func (b *ClientBuilder) Build() (Client, error) {
 		stsClient:           sts.New(sess),
 		cfClient:            cloudformation.New(sess),
 		servicequotasClient: servicequotas.New(sess),
+		kmsClient:           kms.New(sess),
 		awsSession:          sess,
+		partition:           partition,
+	}
+
+	// Credentials of assumed roles are always STS credentials and never belong to the root
+	// account, so there is nothing else to check:
+	if b.assumeRole != "" {
+		return c, nil
+	}
+
+	if checks.Skip() {
+		checks.Skipped("root user check", "'--skip-aws-checks' was used")
+		return c, nil
 	}
 
 	_, root, err := getClientDetails(c)
 	if err != nil {
+		// Commands that only read resources can work without the IAM permissions needed to
+		// check the user:
+		if checks.CanSkip(err) {
+			checks.Skipped("root user check", err)
+			return c, nil
+		}
 		return nil, err
 	}
 
@@ -342,7 +438,7 @@ func (c *awsClient) EnsureOsdCcsAdminUser(stackName string, adminUserName string
 	}
 
 	// Read cloudformation template
-	cfTemplateBody, err := readCFTemplate()
+	cfTemplateBody, err := readCFTemplate(c.partition)
 	if err != nil {
 		return false, err
 	}
@@ -1,6 +1,7 @@
 package aws_test
 
 import (
+	awssdk "github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/iam"
@@ -36,6 +37,7 @@ var _ = Describe("Client", func() {
 			mocks.NewMockSTSAPI(mockCtrl),
 			mockCfAPI,
 			mocks.NewMockServiceQuotasAPI(mockCtrl),
+			mocks.NewMockKMSAPI(mockCtrl),
 			&session.Session{},
 			&aws.AccessKey{},
 		)
@@ -170,4 +172,33 @@ var _ = Describe("Client", func() {
 			})
 		})
 	})
+	Context("GetRolePolicies", func() {
+		It("Returns the attached policies and the permissions boundary", func() {
+			roleName := "prefix-Installer-Role"
+			mockIamAPI.EXPECT().GetRole(gomock.Any()).Return(&iam.GetRoleOutput{
+				Role: &iam.Role{
+					RoleName: &roleName,
+					PermissionsBoundary: &iam.AttachedPermissionsBoundary{
+						PermissionsBoundaryArn: awssdk.String("arn:aws:iam::123:policy/boundary"),
+					},
+				},
+			}, nil)
+			mockIamAPI.EXPECT().ListAttachedRolePoliciesPages(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ *iam.ListAttachedRolePoliciesInput,
+					page func(*iam.ListAttachedRolePoliciesOutput, bool) bool) error {
+					page(&iam.ListAttachedRolePoliciesOutput{
+						AttachedPolicies: []*iam.AttachedPolicy{
+							{PolicyArn: awssdk.String("arn:aws:iam::123:policy/installer")},
+						},
+					}, true)
+					return nil
+				})
+
+			policies, err := client.GetRolePolicies(roleName)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policies.Attached).To(ConsistOf("arn:aws:iam::123:policy/installer"))
+			Expect(policies.PermissionsBoundary).To(Equal("arn:aws:iam::123:policy/boundary"))
+		})
+	})
 })
@@ -0,0 +1,127 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to launch the instances that check the egress of the
+// subnets of a cluster. The instance runs a script given as user data, writes the results to its
+// console and shuts down, which terminates it.
+
+package aws
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// Settings of the probe instances:
+const (
+	probeInstanceType = "t3.micro"
+	probeImageName    = "amzn2-ami-hvm-*-x86_64-gp2"
+	probeImageOwner   = "amazon"
+	probePollInterval = 15 * time.Second
+)
+
+// RunEgressProbe launches an instance in the given subnet that runs the given script, and returns
+// the console output of the instance once the given function reports that the script finished.
+// The instance is terminated when the function returns, also when it fails.
+func (c *awsClient) RunEgressProbe(subnetID string, script string, done func(string) bool,
+	timeout time.Duration) (string, error) {
+	imageID, err := c.findProbeImage()
+	if err != nil {
+		return "", err
+	}
+	reservation, err := c.ec2Client.RunInstances(&ec2.RunInstancesInput{
+		ImageId:                           aws.String(imageID),
+		InstanceType:                      aws.String(probeInstanceType),
+		SubnetId:                          aws.String(subnetID),
+		MinCount:                          aws.Int64(1),
+		MaxCount:                          aws.Int64(1),
+		InstanceInitiatedShutdownBehavior: aws.String(ec2.ShutdownBehaviorTerminate),
+		UserData:                          aws.String(base64.StdEncoding.EncodeToString([]byte(script))),
+		TagSpecifications: []*ec2.TagSpecification{{
+			ResourceType: aws.String(ec2.ResourceTypeInstance),
+			Tags: []*ec2.Tag{
+				{Key: aws.String("Name"), Value: aws.String("rosa-egress-probe")},
+				{Key: aws.String(tags.EgressProbe), Value: aws.String("true")},
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to launch probe instance in subnet '%s': %v", subnetID, err)
+	}
+	instanceID := aws.StringValue(reservation.Instances[0].InstanceId)
+	c.logger.Debugf("Launched probe instance '%s' in subnet '%s'", instanceID, subnetID)
+	defer func() {
+		_, err := c.ec2Client.TerminateInstances(&ec2.TerminateInstancesInput{
+			InstanceIds: aws.StringSlice([]string{instanceID}),
+		})
+		if err != nil {
+			c.logger.Warnf("Failed to terminate probe instance '%s': %v", instanceID, err)
+		}
+	}()
+
+	// The console output is only updated every few minutes, so it is polled until it contains the
+	// results or the timeout expires:
+	deadline := time.Now().Add(timeout)
+	for {
+		output, err := c.ec2Client.GetConsoleOutput(&ec2.GetConsoleOutputInput{
+			InstanceId: aws.String(instanceID),
+			Latest:     aws.Bool(true),
+		})
+		if err != nil {
+			c.logger.Debugf("Failed to get console output of probe instance '%s': %v", instanceID, err)
+		} else if encoded := aws.StringValue(output.Output); encoded != "" {
+			decoded, err := base64.StdEncoding.DecodeString(encoded)
+			if err == nil && done(string(decoded)) {
+				return string(decoded), nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("Probe instance '%s' in subnet '%s' didn't report results in %s",
+				instanceID, subnetID, timeout)
+		}
+		time.Sleep(probePollInterval)
+	}
+}
+
+// findProbeImage returns the identifier of the most recent Amazon Linux 2 image of the region.
+func (c *awsClient) findProbeImage() (string, error) {
+	output, err := c.ec2Client.DescribeImages(&ec2.DescribeImagesInput{
+		Owners: aws.StringSlice([]string{probeImageOwner}),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("name"), Values: aws.StringSlice([]string{probeImageName})},
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{ec2.ImageStateAvailable})},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to find image for probe instance: %v", err)
+	}
+	images := output.Images
+	if len(images) == 0 {
+		return "", fmt.Errorf("There is no image for the probe instance in region '%s'",
+			aws.StringValue(c.awsSession.Config.Region))
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return aws.StringValue(images[i].CreationDate) > aws.StringValue(images[j].CreationDate)
+	})
+	return aws.StringValue(images[0].ImageId), nil
+}
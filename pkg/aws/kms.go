@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+
+	kmspolicy "github.com/openshift/rosa/pkg/aws/kms"
+)
+
+// ValidateKMSKey checks that the given KMS key can be used to encrypt etcd: it must be an
+// enabled, symmetric, customer managed key in the same region as the client. The returned flag
+// indicates if the key policy already allows the installer to use the key.
+func (c *awsClient) ValidateKMSKey(keyARN string) (bool, error) {
+	parsedARN, err := arn.Parse(keyARN)
+	if err != nil {
+		return false, fmt.Errorf("Invalid ARN '%s': %v", keyARN, err)
+	}
+	if parsedARN.Service != "kms" || !strings.HasPrefix(parsedARN.Resource, "key/") {
+		return false, fmt.Errorf("ARN '%s' doesn't identify a KMS key", keyARN)
+	}
+	err = c.checkPartition(keyARN, parsedARN.Partition)
+	if err != nil {
+		return false, err
+	}
+	if parsedARN.Region != c.GetRegion() {
+		return false, fmt.Errorf("KMS key '%s' must be in region '%s'", keyARN, c.GetRegion())
+	}
+
+	describeKeyOutput, err := c.kmsClient.DescribeKey(&kms.DescribeKeyInput{
+		KeyId: aws.String(keyARN),
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to describe KMS key '%s': %v", keyARN, err)
+	}
+	metadata := describeKeyOutput.KeyMetadata
+	if !aws.BoolValue(metadata.Enabled) {
+		return false, fmt.Errorf("KMS key '%s' is not enabled", keyARN)
+	}
+	if aws.StringValue(metadata.KeyManager) != kms.KeyManagerTypeCustomer {
+		return false, fmt.Errorf("KMS key '%s' is not a customer managed key", keyARN)
+	}
+	keySpec := aws.StringValue(metadata.CustomerMasterKeySpec)
+	if keySpec != "" && keySpec != kms.CustomerMasterKeySpecSymmetricDefault {
+		return false, fmt.Errorf("KMS key '%s' is not a symmetric key", keyARN)
+	}
+
+	policy, err := c.getKeyPolicy(keyARN)
+	if err != nil {
+		return false, err
+	}
+	return kmspolicy.AllowsInstaller(policy,
+		adminUserARN(parsedARN.Partition, parsedARN.AccountID),
+		accountRootARN(parsedARN.Partition, parsedARN.AccountID))
+}
+
+// AddKMSKeyInstallerStatement adds to the policy of the given KMS key the statement that allows
+// the osdCcsAdmin user to use the key. See kmspolicy.InstallerStatement for the exact statement.
+func (c *awsClient) AddKMSKeyInstallerStatement(keyARN string) error {
+	parsedARN, err := arn.Parse(keyARN)
+	if err != nil {
+		return fmt.Errorf("Invalid ARN '%s': %v", keyARN, err)
+	}
+
+	policy, err := c.getKeyPolicy(keyARN)
+	if err != nil {
+		return err
+	}
+	policy, err = kmspolicy.AddInstallerStatement(policy,
+		adminUserARN(parsedARN.Partition, parsedARN.AccountID))
+	if err != nil {
+		return err
+	}
+	_, err = c.kmsClient.PutKeyPolicy(&kms.PutKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(kmspolicy.DefaultPolicyName),
+		Policy:     aws.String(policy),
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to update policy of KMS key '%s': %v", keyARN, err)
+	}
+	return nil
+}
+
+// KMSKeyInstallerStatement returns the text of the statement that AddKMSKeyInstallerStatement adds
+// to the policy of the given KMS key.
+func KMSKeyInstallerStatement(keyARN string) (string, error) {
+	parsedARN, err := arn.Parse(keyARN)
+	if err != nil {
+		return "", fmt.Errorf("Invalid ARN '%s': %v", keyARN, err)
+	}
+	statement := kmspolicy.InstallerStatement(adminUserARN(parsedARN.Partition, parsedARN.AccountID))
+	data, err := json.MarshalIndent(statement, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// getKeyPolicy returns the policy document of the given KMS key.
+func (c *awsClient) getKeyPolicy(keyARN string) (string, error) {
+	output, err := c.kmsClient.GetKeyPolicy(&kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(kmspolicy.DefaultPolicyName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to get policy of KMS key '%s': %v", keyARN, err)
+	}
+	return aws.StringValue(output.Policy), nil
+}
+
+func adminUserARN(partition string, accountID string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:user/%s", partition, accountID, AdminUserName)
+}
+
+func accountRootARN(partition string, accountID string) string {
+	return fmt.Sprintf("arn:%s:iam::%s:root", partition, accountID)
+}
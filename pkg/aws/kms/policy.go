@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains functions used to check and update the policies of the KMS keys used to
+// encrypt etcd.
+
+package kms
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// DefaultPolicyName is the name of the only policy that KMS keys support.
+const DefaultPolicyName = "default"
+
+// InstallerStatementID is the identifier of the statement added to the policy of the key.
+const InstallerStatementID = "Allow ROSA installer to use the key"
+
+// InstallerActions are the actions that the installer needs to be able to perform with the key in
+// order to encrypt etcd.
+var InstallerActions = []string{
+	"kms:Encrypt",
+	"kms:Decrypt",
+	"kms:ReEncrypt*",
+	"kms:GenerateDataKey*",
+	"kms:DescribeKey",
+	"kms:CreateGrant",
+}
+
+// InstallerStatement returns the statement that allows the given principal to use the key. This
+// is exactly the statement that AddInstallerStatement adds to the key policy:
+//
+//	{
+//	  "Sid": "Allow ROSA installer to use the key",
+//	  "Effect": "Allow",
+//	  "Principal": {"AWS": "<principal ARN>"},
+//	  "Action": ["kms:Encrypt", "kms:Decrypt", "kms:ReEncrypt*", "kms:GenerateDataKey*",
+//	             "kms:DescribeKey", "kms:CreateGrant"],
+//	  "Resource": "*"
+//	}
+func InstallerStatement(principalARN string) map[string]interface{} {
+	return map[string]interface{}{
+		"Sid":    InstallerStatementID,
+		"Effect": "Allow",
+		"Principal": map[string]interface{}{
+			"AWS": principalARN,
+		},
+		"Action":   InstallerActions,
+		"Resource": "*",
+	}
+}
+
+// AllowsInstaller checks if the given key policy allows the principal to perform all the
+// installer actions. Statements granting access to the root of the account of the principal are
+// also accepted, as they delegate access control to IAM policies.
+func AllowsInstaller(policy string, principalARN string, accountRootARN string) (bool, error) {
+	document, err := parsePolicy(policy)
+	if err != nil {
+		return false, err
+	}
+	statements, _ := document["Statement"].([]interface{})
+	allowed := map[string]bool{}
+	for _, item := range statements {
+		statement, ok := item.(map[string]interface{})
+		if !ok || statement["Effect"] != "Allow" {
+			continue
+		}
+		if !hasPrincipal(statement["Principal"], principalARN, accountRootARN) {
+			continue
+		}
+		for _, action := range toStrings(statement["Action"]) {
+			for _, required := range InstallerActions {
+				if matchesAction(action, required) {
+					allowed[required] = true
+				}
+			}
+		}
+	}
+	return len(allowed) == len(InstallerActions), nil
+}
+
+// AddInstallerStatement returns a copy of the given key policy with the installer statement
+// added. If there is already a statement with the same identifier it is replaced.
+func AddInstallerStatement(policy string, principalARN string) (string, error) {
+	document, err := parsePolicy(policy)
+	if err != nil {
+		return "", err
+	}
+	statements, _ := document["Statement"].([]interface{})
+	var result []interface{}
+	for _, item := range statements {
+		statement, ok := item.(map[string]interface{})
+		if ok && statement["Sid"] == InstallerStatementID {
+			continue
+		}
+		result = append(result, item)
+	}
+	document["Statement"] = append(result, InstallerStatement(principalARN))
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func parsePolicy(policy string) (map[string]interface{}, error) {
+	document := map[string]interface{}{}
+	err := json.Unmarshal([]byte(policy), &document)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse key policy: %v", err)
+	}
+	return document, nil
+}
+
+func hasPrincipal(principal interface{}, arns ...string) bool {
+	if principal == "*" {
+		return true
+	}
+	principals, ok := principal.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	for _, value := range toStrings(principals["AWS"]) {
+		for _, arn := range arns {
+			if value == "*" || value == arn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesAction(pattern string, action string) bool {
+	pattern = strings.ToLower(pattern)
+	action = strings.ToLower(action)
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(action, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == action
+}
+
+func toStrings(value interface{}) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		var result []string
+		for _, item := range typed {
+			if text, ok := item.(string); ok {
+				result = append(result, text)
+			}
+		}
+		return result
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+)
+
+// Tag that Kubernetes adds to the load balancers created for services, containing the namespace
+// and name of the service.
+const serviceNameTag = "kubernetes.io/service-name"
+
+// LoadBalancer contains the details of a load balancer needed to check that DNS records point to
+// it.
+type LoadBalancer struct {
+	Name    string
+	DNSName string
+	Type    string
+	Scheme  string
+	Service string
+}
+
+// FindLoadBalancerByDNSName returns the classic or network load balancer of the region of the
+// client that has the given DNS name, or nil if there is no such load balancer.
+func (c *awsClient) FindLoadBalancerByDNSName(dnsName string) (*LoadBalancer, error) {
+	dnsName = strings.ToLower(strings.TrimSuffix(dnsName, "."))
+
+	var result *LoadBalancer
+	classicClient := elb.New(c.awsSession)
+	err := classicClient.DescribeLoadBalancersPages(&elb.DescribeLoadBalancersInput{},
+		func(page *elb.DescribeLoadBalancersOutput, _ bool) bool {
+			for _, lb := range page.LoadBalancerDescriptions {
+				if strings.EqualFold(aws.StringValue(lb.DNSName), dnsName) {
+					result = &LoadBalancer{
+						Name:    aws.StringValue(lb.LoadBalancerName),
+						DNSName: aws.StringValue(lb.DNSName),
+						Type:    "classic",
+						Scheme:  aws.StringValue(lb.Scheme),
+					}
+					return false
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	if result != nil {
+		output, err := classicClient.DescribeTags(&elb.DescribeTagsInput{
+			LoadBalancerNames: aws.StringSlice([]string{result.Name}),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, description := range output.TagDescriptions {
+			for _, tag := range description.Tags {
+				if aws.StringValue(tag.Key) == serviceNameTag {
+					result.Service = aws.StringValue(tag.Value)
+				}
+			}
+		}
+		return result, nil
+	}
+
+	v2Client := elbv2.New(c.awsSession)
+	var arn string
+	err = v2Client.DescribeLoadBalancersPages(&elbv2.DescribeLoadBalancersInput{},
+		func(page *elbv2.DescribeLoadBalancersOutput, _ bool) bool {
+			for _, lb := range page.LoadBalancers {
+				if strings.EqualFold(aws.StringValue(lb.DNSName), dnsName) {
+					arn = aws.StringValue(lb.LoadBalancerArn)
+					result = &LoadBalancer{
+						Name:    aws.StringValue(lb.LoadBalancerName),
+						DNSName: aws.StringValue(lb.DNSName),
+						Type:    aws.StringValue(lb.Type),
+						Scheme:  aws.StringValue(lb.Scheme),
+					}
+					return false
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	output, err := v2Client.DescribeTags(&elbv2.DescribeTagsInput{
+		ResourceArns: aws.StringSlice([]string{arn}),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, description := range output.TagDescriptions {
+		for _, tag := range description.Tags {
+			if aws.StringValue(tag.Key) == serviceNameTag {
+				result.Service = aws.StringValue(tag.Value)
+			}
+		}
+	}
+	return result, nil
+}
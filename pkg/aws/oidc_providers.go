@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check the OpenID Connect provider of AWS STS clusters.
+// IAM only trusts the tokens of the provider when the certificate chain of the issuer ends with a
+// certificate whose thumbprint is registered in the provider, so the thumbprint needs to be
+// updated when the issuer changes its certificate authority.
+
+package aws
+
+import (
+	"crypto/sha1" // #nosec G505
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+// issuerDialTimeout is the maximum time to connect to the issuer to get its certificates.
+const issuerDialTimeout = 30 * time.Second
+
+// OIDCProvider contains the details of an OpenID Connect provider.
+type OIDCProvider struct {
+	ARN         string     `json:"arn"`
+	URL         string     `json:"url"`
+	Audiences   []string   `json:"audiences"`
+	Thumbprints []string   `json:"thumbprints"`
+	Created     *time.Time `json:"created,omitempty"`
+}
+
+// GetOIDCProvider returns the details of the OpenID Connect provider with the given ARN.
+func (c *awsClient) GetOIDCProvider(providerARN string) (*OIDCProvider, error) {
+	output, err := c.iamClient.GetOpenIDConnectProvider(&iam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get OpenID Connect provider '%s': %v", providerARN, err)
+	}
+	return &OIDCProvider{
+		ARN:         providerARN,
+		URL:         aws.StringValue(output.Url),
+		Audiences:   aws.StringValueSlice(output.ClientIDList),
+		Thumbprints: aws.StringValueSlice(output.ThumbprintList),
+		Created:     output.CreateDate,
+	}, nil
+}
+
+// UpdateOIDCProviderThumbprint replaces the thumbprints of the OpenID Connect provider with the
+// given ARN with the given one.
+func (c *awsClient) UpdateOIDCProviderThumbprint(providerARN string, thumbprint string) error {
+	_, err := c.iamClient.UpdateOpenIDConnectProviderThumbprint(
+		&iam.UpdateOpenIDConnectProviderThumbprintInput{
+			OpenIDConnectProviderArn: aws.String(providerARN),
+			ThumbprintList:           aws.StringSlice([]string{thumbprint}),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("Failed to update thumbprint of OpenID Connect provider '%s': %v",
+			providerARN, err)
+	}
+	return nil
+}
+
+// IssuerThumbprint returns the thumbprint that IAM expects for the given issuer URL: the SHA-1
+// fingerprint of the last certificate of the chain that the issuer presents.
+func IssuerThumbprint(issuerURL string) (string, error) {
+	parsed, err := url.Parse(issuerURL)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("Invalid issuer URL '%s'", issuerURL)
+	}
+	address := parsed.Host
+	if parsed.Port() == "" {
+		address = net.JoinHostPort(parsed.Hostname(), "443")
+	}
+	dialer := &net.Dialer{
+		Timeout: issuerDialTimeout,
+	}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+		ServerName: parsed.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to connect to issuer '%s': %v", issuerURL, err)
+	}
+	defer conn.Close()
+	certificates := conn.ConnectionState().PeerCertificates
+	if len(certificates) == 0 {
+		return "", fmt.Errorf("Issuer '%s' didn't present any certificate", issuerURL)
+	}
+	// #nosec G401
+	sum := sha1.Sum(certificates[len(certificates)-1].Raw)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to delete the IAM resources that AWS STS clusters use to
+// let their operators authenticate: the operator roles and the OpenID Connect provider that issues
+// the tokens that the operators exchange for the credentials of those roles.
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+)
+
+// DeleteOperatorRole detaches the policies of the given operator role and then deletes it. A role
+// that doesn't exist is considered already deleted.
+func (c *awsClient) DeleteOperatorRole(roleARN string) error {
+	roleName, err := roleNameFromARN(roleARN)
+	if err != nil {
+		return err
+	}
+
+	var attached []*iam.AttachedPolicy
+	err = c.iamClient.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+		attached = append(attached, page.AttachedPolicies...)
+		return true
+	})
+	if isNoSuchEntity(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to list policies of role '%s': %v", roleName, err)
+	}
+	for _, policy := range attached {
+		_, err = c.iamClient.DetachRolePolicy(&iam.DetachRolePolicyInput{
+			RoleName:  aws.String(roleName),
+			PolicyArn: policy.PolicyArn,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to detach policy '%s' from role '%s': %v",
+				aws.StringValue(policy.PolicyArn), roleName, err)
+		}
+	}
+
+	var inline []*string
+	err = c.iamClient.ListRolePoliciesPages(&iam.ListRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(page *iam.ListRolePoliciesOutput, _ bool) bool {
+		inline = append(inline, page.PolicyNames...)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to list inline policies of role '%s': %v", roleName, err)
+	}
+	for _, policyName := range inline {
+		_, err = c.iamClient.DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+			RoleName:   aws.String(roleName),
+			PolicyName: policyName,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to delete inline policy '%s' of role '%s': %v",
+				aws.StringValue(policyName), roleName, err)
+		}
+	}
+
+	_, err = c.iamClient.DeleteRole(&iam.DeleteRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil && !isNoSuchEntity(err) {
+		return fmt.Errorf("Failed to delete role '%s': %v", roleName, err)
+	}
+	return nil
+}
+
+// FindOIDCProvider returns the ARN of the OpenID Connect provider of the account for the given
+// issuer URL, or an empty string if there is no such provider.
+func (c *awsClient) FindOIDCProvider(issuerURL string) (string, error) {
+	output, err := c.iamClient.ListOpenIDConnectProviders(&iam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return "", fmt.Errorf("Failed to list OpenID Connect providers: %v", err)
+	}
+	for _, provider := range output.OpenIDConnectProviderList {
+		providerARN := aws.StringValue(provider.Arn)
+		if IsOIDCProviderFor(providerARN, issuerURL) {
+			return providerARN, nil
+		}
+	}
+	return "", nil
+}
+
+// GetRoleOIDCProvider returns the ARN of the OpenID Connect provider trusted by the given operator
+// role, or an empty string if the role doesn't trust any provider.
+func (c *awsClient) GetRoleOIDCProvider(roleARN string) (string, error) {
+	roleName, err := roleNameFromARN(roleARN)
+	if err != nil {
+		return "", err
+	}
+	output, err := c.iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	policy, err := parseTrustPolicy(aws.StringValue(output.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse trust policy of role '%s': %v", roleName, err)
+	}
+	for _, statement := range policy.Statement {
+		var principals []interface{}
+		switch typed := statement.Principal["Federated"].(type) {
+		case string:
+			principals = append(principals, typed)
+		case []interface{}:
+			principals = typed
+		}
+		for _, principal := range principals {
+			providerARN, _ := principal.(string)
+			if strings.Contains(providerARN, ":oidc-provider/") {
+				return providerARN, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// IsOIDCProviderFor checks if the OpenID Connect provider with the given ARN is the one of the
+// given issuer URL.
+func IsOIDCProviderFor(providerARN string, issuerURL string) bool {
+	issuer := strings.TrimSuffix(strings.TrimPrefix(issuerURL, "https://"), "/")
+	return issuer != "" && strings.HasSuffix(providerARN, ":oidc-provider/"+issuer)
+}
+
+// DeleteOIDCProvider deletes the OpenID Connect provider with the given ARN. A provider that
+// doesn't exist is considered already deleted.
+func (c *awsClient) DeleteOIDCProvider(providerARN string) error {
+	_, err := c.iamClient.DeleteOpenIDConnectProvider(&iam.DeleteOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(providerARN),
+	})
+	if err != nil && !isNoSuchEntity(err) {
+		return fmt.Errorf("Failed to delete OpenID Connect provider '%s': %v", providerARN, err)
+	}
+	return nil
+}
+
+// roleNameFromARN returns the name of the IAM role identified by the given ARN.
+func roleNameFromARN(roleARN string) (string, error) {
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return "", fmt.Errorf("Invalid ARN '%s': %v", roleARN, err)
+	}
+	if parsedARN.Service != "iam" || !strings.HasPrefix(parsedARN.Resource, "role/") {
+		return "", fmt.Errorf("ARN '%s' doesn't identify an IAM role", roleARN)
+	}
+	return parsedARN.Resource[strings.LastIndex(parsedARN.Resource, "/")+1:], nil
+}
+
+func isNoSuchEntity(err error) bool {
+	typed, ok := err.(awserr.Error)
+	return ok && typed.Code() == iam.ErrCodeNoSuchEntityException
+}
@@ -0,0 +1,89 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find the AWS partition of a region, so that ARNs and
+// endpoints are built correctly outside of the commercial partition.
+
+package aws
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+)
+
+// Partitions supported by the tool:
+const (
+	PartitionAWS      = endpoints.AwsPartitionID
+	PartitionGovCloud = endpoints.AwsUsGovPartitionID
+)
+
+var supportedPartitions = []string{
+	PartitionAWS,
+	PartitionGovCloud,
+}
+
+// GetPartition returns the identifier of the partition that contains the given region, for
+// example 'aws' or 'aws-us-gov'. It fails if the region is unknown or if the partition isn't
+// supported.
+func GetPartition(region string) (string, error) {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return "", fmt.Errorf("Region '%s' isn't a known AWS region", region)
+	}
+	for _, supported := range supportedPartitions {
+		if partition.ID() == supported {
+			return partition.ID(), nil
+		}
+	}
+	return "", fmt.Errorf(
+		"Region '%s' belongs to AWS partition '%s', which isn't supported, the supported "+
+			"partitions are: %s",
+		region, partition.ID(), strings.Join(supportedPartitions, ", "),
+	)
+}
+
+// GetPartitionDNSSuffix returns the DNS suffix of the endpoints of the partition that contains the
+// given region, for example 'amazonaws.com'.
+func GetPartitionDNSSuffix(region string) string {
+	partition, ok := endpoints.PartitionForRegion(endpoints.DefaultPartitions(), region)
+	if !ok {
+		return endpoints.AwsPartition().DNSSuffix()
+	}
+	return partition.DNSSuffix()
+}
+
+// checkPartition checks that the given ARN belongs to the same partition as the client, so that
+// resources from other partitions are rejected early with a clear message.
+func (c *awsClient) checkPartition(arn string, partition string) error {
+	if partition != c.partition {
+		return fmt.Errorf(
+			"ARN '%s' belongs to AWS partition '%s' but region '%s' belongs to partition '%s'",
+			arn, partition, c.GetRegion(), c.partition,
+		)
+	}
+	return nil
+}
+
+// partitionTemplate replaces the commercial partition in the ARNs of the given template with the
+// given partition.
+func partitionTemplate(template string, partition string) string {
+	if partition == PartitionAWS || partition == "" {
+		return template
+	}
+	return strings.ReplaceAll(template, "arn:aws:", fmt.Sprintf("arn:%s:", partition))
+}
@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to inspect the policy documents and templates bundled
+// with the tool, so that they can be reviewed before the tool creates anything with them.
+
+package aws
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/info"
+)
+
+// Types of the bundled policies:
+const (
+	PolicyTypePolicy         = "policy"
+	PolicyTypeCloudFormation = "cloudformation"
+)
+
+// Policy is a policy document or template bundled with the tool. Templates are part of the tool,
+// so their version is the version of the tool.
+type Policy struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Version     string `json:"version"`
+	Checksum    string `json:"checksum"`
+	Description string `json:"description"`
+	path        string
+}
+
+var bundledPolicies = []*Policy{
+	{
+		Name:        "osd-scp-policy",
+		Type:        PolicyTypePolicy,
+		Description: fmt.Sprintf("Actions that the '%s' user needs to install clusters", AdminUserName),
+		path:        "templates/policies/osd_scp_policy.json",
+	},
+	{
+		Name:        "osd-ccs-admin-stack",
+		Type:        PolicyTypeCloudFormation,
+		Description: fmt.Sprintf("CloudFormation template that creates the '%s' user", AdminUserName),
+		path:        "templates/cloudformation/iam_user_osdCcsAdmin.json",
+	},
+}
+
+// ListPolicies returns the policy documents and templates bundled with the tool, with the SHA-256
+// checksum of their content.
+func ListPolicies() ([]*Policy, error) {
+	result := make([]*Policy, 0, len(bundledPolicies))
+	for _, policy := range bundledPolicies {
+		data, err := assets.Asset(policy.path)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read policy '%s': %v", policy.Name, err)
+		}
+		sum := sha256.Sum256(data)
+		item := *policy
+		item.Version = info.Version
+		item.Checksum = hex.EncodeToString(sum[:])
+		result = append(result, &item)
+	}
+	return result, nil
+}
+
+// RenderPolicy returns the content of the bundled policy with the given name as it would be used
+// in the given region, adjusting the ARNs to the partition of the region. An empty region means
+// the default partition.
+func RenderPolicy(name string, region string) (string, error) {
+	for _, policy := range bundledPolicies {
+		if policy.Name != name {
+			continue
+		}
+		data, err := assets.Asset(policy.path)
+		if err != nil {
+			return "", fmt.Errorf("Unable to read policy '%s': %v", policy.Name, err)
+		}
+		partition := PartitionAWS
+		if region != "" {
+			partition, err = GetPartition(region)
+			if err != nil {
+				return "", err
+			}
+		}
+		return partitionTemplate(string(data), partition), nil
+	}
+	return "", fmt.Errorf("There is no bundled policy named '%s'", name)
+}
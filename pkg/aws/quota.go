@@ -2,8 +2,10 @@ package aws
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/servicequotas"
 )
 
@@ -113,3 +115,61 @@ func GetServiceQuota(serviceQuotas []*servicequotas.ServiceQuota,
 	}
 	return nil, fmt.Errorf("Unable to find quota with service code: %s", quotaCode)
 }
+
+// onDemandStandardQuotaCode is the code of the quota of vCPUs of the running on-demand standard
+// instances, the ones used by the nodes of clusters.
+const onDemandStandardQuotaCode = "L-1216C47A"
+
+// standardInstanceFamilies contains the first letters of the instance families counted by the
+// quota of on-demand standard instances.
+const standardInstanceFamilies = "acdhimrtz"
+
+// GetAvailableVCPUs returns the number of vCPUs of on-demand standard instances that can still be
+// started in the region of the client before reaching the quota.
+func (c *awsClient) GetAvailableVCPUs() (int, error) {
+	output, err := c.servicequotasClient.GetServiceQuota(&servicequotas.GetServiceQuotaInput{
+		ServiceCode: aws.String("ec2"),
+		QuotaCode:   aws.String(onDemandStandardQuotaCode),
+	})
+	if err != nil {
+		return 0, err
+	}
+	if output.Quota == nil || output.Quota.Value == nil {
+		return 0, fmt.Errorf("Unable to find quota with service code: %s", onDemandStandardQuotaCode)
+	}
+
+	used := 0
+	err = c.ec2Client.DescribeInstancesPages(&ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: aws.StringSlice([]string{"pending", "running"}),
+			},
+		},
+	}, func(page *ec2.DescribeInstancesOutput, _ bool) bool {
+		for _, reservation := range page.Reservations {
+			for _, instance := range reservation.Instances {
+				// Spot instances have their own quota:
+				if instance.InstanceLifecycle != nil || instance.CpuOptions == nil {
+					continue
+				}
+				instanceType := aws.StringValue(instance.InstanceType)
+				if instanceType == "" || !strings.ContainsRune(standardInstanceFamilies, rune(instanceType[0])) {
+					continue
+				}
+				used += int(aws.Int64Value(instance.CpuOptions.CoreCount) *
+					aws.Int64Value(instance.CpuOptions.ThreadsPerCore))
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	available := int(*output.Quota.Value) - used
+	if available < 0 {
+		available = 0
+	}
+	return available, nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the retryer used by all the AWS clients. It retries throttled requests with
+// the jittered backoff of the SDK and keeps count of them, so that commands that are slowed down
+// or fail because of API rate limits can tell the user why.
+
+package aws
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/sirupsen/logrus"
+)
+
+// throttleRetryer is a retryer that sends to the log the requests throttled by AWS and counts
+// them by operation.
+type throttleRetryer struct {
+	client.DefaultRetryer
+	logger *logrus.Logger
+}
+
+// throttles contains the number of throttled requests for each operation, and the number of
+// requests that failed because they were still throttled after all the retries.
+var throttles struct {
+	sync.Mutex
+	operations map[string]int
+	exhausted  int
+}
+
+func newThrottleRetryer(logger *logrus.Logger) request.Retryer {
+	return throttleRetryer{
+		DefaultRetryer: client.DefaultRetryer{
+			NumMaxRetries:    5,
+			MinThrottleDelay: 1 * time.Second,
+		},
+		logger: logger,
+	}
+}
+
+// ShouldRetry is the implementation of the request.Retryer interface.
+func (r throttleRetryer) ShouldRetry(req *request.Request) bool {
+	retry := r.DefaultRetryer.ShouldRetry(req)
+	if !req.IsErrorThrottle() {
+		return retry
+	}
+	operation := fmt.Sprintf("%s:%s", req.ClientInfo.ServiceName, req.Operation.Name)
+	throttles.Lock()
+	if throttles.operations == nil {
+		throttles.operations = map[string]int{}
+	}
+	throttles.operations[operation]++
+	exhausted := req.RetryCount >= r.MaxRetries()
+	if exhausted {
+		throttles.exhausted++
+	}
+	throttles.Unlock()
+	if exhausted {
+		r.logger.Warnf("Request '%s' is still throttled by AWS after %d retries",
+			operation, r.MaxRetries())
+		return retry
+	}
+	r.logger.Debugf("Request '%s' was throttled by AWS (attempt %d of %d)",
+		operation, req.RetryCount+1, r.MaxRetries()+1)
+	return retry
+}
+
+// ThrottleSummary returns a description of the AWS requests that were throttled during the
+// execution of the command, or an empty string if there were none.
+func ThrottleSummary() string {
+	throttles.Lock()
+	defer throttles.Unlock()
+	if len(throttles.operations) == 0 {
+		return ""
+	}
+	total := 0
+	operations := make([]string, 0, len(throttles.operations))
+	for operation, count := range throttles.operations {
+		total += count
+		operations = append(operations, fmt.Sprintf("%s (%d)", operation, count))
+	}
+	sort.Strings(operations)
+	summary := fmt.Sprintf("AWS throttled %d requests: %s", total, strings.Join(operations, ", "))
+	if throttles.exhausted > 0 {
+		summary += fmt.Sprintf(". %d of them failed after all retries, try again later or "+
+			"reduce the number of commands running in parallel in the same AWS account",
+			throttles.exhausted)
+	}
+	return summary
+}
@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+
+	"github.com/openshift/rosa/pkg/aws/tags"
+)
+
+// trustPolicyStatement models a statement of an IAM role trust policy. Unlike regular policy
+// statements, the action and the principals can be either a single string or a list of strings.
+type trustPolicyStatement struct {
+	Effect    string                            `json:"Effect"`
+	Action    interface{}                       `json:"Action"`
+	Principal map[string]interface{}            `json:"Principal"`
+	Condition map[string]map[string]interface{} `json:"Condition"`
+}
+
+type trustPolicyDocument struct {
+	Statement []trustPolicyStatement `json:"Statement"`
+}
+
+// parseTrustPolicy parses the given URL encoded trust policy document.
+func parseTrustPolicy(encodedDocument string) (*trustPolicyDocument, error) {
+	document, err := url.QueryUnescape(encodedDocument)
+	if err != nil {
+		return nil, err
+	}
+	policy := &trustPolicyDocument{}
+	err = json.Unmarshal([]byte(document), policy)
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// containsString checks if a policy value, which can be a single string or a list of strings,
+// contains the given string.
+func containsString(value interface{}, wanted string) bool {
+	switch typed := value.(type) {
+	case string:
+		return typed == wanted || typed == "*"
+	case []interface{}:
+		for _, item := range typed {
+			if containsString(item, wanted) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Types of the IAM roles related to clusters:
+const (
+	RoleTypeOCM          = "ocm"
+	RoleTypeUser         = "user"
+	RoleTypeInstaller    = "installer"
+	RoleTypeControlPlane = "control-plane"
+	RoleTypeWorker       = "worker"
+	RoleTypeSupport      = "support"
+	RoleTypeOperator     = "operator"
+	RoleTypeNode         = "node"
+)
+
+// Tags that the installer adds to the roles of the nodes that it creates. The name of the
+// ownership tag is followed by the infrastructure identifier of the cluster.
+const (
+	clusterOwnerTagPrefix = "kubernetes.io/cluster/"
+	clusterIDTag          = "api.openshift.com/id"
+)
+
+// rolePatterns are the regular expressions that match the names of the roles related to clusters,
+// with the prefix of the name as first group.
+var rolePatterns = []struct {
+	roleType string
+	pattern  *regexp.Regexp
+}{
+	{RoleTypeOCM, regexp.MustCompile(`^(.+)-OCM-Role-[0-9a-zA-Z]+$`)},
+	{RoleTypeUser, regexp.MustCompile(`^(.+)-User-.+-Role$`)},
+	{RoleTypeInstaller, regexp.MustCompile(`^(.+)-Installer-Role$`)},
+	{RoleTypeControlPlane, regexp.MustCompile(`^(.+)-ControlPlane-Role$`)},
+	{RoleTypeWorker, regexp.MustCompile(`^(.+)-Worker-Role$`)},
+	{RoleTypeSupport, regexp.MustCompile(`^(.+)-Support-Role$`)},
+	{RoleTypeOperator, regexp.MustCompile(`^(.+)-openshift-[a-z0-9-]+$`)},
+	{RoleTypeNode, regexp.MustCompile(`^(.+)-(master|worker)-role$`)},
+}
+
+// Role is an IAM role that was created for clusters, either by the tool, by the installer or by
+// the user following the naming conventions of the tool.
+type Role struct {
+	Name      string    `json:"name"`
+	ARN       string    `json:"arn"`
+	Type      string    `json:"type"`
+	Prefix    string    `json:"prefix"`
+	Version   string    `json:"version,omitempty"`
+	InfraID   string    `json:"infra_id,omitempty"`
+	ClusterID string    `json:"cluster_id,omitempty"`
+	Created   time.Time `json:"created"`
+}
+
+// ListClusterRoles returns the IAM roles of the account whose names match the naming conventions
+// of the roles related to clusters. The type, prefix and version are taken from the tags of the
+// role when present, and from the name otherwise.
+func (c *awsClient) ListClusterRoles() ([]*Role, error) {
+	var roles []*Role
+	err := c.iamClient.ListRolesPages(&iam.ListRolesInput{},
+		func(page *iam.ListRolesOutput, _ bool) bool {
+			for _, item := range page.Roles {
+				name := aws.StringValue(item.RoleName)
+				for _, candidate := range rolePatterns {
+					match := candidate.pattern.FindStringSubmatch(name)
+					if match == nil {
+						continue
+					}
+					roles = append(roles, &Role{
+						Name:    name,
+						ARN:     aws.StringValue(item.Arn),
+						Type:    candidate.roleType,
+						Prefix:  match[1],
+						Created: aws.TimeValue(item.CreateDate),
+					})
+					break
+				}
+			}
+			return true
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	// The list of roles doesn't contain the tags, so they are requested for each role:
+	for _, role := range roles {
+		output, err := c.iamClient.ListRoleTags(&iam.ListRoleTagsInput{
+			RoleName: aws.String(role.Name),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get tags of role '%s': %v", role.Name, err)
+		}
+		for _, tag := range output.Tags {
+			key := aws.StringValue(tag.Key)
+			value := aws.StringValue(tag.Value)
+			switch {
+			case key == tags.RoleType && value != "":
+				role.Type = value
+			case key == tags.RolePrefix && value != "":
+				role.Prefix = value
+			case key == tags.OpenShiftVersion:
+				role.Version = value
+			case key == tags.ClusterID || key == clusterIDTag:
+				role.ClusterID = value
+			case strings.HasPrefix(key, clusterOwnerTagPrefix) && value == "owned":
+				role.InfraID = strings.TrimPrefix(key, clusterOwnerTagPrefix)
+			}
+		}
+	}
+	return roles, nil
+}
+
+// RolePolicies contains the managed policies attached to an IAM role and its permissions boundary.
+type RolePolicies struct {
+	Attached            []string `json:"attached_policies"`
+	PermissionsBoundary string   `json:"permissions_boundary,omitempty"`
+}
+
+// GetRolePolicies returns the ARNs of the managed policies attached to the given role and the ARN of
+// its permissions boundary, if it has one.
+func (c *awsClient) GetRolePolicies(roleName string) (*RolePolicies, error) {
+	output, err := c.iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	result := &RolePolicies{
+		Attached: []string{},
+	}
+	if output.Role.PermissionsBoundary != nil {
+		result.PermissionsBoundary = aws.StringValue(output.Role.PermissionsBoundary.PermissionsBoundaryArn)
+	}
+	err = c.iamClient.ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{
+		RoleName: aws.String(roleName),
+	}, func(page *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+		for _, policy := range page.AttachedPolicies {
+			result.Attached = append(result.Attached, aws.StringValue(policy.PolicyArn))
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list policies of role '%s': %v", roleName, err)
+	}
+	return result, nil
+}
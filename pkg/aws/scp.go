@@ -0,0 +1,309 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to detect AWS Organizations service control policies that
+// would make the installation of a cluster fail.
+
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/organizations"
+)
+
+// SCPRestrictions describes the restrictions that the service control policies of the
+// organization impose on the account.
+type SCPRestrictions struct {
+	// DeniedActions are the actions needed by the installer that the simulation reports as
+	// denied by the organization.
+	DeniedActions []string
+
+	// DeniedInstanceTypes are the instance types that the simulation reports as not allowed to be
+	// launched in the region because of the organization.
+	DeniedInstanceTypes []string
+
+	// Policies are the service control policies attached to the account or to its parents that
+	// contain deny statements. This is only filled when the Organizations API is accessible.
+	Policies []SCPPolicy
+
+	// OrganizationsError is the reason why the Organizations API couldn't be used to inspect
+	// the policies, usually because the credentials don't belong to the management account.
+	OrganizationsError error
+}
+
+// SCPPolicy is a service control policy that contains deny statements.
+type SCPPolicy struct {
+	ID     string
+	Name   string
+	Target string
+	Denies []string
+}
+
+// Empty returns true if no restriction was detected.
+func (r *SCPRestrictions) Empty() bool {
+	return len(r.DeniedActions) == 0 && len(r.DeniedInstanceTypes) == 0 && len(r.Policies) == 0
+}
+
+// scpStatement is a statement of a service control policy. The fields can be strings or lists of
+// strings, so they are decoded as generic values.
+type scpStatement struct {
+	Effect    string                 `json:"Effect"`
+	Action    interface{}            `json:"Action"`
+	NotAction interface{}            `json:"NotAction"`
+	Condition map[string]interface{} `json:"Condition"`
+}
+
+type scpDocument struct {
+	Statement []scpStatement `json:"Statement"`
+}
+
+// DetectSCPRestrictions checks if the service control policies of the organization deny any of
+// the actions needed to install a cluster in the region of the client, or the launch of any of
+// the given instance types.
+func (c *awsClient) DetectSCPRestrictions(instanceTypes []string) (*SCPRestrictions, error) {
+	targetUser, _, err := getClientDetails(c)
+	if err != nil {
+		return nil, fmt.Errorf("getClientDetails: %v\n"+
+			"Run 'rosa init' and try again", err)
+	}
+	region := *c.awsSession.Config.Region
+	restrictions := &SCPRestrictions{}
+
+	// Simulate the actions of the installer, looking only at the decisions of the organization:
+	osdPolicyDocument := readSCPPolicy("templates/policies/osd_scp_policy.json")
+	actions := []string{}
+	for _, statement := range osdPolicyDocument.Statement {
+		actions = append(actions, statement.Action...)
+	}
+	restrictions.DeniedActions, err = simulateOrganizationsDenials(c, targetUser.Arn, actions,
+		regionContext(region))
+	if err != nil {
+		return nil, err
+	}
+
+	// Simulate launching each of the instance types, as organizations often restrict them:
+	for _, instanceType := range instanceTypes {
+		entries := append(regionContext(region), &iam.ContextEntry{
+			ContextKeyName:   aws.String("ec2:InstanceType"),
+			ContextKeyType:   aws.String("string"),
+			ContextKeyValues: []*string{aws.String(instanceType)},
+		})
+		denied, err := simulateOrganizationsDenials(c, targetUser.Arn, []string{"ec2:RunInstances"}, entries)
+		if err != nil {
+			return nil, err
+		}
+		if len(denied) > 0 {
+			restrictions.DeniedInstanceTypes = append(restrictions.DeniedInstanceTypes, instanceType)
+		}
+	}
+
+	// Try to find the policies that cause the restrictions. This only works with credentials of
+	// the management account or of a delegated administrator, so failures aren't fatal:
+	restrictions.Policies, restrictions.OrganizationsError = c.getDenySCPs()
+
+	return restrictions, nil
+}
+
+func regionContext(region string) []*iam.ContextEntry {
+	return []*iam.ContextEntry{
+		{
+			ContextKeyName:   aws.String("aws:RequestedRegion"),
+			ContextKeyType:   aws.String("stringList"),
+			ContextKeyValues: []*string{aws.String(region)},
+		},
+	}
+}
+
+// simulateOrganizationsDenials simulates the given actions and returns the ones that are denied
+// by the service control policies of the organization, ignoring denials caused by the IAM
+// policies of the principal.
+func simulateOrganizationsDenials(queryClient *awsClient, principalARN *string, actions []string,
+	entries []*iam.ContextEntry) ([]string, error) {
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: principalARN,
+		ActionNames:     aws.StringSlice(actions),
+		ContextEntries:  entries,
+	}
+
+	var deniedActions []string
+	err := queryClient.iamClient.SimulatePrincipalPolicyPages(input,
+		func(response *iam.SimulatePolicyResponse, lastPage bool) bool {
+			for _, result := range response.EvaluationResults {
+				detail := result.OrganizationsDecisionDetail
+				if detail != nil && !aws.BoolValue(detail.AllowedByOrganizations) {
+					deniedActions = append(deniedActions, aws.StringValue(result.EvalActionName))
+				}
+			}
+			return !lastPage
+		})
+	if err != nil {
+		return nil, fmt.Errorf("Error simulating policy: %v", err)
+	}
+
+	return deniedActions, nil
+}
+
+// getDenySCPs returns the service control policies with deny statements that apply to the account,
+// either directly or through the organizational units and the root that contain it.
+func (c *awsClient) getDenySCPs() ([]SCPPolicy, error) {
+	creator, err := c.GetCreator()
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the account and all its parents up to the root:
+	targets := []string{creator.AccountID}
+	for child := creator.AccountID; ; {
+		output, err := c.orgClient.ListParents(&organizations.ListParentsInput{
+			ChildId: aws.String(child),
+		})
+		if err != nil {
+			return nil, organizationsError(err)
+		}
+		if len(output.Parents) == 0 {
+			break
+		}
+		parent := output.Parents[0]
+		targets = append(targets, aws.StringValue(parent.Id))
+		if aws.StringValue(parent.Type) == organizations.ParentTypeRoot {
+			break
+		}
+		child = aws.StringValue(parent.Id)
+	}
+
+	var policies []SCPPolicy
+	seen := map[string]bool{}
+	for _, target := range targets {
+		var summaries []*organizations.PolicySummary
+		err := c.orgClient.ListPoliciesForTargetPages(
+			&organizations.ListPoliciesForTargetInput{
+				TargetId: aws.String(target),
+				Filter:   aws.String(organizations.PolicyTypeServiceControlPolicy),
+			},
+			func(output *organizations.ListPoliciesForTargetOutput, lastPage bool) bool {
+				summaries = append(summaries, output.Policies...)
+				return !lastPage
+			})
+		if err != nil {
+			return nil, organizationsError(err)
+		}
+		for _, summary := range summaries {
+			id := aws.StringValue(summary.Id)
+			// The 'FullAWSAccess' policy managed by AWS only allows, no need to download it:
+			if seen[id] || aws.BoolValue(summary.AwsManaged) {
+				continue
+			}
+			seen[id] = true
+			output, err := c.orgClient.DescribePolicy(&organizations.DescribePolicyInput{
+				PolicyId: aws.String(id),
+			})
+			if err != nil {
+				return nil, organizationsError(err)
+			}
+			denies, err := scpDenies(aws.StringValue(output.Policy.Content))
+			if err != nil {
+				c.logger.Debugf("Failed to parse service control policy '%s': %v", id, err)
+				continue
+			}
+			if len(denies) > 0 {
+				policies = append(policies, SCPPolicy{
+					ID:     id,
+					Name:   aws.StringValue(summary.Name),
+					Target: target,
+					Denies: denies,
+				})
+			}
+		}
+	}
+
+	return policies, nil
+}
+
+// scpDenies returns a human readable description of the deny statements of the given policy.
+func scpDenies(content string) ([]string, error) {
+	// Policies returned by some tools are URL encoded:
+	if decoded, err := url.QueryUnescape(content); err == nil {
+		content = decoded
+	}
+	document := scpDocument{}
+	err := json.Unmarshal([]byte(content), &document)
+	if err != nil {
+		return nil, err
+	}
+
+	var denies []string
+	for _, statement := range document.Statement {
+		if statement.Effect != "Deny" {
+			continue
+		}
+		var description string
+		if statement.NotAction != nil {
+			description = fmt.Sprintf("all actions except %s", strings.Join(stringList(statement.NotAction), ", "))
+		} else {
+			description = strings.Join(stringList(statement.Action), ", ")
+		}
+		var keys []string
+		for _, condition := range statement.Condition {
+			if values, ok := condition.(map[string]interface{}); ok {
+				for key := range values {
+					keys = append(keys, key)
+				}
+			}
+		}
+		if len(keys) > 0 {
+			sort.Strings(keys)
+			description = fmt.Sprintf("%s (depending on %s)", description, strings.Join(keys, ", "))
+		}
+		denies = append(denies, description)
+	}
+	return denies, nil
+}
+
+func stringList(value interface{}) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case []interface{}:
+		var list []string
+		for _, item := range typed {
+			if text, ok := item.(string); ok {
+				list = append(list, text)
+			}
+		}
+		return list
+	}
+	return nil
+}
+
+func organizationsError(err error) error {
+	if aerr, ok := err.(awserr.Error); ok {
+		switch aerr.Code() {
+		case organizations.ErrCodeAccessDeniedException:
+			return fmt.Errorf("The credentials aren't allowed to read the policies of the organization")
+		case organizations.ErrCodeAWSOrganizationsNotInUseException:
+			return fmt.Errorf("The account isn't a member of an organization")
+		}
+	}
+	return err
+}
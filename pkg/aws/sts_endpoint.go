@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check that the credentials and the installer role
+// work with the STS endpoint model selected with the '--sts-region-endpoint' flag. Outages of
+// the global STS endpoint break installations that depend on it, so users may want to make sure
+// that only regional endpoints are used.
+
+package aws
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/sts"
+
+	"github.com/openshift/rosa/pkg/aws/checks"
+)
+
+// requestedRegionKey is the condition key that contains the region that a request was sent to.
+const requestedRegionKey = "aws:requestedregion"
+
+// stsEndpoint returns the endpoint that the AWS SDK uses for STS requests in the given region with
+// the given endpoint model.
+func stsEndpoint(region string, model endpoints.STSRegionalEndpoint) (endpoints.ResolvedEndpoint, error) {
+	return endpoints.DefaultResolver().EndpointFor(sts.EndpointsID, region,
+		func(options *endpoints.Options) {
+			options.STSRegionalEndpoint = model
+		})
+}
+
+// stsEndpointURL returns the URL of the STS endpoint used in the given region with the given
+// endpoint model.
+func stsEndpointURL(region string, model endpoints.STSRegionalEndpoint) string {
+	endpoint, err := stsEndpoint(region, model)
+	if err != nil {
+		return fmt.Sprintf("https://sts.%s.%s", region, GetPartitionDNSSuffix(region))
+	}
+	return endpoint.URL
+}
+
+// ValidateSTSEndpoint checks that the STS endpoint selected for the region of the client is
+// active for the account, and that the trust policy of the given role, if any, allows it to be
+// assumed through that endpoint.
+func (c *awsClient) ValidateSTSEndpoint(roleARN string) error {
+	region := c.GetRegion()
+	endpoint, err := stsEndpoint(region, c.awsSession.Config.STSRegionalEndpoint)
+	if err != nil {
+		return fmt.Errorf("Failed to find STS endpoint for region '%s': %v", region, err)
+	}
+	c.logger.Debugf("Using STS endpoint '%s' signed for region '%s'", endpoint.URL,
+		endpoint.SigningRegion)
+
+	// Requests fail when the regional endpoint has been deactivated in the account settings:
+	_, err = c.stsClient.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		if typed, ok := err.(awserr.Error); ok && typed.Code() == "RegionDisabledException" {
+			return fmt.Errorf("STS endpoint '%s' is deactivated for the account. Activate it in "+
+				"the account settings of the IAM console, or select another STS endpoint model",
+				endpoint.URL)
+		}
+		return fmt.Errorf("Failed to get caller identity from STS endpoint '%s': %v", endpoint.URL, err)
+	}
+
+	if roleARN == "" {
+		return nil
+	}
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return fmt.Errorf("Invalid ARN '%s': %v", roleARN, err)
+	}
+	roleName := parsedARN.Resource[strings.LastIndex(parsedARN.Resource, "/")+1:]
+	getRoleOutput, err := c.iamClient.GetRole(&iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	if err != nil {
+		if checks.IsAccessDenied(err) {
+			checks.Skipped("trust policy of the installer role", err)
+			return nil
+		}
+		return fmt.Errorf("Failed to get role '%s': %v", roleName, err)
+	}
+	policy, err := parseTrustPolicy(aws.StringValue(getRoleOutput.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return fmt.Errorf("Failed to parse trust policy of role '%s': %v", roleName, err)
+	}
+	if !allowsRequestedRegion(policy, endpoint.SigningRegion) {
+		return fmt.Errorf("Trust policy of role '%s' restricts '%s' with a condition that doesn't "+
+			"allow region '%s', used by STS endpoint '%s'. Update the condition or select "+
+			"another STS endpoint model", roleName, "aws:RequestedRegion", endpoint.SigningRegion,
+			endpoint.URL)
+	}
+	return nil
+}
+
+// allowsRequestedRegion checks if any of the statements of the trust policy that allow to assume
+// the role is compatible with requests sent to the given region. Only the conditions on the
+// requested region are evaluated.
+func allowsRequestedRegion(policy *trustPolicyDocument, region string) bool {
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || !containsString(statement.Action, "sts:AssumeRole") {
+			continue
+		}
+		allowed := true
+		for operator, values := range statement.Condition {
+			for key, value := range values {
+				if strings.ToLower(key) != requestedRegionKey {
+					continue
+				}
+				if !matchesCondition(operator, value, region) {
+					allowed = false
+				}
+			}
+		}
+		if allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesCondition checks if the given region satisfies a string condition of a policy.
+func matchesCondition(operator string, value interface{}, region string) bool {
+	var patterns []string
+	switch typed := value.(type) {
+	case string:
+		patterns = []string{typed}
+	case []interface{}:
+		for _, item := range typed {
+			if text, ok := item.(string); ok {
+				patterns = append(patterns, text)
+			}
+		}
+	}
+	operator = strings.TrimSuffix(strings.ToLower(operator), "ifexists")
+	operator = operator[strings.Index(operator, ":")+1:]
+	negated := strings.HasPrefix(operator, "stringnot")
+	like := strings.HasSuffix(operator, "like")
+	matched := false
+	for _, pattern := range patterns {
+		if like {
+			ok, _ := path.Match(pattern, region)
+			matched = matched || ok
+		} else {
+			matched = matched || strings.EqualFold(pattern, region)
+		}
+	}
+	return matched != negated
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--sts-region-endpoint' command line option.
+
+package stsendpoint
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/spf13/pflag"
+)
+
+// Endpoint models supported by the flag:
+const (
+	Regional = "regional"
+	Legacy   = "legacy"
+)
+
+// AddFlag adds the STS endpoint flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&endpoint,
+		"sts-region-endpoint",
+		"",
+		fmt.Sprintf("STS endpoint model used to get credentials, either '%s' or '%s', overriding "+
+			"the AWS_STS_REGIONAL_ENDPOINTS environment variable. The '%s' model uses the "+
+			"endpoint of the region of the cluster, the '%s' model uses the global endpoint "+
+			"for the regions that supported it originally.", Regional, Legacy, Regional, Legacy),
+	)
+}
+
+// Endpoint returns the STS endpoint model selected by the user, or an empty string if the default
+// of the AWS SDK should be used.
+func Endpoint() string {
+	if endpoint != "" {
+		return strings.ToLower(endpoint)
+	}
+	return strings.ToLower(os.Getenv("AWS_STS_REGIONAL_ENDPOINTS"))
+}
+
+// Model returns the STS endpoint model selected by the user, as used by the AWS SDK.
+func Model() endpoints.STSRegionalEndpoint {
+	model, err := endpoints.GetSTSRegionalEndpoint(Endpoint())
+	if err != nil {
+		return endpoints.UnsetSTSEndpoint
+	}
+	return model
+}
+
+// Validate checks that the STS endpoint model selected by the user is supported.
+func Validate() error {
+	value := Endpoint()
+	if value != "" && value != Regional && value != Legacy {
+		return fmt.Errorf("Invalid STS endpoint model '%s', allowed values are [%s, %s]",
+			value, Regional, Legacy)
+	}
+	return nil
+}
+
+// endpoint is a string flag that indicates which STS endpoint model is being used.
+var endpoint string
@@ -27,3 +27,19 @@ const ClusterName = prefix + "cluster_name"
 
 // ClusterID is the name of the tag that will contain the identifier of the cluster.
 const ClusterID = prefix + "cluster_id"
+
+// RoleType is the name of the tag that will contain the type of an IAM role, like 'installer' or
+// 'operator'.
+const RoleType = prefix + "role_type"
+
+// RolePrefix is the name of the tag that will contain the prefix of the names of a set of IAM
+// roles created together.
+const RolePrefix = prefix + "role_prefix"
+
+// OpenShiftVersion is the name of the tag that will contain the OpenShift version that an IAM role
+// was created for.
+const OpenShiftVersion = prefix + "openshift_version"
+
+// EgressProbe is the name of the tag that marks the instances launched to check the egress of a
+// subnet, so that they can be found if the tool is interrupted before deleting them.
+const EgressProbe = prefix + "egress_probe"
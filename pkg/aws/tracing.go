@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the request handlers that generate a span for each call to the AWS API when
+// tracing is enabled. Retries of throttled requests are part of the span of the request.
+
+package aws
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/openshift/rosa/pkg/tracing"
+)
+
+// requestSpans contains the spans of the requests that are in progress.
+var requestSpans sync.Map
+
+// addTracingHandlers adds to the session the handlers that start a span when a request is sent
+// and end it when the request completes. It does nothing if tracing isn't enabled.
+func addTracingHandlers(sess *session.Session) {
+	if !tracing.Enabled() {
+		return
+	}
+	sess.Handlers.Validate.PushFrontNamed(request.NamedHandler{
+		Name: "rosa.tracing.start",
+		Fn:   startRequestSpan,
+	})
+	sess.Handlers.Complete.PushBackNamed(request.NamedHandler{
+		Name: "rosa.tracing.end",
+		Fn:   endRequestSpan,
+	})
+}
+
+func startRequestSpan(req *request.Request) {
+	span := tracing.StartSpan("AWS "+req.ClientInfo.ServiceName+"."+req.Operation.Name,
+		tracing.KindClient)
+	span.SetAttribute("rpc.system", "aws-api")
+	span.SetAttribute("rpc.service", req.ClientInfo.ServiceName)
+	span.SetAttribute("rpc.method", req.Operation.Name)
+	if req.Config.Region != nil {
+		span.SetAttribute("aws.region", *req.Config.Region)
+	}
+	requestSpans.Store(req, span)
+}
+
+func endRequestSpan(req *request.Request) {
+	value, ok := requestSpans.Load(req)
+	if !ok {
+		return
+	}
+	requestSpans.Delete(req)
+	span := value.(*tracing.Span)
+	if req.HTTPResponse != nil {
+		span.SetAttribute("http.status_code", req.HTTPResponse.StatusCode)
+	}
+	if req.RequestID != "" {
+		span.SetAttribute("aws.request_id", req.RequestID)
+	}
+	if req.RetryCount > 0 {
+		span.SetAttribute("aws.retry_count", req.RetryCount)
+	}
+	span.End(req.Error)
+}
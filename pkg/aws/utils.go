@@ -86,8 +86,8 @@ func buildUpdateStackInput(cfTemplateBody, stackName string) *cloudformation.Upd
 	}
 }
 
-// Read cloudformation template
-func readCFTemplate() (string, error) {
+// Read cloudformation template, adjusting the ARNs to the given partition
+func readCFTemplate(partition string) (string, error) {
 	cfTemplateBodyPath := "templates/cloudformation/iam_user_osdCcsAdmin.json"
 
 	cfTemplate, err := assets.Asset(cfTemplateBodyPath)
@@ -95,5 +95,5 @@ func readCFTemplate() (string, error) {
 		return "", fmt.Errorf("Unable to read cloudformation template: %s", err)
 	}
 
-	return string(cfTemplate), nil
+	return partitionTemplate(string(cfTemplate), partition), nil
 }
@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// Types of the zones of a region, as reported by EC2:
+const (
+	ZoneTypeAvailabilityZone = "availability-zone"
+	ZoneTypeLocalZone        = "local-zone"
+	ZoneTypeWavelengthZone   = "wavelength-zone"
+)
+
+// wavelengthGroupRE matches the names of the groups of wavelength zones, like
+// 'us-east-1-wl1-bos-wlz-1'.
+var wavelengthGroupRE = regexp.MustCompile(`-wl\d+-`)
+
+// GetZoneTypes returns the types of the zones of the given region, including the local and
+// wavelength zones that the account hasn't opted in to yet.
+func (c *awsClient) GetZoneTypes(region string) (map[string]bool, error) {
+	client := ec2.New(c.awsSession.Copy(&aws.Config{
+		Region: aws.String(region),
+	}))
+	output, err := client.DescribeAvailabilityZones(&ec2.DescribeAvailabilityZonesInput{
+		AllAvailabilityZones: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	types := map[string]bool{}
+	for _, zone := range output.AvailabilityZones {
+		types[zoneType(region, aws.StringValue(zone.GroupName))] = true
+	}
+	return types, nil
+}
+
+// zoneType returns the type of a zone from the name of its group, as the version of the SDK used
+// by the tool doesn't support the zone type attribute. Regular availability zones belong to the
+// group named like the region.
+func zoneType(region string, group string) string {
+	switch {
+	case group == region:
+		return ZoneTypeAvailabilityZone
+	case wavelengthGroupRE.MatchString(group):
+		return ZoneTypeWavelengthZone
+	default:
+		return ZoneTypeLocalZone
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to check, download and install the client tools that
+// are used together with the tool, like the OpenShift command-line tool.
+
+package clients
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ocMirrorURL is the base URL of the mirror that contains the releases of the OpenShift
+// command-line tool. It can be changed with the ROSA_OC_MIRROR_URL environment variable, for
+// example to use an internal mirror with the same layout.
+const ocMirrorURL = "https://mirror.openshift.com/pub/openshift-v4/clients/ocp"
+
+// timeout is the maximum time of each request.
+const timeout = 10 * time.Minute
+
+// ErrOCNotFound is returned when the OpenShift command-line tool isn't in the PATH.
+var ErrOCNotFound = errors.New("OpenShift command-line tool is not installed")
+
+// ocVersionRE extracts the client version from the output of 'oc version --client'. Old releases
+// print the version as part of a Go structure, like 'GitVersion:"v4.2.0-..."'.
+var ocVersionRE = regexp.MustCompile(`(?:Client Version: |GitVersion:")v?(\d+\.\d+[^\s"]*)`)
+
+// OCVersion returns the version of the OpenShift command-line tool found in the PATH.
+func OCVersion() (string, error) {
+	path, err := exec.LookPath(ocBinary())
+	if err != nil {
+		return "", ErrOCNotFound
+	}
+	// #nosec G204
+	output, err := exec.Command(path, "version", "--client").Output()
+	if len(output) == 0 && err != nil {
+		return "", fmt.Errorf("Failed to run '%s version': %v", path, err)
+	}
+	match := ocVersionRE.FindSubmatch(output)
+	if match == nil {
+		return "", fmt.Errorf("Failed to parse version of '%s'", path)
+	}
+	return string(match[1]), nil
+}
+
+// OCCompatible checks if the given version of the OpenShift command-line tool can be used with a
+// cluster that runs the given version. The client supports one minor version older or newer than
+// its own.
+func OCCompatible(clientVersion string, clusterVersion string) bool {
+	clientMajor, clientMinor, err := majorMinor(clientVersion)
+	if err != nil || clientMajor != 4 {
+		return false
+	}
+	if clusterVersion == "" {
+		return true
+	}
+	clusterMajor, clusterMinor, err := majorMinor(clusterVersion)
+	if err != nil {
+		return true
+	}
+	skew := clientMinor - clusterMinor
+	return clientMajor == clusterMajor && skew >= -1 && skew <= 1
+}
+
+// OCArchive returns the name of the archive that contains the OpenShift command-line tool for
+// the running operating system and architecture.
+func OCArchive() string {
+	platform := runtime.GOOS
+	if platform == "darwin" {
+		platform = "mac"
+	}
+	if runtime.GOARCH != "amd64" {
+		platform += "-" + runtime.GOARCH
+	}
+	extension := "tar.gz"
+	if runtime.GOOS == "windows" {
+		extension = "zip"
+	}
+	return fmt.Sprintf("openshift-client-%s.%s", platform, extension)
+}
+
+// OCURL returns the URL of the directory of the mirror that contains the given version of the
+// OpenShift command-line tool. An empty version means the latest one.
+func OCURL(version string) string {
+	base := os.Getenv("ROSA_OC_MIRROR_URL")
+	if base == "" {
+		base = ocMirrorURL
+	}
+	if version == "" {
+		version = "latest"
+	}
+	return fmt.Sprintf("%s/%s", strings.TrimSuffix(base, "/"), version)
+}
+
+// NewHTTPClient returns the HTTP client used to download the client tools. Requests go through
+// the proxy selected by the given function, usually http.ProxyFromEnvironment.
+func NewHTTPClient(selector func(*http.Request) (*url.URL, error)) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = selector
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}
+}
+
+// DownloadOC downloads the archive that contains the given version of the OpenShift command-line
+// tool to the given directory and verifies it against the checksums published in the mirror. The
+// downloaded data is also written to the progress writer, if any. It returns the path of the
+// archive.
+func DownloadOC(client *http.Client, version string, dir string, progress io.Writer) (string, error) {
+	name := OCArchive()
+	expected, err := fetchChecksum(client, OCURL(version)+"/sha256sum.txt", name)
+	if err != nil {
+		return "", err
+	}
+
+	// The archive is downloaded with a temporary name, so that an existing file isn't replaced
+	// until the download has been verified:
+	path := filepath.Join(dir, name)
+	out, err := os.Create(path + ".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(out.Name())
+	var writer io.Writer = out
+	if progress != nil {
+		writer = io.MultiWriter(out, progress)
+	}
+	actual, err := download(client, OCURL(version)+"/"+name, writer)
+	out.Close()
+	if err != nil {
+		return "", err
+	}
+	if actual != expected {
+		return "", fmt.Errorf("Checksum of '%s' is '%s', expected '%s'", name, actual, expected)
+	}
+	err = os.Rename(out.Name(), path)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// InstallOC extracts the OpenShift command-line tool from the given archive into the given
+// directory, and returns the path of the binary.
+func InstallOC(archive string, dir string) (string, error) {
+	err := os.MkdirAll(dir, 0755) // #nosec G301
+	if err != nil {
+		return "", err
+	}
+	binary := ocBinary()
+	path := filepath.Join(dir, binary)
+	if strings.HasSuffix(archive, ".zip") {
+		err = extractZip(archive, binary, path)
+	} else {
+		err = extractTar(archive, binary, path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Failed to extract '%s' from '%s': %v", binary, archive, err)
+	}
+	return path, nil
+}
+
+// InPath checks if the given directory is part of the PATH environment variable.
+func InPath(dir string) bool {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, item := range filepath.SplitList(os.Getenv("PATH")) {
+		item, err = filepath.Abs(item)
+		if err == nil && item == dir {
+			return true
+		}
+	}
+	return false
+}
+
+func ocBinary() string {
+	if runtime.GOOS == "windows" {
+		return "oc.exe"
+	}
+	return "oc"
+}
+
+func majorMinor(version string) (int, int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("Invalid version '%s'", version)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid version '%s'", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid version '%s'", version)
+	}
+	return major, minor, nil
+}
+
+// fetchChecksum returns the SHA-256 checksum of the given file from a checksum list in the format
+// generated by the sha256sum command.
+func fetchChecksum(client *http.Client, url string, name string) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download checksums: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to download checksums from '%s': %s", url, response.Status)
+	}
+	scanner := bufio.NewScanner(io.LimitReader(response.Body, 1<<20))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return "", fmt.Errorf("Failed to download checksums: %v", err)
+	}
+	return "", fmt.Errorf("There is no checksum for '%s' in '%s'", name, url)
+}
+
+// download writes the content of the given URL to the given writer, and returns its SHA-256
+// checksum.
+func download(client *http.Client, url string, out io.Writer) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download '%s': %v", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to download '%s': %s", url, response.Status)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(out, hash), response.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download '%s': %v", url, err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func extractTar(archive string, name string, path string) error {
+	file, err := os.Open(archive) // #nosec G304
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	decompressor, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer decompressor.Close()
+	reader := tar.NewReader(decompressor)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			return fmt.Errorf("File not found in archive")
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag == tar.TypeReg && filepath.Base(header.Name) == name {
+			return writeBinary(reader, path)
+		}
+	}
+}
+
+func extractZip(archive string, name string, path string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	for _, file := range reader.File {
+		if filepath.Base(file.Name) != name {
+			continue
+		}
+		in, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		return writeBinary(in, path)
+	}
+	return fmt.Errorf("File not found in archive")
+}
+
+// writeBinary writes the binary next to the destination and renames it, so that a running binary
+// with the same name isn't corrupted.
+func writeBinary(in io.Reader, path string) error {
+	tmp := path + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0755) // #nosec G302 G304
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+	// #nosec G110
+	_, err = io.Copy(out, in)
+	out.Close()
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -20,31 +20,36 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"regexp"
+	"strings"
 	"time"
 
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/info"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/clusters"
 	"github.com/openshift/rosa/pkg/ocm/properties"
+	"github.com/openshift/rosa/pkg/ocm/registries"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/validation"
 )
 
 // Regular expression to used to make sure that the identifier or name given by the user is
 // safe and that it there is no risk of SQL injection:
 var clusterKeyRE = regexp.MustCompile(`^(\w|-)+$`)
 
-// Cluster names must be valid DNS-1035 labels, so they must consist of lower case alphanumeric
-// characters or '-', start with an alphabetic character, and end with an alphanumeric character
-var clusterNameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,13}[a-z0-9])?$`)
-
 // Spec is the configuration for a cluster spec.
 type Spec struct {
 	// Basic configs
 	Name         string
+	BaseDomain   string
 	Region       string
 	MultiAZ      bool
 	Version      string
@@ -55,6 +60,7 @@ type Spec struct {
 	// Scaling config
 	ComputeMachineType string
 	ComputeNodes       int
+	ComputeLabels      map[string]string
 	Autoscaling        bool
 	MinReplicas        int
 	MaxReplicas        int
@@ -73,6 +79,37 @@ type Spec struct {
 	Private     *bool
 	PrivateLink *bool
 
+	// Role to assume in order to provision the cluster in a different AWS account
+	AssumeRole string
+
+	// Load balancer type of the default ingress, either 'classic' or 'nlb'
+	DefaultIngressLBType string
+
+	// Encryption of etcd, optionally with a customer managed KMS key
+	EtcdEncryption bool
+	KMSKeyARN      string
+
+	// Time to wait for pods to be drained when removing nodes
+	NodeDrainGracePeriod *time.Duration
+
+	// Billing model, and AWS account that pays for the cluster when it is billed through a
+	// marketplace
+	BillingModel   string
+	BillingAccount string
+
+	// Disable the monitoring of user workloads, for clusters that run their own Prometheus
+	DisableWorkloadMonitoring *bool
+
+	// Registries that images can or can't be pulled from, registries that don't need TLS, and
+	// registries that image streams can import from
+	AllowedRegistries          []string
+	BlockedRegistries          []string
+	InsecureRegistries         []string
+	AllowedRegistriesForImport []*registries.ImportRegistry
+
+	// Additional machine pools, created right after the cluster
+	MachinePools []*MachinePoolSpec
+
 	// Properties
 	CustomProperties map[string]string
 
@@ -88,7 +125,7 @@ func IsValidClusterKey(clusterKey string) bool {
 }
 
 func IsValidClusterName(clusterName string) bool {
-	return clusterNameRE.MatchString(clusterName)
+	return clusterName != "" && validation.ClusterName(clusterName) == nil
 }
 
 func HasClusters(client *cmv1.ClustersClient, creatorARN string) (bool, error) {
@@ -105,7 +142,9 @@ func HasClusters(client *cmv1.ClustersClient, creatorARN string) (bool, error) {
 	return response.Total() > 0, nil
 }
 
-func CreateCluster(client *cmv1.ClustersClient, config Spec) (*cmv1.Cluster, error) {
+func CreateCluster(connection *sdk.Connection, config Spec) (*cmv1.Cluster, error) {
+	client := connection.ClustersMgmt().V1().Clusters()
+
 	reporter, err := rprtr.New().
 		Build()
 
@@ -123,16 +162,48 @@ func CreateCluster(client *cmv1.ClustersClient, config Spec) (*cmv1.Cluster, err
 	awsClient, err := aws.NewClient().
 		Logger(logger).
 		Region(aws.DefaultRegion).
+		AssumeRole(config.AssumeRole).
 		Build()
 	if err != nil {
 		return nil, fmt.Errorf("Failed to create AWS client: %v", err)
 	}
 
-	spec, err := createClusterSpec(config, awsClient)
+	// The cluster belongs to the user that creates it and not to the assumed role, so that it can
+	// be found later without having to assume the role again:
+	creatorClient := awsClient
+	if config.AssumeRole != "" {
+		creatorClient, err = aws.NewClient().
+			Logger(logger).
+			Region(aws.DefaultRegion).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to create AWS client: %v", err)
+		}
+	}
+	creator, err := creatorClient.GetCreator()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get AWS creator: %v", err)
+	}
+
+	spec, err := createClusterSpec(config, awsClient, creator.ARN)
 	if err != nil {
 		return nil, fmt.Errorf("Unable to create cluster spec: %v", err)
 	}
 
+	// Attributes not supported yet by the SDK need to be sent with a raw request:
+	attributes := clusterAttributes(config)
+	if len(attributes) > 0 {
+		clusterObject, err := ocm.AddCluster(connection, spec, attributes, *config.DryRun)
+		if err != nil || clusterObject == nil {
+			return nil, err
+		}
+		err = awsClient.TagUser(aws.AdminUserName, clusterObject.ID(), clusterObject.Name())
+		if err != nil {
+			reporter.Warnf("Failed to add cluster tags to user '%s'", aws.AdminUserName)
+		}
+		return clusterObject, nil
+	}
+
 	cluster, err := client.Add().Parameter("dryRun", *config.DryRun).Body(spec).Send()
 	if config.DryRun != nil && *config.DryRun {
 		if cluster.Error() != nil {
@@ -155,56 +226,51 @@ func CreateCluster(client *cmv1.ClustersClient, config Spec) (*cmv1.Cluster, err
 }
 
 func GetClusters(client *cmv1.ClustersClient, creatorARN string, count int) (clusters []*cmv1.Cluster, err error) {
+	return GetClustersWithFilter(client, creatorARN, "", count)
+}
+
+// GetClustersWithFilter returns the clusters created by the given AWS ARN that also match the given
+// search query, if it isn't empty.
+func GetClustersWithFilter(client *cmv1.ClustersClient, creatorARN string, filter string,
+	count int) (clusters []*cmv1.Cluster, err error) {
 	if count < 1 {
 		err = errors.New("Cannot fetch fewer than 1 cluster")
 		return
 	}
 	query := fmt.Sprintf("properties.%s = '%s'", properties.CreatorARN, creatorARN)
+	if filter != "" {
+		query = fmt.Sprintf("%s and (%s)", query, filter)
+	}
 	request := client.List().Search(query)
-	page := 1
-	for {
-		response, err := request.Page(page).Size(count).Send()
+	paginator := &ocm.Paginator{Size: count}
+	err = paginator.Each(func(page int, size int) (int, bool, error) {
+		response, err := request.Page(page).Size(size).Send()
 		if err != nil {
-			return clusters, err
+			return 0, false, handleErr(response.Error(), err)
 		}
-		response.Items().Each(func(cluster *cmv1.Cluster) bool {
-			clusters = append(clusters, cluster)
-			return true
-		})
-		if response.Size() != count {
-			break
-		}
-		page++
-	}
-	return clusters, nil
+		clusters = append(clusters, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	return clusters, err
 }
 
-func GetCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN string) (*cmv1.Cluster, error) {
-	query := fmt.Sprintf(
-		"(id = '%s' or name = '%s') and properties.%s = '%s'",
-		clusterKey, clusterKey, properties.CreatorARN, creatorARN,
-	)
-	response, err := client.List().
-		Search(query).
-		Page(1).
-		Size(1).
-		Send()
-	if err != nil {
-		return nil, handleErr(response.Error(), err)
-	}
-
-	switch response.Total() {
-	case 0:
-		return nil, fmt.Errorf("There is no cluster with identifier or name '%s'", clusterKey)
-	case 1:
-		return response.Items().Slice()[0], nil
-	default:
-		return nil, fmt.Errorf("There are %d clusters with identifier or name '%s'", response.Total(), clusterKey)
-	}
+// GetAWSClusters returns all the AWS clusters visible to the current OCM user, regardless of the
+// AWS identity that created them.
+func GetAWSClusters(client *cmv1.ClustersClient) (clusters []*cmv1.Cluster, err error) {
+	request := client.List().Search("cloud_provider.id = 'aws'")
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := request.Page(page).Size(size).Send()
+		if err != nil {
+			return 0, false, handleErr(response.Error(), err)
+		}
+		clusters = append(clusters, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	return clusters, err
 }
 
 func UpdateCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN string, config Spec) error {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return err
 	}
@@ -248,6 +314,10 @@ func UpdateCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN st
 		}
 	}
 
+	if config.NodeDrainGracePeriod != nil {
+		clusterBuilder = clusterBuilder.NodeDrainGracePeriod(nodeDrainGracePeriodValue(*config.NodeDrainGracePeriod))
+	}
+
 	clusterSpec, err := clusterBuilder.Build()
 	if err != nil {
 		return err
@@ -262,7 +332,7 @@ func UpdateCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN st
 }
 
 func DeleteCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN string) (*cmv1.Cluster, error) {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return nil, err
 	}
@@ -275,6 +345,155 @@ func DeleteCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN st
 	return cluster, nil
 }
 
+// propertyKeyRE is the regular expression used to check the keys of properties used in search
+// queries, so that there is no risk of SQL injection.
+var propertyKeyRE = regexp.MustCompile(`^[\w.-]+$`)
+
+// PropertiesFilter returns the search query that selects the clusters that have all the given
+// properties, given as 'key=value' pairs.
+func PropertiesFilter(pairs []string) (string, error) {
+	terms := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || !propertyKeyRE.MatchString(parts[0]) {
+			return "", fmt.Errorf("Property '%s' isn't valid, it must be like 'key=value'", pair)
+		}
+		// Single quotes are escaped by doubling them, like in SQL:
+		value := strings.ReplaceAll(parts[1], "'", "''")
+		terms = append(terms, fmt.Sprintf("properties.%s = '%s'", parts[0], value))
+	}
+	return strings.Join(terms, " and "), nil
+}
+
+// WaitForInstallation polls the state of the cluster until it is ready or has failed, or until the
+// timeout expires. It returns the last state of the cluster.
+func WaitForInstallation(client *cmv1.ClustersClient, clusterID string, timeout time.Duration) (
+	cmv1.ClusterState, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		state, err := ocm.GetClusterState(client, clusterID)
+		if err != nil {
+			return state, err
+		}
+		if state == cmv1.ClusterStateReady || state == cmv1.ClusterStateError {
+			return state, nil
+		}
+		if time.Now().After(deadline) {
+			return state, fmt.Errorf("Cluster wasn't installed after %s, it is still in '%s' state",
+				timeout, state)
+		}
+		time.Sleep(installationPollInterval)
+	}
+}
+
+// installationPollInterval is the time between checks of the state of clusters being installed.
+const installationPollInterval = 30 * time.Second
+
+// WaitForComputeNodes polls the metrics of the cluster until the number of compute nodes reported
+// matches the number of replicas of the machine pools, or until the timeout expires. For machine
+// pools with autoscaling any number between the minimum and the maximum is accepted. It returns
+// the last number of compute nodes reported.
+func WaitForComputeNodes(client *cmv1.ClustersClient, clusterID string, timeout time.Duration) (int, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		response, err := client.Cluster(clusterID).Get().Send()
+		if err != nil {
+			return 0, handleErr(response.Error(), err)
+		}
+		cluster := response.Body()
+		machinePools, err := ocm.GetMachinePools(client, clusterID)
+		if err != nil {
+			return 0, err
+		}
+		min, max := expectedComputeNodes(cluster, machinePools)
+		current := cluster.Metrics().Nodes().Compute()
+		if current >= min && current <= max {
+			return current, nil
+		}
+		if time.Now().After(deadline) {
+			expected := fmt.Sprintf("%d", min)
+			if min != max {
+				expected = fmt.Sprintf("between %d and %d", min, max)
+			}
+			return current, fmt.Errorf("Cluster reports %d compute nodes after %s, expected %s",
+				current, timeout, expected)
+		}
+		time.Sleep(scalingPollInterval)
+	}
+}
+
+// scalingPollInterval is the time between checks of the compute nodes of clusters being scaled.
+const scalingPollInterval = 30 * time.Second
+
+// WaitForClusterDeleted polls the cluster until OCM no longer knows about it, which happens when
+// the deprovision of its resources has finished.
+func WaitForClusterDeleted(client *cmv1.ClustersClient, clusterID string) error {
+	for {
+		response, err := client.Cluster(clusterID).Get().Send()
+		if response != nil && response.Status() == http.StatusNotFound {
+			return nil
+		}
+		if err != nil {
+			return handleErr(response.Error(), err)
+		}
+		if response.Body().State() == cmv1.ClusterStateError {
+			return fmt.Errorf("Cluster '%s' is in error state", clusterID)
+		}
+		time.Sleep(deletionPollInterval)
+	}
+}
+
+// deletionPollInterval is the time between checks of clusters being deleted.
+const deletionPollInterval = 30 * time.Second
+
+// expectedComputeNodes returns the minimum and maximum number of compute nodes that the cluster
+// should have according to its default machine pool and its additional machine pools.
+func expectedComputeNodes(cluster *cmv1.Cluster, machinePools []*cmv1.MachinePool) (min, max int) {
+	add := func(autoscaling *cmv1.MachinePoolAutoscaling, replicas int) {
+		if autoscaling != nil {
+			min += autoscaling.MinReplicas()
+			max += autoscaling.MaxReplicas()
+			return
+		}
+		min += replicas
+		max += replicas
+	}
+	add(cluster.Nodes().AutoscaleCompute(), cluster.Nodes().Compute())
+	for _, machinePool := range machinePools {
+		add(machinePool.Autoscaling(), machinePool.Replicas())
+	}
+	return
+}
+
+// DeleteClusterByID deletes the cluster with the given identifier.
+func DeleteClusterByID(client *cmv1.ClustersClient, clusterID string) error {
+	response, err := client.Cluster(clusterID).Delete().Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// GetClusterCredentials returns the administrator credentials and kubeconfig of the cluster. Most
+// clusters don't expose them, in which case an error explaining it is returned.
+func GetClusterCredentials(client *cmv1.ClustersClient, clusterID string) (*cmv1.ClusterCredentials, error) {
+	response, err := client.Cluster(clusterID).Credentials().Get().Send()
+	if err != nil {
+		if response != nil &&
+			(response.Status() == http.StatusForbidden || response.Status() == http.StatusNotFound) {
+			return nil, fmt.Errorf("Credentials of the cluster aren't available to the current "+
+				"user. Use an identity provider or 'rosa create admin' instead: %v",
+				handleErr(response.Error(), err))
+		}
+		return nil, handleErr(response.Error(), err)
+	}
+	credentials := response.Body()
+	if credentials.Kubeconfig() == "" {
+		return nil, fmt.Errorf("Cluster doesn't have a kubeconfig, it may still be installing")
+	}
+	return credentials, nil
+}
+
 func GetAddOnParameters(client *cmv1.AddOnsClient, addOnID string) (*cmv1.AddOnParameterList, error) {
 	response, err := client.Addon(addOnID).Get().Send()
 	if err != nil {
@@ -290,7 +509,7 @@ type AddOnParam struct {
 
 func InstallAddOn(client *cmv1.ClustersClient, clusterKey string, creatorARN string, addOnID string,
 	params []AddOnParam) error {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return err
 	}
@@ -321,7 +540,7 @@ func InstallAddOn(client *cmv1.ClustersClient, clusterKey string, creatorARN str
 }
 
 func UninstallAddOn(client *cmv1.ClustersClient, clusterKey string, creatorARN string, addOnID string) error {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return err
 	}
@@ -336,7 +555,7 @@ func UninstallAddOn(client *cmv1.ClustersClient, clusterKey string, creatorARN s
 
 func GetAddOnInstallation(client *cmv1.ClustersClient, clusterKey string, creatorARN string,
 	addOnID string) (*cmv1.AddOnInstallation, error) {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return nil, err
 	}
@@ -351,7 +570,7 @@ func GetAddOnInstallation(client *cmv1.ClustersClient, clusterKey string, creato
 
 func UpdateAddOnInstallation(client *cmv1.ClustersClient, clusterKey string, creatorARN string, addOnID string,
 	params []AddOnParam) error {
-	cluster, err := GetCluster(client, clusterKey, creatorARN)
+	cluster, err := clusters.GetCluster(client, clusterKey, creatorARN)
 	if err != nil {
 		return err
 	}
@@ -383,7 +602,7 @@ func UpdateAddOnInstallation(client *cmv1.ClustersClient, clusterKey string, cre
 	return nil
 }
 
-func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error) {
+func createClusterSpec(config Spec, awsClient aws.Client, creatorARN string) (*cmv1.Cluster, error) {
 	reporter, err := rprtr.New().
 		Build()
 
@@ -422,7 +641,7 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 		return nil, fmt.Errorf("Custom properties key %s collides with a property needed by rosa", properties.CLIVersion)
 	}
 
-	clusterProperties[properties.CreatorARN] = awsCreator.ARN
+	clusterProperties[properties.CreatorARN] = creatorARN
 	clusterProperties[properties.CLIVersion] = info.Version
 
 	// Create the cluster:
@@ -440,6 +659,14 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 		).
 		Properties(clusterProperties)
 
+	if config.BaseDomain != "" {
+		clusterBuilder = clusterBuilder.DNS(
+			cmv1.NewDNS().
+				BaseDomain(config.BaseDomain),
+		)
+		reporter.Debugf("Using base DNS domain '%s'", config.BaseDomain)
+	}
+
 	if config.Flavour != "" {
 		clusterBuilder = clusterBuilder.Flavour(
 			cmv1.NewFlavour().
@@ -465,7 +692,7 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 	}
 
 	if config.ComputeMachineType != "" || config.ComputeNodes != 0 || len(config.AvailabilityZones) > 0 ||
-		config.Autoscaling {
+		config.Autoscaling || len(config.ComputeLabels) > 0 {
 		clusterNodesBuilder := cmv1.NewClusterNodes()
 		if config.ComputeMachineType != "" {
 			clusterNodesBuilder = clusterNodesBuilder.ComputeMachineType(
@@ -485,6 +712,9 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 		if len(config.AvailabilityZones) > 0 {
 			clusterNodesBuilder = clusterNodesBuilder.AvailabilityZones(config.AvailabilityZones...)
 		}
+		if len(config.ComputeLabels) > 0 {
+			clusterNodesBuilder = clusterNodesBuilder.ComputeLabels(config.ComputeLabels)
+		}
 		clusterBuilder = clusterBuilder.Nodes(clusterNodesBuilder)
 	}
 
@@ -540,6 +770,18 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 		}
 	}
 
+	if config.BillingModel != "" {
+		clusterBuilder = clusterBuilder.BillingModel(cmv1.BillingModel(config.BillingModel))
+	}
+
+	if config.EtcdEncryption || config.KMSKeyARN != "" {
+		clusterBuilder = clusterBuilder.EtcdEncryption(true)
+	}
+
+	if config.NodeDrainGracePeriod != nil {
+		clusterBuilder = clusterBuilder.NodeDrainGracePeriod(nodeDrainGracePeriodValue(*config.NodeDrainGracePeriod))
+	}
+
 	if config.DisableSCPChecks != nil && *config.DisableSCPChecks {
 		clusterBuilder = clusterBuilder.CCS(cmv1.NewCCS().
 			Enabled(true).
@@ -555,6 +797,109 @@ func createClusterSpec(config Spec, awsClient aws.Client) (*cmv1.Cluster, error)
 	return clusterSpec, nil
 }
 
+// MaxNodeDrainGracePeriod is the longest time that can be used as node drain grace period.
+const MaxNodeDrainGracePeriod = 7 * 24 * time.Hour
+
+// ValidateNodeDrainGracePeriod checks that the node drain grace period is a whole number of minutes
+// between zero and one week.
+func ValidateNodeDrainGracePeriod(period time.Duration) error {
+	if period < 0 || period > MaxNodeDrainGracePeriod {
+		return fmt.Errorf("Node drain grace period must be between 0 and %s, got %s",
+			MaxNodeDrainGracePeriod, period)
+	}
+	if period%time.Minute != 0 {
+		return fmt.Errorf("Node drain grace period must be a whole number of minutes, got %s", period)
+	}
+	return nil
+}
+
+// NodeDrainGracePeriod returns the grace period of the given value object, which the API stores
+// in minutes or hours.
+func NodeDrainGracePeriod(value *cmv1.Value) time.Duration {
+	switch value.Unit() {
+	case "hour", "hours":
+		return time.Duration(value.Value() * float64(time.Hour))
+	default:
+		return time.Duration(value.Value() * float64(time.Minute))
+	}
+}
+
+func nodeDrainGracePeriodValue(period time.Duration) *cmv1.ValueBuilder {
+	return cmv1.NewValue().
+		Unit("minutes").
+		Value(period.Minutes())
+}
+
+// clusterAttributes returns the attributes of the cluster that can't be set using the builders of
+// the SDK.
+func clusterAttributes(config Spec) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	if config.DefaultIngressLBType != "" {
+		attributes["ingresses"] = []interface{}{
+			map[string]interface{}{
+				"default":            true,
+				"load_balancer_type": config.DefaultIngressLBType,
+			},
+		}
+	}
+	awsAttributes := map[string]interface{}{}
+	if config.KMSKeyARN != "" {
+		awsAttributes["kms_key_arn"] = config.KMSKeyARN
+	}
+	if config.BillingAccount != "" {
+		awsAttributes["billing_account_id"] = config.BillingAccount
+	}
+	if len(awsAttributes) > 0 {
+		attributes["aws"] = awsAttributes
+	}
+	if config.DisableWorkloadMonitoring != nil {
+		attributes[disableWorkloadMonitoringAttribute] = *config.DisableWorkloadMonitoring
+	}
+	registrySources := map[string]interface{}{}
+	if len(config.AllowedRegistries) > 0 {
+		registrySources["allowed_registries"] = config.AllowedRegistries
+	}
+	if len(config.BlockedRegistries) > 0 {
+		registrySources["blocked_registries"] = config.BlockedRegistries
+	}
+	if len(config.InsecureRegistries) > 0 {
+		registrySources["insecure_registries"] = config.InsecureRegistries
+	}
+	registryConfig := map[string]interface{}{}
+	if len(registrySources) > 0 {
+		registryConfig["registry_sources"] = registrySources
+	}
+	if len(config.AllowedRegistriesForImport) > 0 {
+		registryConfig["allowed_registries_for_import"] = config.AllowedRegistriesForImport
+	}
+	if len(registryConfig) > 0 {
+		attributes["registry_config"] = registryConfig
+	}
+	return attributes
+}
+
+// disableWorkloadMonitoringAttribute is the attribute of the cluster that disables the monitoring
+// of user workloads. It isn't supported yet by the SDK.
+const disableWorkloadMonitoringAttribute = "disable_user_workload_monitoring"
+
+// SetWorkloadMonitoring enables or disables the monitoring of user workloads of the cluster.
+func SetWorkloadMonitoring(connection *sdk.Connection, clusterID string, disable bool) error {
+	return ocm.PatchAttributes(connection, ocm.ClusterPath(clusterID), map[string]interface{}{
+		disableWorkloadMonitoringAttribute: disable,
+	})
+}
+
+// IsWorkloadMonitoringDisabled checks if the monitoring of user workloads of the cluster is
+// disabled.
+func IsWorkloadMonitoringDisabled(connection *sdk.Connection, clusterID string) (bool, error) {
+	attributes, err := ocm.GetAttributes(connection, ocm.ClusterPath(clusterID))
+	if err != nil {
+		return false, err
+	}
+	disabled, _ := attributes[disableWorkloadMonitoringAttribute].(bool)
+	return disabled, nil
+}
+
 // nolint:interfacer
 func IsEmptyCIDR(cidr net.IPNet) bool {
 	return cidr.String() == "<nil>"
@@ -571,5 +916,5 @@ func handleErr(res *ocmerrors.Error, err error) error {
 			"Go to https://www.redhat.com/wapps/tnc/ackrequired?site=ocm&event=register\n" +
 			"Once you accept the terms, you will need to retry the action that was blocked."
 	}
-	return errors.New(msg)
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
 }
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to add machine pools to a cluster right after requesting
+// its creation.
+
+package cluster
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Regular expression to used to make sure that the identifier given by the user is safe and that
+// it there is no risk of SQL injection:
+var machinePoolKeyRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// MachinePoolSpec is the configuration of an additional machine pool, given in the command line as
+// a comma separated list of key=value pairs, for example 'name=gpu,type=p3.2xlarge,replicas=2'.
+type MachinePoolSpec struct {
+	Name         string
+	InstanceType string
+	Replicas     int
+	MinReplicas  int
+	MaxReplicas  int
+}
+
+// machinePoolSpecKeys are the keys accepted in the text of a machine pool spec.
+var machinePoolSpecKeys = []string{"name", "type", "replicas", "min-replicas", "max-replicas"}
+
+// ParseMachinePoolSpec parses the text of a machine pool spec. The 'name' and 'type' keys are
+// required, and either 'replicas' or both 'min-replicas' and 'max-replicas' for autoscaling.
+func ParseMachinePoolSpec(text string) (*MachinePoolSpec, error) {
+	spec := &MachinePoolSpec{}
+	values := map[string]string{}
+	for _, pair := range strings.Split(text, ",") {
+		tokens := strings.SplitN(pair, "=", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("Expected key=value format for machine pool option '%s'", pair)
+		}
+		key := strings.TrimSpace(tokens[0])
+		value := strings.TrimSpace(tokens[1])
+		if !isMachinePoolSpecKey(key) {
+			return nil, fmt.Errorf("Unknown machine pool option '%s', expected one of: %s",
+				key, strings.Join(machinePoolSpecKeys, ", "))
+		}
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("Machine pool option '%s' given more than once", key)
+		}
+		values[key] = value
+	}
+
+	spec.Name = values["name"]
+	if !machinePoolKeyRE.MatchString(spec.Name) {
+		return nil, fmt.Errorf("Expected a valid name for the machine pool in '%s'", text)
+	}
+	spec.InstanceType = values["type"]
+	if spec.InstanceType == "" {
+		return nil, fmt.Errorf("Expected an instance type for machine pool '%s'", spec.Name)
+	}
+
+	var err error
+	for key, target := range map[string]*int{
+		"replicas":     &spec.Replicas,
+		"min-replicas": &spec.MinReplicas,
+		"max-replicas": &spec.MaxReplicas,
+	} {
+		value, ok := values[key]
+		if !ok {
+			continue
+		}
+		*target, err = strconv.Atoi(value)
+		if err != nil || *target < 0 {
+			return nil, fmt.Errorf("Expected a non-negative number for '%s' of machine pool '%s'",
+				key, spec.Name)
+		}
+	}
+	_, hasReplicas := values["replicas"]
+	_, hasMin := values["min-replicas"]
+	_, hasMax := values["max-replicas"]
+	switch {
+	case hasReplicas && (hasMin || hasMax):
+		return nil, fmt.Errorf("Machine pool '%s' can't have both 'replicas' and autoscaling "+
+			"limits", spec.Name)
+	case hasMin != hasMax:
+		return nil, fmt.Errorf("Machine pool '%s' needs both 'min-replicas' and 'max-replicas' "+
+			"for autoscaling", spec.Name)
+	case hasMax && spec.MaxReplicas < 1:
+		return nil, fmt.Errorf("Machine pool '%s' needs 'max-replicas' greater than zero",
+			spec.Name)
+	case hasMin && spec.MinReplicas > spec.MaxReplicas:
+		return nil, fmt.Errorf("Machine pool '%s' has 'min-replicas' greater than 'max-replicas'",
+			spec.Name)
+	case !hasReplicas && !hasMin:
+		return nil, fmt.Errorf("Expected 'replicas' or 'min-replicas' and 'max-replicas' for "+
+			"machine pool '%s'", spec.Name)
+	}
+
+	return spec, nil
+}
+
+func isMachinePoolSpecKey(key string) bool {
+	for _, item := range machinePoolSpecKeys {
+		if item == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Autoscaling returns true if the machine pool uses autoscaling.
+func (s *MachinePoolSpec) Autoscaling() bool {
+	return s.MaxReplicas > 0
+}
+
+// String returns the text of the spec in the format accepted by ParseMachinePoolSpec.
+func (s *MachinePoolSpec) String() string {
+	if s.Autoscaling() {
+		return fmt.Sprintf("name=%s,type=%s,min-replicas=%d,max-replicas=%d",
+			s.Name, s.InstanceType, s.MinReplicas, s.MaxReplicas)
+	}
+	return fmt.Sprintf("name=%s,type=%s,replicas=%d", s.Name, s.InstanceType, s.Replicas)
+}
+
+// ValidateMachinePoolSpecs checks that the names of the machine pools are unique and that the
+// number of replicas is valid for the availability zones of the cluster.
+func ValidateMachinePoolSpecs(specs []*MachinePoolSpec, multiAZ bool) error {
+	names := map[string]bool{}
+	for _, spec := range specs {
+		if names[spec.Name] {
+			return fmt.Errorf("Machine pool '%s' given more than once", spec.Name)
+		}
+		names[spec.Name] = true
+		if multiAZ && (spec.Replicas%3 != 0 || spec.MinReplicas%3 != 0 || spec.MaxReplicas%3 != 0) {
+			return fmt.Errorf("Multi AZ clusters require that the number of replicas of machine "+
+				"pool '%s' be a multiple of 3", spec.Name)
+		}
+	}
+	return nil
+}
+
+// AddMachinePools adds the given machine pools to the cluster. It tries to add all of them even if
+// some fail, and returns the errors indexed by the name of the machine pool.
+func AddMachinePools(client *cmv1.ClustersClient, clusterID string,
+	specs []*MachinePoolSpec) map[string]error {
+	failures := map[string]error{}
+	for _, spec := range specs {
+		builder := cmv1.NewMachinePool().
+			ID(spec.Name).
+			InstanceType(spec.InstanceType)
+		if spec.Autoscaling() {
+			builder = builder.Autoscaling(
+				cmv1.NewMachinePoolAutoscaling().
+					MinReplicas(spec.MinReplicas).
+					MaxReplicas(spec.MaxReplicas))
+		} else {
+			builder = builder.Replicas(spec.Replicas)
+		}
+		machinePool, err := builder.Build()
+		if err != nil {
+			failures[spec.Name] = err
+			continue
+		}
+		response, err := client.Cluster(clusterID).
+			MachinePools().
+			Add().
+			Body(machinePool).
+			Send()
+		if err != nil {
+			failures[spec.Name] = handleErr(response.Error(), err)
+		}
+	}
+	return failures
+}
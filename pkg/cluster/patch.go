@@ -0,0 +1,139 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to validate the raw partial cluster documents given with
+// the '--patch' flag of the 'edit cluster' command.
+
+package cluster
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of the values of the fields that can be patched:
+const (
+	patchString = "string"
+	patchNumber = "number"
+	patchBool   = "boolean"
+)
+
+// patchFields are the mutable fields of the cluster that can be changed with a patch. Values are
+// either the kind of the field or the nested fields of an object.
+var patchFields = map[string]interface{}{
+	"display_name":         patchString,
+	"expiration_timestamp": patchString,
+	"api": map[string]interface{}{
+		"listening": patchString,
+	},
+	"node_drain_grace_period": map[string]interface{}{
+		"value": patchNumber,
+		"unit":  patchString,
+	},
+	disableWorkloadMonitoringAttribute: patchBool,
+	"nodes": map[string]interface{}{
+		"compute": patchNumber,
+		"autoscale_compute": map[string]interface{}{
+			"min_replicas": patchNumber,
+			"max_replicas": patchNumber,
+		},
+	},
+}
+
+// ParsePatch parses the given raw partial cluster document and checks that it only contains
+// mutable fields with values of the right kind.
+func ParsePatch(data []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var patch map[string]interface{}
+	err := decoder.Decode(&patch)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse patch: %v", err)
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("Failed to parse patch: expected a single JSON object")
+	}
+	if len(patch) == 0 {
+		return nil, fmt.Errorf("Patch doesn't contain any field")
+	}
+	var problems []string
+	checkPatch("", patch, patchFields, &problems)
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, fmt.Errorf("Invalid patch: %s. Fields that can be patched are: %s",
+			strings.Join(problems, ", "), strings.Join(PatchFields(), ", "))
+	}
+	return patch, nil
+}
+
+// PatchFields returns the paths of the fields that can be changed with a patch, sorted
+// alphabetically.
+func PatchFields() []string {
+	var paths []string
+	collectPatchFields("", patchFields, &paths)
+	sort.Strings(paths)
+	return paths
+}
+
+func checkPatch(prefix string, patch map[string]interface{}, allowed map[string]interface{},
+	problems *[]string) {
+	for name, value := range patch {
+		path := prefix + name
+		field, ok := allowed[name]
+		if !ok {
+			*problems = append(*problems, fmt.Sprintf("field '%s' can't be patched", path))
+			continue
+		}
+		switch kind := field.(type) {
+		case map[string]interface{}:
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				*problems = append(*problems, fmt.Sprintf("field '%s' must be an object", path))
+				continue
+			}
+			checkPatch(path+".", object, kind, problems)
+		case string:
+			if !patchKind(value, kind) {
+				*problems = append(*problems, fmt.Sprintf("field '%s' must be a %s", path, kind))
+			}
+		}
+	}
+}
+
+func patchKind(value interface{}, kind string) bool {
+	switch value.(type) {
+	case string:
+		return kind == patchString
+	case json.Number:
+		return kind == patchNumber
+	case bool:
+		return kind == patchBool
+	}
+	return false
+}
+
+func collectPatchFields(prefix string, fields map[string]interface{}, paths *[]string) {
+	for name, field := range fields {
+		if nested, ok := field.(map[string]interface{}); ok {
+			collectPatchFields(prefix+name+".", nested, paths)
+			continue
+		}
+		*paths = append(*paths, prefix+name)
+	}
+}
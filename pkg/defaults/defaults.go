@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package defaults contains the functions used to manage the default values of command line
+// flags stored in the '~/.rosa/config.yaml' file. Defaults can be global or specific to a
+// cluster, for example:
+//
+//	defaults:
+//	  region: us-east-1
+//	clusters:
+//	  mycluster:
+//	    defaults:
+//	      output: name
+//
+// The file also contains settings that aren't defaults of flags, like the webhooks notified by
+// long running commands:
+//
+//	notify:
+//	  webhooks:
+//	  - https://hooks.slack.com/services/...
+//
+// The defaults are applied to the flags by the arguments package.
+package defaults
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// KeyPrefix is the prefix of the keys used to set default values of flags, for example
+// 'default.region'.
+const KeyPrefix = "default."
+
+// Flags that select the cluster, used to find the cluster specific defaults.
+var clusterFlags = []string{"cluster", "cluster-name"}
+
+// Config is the content of the configuration file.
+type Config struct {
+	Defaults map[string]string         `json:"defaults,omitempty"`
+	Clusters map[string]*ClusterConfig `json:"clusters,omitempty"`
+	Notify   *NotifyConfig             `json:"notify,omitempty"`
+}
+
+// ClusterConfig contains the configuration specific to a cluster.
+type ClusterConfig struct {
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+// Load loads the configuration file. If the file doesn't exist it returns an empty configuration.
+func Load() (*Config, error) {
+	file, err := Location()
+	if err != nil {
+		return nil, err
+	}
+	cfg := &Config{}
+	// #nosec G304
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read config file '%s': %v", file, err)
+	}
+	err = yaml.Unmarshal(data, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse config file '%s': %v", file, err)
+	}
+	return cfg, nil
+}
+
+// Save saves the given configuration to the configuration file, creating the directory if needed.
+func Save(cfg *Config) error {
+	file, err := Location()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal config: %v", err)
+	}
+	err = os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return fmt.Errorf("Failed to create directory for config file '%s': %v", file, err)
+	}
+	err = ioutil.WriteFile(file, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write file '%s': %v", file, err)
+	}
+	return nil
+}
+
+// Location returns the location of the configuration file. It can be changed with the
+// 'ROSA_CONFIG' environment variable.
+func Location() (string, error) {
+	if file := os.Getenv("ROSA_CONFIG"); file != "" {
+		return file, nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".rosa", "config.yaml"), nil
+}
+
+// Get returns the defaults of the given cluster, or the global defaults if the cluster is empty.
+// The returned map can be modified, and is stored in the configuration.
+func (c *Config) Get(cluster string) map[string]string {
+	if cluster == "" {
+		if c.Defaults == nil {
+			c.Defaults = map[string]string{}
+		}
+		return c.Defaults
+	}
+	if c.Clusters == nil {
+		c.Clusters = map[string]*ClusterConfig{}
+	}
+	clusterConfig, ok := c.Clusters[cluster]
+	if !ok {
+		clusterConfig = &ClusterConfig{}
+		c.Clusters[cluster] = clusterConfig
+	}
+	if clusterConfig.Defaults == nil {
+		clusterConfig.Defaults = map[string]string{}
+	}
+	return clusterConfig.Defaults
+}
+
+// Prune removes the empty sections of the configuration, so that they aren't saved.
+func (c *Config) Prune() {
+	for name, clusterConfig := range c.Clusters {
+		if len(clusterConfig.Defaults) == 0 {
+			delete(c.Clusters, name)
+		}
+	}
+	if len(c.Clusters) == 0 {
+		c.Clusters = nil
+	}
+	if len(c.Defaults) == 0 {
+		c.Defaults = nil
+	}
+	if c.Notify != nil && len(c.Notify.Webhooks) == 0 && c.Notify.Template == "" {
+		c.Notify = nil
+	}
+}
+
+// ParseKey returns the name of the flag of a key like 'default.region'.
+func ParseKey(key string) (string, error) {
+	if !strings.HasPrefix(key, KeyPrefix) || key == KeyPrefix {
+		return "", fmt.Errorf("Invalid key '%s', keys must have the form '%s<flag>' or be one of '%s'",
+			key, KeyPrefix, strings.Join(SettingKeys, "', '"))
+	}
+	name := strings.TrimPrefix(key, KeyPrefix)
+	for _, clusterFlag := range clusterFlags {
+		if name == clusterFlag {
+			return "", fmt.Errorf("Flag '%s' can't have a default value", name)
+		}
+	}
+	return name, nil
+}
+
+// FindFlag returns a flag with the given name from any of the commands of the tree, so that the
+// value of a default can be checked before it is saved. It returns nil if there is no such flag.
+func FindFlag(root *cobra.Command, name string) *pflag.Flag {
+	flag := root.Flags().Lookup(name)
+	if flag == nil {
+		flag = root.PersistentFlags().Lookup(name)
+	}
+	if flag != nil {
+		return flag
+	}
+	for _, child := range root.Commands() {
+		flag = FindFlag(child, name)
+		if flag != nil {
+			return flag
+		}
+	}
+	return nil
+}
+
+// Keys returns the names of the flags of the given defaults, sorted alphabetically.
+func Keys(defaults map[string]string) []string {
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SelectedCluster returns the name or identifier of the cluster selected with the '--cluster' or
+// '--cluster-name' flags, or an empty string if no cluster is selected.
+func SelectedCluster(flags *pflag.FlagSet) string {
+	for _, name := range clusterFlags {
+		flag := flags.Lookup(name)
+		if flag != nil && flag.Value.String() != "" {
+			return flag.Value.String()
+		}
+	}
+	return ""
+}
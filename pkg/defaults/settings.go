@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the settings stored in the configuration file that aren't default values of
+// flags, like the webhooks notified by long running commands.
+
+package defaults
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Keys of the settings:
+const (
+	NotifyTemplateKey = "notify.template"
+	NotifyWebhooksKey = "notify.webhooks"
+)
+
+// SettingKeys are the keys of the settings, sorted alphabetically.
+var SettingKeys = []string{
+	NotifyTemplateKey,
+	NotifyWebhooksKey,
+}
+
+// NotifyConfig contains the webhooks notified when long running commands finish, in addition to
+// the ones given with the '--notify-webhook' flag, and the template used to render the payload
+// sent to them.
+type NotifyConfig struct {
+	Webhooks []string `json:"webhooks,omitempty"`
+	Template string   `json:"template,omitempty"`
+}
+
+// IsSetting checks if the given key is the key of a setting instead of the key of the default
+// value of a flag.
+func IsSetting(key string) bool {
+	for _, settingKey := range SettingKeys {
+		if key == settingKey {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSetting returns the value of the setting with the given key, or an empty string if it isn't
+// set. Lists are returned as comma separated values.
+func (c *Config) GetSetting(key string) string {
+	if c.Notify == nil {
+		return ""
+	}
+	switch key {
+	case NotifyTemplateKey:
+		return c.Notify.Template
+	case NotifyWebhooksKey:
+		return strings.Join(c.Notify.Webhooks, ",")
+	}
+	return ""
+}
+
+// SetSetting changes the value of the setting with the given key. Lists are given as comma
+// separated values.
+func (c *Config) SetSetting(key string, value string) error {
+	if c.Notify == nil {
+		c.Notify = &NotifyConfig{}
+	}
+	switch key {
+	case NotifyTemplateKey:
+		c.Notify.Template = value
+	case NotifyWebhooksKey:
+		c.Notify.Webhooks = nil
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item != "" {
+				c.Notify.Webhooks = append(c.Notify.Webhooks, item)
+			}
+		}
+	default:
+		return fmt.Errorf("Unknown setting '%s'", key)
+	}
+	return nil
+}
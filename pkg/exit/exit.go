@@ -0,0 +1,315 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the exit codes of the tool. The codes are stable, so that scripts and
+// wrappers can use them to decide what to do when a command fails, for example if it is worth
+// retrying it. The code is selected from the last error reported before the command fails: from
+// the code given explicitly with WithCode, else from the typed errors of the OCM and AWS APIs, and
+// only as a last resort from the text of the message.
+
+package exit
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// Exit codes of the tool:
+const (
+	Success    = 0
+	Error      = 1
+	Usage      = 2
+	Auth       = 3
+	NotFound   = 4
+	Validation = 5
+	Quota      = 6
+	Timeout    = 7
+	API        = 8
+)
+
+// Code describes an exit code of the tool.
+type Code struct {
+	Code        int    `json:"code"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Retryable indicates if running the same command again may succeed without changes.
+	Retryable bool `json:"retryable"`
+}
+
+// Codes contains the descriptions of all the exit codes, sorted by code.
+var Codes = []*Code{
+	{
+		Code:        Success,
+		Name:        "success",
+		Description: "The command finished successfully.",
+	},
+	{
+		Code:        Error,
+		Name:        "error",
+		Description: "The command failed for a reason not covered by the rest of the codes.",
+	},
+	{
+		Code:        Usage,
+		Name:        "usage",
+		Description: "The command line is wrong, like an unknown command or flag.",
+	},
+	{
+		Code:        Auth,
+		Name:        "auth",
+		Description: "The credentials are missing, wrong or expired, or don't allow the operation.",
+	},
+	{
+		Code:        NotFound,
+		Name:        "not-found",
+		Description: "A resource, like a cluster or a machine pool, doesn't exist.",
+	},
+	{
+		Code:        Validation,
+		Name:        "validation",
+		Description: "A value given by the user isn't valid.",
+	},
+	{
+		Code:        Quota,
+		Name:        "quota",
+		Description: "A quota of the OCM organization or of the AWS account has been exceeded.",
+	},
+	{
+		Code:        Timeout,
+		Name:        "timeout",
+		Description: "An operation didn't finish in time.",
+		Retryable:   true,
+	},
+	{
+		Code:        API,
+		Name:        "api",
+		Description: "The OCM or AWS API failed with a server error.",
+		Retryable:   true,
+	},
+}
+
+// patterns are the regular expressions that select the exit code of an error message, in the
+// order they are checked. HTTP status codes and server errors are checked first, as they are more
+// reliable than the words used to describe the failed operation, like in 'Failed to get quota: 503
+// Service Unavailable'.
+var patterns = []struct {
+	code    int
+	pattern *regexp.Regexp
+}{
+	{API, regexp.MustCompile(`internal server error|service unavailable|bad gateway|` +
+		`gateway time|\b50[0-4]\b`)},
+	{NotFound, regexp.MustCompile(`\b404\b`)},
+	{Auth, regexp.MustCompile(`\b40[13]\b`)},
+	{Timeout, regexp.MustCompile(`timeout|timed out|deadline exceeded`)},
+	{Auth, regexp.MustCompile(`not logged in|unauthori[sz]ed|forbidden|access ?denied|` +
+		`invalid token|token (is )?expired|expired token|credentials`)},
+	{Quota, regexp.MustCompile(`quota|limit ?exceeded|exceeds? the (maximum|limit)`)},
+	{NotFound, regexp.MustCompile(`not found|doesn't exist|does not exist|there is no`)},
+	{Validation, regexp.MustCompile(`expected|invalid|isn't valid|is not valid|must|required|` +
+		`can't be|cannot be|mutually exclusive|not supported|unknown`)},
+}
+
+// awsCodes are the exit codes that correspond to the error codes of the AWS API. Codes that
+// aren't here are classified using the HTTP status of the response.
+var awsCodes = map[string]int{
+	"AccessDenied":                    Auth,
+	"AccessDeniedException":           Auth,
+	"ExpiredToken":                    Auth,
+	"ExpiredTokenException":           Auth,
+	"InvalidClientTokenId":            Auth,
+	"NoCredentialProviders":           Auth,
+	"SignatureDoesNotMatch":           Auth,
+	"UnauthorizedOperation":           Auth,
+	"UnrecognizedClientException":     Auth,
+	"NoSuchEntity":                    NotFound,
+	"NotFoundException":               NotFound,
+	"NoSuchResourceException":         NotFound,
+	"LimitExceeded":                   Quota,
+	"LimitExceededException":          Quota,
+	"ServiceQuotaExceededException":   Quota,
+	"InvalidParameterValue":           Validation,
+	"MalformedPolicyDocument":         Validation,
+	"ValidationError":                 Validation,
+	"ValidationException":             Validation,
+	"DeleteConflict":                  Validation,
+	"EntityAlreadyExists":             Validation,
+	"RegionDisabledException":         Validation,
+	"RequestCanceled":                 Timeout,
+	"RequestTimeout":                  Timeout,
+	"RequestTimeoutException":         Timeout,
+	"RequestLimitExceeded":            API,
+	"Throttling":                      API,
+	"ThrottlingException":             API,
+	"TooManyRequestsException":        API,
+	"ConcurrentModificationException": API,
+	"InternalFailure":                 API,
+	"ServiceUnavailable":              API,
+	"ServiceUnavailableException":     API,
+}
+
+// ocmCodeRE extracts the HTTP status from the codes of the errors of the OCM API, like
+// 'CLUSTERS-MGMT-404'.
+var ocmCodeRE = regexp.MustCompile(`-(\d{3})$`)
+
+// codeError is an error that selects the exit code explicitly.
+type codeError struct {
+	code int
+	err  error
+}
+
+func (e *codeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codeError) Unwrap() error {
+	return e.err
+}
+
+// WithCode returns an error with the same message as the given one that selects the given exit
+// code when it is reported, regardless of the text of the message.
+func WithCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &codeError{
+		code: code,
+		err:  err,
+	}
+}
+
+// Wrap returns an error with the message of the given error that selects the exit code of the
+// cause. It is intended for helpers that replace the errors of the OCM and AWS APIs with more
+// friendly messages, so that the code isn't lost.
+func Wrap(err error, cause error) error {
+	code, ok := CodeOf(cause)
+	if !ok {
+		return err
+	}
+	return WithCode(code, err)
+}
+
+// CodeOf returns the exit code selected by the given error, either explicitly with WithCode or by
+// the typed errors of the OCM and AWS APIs that it wraps. The boolean result is false if the error
+// doesn't select a code, and then it should be classified from its message.
+func CodeOf(err error) (code int, ok bool) {
+	if err == nil {
+		return
+	}
+	var withCode *codeError
+	if errors.As(err, &withCode) {
+		return withCode.code, true
+	}
+	var ocmErr *ocmerrors.Error
+	if errors.As(err, &ocmErr) {
+		match := ocmCodeRE.FindStringSubmatch(ocmErr.Code())
+		if match != nil {
+			status, _ := strconv.Atoi(match[1])
+			return FromStatus(status)
+		}
+		return
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) {
+		code, ok = awsCodes[awsErr.Code()]
+		if ok {
+			return
+		}
+		var failure awserr.RequestFailure
+		if errors.As(err, &failure) {
+			return FromStatus(failure.StatusCode())
+		}
+	}
+	return
+}
+
+// FromStatus returns the exit code that corresponds to the given HTTP status. The boolean result
+// is false if the status isn't an error.
+func FromStatus(status int) (code int, ok bool) {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return Auth, true
+	case status == http.StatusNotFound:
+		return NotFound, true
+	case status == http.StatusRequestTimeout:
+		return Timeout, true
+	case status == http.StatusTooManyRequests || status >= 500 && status < 600:
+		return API, true
+	case status >= 400 && status < 500:
+		return Validation, true
+	}
+	return
+}
+
+var lock sync.Mutex
+var code = Error
+var message string
+var hooks []func(code int, message string)
+
+// Classify returns the exit code that corresponds to the given error message. It is the last
+// resort when the error doesn't select a code with CodeOf.
+func Classify(message string) int {
+	message = strings.ToLower(message)
+	for _, item := range patterns {
+		if item.pattern.MatchString(message) {
+			return item.code
+		}
+	}
+	return Error
+}
+
+// Record selects the exit code used by Fail from the given error, or from the error message if
+// the error is nil or doesn't select a code. It is called by the reporter for each error, so the
+// last error reported decides the code.
+func Record(text string, err error) {
+	value, ok := CodeOf(err)
+	if !ok {
+		value = Classify(text)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	code = value
+	message = text
+}
+
+// OnExit registers a function that Fail calls before terminating the process, with the exit code
+// and the message of the last error reported. Hooks are called in the order they were registered.
+func OnExit(hook func(code int, message string)) {
+	lock.Lock()
+	defer lock.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// Fail terminates the process with the exit code of the last error reported.
+func Fail() {
+	lock.Lock()
+	value := code
+	text := message
+	pending := hooks
+	hooks = nil
+	lock.Unlock()
+	for _, hook := range pending {
+		hook(value, text)
+	}
+	os.Exit(value)
+}
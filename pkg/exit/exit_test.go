@@ -0,0 +1,109 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package exit_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+)
+
+var _ = Describe("Exit", func() {
+	Context("Classify", func() {
+		cases := []struct {
+			message string
+			code    int
+		}{
+			{"Failed to get quota: 503 Service Unavailable", exit.API},
+			{"Failed to get quota: internal server error", exit.API},
+			{"Failed to get AWS credentials: 404 Not Found", exit.NotFound},
+			{"Failed to get cluster: 403 Forbidden", exit.Auth},
+			{"Not logged in, run the 'rosa login' command", exit.Auth},
+			{"Failed to get AWS credentials", exit.Auth},
+			{"Timed out waiting for cluster 'mycluster'", exit.Timeout},
+			{"The quota of compute nodes has been exceeded", exit.Quota},
+			{"There is no cluster with identifier or name 'mycluster'", exit.NotFound},
+			{"Invalid cluster name 'My_Cluster'", exit.Validation},
+			{"Something went wrong", exit.Error},
+		}
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("classifies '%s'", c.message), func() {
+				Expect(exit.Classify(c.message)).To(Equal(c.code))
+			})
+		}
+	})
+
+	Context("CodeOf", func() {
+		It("returns the explicit code", func() {
+			err := exit.WithCode(exit.Quota, errors.New("Failed to create cluster: 503"))
+			Expect(err.Error()).To(Equal("Failed to create cluster: 503"))
+			code, ok := exit.CodeOf(err)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(exit.Quota))
+		})
+
+		It("uses the status of the errors of the OCM API", func() {
+			cause, err := ocmerrors.NewError().
+				Code("CLUSTERS-MGMT-404").
+				Reason("Invalid credentials of cluster 'mycluster'").
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			code, ok := exit.CodeOf(exit.Wrap(errors.New(cause.Reason()), cause))
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(exit.NotFound))
+		})
+
+		It("ignores codes of the OCM API without status", func() {
+			cause, err := ocmerrors.NewError().
+				Code("ACCOUNT-MGMT-11").
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			_, ok := exit.CodeOf(cause)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("uses the codes of the errors of the AWS API", func() {
+			cause := awserr.NewRequestFailure(
+				awserr.New("Throttling", "Rate exceeded", nil), 400, "my-request")
+			code, ok := exit.CodeOf(cause)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(exit.API))
+		})
+
+		It("uses the status of the errors of the AWS API with unknown codes", func() {
+			cause := awserr.NewRequestFailure(
+				awserr.New("SomethingElse", "Forbidden", nil), 403, "my-request")
+			code, ok := exit.CodeOf(cause)
+			Expect(ok).To(BeTrue())
+			Expect(code).To(Equal(exit.Auth))
+		})
+
+		It("doesn't select a code for other errors", func() {
+			_, ok := exit.CodeOf(errors.New("Invalid credentials"))
+			Expect(ok).To(BeFalse())
+			_, ok = exit.CodeOf(nil)
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
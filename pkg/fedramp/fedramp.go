@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the details of the FedRAMP environment of OCM, used to manage clusters in
+// the AWS GovCloud regions. It has its own SSO server and API gateway, and not all the features of
+// the commercial environment are available in it.
+
+package fedramp
+
+import (
+	"net/url"
+	"strings"
+)
+
+// URLAliases maps the values of the '--env' option to the API URLs of the FedRAMP environment.
+var URLAliases = map[string]string{
+	"production":  "https://api.openshiftusgov.com",
+	"staging":     "https://api.stage.openshiftusgov.com",
+	"integration": "https://api.int.openshiftusgov.com",
+}
+
+// TokenURL is the URL of the SSO server of the FedRAMP environment used to request tokens.
+// #nosec G101
+const TokenURL = "https://sso.openshiftusgov.com/realms/redhat-external/protocol/openid-connect/token"
+
+// ClientID is the OpenID client identifier used with the SSO server of the FedRAMP environment.
+const ClientID = "console-dot"
+
+// TokenPage is the page where users of the FedRAMP environment can get a new offline access token.
+// #nosec G101
+const TokenPage = "https://console.openshiftusgov.com/openshift/token"
+
+// issuerDomain is the domain of the SSO servers of the FedRAMP environment.
+const issuerDomain = "openshiftusgov.com"
+
+// IsIssuer checks if the given token issuer is an SSO server of the FedRAMP environment.
+func IsIssuer(issuer string) bool {
+	parsed, err := url.Parse(issuer)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	return host == issuerDomain || strings.HasSuffix(host, "."+issuerDomain)
+}
+
+// unsupported contains the commands that can't be used in the FedRAMP environment, with the
+// reason. Subcommands of these commands can't be used either.
+var unsupported = map[string]string{
+	"rosa init": "it creates an IAM user with long lived credentials, and clusters in the " +
+		"FedRAMP environment need to use AWS STS",
+	"rosa create cluster": "clusters in the FedRAMP environment need to use AWS STS, create them " +
+		"from the console",
+	"rosa install addon":   "add-ons aren't available in the FedRAMP environment",
+	"rosa uninstall addon": "add-ons aren't available in the FedRAMP environment",
+	"rosa list addons":     "add-ons aren't available in the FedRAMP environment",
+}
+
+// Unsupported returns the reason why the command with the given path, like 'rosa create cluster',
+// can't be used in the FedRAMP environment, or an empty string if it can be used.
+func Unsupported(commandPath string) string {
+	for path, reason := range unsupported {
+		if commandPath == path || strings.HasPrefix(commandPath, path+" ") {
+			return reason
+		}
+	}
+	return ""
+}
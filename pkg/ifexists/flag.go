@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--if-not-exists' and '--update-if-exists'
+// command line options, that make the create commands safe to run again with the same
+// parameters, for example from declarative automation.
+
+package ifexists
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// AddFlags adds the flags that control what happens when the resource already exists to the
+// given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&ifNotExists,
+		"if-not-exists",
+		false,
+		"Do nothing and exit successfully if a resource with the same name already exists.",
+	)
+	flags.BoolVar(
+		&updateIfExists,
+		"update-if-exists",
+		false,
+		"Update the resource to the requested configuration if a resource with the same name "+
+			"already exists.",
+	)
+}
+
+// Validate checks that the flags aren't used together.
+func Validate() error {
+	if ifNotExists && updateIfExists {
+		return fmt.Errorf("Options '--if-not-exists' and '--update-if-exists' can't be used together")
+	}
+	return nil
+}
+
+// Skip returns a boolean flag that indicates if nothing should be done when the resource already
+// exists.
+func Skip() bool {
+	return ifNotExists
+}
+
+// Update returns a boolean flag that indicates if the resource should be updated when it already
+// exists.
+func Update() bool {
+	return updateIfExists
+}
+
+// Enabled returns a boolean flag that indicates if an existing resource is acceptable.
+func Enabled() bool {
+	return ifNotExists || updateIfExists
+}
+
+// ifNotExists is a boolean flag that indicates if existing resources should be left unchanged.
+var ifNotExists bool
+
+// updateIfExists is a boolean flag that indicates if existing resources should be updated.
+var updateIfExists bool
@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the checks of the wildcard DNS record and of the serving certificate of
+// the routers of a cluster, run from the host where the tool runs.
+
+package ingress
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// checkHost is the name prepended to the domain of the router to check the wildcard record, as
+// no route is expected to exist with that name.
+const checkHost = "rosa-ingress-check"
+
+// timeout is the maximum time spent resolving names or connecting to the router.
+const timeout = 10 * time.Second
+
+// DNSResult is the result of resolving the wildcard DNS record of a router.
+type DNSResult struct {
+	Host  string
+	CNAME string
+	IPs   []string
+}
+
+// CertificateResult is the result of checking the certificate served by a router.
+type CertificateResult struct {
+	Subject   string
+	Issuer    string
+	DNSNames  []string
+	NotAfter  time.Time
+	Trusted   bool
+	TrustErr  error
+	MatchesDN bool
+}
+
+// ExpiresIn returns the time remaining until the certificate expires.
+func (r *CertificateResult) ExpiresIn(now time.Time) time.Duration {
+	return r.NotAfter.Sub(now)
+}
+
+// WildcardHost returns the host name used to check the wildcard DNS record and the certificate of
+// the router that serves the given domain.
+func WildcardHost(domain string) string {
+	return fmt.Sprintf("%s.%s", checkHost, strings.TrimSuffix(domain, "."))
+}
+
+// ResolveDNS resolves the canonical name and the addresses of the given host.
+func ResolveDNS(host string) (*DNSResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var resolver net.Resolver
+	result := &DNSResult{
+		Host: host,
+	}
+	cname, err := resolver.LookupCNAME(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to resolve '%s': %v", host, err)
+	}
+	if !strings.EqualFold(strings.TrimSuffix(cname, "."), host) {
+		result.CNAME = strings.TrimSuffix(cname, ".")
+	}
+	addrs, err := resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to resolve '%s': %v", host, err)
+	}
+	result.IPs = addrs
+	return result, nil
+}
+
+// CheckCertificate connects to port 443 of the given host and returns the details of the
+// certificate that it serves. Certificates that aren't trusted by the host are also returned, with
+// the reason why verification failed.
+func CheckCertificate(host string) (*CertificateResult, error) {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+	address := net.JoinHostPort(host, "443")
+
+	// Connect without verification first, so that the details of the certificate can be
+	// reported even when it isn't trusted:
+	// #nosec G402
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to connect to '%s': %v", address, err)
+	}
+	defer conn.Close()
+	certs := conn.ConnectionState().PeerCertificates
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("Router at '%s' didn't present a certificate", address)
+	}
+	leaf := certs[0]
+	result := &CertificateResult{
+		Subject:   leaf.Subject.CommonName,
+		Issuer:    leaf.Issuer.CommonName,
+		DNSNames:  leaf.DNSNames,
+		NotAfter:  leaf.NotAfter,
+		MatchesDN: leaf.VerifyHostname(host) == nil,
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Intermediates: intermediates,
+	})
+	result.Trusted = err == nil
+	result.TrustErr = err
+	return result, nil
+}
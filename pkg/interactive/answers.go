@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to save the answers given in interactive mode to a file
+// and to use them again in later sessions. Answers are indexed by question, and only the questions
+// that don't have an answer in the file are asked. Passwords are never saved.
+
+package interactive
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// loadedAnswers contains the answers read from the answers file, indexed by question.
+var loadedAnswers map[string]interface{}
+
+// givenAnswers contains the answers given during this session, including the ones taken from the
+// answers file, indexed by question.
+var givenAnswers = map[string]interface{}{}
+
+// LoadAnswers reads the answers file with the given path. The answers in it are used instead of
+// asking the corresponding questions.
+func LoadAnswers(path string) error {
+	// #nosec G304
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Failed to read answers file '%s': %v", path, err)
+	}
+	answers := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &answers)
+	if err != nil {
+		return fmt.Errorf("Failed to parse answers file '%s': %v", path, err)
+	}
+	loadedAnswers = answers
+	return nil
+}
+
+// SaveAnswers writes the answers given during this session to the answers file with the given
+// path, so that they can be used again with LoadAnswers.
+func SaveAnswers(path string) error {
+	data, err := yaml.Marshal(givenAnswers)
+	if err != nil {
+		return fmt.Errorf("Failed to marshal answers: %v", err)
+	}
+	err = ioutil.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write answers file '%s': %v", path, err)
+	}
+	return nil
+}
+
+// record remembers the answer given to the question of the input, so that it can be saved.
+func record(input Input, answer interface{}) {
+	givenAnswers[input.Question] = answer
+}
+
+// loadedAnswer returns the answer to the question of the input from the answers file, if any.
+func loadedAnswer(input Input) (interface{}, bool) {
+	answer, ok := loadedAnswers[input.Question]
+	return answer, ok
+}
+
+// checkAnswer applies the checks of the input, and the given additional validators, to an answer
+// taken from the answers file, the same way that they are applied to the answers typed by the user.
+func checkAnswer(input Input, answer string, validators ...Validator) error {
+	if input.Required && answer == "" {
+		return fmt.Errorf("Answer to '%s' in answers file is required", input.Question)
+	}
+	if answer != "" && len(input.Options) > 0 && !containsOption(input.Options, answer) {
+		return fmt.Errorf("Answer '%s' to '%s' in answers file isn't valid, expected one of '%s'",
+			answer, input.Question, strings.Join(input.Options, "', '"))
+	}
+	for _, validator := range append(validators, input.Validators...) {
+		err := validator(answer)
+		if err != nil {
+			return fmt.Errorf("Answer to '%s' in answers file isn't valid: %v", input.Question, err)
+		}
+	}
+	return nil
+}
+
+func containsOption(options []string, value string) bool {
+	for _, option := range options {
+		if option == value {
+			return true
+		}
+	}
+	return false
+}
+
+// The following functions convert the answers of the file, which can have any of the types of
+// YAML values, to the type of the question.
+
+func answerString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
+
+func answerStrings(value interface{}) []string {
+	switch typed := value.(type) {
+	case nil:
+		return []string{}
+	case []interface{}:
+		result := make([]string, 0, len(typed))
+		for _, item := range typed {
+			result = append(result, answerString(item))
+		}
+		return result
+	default:
+		return []string{answerString(typed)}
+	}
+}
+
+func answerBool(input Input, value interface{}) (bool, error) {
+	switch typed := value.(type) {
+	case bool:
+		return typed, nil
+	case string:
+		result, err := strconv.ParseBool(typed)
+		if err == nil {
+			return result, nil
+		}
+	}
+	return false, fmt.Errorf("Answer to '%s' in answers file must be 'true' or 'false'", input.Question)
+}
@@ -29,11 +29,28 @@ import (
 )
 
 type Input struct {
-	Question string
-	Help     string
-	Options  []string
-	Default  interface{}
-	Required bool
+	Question   string
+	Help       string
+	Options    []string
+	Default    interface{}
+	Required   bool
+	Validators []Validator
+}
+
+// Validator checks an answer as soon as it is entered, so that the user can fix it right away.
+type Validator func(answer interface{}) error
+
+// askOptions returns the survey options that apply the validators of the input, plus the given
+// additional validators.
+func askOptions(input Input, validators ...Validator) []survey.AskOpt {
+	var options []survey.AskOpt
+	if input.Required {
+		options = append(options, survey.WithValidator(survey.Required))
+	}
+	for _, validator := range append(validators, input.Validators...) {
+		options = append(options, survey.WithValidator(survey.Validator(validator)))
+	}
+	return options
 }
 
 // Gets user input from the command line
@@ -47,6 +64,14 @@ func GetInput(q string) (a string, err error) {
 
 // Gets string input from the command line
 func GetString(input Input) (a string, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		a = answerString(answer)
+		err = checkAnswer(input, a)
+		if err == nil {
+			record(input, a)
+		}
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
@@ -60,16 +85,18 @@ func GetString(input Input) (a string, err error) {
 		Help:    input.Help,
 		Default: dflt,
 	}
-	if input.Required {
-		err = survey.AskOne(prompt, &a, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &a)
+	err = survey.AskOne(prompt, &a, askOptions(input)...)
+	if err == nil {
+		record(input, a)
 	}
 	return
 }
 
 // Gets int number input from the command line
 func GetInt(input Input) (a int, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		return intAnswer(input, answerString(answer))
+	}
 	dflt, ok := input.Default.(int)
 	if !ok {
 		dflt = 0
@@ -88,18 +115,35 @@ func GetInt(input Input) (a int, err error) {
 		Default: dfltStr,
 	}
 	var str string
-	if input.Required {
-		err = survey.AskOne(prompt, &str, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &str)
+	err = survey.AskOne(prompt, &str, askOptions(input, intValidator)...)
+	if err != nil {
+		return
 	}
+	if str == "" {
+		record(input, nil)
+		return
+	}
+	a, err = parseInt(str)
+	if err == nil {
+		record(input, a)
+	}
+	return
+}
+
+func intAnswer(input Input, str string) (a int, err error) {
+	err = checkAnswer(input, str, intValidator)
 	if err != nil {
 		return
 	}
 	if str == "" {
+		record(input, nil)
 		return
 	}
-	return parseInt(str)
+	a, err = parseInt(str)
+	if err == nil {
+		record(input, a)
+	}
+	return
 }
 
 func parseInt(str string) (num int, err error) {
@@ -108,6 +152,9 @@ func parseInt(str string) (num int, err error) {
 
 // Gets float number input from the command line
 func GetFloat(input Input) (a float64, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		return floatAnswer(input, answerString(answer))
+	}
 	dflt, ok := input.Default.(float64)
 	if !ok {
 		dflt = 0
@@ -126,18 +173,37 @@ func GetFloat(input Input) (a float64, err error) {
 		Default: dfltStr,
 	}
 	var str string
-	if input.Required {
-		err = survey.AskOne(prompt, &str, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &str)
+	err = survey.AskOne(prompt, &str, askOptions(input)...)
+	if err != nil {
+		return
+	}
+	if str == "" {
+		record(input, nil)
+		return
+	}
+	a, err = parseFloat(str)
+	if err == nil {
+		record(input, a)
 	}
+	return
+}
+
+func floatAnswer(input Input, str string) (a float64, err error) {
+	err = checkAnswer(input, str)
 	if err != nil {
 		return
 	}
 	if str == "" {
+		record(input, nil)
 		return
 	}
-	return parseFloat(str)
+	a, err = parseFloat(str)
+	if err != nil {
+		err = fmt.Errorf("Answer to '%s' in answers file isn't a number: %v", input.Question, err)
+		return
+	}
+	record(input, a)
+	return
 }
 
 func parseFloat(str string) (num float64, err error) {
@@ -148,6 +214,20 @@ func parseFloat(str string) (num float64, err error) {
 func GetMultipleOptions(input Input) ([]string, error) {
 	var err error
 	res := make([]string, 0)
+	if answer, ok := loadedAnswer(input); ok {
+		res = answerStrings(answer)
+		if input.Required && len(res) == 0 {
+			return res, fmt.Errorf("Answer to '%s' in answers file is required", input.Question)
+		}
+		for _, value := range res {
+			err = checkAnswer(input, value)
+			if err != nil {
+				return res, err
+			}
+		}
+		record(input, res)
+		return res, nil
+	}
 	dflt, ok := input.Default.([]string)
 	if !ok {
 		dflt = []string{}
@@ -163,16 +243,23 @@ func GetMultipleOptions(input Input) ([]string, error) {
 		Default: dflt,
 	}
 
-	if input.Required {
-		err = survey.AskOne(prompt, &res, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &res)
+	err = survey.AskOne(prompt, &res, askOptions(input)...)
+	if err == nil {
+		record(input, res)
 	}
 	return res, err
 }
 
 // Asks for option selection in the command line
 func GetOption(input Input) (a string, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		a = answerString(answer)
+		err = checkAnswer(input, a)
+		if err == nil {
+			record(input, a)
+		}
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
@@ -187,16 +274,22 @@ func GetOption(input Input) (a string, err error) {
 		Options: input.Options,
 		Default: dflt,
 	}
-	if input.Required {
-		err = survey.AskOne(prompt, &a, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &a)
+	err = survey.AskOne(prompt, &a, askOptions(input)...)
+	if err == nil {
+		record(input, a)
 	}
 	return
 }
 
 // Asks for true/false value in the command line
 func GetBool(input Input) (a bool, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		a, err = answerBool(input, answer)
+		if err == nil {
+			record(input, a)
+		}
+		return
+	}
 	dflt, ok := input.Default.(bool)
 	if !ok {
 		dflt = false
@@ -215,11 +308,23 @@ func GetBool(input Input) (a bool, err error) {
 	} else {
 		err = survey.AskOne(prompt, &a)
 	}
+	if err == nil {
+		record(input, a)
+	}
 	return
 }
 
 // Asks for CIDR value in the command line
 func GetIPNet(input Input) (a net.IPNet, err error) {
+	var str string
+	if answer, ok := loadedAnswer(input); ok {
+		str = answerString(answer)
+		err = checkAnswer(input, str, cidrValidator)
+		if err != nil {
+			return
+		}
+		return parseIPNet(input, str)
+	}
 	dflt, ok := input.Default.(net.IPNet)
 	if !ok {
 		dflt = net.IPNet{}
@@ -237,16 +342,16 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 		Help:    input.Help,
 		Default: dfltStr,
 	}
-	var str string
-	if input.Required {
-		err = survey.AskOne(prompt, &str, survey.WithValidator(survey.Required))
-	} else {
-		err = survey.AskOne(prompt, &str)
-	}
+	err = survey.AskOne(prompt, &str, askOptions(input, cidrValidator)...)
 	if err != nil {
 		return
 	}
+	return parseIPNet(input, str)
+}
+
+func parseIPNet(input Input, str string) (a net.IPNet, err error) {
 	if str == "" {
+		record(input, nil)
 		return
 	}
 	_, cidr, err := net.ParseCIDR(str)
@@ -256,6 +361,7 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 	if cidr != nil {
 		a = *cidr
 	}
+	record(input, a.String())
 	return
 }
 
@@ -279,6 +385,14 @@ func GetPassword(input Input) (a string, err error) {
 
 // Gets path to certificate file from the command line
 func GetCert(input Input) (a string, err error) {
+	if answer, ok := loadedAnswer(input); ok {
+		a = answerString(answer)
+		err = checkAnswer(input, a, certValidator)
+		if err == nil {
+			record(input, a)
+		}
+		return
+	}
 	dflt, ok := input.Default.(string)
 	if !ok {
 		dflt = ""
@@ -297,9 +411,40 @@ func GetCert(input Input) (a string, err error) {
 	} else {
 		err = survey.AskOne(prompt, &a, survey.WithValidator(certValidator))
 	}
+	if err == nil {
+		record(input, a)
+	}
 	return
 }
 
+// intValidator validates whether the given answer is a whole number
+func intValidator(answer interface{}) error {
+	if s, ok := answer.(string); ok {
+		if s == "" {
+			return nil
+		}
+		if _, err := strconv.Atoi(s); err != nil {
+			return fmt.Errorf("'%s' is not a whole number", s)
+		}
+		return nil
+	}
+	return fmt.Errorf("can only validate strings, got %v", answer)
+}
+
+// cidrValidator validates whether the given answer is a block of IP addresses in CIDR notation
+func cidrValidator(answer interface{}) error {
+	if s, ok := answer.(string); ok {
+		if s == "" {
+			return nil
+		}
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("'%s' is not a valid CIDR, for example '10.0.0.0/16'", s)
+		}
+		return nil
+	}
+	return fmt.Errorf("can only validate strings, got %v", answer)
+}
+
 // certValidator validates whether the given filepath is a valid cert file
 func certValidator(filepath interface{}) error {
 	if filepath == nil {
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to merge the kubeconfig of a cluster into the
+// kubeconfig file used by the 'oc' and 'kubectl' tools. The files are handled as generic documents
+// so that the settings that the tool doesn't know about are preserved.
+
+package kubeconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/mitchellh/go-homedir"
+)
+
+// ContextName returns the name of the context added for the cluster with the given name.
+func ContextName(clusterName string) string {
+	return fmt.Sprintf("rosa/%s", clusterName)
+}
+
+// Location returns the path of the kubeconfig file used by the 'oc' and 'kubectl' tools, which is
+// the first file of the KUBECONFIG environment variable or '~/.kube/config'.
+func Location() (string, error) {
+	if value := os.Getenv("KUBECONFIG"); value != "" {
+		return filepath.SplitList(value)[0], nil
+	}
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// Merge adds the cluster, user and current context of the given kubeconfig to the file at the
+// given path, with the given context name, and makes it the current context. Entries with the same
+// names are replaced. The file is created if it doesn't exist.
+func Merge(path string, data []byte, contextName string) error {
+	source := map[string]interface{}{}
+	err := yaml.Unmarshal(data, &source)
+	if err != nil {
+		return fmt.Errorf("Failed to parse kubeconfig of the cluster: %v", err)
+	}
+	context, err := currentContext(source)
+	if err != nil {
+		return err
+	}
+	clusterName, _ := context["cluster"].(string)
+	userName, _ := context["user"].(string)
+	cluster := find(source, "clusters", clusterName)
+	if cluster == nil {
+		return fmt.Errorf("Kubeconfig of the cluster doesn't contain cluster '%s'", clusterName)
+	}
+	user := find(source, "users", userName)
+	if user == nil {
+		return fmt.Errorf("Kubeconfig of the cluster doesn't contain user '%s'", userName)
+	}
+
+	target := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Config",
+	}
+	// #nosec G304
+	existing, err := ioutil.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		err = yaml.Unmarshal(existing, &target)
+		if err != nil {
+			return fmt.Errorf("Failed to parse '%s': %v", path, err)
+		}
+		if target == nil {
+			target = map[string]interface{}{}
+		}
+	}
+
+	userAlias := contextName + "/admin"
+	context["cluster"] = contextName
+	context["user"] = userAlias
+	replace(target, "clusters", contextName, cluster["cluster"])
+	replace(target, "users", userAlias, user["user"])
+	replace(target, "contexts", contextName, context)
+	target["current-context"] = contextName
+
+	result, err := yaml.Marshal(target)
+	if err != nil {
+		return err
+	}
+	return write(path, result)
+}
+
+// currentContext returns the current context of the given kubeconfig, or its only context if it
+// doesn't have a current context.
+func currentContext(config map[string]interface{}) (map[string]interface{}, error) {
+	name, _ := config["current-context"].(string)
+	if name != "" {
+		entry := find(config, "contexts", name)
+		if entry == nil {
+			return nil, fmt.Errorf("Kubeconfig of the cluster doesn't contain context '%s'", name)
+		}
+		context, _ := entry["context"].(map[string]interface{})
+		return context, nil
+	}
+	items, _ := config["contexts"].([]interface{})
+	if len(items) != 1 {
+		return nil, fmt.Errorf("Kubeconfig of the cluster doesn't have a current context")
+	}
+	entry, _ := items[0].(map[string]interface{})
+	context, _ := entry["context"].(map[string]interface{})
+	return context, nil
+}
+
+// find returns the named entry of the given list of the kubeconfig, like 'clusters' or 'users'.
+func find(config map[string]interface{}, list string, name string) map[string]interface{} {
+	items, _ := config[list].([]interface{})
+	for _, item := range items {
+		entry, ok := item.(map[string]interface{})
+		if ok && entry["name"] == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+// replace adds to the given list of the kubeconfig an entry with the given name and value,
+// replacing the existing entry with the same name.
+func replace(config map[string]interface{}, list string, name string, value interface{}) {
+	key := strings.TrimSuffix(list, "s")
+	entry := map[string]interface{}{
+		"name": name,
+		key:    value,
+	}
+	items, _ := config[list].([]interface{})
+	for i, item := range items {
+		existing, ok := item.(map[string]interface{})
+		if ok && existing["name"] == name {
+			items[i] = entry
+			config[list] = items
+			return
+		}
+	}
+	config[list] = append(items, entry)
+}
+
+// write replaces the file atomically, so that tools reading it concurrently never see a partial
+// file. Kubeconfig files contain credentials, so they are only readable by the owner.
+func write(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(dir, ".kubeconfig-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err != nil {
+		tmp.Close()
+		return err
+	}
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(tmp.Name(), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -65,7 +65,7 @@ func (b *AWSLoggerBuilder) Build() (result *AWSLogger, err error) {
 
 	// Create and populate the object:
 	result = &AWSLogger{
-		logger: b.logger,
+		logger: ForModule(b.logger, ModuleAWS),
 	}
 
 	return
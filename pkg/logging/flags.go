@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the '--log-format', '--log-file' and
+// '--log-level' command line options.
+
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+)
+
+// Formats of the log:
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Modules that can have their own log level. Messages that don't come from a module use the
+// default level.
+const (
+	ModuleAWS = "aws"
+	ModuleOCM = "ocm"
+)
+
+var modules = []string{ModuleAWS, ModuleOCM}
+
+var (
+	logFormat string
+	logFile   string
+	logLevel  string
+)
+
+// AddFlags adds the log flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&logFormat,
+		"log-format",
+		FormatText,
+		fmt.Sprintf("Format of the log messages, one of '%s' or '%s'.", FormatText, FormatJSON),
+	)
+	flags.StringVar(
+		&logFile,
+		"log-file",
+		"",
+		"Write the log messages to this file instead of the standard error.",
+	)
+	flags.StringVar(
+		&logLevel,
+		"log-level",
+		"",
+		fmt.Sprintf("Log level, like 'debug', optionally followed by levels for modules, like "+
+			"'info,ocm=debug,aws=warn'. Valid modules are '%s'. The '--debug' option sets the "+
+			"default level to 'debug'.", strings.Join(modules, "', '")),
+	)
+}
+
+// levels contains the default log level and the levels of the modules.
+type levels struct {
+	defaultLevel logrus.Level
+	modules      map[string]logrus.Level
+}
+
+// parseLevels parses the value of the '--log-level' option.
+func parseLevels(text string, defaultLevel logrus.Level) (*levels, error) {
+	result := &levels{
+		defaultLevel: defaultLevel,
+		modules:      map[string]logrus.Level{},
+	}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return result, nil
+	}
+	for _, item := range strings.Split(text, ",") {
+		module := ""
+		name := strings.TrimSpace(item)
+		if tokens := strings.SplitN(name, "=", 2); len(tokens) == 2 {
+			module = strings.TrimSpace(tokens[0])
+			name = strings.TrimSpace(tokens[1])
+			if !isModule(module) {
+				return nil, fmt.Errorf("Unknown log module '%s', valid modules are '%s'",
+					module, strings.Join(modules, "', '"))
+			}
+		}
+		level, err := logrus.ParseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid log level '%s'", name)
+		}
+		if module == "" {
+			result.defaultLevel = level
+		} else {
+			result.modules[module] = level
+		}
+	}
+	return result, nil
+}
+
+func isModule(name string) bool {
+	for _, module := range modules {
+		if module == name {
+			return true
+		}
+	}
+	return false
+}
+
+// formatter returns the log formatter selected with the '--log-format' option.
+func formatter() (logrus.Formatter, error) {
+	switch logFormat {
+	case "", FormatText:
+		return &logrus.TextFormatter{
+			DisableColors: true,
+			FullTimestamp: true,
+		}, nil
+	case FormatJSON:
+		return &logrus.JSONFormatter{}, nil
+	default:
+		return nil, fmt.Errorf("Invalid log format '%s', valid formats are '%s' and '%s'",
+			logFormat, FormatText, FormatJSON)
+	}
+}
+
+// The log file is opened only once, even if several loggers are created.
+var (
+	logFileOnce sync.Once
+	logFileOut  *os.File
+	logFileErr  error
+)
+
+// output returns the destination of the log messages selected with the '--log-file' option.
+func output() (*os.File, error) {
+	if logFile == "" {
+		return os.Stderr, nil
+	}
+	logFileOnce.Do(func() {
+		// #nosec G304
+		logFileOut, logFileErr = os.OpenFile(logFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if logFileErr != nil {
+			logFileErr = fmt.Errorf("Failed to open log file '%s': %v", logFile, logFileErr)
+		}
+	})
+	return logFileOut, logFileErr
+}
+
+// JSON checks if the log messages are written in JSON format.
+func JSON() bool {
+	return logFormat == FormatJSON
+}
@@ -19,11 +19,10 @@ limitations under the License.
 package logging
 
 import (
-	"os"
-
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/exit"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
@@ -38,23 +37,71 @@ func NewLogger() *LoggerBuilder {
 	return &LoggerBuilder{}
 }
 
-// Build uses the information stored in the builder to create a new logger.
+// Build uses the information stored in the builder to create a new logger. The format,
+// destination and level of the log are taken from the command line options.
 func (b *LoggerBuilder) Build() (result *logrus.Logger, err error) {
-	// Create the logger:
-	result = logrus.New()
-	result.SetFormatter(&logrus.TextFormatter{
-		DisableColors: true,
-		FullTimestamp: true,
-	})
+	formatter, err := formatter()
+	if err != nil {
+		return
+	}
+	out, err := output()
+	if err != nil {
+		return
+	}
 
 	// Enable the debug level if needed:
+	defaultLevel := logrus.InfoLevel
 	if debug.Enabled() {
-		result.SetLevel(logrus.DebugLevel)
+		defaultLevel = logrus.DebugLevel
 	}
+	levels, err := parseLevels(logLevel, defaultLevel)
+	if err != nil {
+		return
+	}
+	moduleLevels = levels.modules
+
+	// Create the logger:
+	result = logrus.New()
+	result.SetFormatter(formatter)
+	result.SetOutput(out)
+	result.SetLevel(levels.defaultLevel)
 
 	return
 }
 
+// moduleLevels contains the log levels of the modules given with the '--log-level' option.
+var moduleLevels map[string]logrus.Level
+
+// ForModule returns a logger that writes to the same destination and with the same format than
+// the given one, using the level of the given module, and that adds the name of the module to the
+// messages.
+func ForModule(logger *logrus.Logger, module string) *logrus.Logger {
+	level, ok := moduleLevels[module]
+	if !ok {
+		level = logger.GetLevel()
+	}
+	result := logrus.New()
+	result.SetOutput(logger.Out)
+	result.SetFormatter(&moduleFormatter{
+		module: module,
+		next:   logger.Formatter,
+	})
+	result.SetLevel(level)
+	result.ReplaceHooks(logger.Hooks)
+	return result
+}
+
+// moduleFormatter is a log formatter that adds the name of the module to the messages.
+type moduleFormatter struct {
+	module string
+	next   logrus.Formatter
+}
+
+func (f *moduleFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	entry.Data["module"] = f.module
+	return f.next.Format(entry)
+}
+
 // CreateLoggerOrExit creates the logger instance or exits to the console
 // noting the error on failure.
 func CreateLoggerOrExit(reporter *rprtr.Object) *logrus.Logger {
@@ -62,7 +109,7 @@ func CreateLoggerOrExit(reporter *rprtr.Object) *logrus.Logger {
 	logger, err := NewLogger().Build()
 	if err != nil {
 		reporter.Errorf("Failed to create logger: %v", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	return logger
 }
@@ -66,7 +66,7 @@ func (b *OCMLoggerBuilder) Build() (result *OCMLogger, err error) {
 
 	// Create and populate the object:
 	result = &OCMLogger{
-		logger: b.logger,
+		logger: ForModule(b.logger, ModuleOCM),
 	}
 
 	return
@@ -312,6 +312,10 @@ func (d *RoundTripper) dumpJSON(what string, data []byte) {
 // dumpBytes dump the given data as an array of bytes.
 func (d *RoundTripper) dumpBytes(what string, data []byte) {
 	size := len(data)
+	if size > 0 && JSON() {
+		d.logger.WithField("body", string(data)).Debugf("%s body", what)
+		return
+	}
 	if size > 0 {
 		d.logger.Debugf("%s body follows", what)
 		d.logger.Out.Write(data)
@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to check that the endpoints that clusters need to
+// reach aren't blocked by a firewall, either from the host that runs the tool or from a probe
+// instance launched in the subnets of the cluster.
+
+package egress
+
+import (
+	"bufio"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Markers of the lines written to the console by the probe script:
+const (
+	probeMarker     = "ROSA-EGRESS"
+	probeDoneMarker = "ROSA-EGRESS-DONE"
+)
+
+// Result is the outcome of checking an endpoint.
+type Result struct {
+	Endpoint
+	Blocked bool   `json:"blocked"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Address returns the address of the endpoint, in the 'host:port' format.
+func (e Endpoint) Address() string {
+	return net.JoinHostPort(e.Host, strconv.Itoa(e.Port))
+}
+
+// Required returns the endpoints that a cluster with the given region and OpenShift version needs
+// to reach. An empty version means any version.
+func Required(region string, version string) []Endpoint {
+	var result []Endpoint
+	for _, endpoint := range Endpoints {
+		if version != "" && endpoint.MinVersion != "" && olderThan(version, endpoint.MinVersion) {
+			continue
+		}
+		endpoint.Host = strings.Replace(endpoint.Host, RegionPlaceholder, region, -1)
+		result = append(result, endpoint)
+	}
+	return result
+}
+
+// Check connects to each of the given endpoints from the host that runs the tool. Connections to
+// port 443 also complete a TLS handshake, as some firewalls accept the connection and then reject
+// it based on the server name.
+func Check(endpoints []Endpoint, timeout time.Duration) []*Result {
+	results := make([]*Result, len(endpoints))
+	var wg sync.WaitGroup
+	for i, endpoint := range endpoints {
+		wg.Add(1)
+		go func(i int, endpoint Endpoint) {
+			defer wg.Done()
+			result := &Result{
+				Endpoint: endpoint,
+			}
+			err := connect(endpoint, timeout)
+			if err != nil {
+				result.Blocked = true
+				result.Error = err.Error()
+			}
+			results[i] = result
+		}(i, endpoint)
+	}
+	wg.Wait()
+	return results
+}
+
+func connect(endpoint Endpoint, timeout time.Duration) error {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+	}
+	if endpoint.Port != 443 {
+		conn, err := dialer.Dial("tcp", endpoint.Address())
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+	conn, err := tls.DialWithDialer(dialer, "tcp", endpoint.Address(), &tls.Config{
+		ServerName: endpoint.Host,
+		MinVersion: tls.VersionTLS12,
+	})
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+// ProbeScript returns the script that the probe instance runs to check the given endpoints. The
+// results are written to the console of the instance, which is then shut down.
+func ProbeScript(endpoints []Endpoint, timeout time.Duration) string {
+	var buffer strings.Builder
+	buffer.WriteString("#!/bin/bash\n")
+	buffer.WriteString("for target in")
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(&buffer, " %s:%d", endpoint.Host, endpoint.Port)
+	}
+	buffer.WriteString("; do\n")
+	fmt.Fprintf(&buffer, "  if timeout %d bash -c \"</dev/tcp/${target%%:*}/${target##*:}\"; then\n",
+		int(timeout.Seconds()))
+	buffer.WriteString("    result=ok\n")
+	buffer.WriteString("  else\n")
+	buffer.WriteString("    result=blocked\n")
+	buffer.WriteString("  fi\n")
+	fmt.Fprintf(&buffer, "  echo \"%s ${target} ${result}\" > /dev/console\n", probeMarker)
+	buffer.WriteString("done\n")
+	fmt.Fprintf(&buffer, "echo \"%s\" > /dev/console\n", probeDoneMarker)
+	buffer.WriteString("shutdown -h now\n")
+	return buffer.String()
+}
+
+// ProbeDone checks if the given console output of the probe instance contains all the results.
+func ProbeDone(output string) bool {
+	return strings.Contains(output, probeDoneMarker)
+}
+
+// ParseProbeOutput extracts the results for the given endpoints from the console output of the
+// probe instance. Endpoints without a result are considered blocked.
+func ParseProbeOutput(endpoints []Endpoint, output string) []*Result {
+	reachable := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for i := 0; i+2 < len(fields); i++ {
+			if fields[i] == probeMarker {
+				reachable[fields[i+1]] = fields[i+2] == "ok"
+				break
+			}
+		}
+	}
+	results := make([]*Result, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		result := &Result{
+			Endpoint: endpoint,
+		}
+		ok, found := reachable[fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port)]
+		switch {
+		case !found:
+			result.Blocked = true
+			result.Error = "no result from the probe instance"
+		case !ok:
+			result.Blocked = true
+			result.Error = "connection failed"
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Blocked returns the results of the endpoints that are blocked, sorted by purpose and host.
+func Blocked(results []*Result) []*Result {
+	var blocked []*Result
+	for _, result := range results {
+		if result.Blocked {
+			blocked = append(blocked, result)
+		}
+	}
+	sort.Slice(blocked, func(i, j int) bool {
+		if blocked[i].Purpose != blocked[j].Purpose {
+			return blocked[i].Purpose < blocked[j].Purpose
+		}
+		return blocked[i].Host < blocked[j].Host
+	})
+	return blocked
+}
+
+// olderThan checks if the major and minor numbers of the given version are lower than the ones of
+// the other version. Versions that can't be parsed aren't considered older.
+func olderThan(version string, other string) bool {
+	major, minor, ok := majorMinor(version)
+	if !ok {
+		return false
+	}
+	otherMajor, otherMinor, ok := majorMinor(other)
+	if !ok {
+		return false
+	}
+	return major < otherMajor || (major == otherMajor && minor < otherMinor)
+}
+
+func majorMinor(version string) (int, int, bool) {
+	version = strings.TrimPrefix(strings.TrimPrefix(version, "openshift-"), "v")
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
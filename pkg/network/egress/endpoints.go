@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the list of the endpoints that clusters need to reach to be installed and
+// managed. It follows the firewall prerequisites of the documentation, and it should be updated
+// when they change.
+
+package egress
+
+// Purposes of the endpoints:
+const (
+	PurposeInstallation = "installation"
+	PurposeTelemetry    = "telemetry"
+	PurposeAWS          = "aws"
+	PurposeManagement   = "management"
+)
+
+// RegionPlaceholder is replaced with the region of the cluster in the host of the endpoints.
+const RegionPlaceholder = "{region}"
+
+// Endpoint is a destination that clusters need to reach.
+type Endpoint struct {
+	// Host is the name of the host. It can contain RegionPlaceholder.
+	Host string `json:"host"`
+
+	// Port is the TCP port.
+	Port int `json:"port"`
+
+	// Purpose explains what the endpoint is used for.
+	Purpose string `json:"purpose"`
+
+	// MinVersion is the first OpenShift version that needs the endpoint. Empty means all of them.
+	MinVersion string `json:"min_version,omitempty"`
+}
+
+// Endpoints is the list of endpoints that clusters need to reach.
+var Endpoints = []Endpoint{
+	// Container images and installation artifacts:
+	{Host: "registry.redhat.io", Port: 443, Purpose: PurposeInstallation},
+	{Host: "registry.access.redhat.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "quay.io", Port: 443, Purpose: PurposeInstallation},
+	{Host: "cdn01.quay.io", Port: 443, Purpose: PurposeInstallation},
+	{Host: "cdn02.quay.io", Port: 443, Purpose: PurposeInstallation},
+	{Host: "cdn03.quay.io", Port: 443, Purpose: PurposeInstallation},
+	{Host: "quay-registry.s3.amazonaws.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "ocm-quay-production-s3.s3.amazonaws.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "quayio-production-s3.s3.amazonaws.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "cart-rhcos-ci.s3.amazonaws.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "mirror.openshift.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "storage.googleapis.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "api.openshift.com", Port: 443, Purpose: PurposeInstallation},
+	{Host: "sso.redhat.com", Port: 443, Purpose: PurposeInstallation},
+
+	// Telemetry and support:
+	{Host: "cert-api.access.redhat.com", Port: 443, Purpose: PurposeTelemetry},
+	{Host: "api.access.redhat.com", Port: 443, Purpose: PurposeTelemetry},
+	{Host: "infogw.api.openshift.com", Port: 443, Purpose: PurposeTelemetry},
+	{Host: "console.redhat.com", Port: 443, Purpose: PurposeTelemetry},
+	{Host: "observatorium.api.openshift.com", Port: 443, Purpose: PurposeTelemetry, MinVersion: "4.10"},
+
+	// AWS APIs:
+	{Host: "ec2.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "ec2." + RegionPlaceholder + ".amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "elasticloadbalancing." + RegionPlaceholder + ".amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "events.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "iam.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "route53.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "sts.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "sts." + RegionPlaceholder + ".amazonaws.com", Port: 443, Purpose: PurposeAWS},
+	{Host: "tagging.us-east-1.amazonaws.com", Port: 443, Purpose: PurposeAWS},
+
+	// Management by Red Hat site reliability engineers:
+	{Host: "api.pagerduty.com", Port: 443, Purpose: PurposeManagement},
+	{Host: "events.pagerduty.com", Port: 443, Purpose: PurposeManagement},
+	{Host: "api.deadmanssnitch.com", Port: 443, Purpose: PurposeManagement},
+	{Host: "nosnch.in", Port: 443, Purpose: PurposeManagement},
+	{Host: "http-inputs-osdsecuritylogs.splunkcloud.com", Port: 443, Purpose: PurposeManagement},
+	{Host: "sftp.access.redhat.com", Port: 22, Purpose: PurposeManagement},
+}
@@ -0,0 +1,49 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--notify-webhook' and '--notify-template'
+// command line options.
+
+package notify
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// AddFlags adds the notification flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringArrayVar(
+		&webhooks,
+		"notify-webhook",
+		nil,
+		"URL of a webhook that will receive a JSON payload when the operation is accepted, or "+
+			"when it finishes or fails if the command waits for it. "+
+			"Can be repeated to notify multiple webhooks.",
+	)
+	flags.StringVar(
+		&payloadTemplate,
+		"notify-template",
+		"",
+		"Go template used to render the payload sent to the notification webhooks. "+
+			"Use '@' followed by a path to read the template from a file.",
+	)
+}
+
+// webhooks is the list of URLs given with the '--notify-webhook' flag.
+var webhooks []string
+
+// template is the payload template given with the '--notify-template' flag.
+var payloadTemplate string
@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to notify webhooks when the operations started by
+// long running commands, like creating, upgrading or deleting clusters, are accepted, finish or
+// fail.
+
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/openshift/rosa/pkg/defaults"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
+)
+
+// Status values of the events. Commands that don't wait for the operation to finish only report
+// that it has been accepted:
+const (
+	StatusAccepted  = "accepted"
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// Number of attempts made to deliver a notification, and delay before the first retry. The delay
+// is doubled after each failed attempt.
+const (
+	maxAttempts = 3
+	retryDelay  = time.Second
+)
+
+// slackTemplate is the default template used for Slack incoming webhooks, as they don't accept
+// arbitrary JSON payloads.
+const slackTemplate = `{"text": {{json .Summary}}}`
+
+// Event contains the details of the command that are sent to the webhooks.
+type Event struct {
+	Command   string    `json:"command"`
+	Cluster   string    `json:"cluster,omitempty"`
+	ClusterID string    `json:"cluster_id,omitempty"`
+	Status    string    `json:"status"`
+	Message   string    `json:"message,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Summary returns a one line human readable description of the event.
+func (e *Event) Summary() string {
+	summary := fmt.Sprintf("rosa %s %s", e.Command, e.Status)
+	if e.Cluster != "" {
+		summary = fmt.Sprintf("rosa %s %s for cluster '%s'", e.Command, e.Status, e.Cluster)
+	}
+	if e.Message != "" {
+		summary = fmt.Sprintf("%s: %s", summary, e.Message)
+	}
+	return summary
+}
+
+// Accepted sends a notification that the operation of the given command has been accepted, but the
+// command doesn't wait for it to finish.
+func Accepted(reporter *rprtr.Object, command string, cluster string, clusterID string, message string) {
+	Send(reporter, &Event{
+		Command:   command,
+		Cluster:   cluster,
+		ClusterID: clusterID,
+		Status:    StatusAccepted,
+		Message:   message,
+	})
+}
+
+// Succeeded sends a notification that the operation of the given command finished successfully.
+func Succeeded(reporter *rprtr.Object, command string, cluster string, clusterID string, message string) {
+	Send(reporter, &Event{
+		Command:   command,
+		Cluster:   cluster,
+		ClusterID: clusterID,
+		Status:    StatusSucceeded,
+		Message:   message,
+	})
+}
+
+// Failed sends a notification that the given command or its operation failed.
+func Failed(reporter *rprtr.Object, command string, cluster string, clusterID string, err error) {
+	event := &Event{
+		Command:   command,
+		Cluster:   cluster,
+		ClusterID: clusterID,
+		Status:    StatusFailed,
+	}
+	if err != nil {
+		event.Message = err.Error()
+	}
+	Send(reporter, event)
+}
+
+// Send sends the event to the webhooks given in the command line and in the configuration file.
+// Failures to deliver the notification are reported as warnings, as they shouldn't change the
+// result of the command.
+func Send(reporter *rprtr.Object, event *Event) {
+	hooks, text, err := load()
+	if err != nil {
+		reporter.Warnf("Failed to load notification webhooks: %v", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now().UTC()
+	}
+
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	for _, hook := range hooks {
+		payload, err := render(hook, text, event)
+		if err != nil {
+			reporter.Warnf("Failed to render notification for webhook '%s': %v", redact(hook), err)
+			continue
+		}
+		err = post(client, hook, payload)
+		if err != nil {
+			reporter.Warnf("Failed to notify webhook '%s': %v", redact(hook), err)
+			continue
+		}
+		reporter.Debugf("Notified webhook '%s' that '%s' %s", redact(hook), event.Command, event.Status)
+	}
+}
+
+// load returns the webhooks and the payload template, combining the command line flags and the
+// configuration file. The template given in the command line takes precedence.
+func load() (hooks []string, text string, err error) {
+	hooks = append(hooks, webhooks...)
+	text = payloadTemplate
+	cfg, err := defaults.Load()
+	if err != nil {
+		return
+	}
+	if cfg.Notify != nil {
+		hooks = append(hooks, cfg.Notify.Webhooks...)
+		if text == "" {
+			text = cfg.Notify.Template
+		}
+	}
+	for _, hook := range hooks {
+		err = ValidateWebhook(hook)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	if strings.HasPrefix(text, "@") {
+		// #nosec G304
+		data, err := ioutil.ReadFile(strings.TrimPrefix(text, "@"))
+		if err != nil {
+			return nil, "", fmt.Errorf("Failed to read notification template: %v", err)
+		}
+		text = string(data)
+	}
+	return
+}
+
+// ValidateWebhook checks that the given webhook is an HTTP URL.
+func ValidateWebhook(hook string) error {
+	parsed, err := url.Parse(hook)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("Webhook '%s' isn't a valid HTTP URL", redact(hook))
+	}
+	return nil
+}
+
+// render generates the payload for the given webhook. Without a template the event is sent as
+// JSON, except for Slack webhooks that need a message.
+func render(hook string, text string, event *Event) ([]byte, error) {
+	if text == "" && isSlack(hook) {
+		text = slackTemplate
+	}
+	if text == "" {
+		return json.Marshal(event)
+	}
+	tmpl, err := template.New("payload").
+		Funcs(template.FuncMap{
+			"json": func(value interface{}) (string, error) {
+				data, err := json.Marshal(value)
+				return string(data), err
+			},
+		}).
+		Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	buffer := &bytes.Buffer{}
+	err = tmpl.Execute(buffer, event)
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// post sends the payload to the webhook, retrying when the request fails because of a network
+// error or a server side error.
+func post(client *http.Client, hook string, payload []byte) error {
+	delay := retryDelay
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(delay)
+			delay *= 2
+		}
+		var response *http.Response
+		response, err = client.Post(hook, "application/json", bytes.NewReader(payload))
+		if err != nil {
+			continue
+		}
+		response.Body.Close()
+		if response.StatusCode < http.StatusBadRequest {
+			return nil
+		}
+		err = fmt.Errorf("Webhook responded with status %d", response.StatusCode)
+		if response.StatusCode != http.StatusTooManyRequests &&
+			response.StatusCode < http.StatusInternalServerError {
+			return err
+		}
+	}
+	return err
+}
+
+func isSlack(hook string) bool {
+	parsed, err := url.Parse(hook)
+	return err == nil && parsed.Host == "hooks.slack.com"
+}
+
+// redact removes the path of the webhook URL before it is displayed, as it usually contains the
+// secret that authorizes the requests.
+func redact(hook string) string {
+	parsed, err := url.Parse(hook)
+	if err != nil || parsed.Host == "" {
+		return "<invalid>"
+	}
+	return fmt.Sprintf("%s://%s/...", parsed.Scheme, parsed.Host)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addons
+
+import (
+	"errors"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// MaskedValue is the text displayed instead of the value of parameters that contain secrets.
+const MaskedValue = "********"
+
+// Installation contains an add-on installation together with the definition of the add-on, so
+// that parameter values can be displayed along with their metadata.
+type Installation struct {
+	*cmv1.AddOnInstallation
+	AddOn *cmv1.AddOn
+}
+
+// InstallationParameter is a parameter value of an add-on installation.
+type InstallationParameter struct {
+	ID     string
+	Name   string
+	Value  string
+	Secret bool
+}
+
+// GetAddOnInstallation retrieves the installation of the given add-on on the cluster, together
+// with the add-on definition.
+func GetAddOnInstallation(client *cmv1.Client, clusterID string, addOnID string) (*Installation, error) {
+	response, err := client.Clusters().
+		Cluster(clusterID).
+		Addons().
+		Addoninstallation(addOnID).
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+
+	addOnResponse, err := client.Addons().Addon(addOnID).Get().Send()
+	if err != nil {
+		return nil, handleErr(addOnResponse.Error(), err)
+	}
+
+	return &Installation{
+		AddOnInstallation: response.Body(),
+		AddOn:             addOnResponse.Body(),
+	}, nil
+}
+
+// ParameterValues returns the parameter values of the installation. Values of parameters that
+// look like secrets are masked.
+func (i *Installation) ParameterValues() []*InstallationParameter {
+	var params []*InstallationParameter
+	i.AddOnInstallation.Parameters().Each(func(param *cmv1.AddOnInstallationParameter) bool {
+		result := &InstallationParameter{
+			ID:    param.ID(),
+			Name:  param.ID(),
+			Value: param.Value(),
+		}
+		i.AddOn.Parameters().Each(func(definition *cmv1.AddOnParameter) bool {
+			if definition.ID() != param.ID() {
+				return true
+			}
+			result.Name = definition.Name()
+			result.Secret = isSecret(definition)
+			return false
+		})
+		if result.Secret || isSecretID(param.ID()) {
+			result.Secret = true
+			result.Value = MaskedValue
+		}
+		params = append(params, result)
+		return true
+	})
+	return params
+}
+
+// Requirements returns the enabled requirements of the add-on. The API doesn't report the status
+// of individual requirements, so when the installation fails these are the candidates to check.
+func (i *Installation) Requirements() []*cmv1.AddOnRequirement {
+	var requirements []*cmv1.AddOnRequirement
+	for _, requirement := range i.AddOn.Requirements() {
+		if requirement.Enabled() {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return requirements
+}
+
+func isSecret(param *cmv1.AddOnParameter) bool {
+	valueType := strings.ToLower(param.ValueType())
+	return valueType == "password" || valueType == "secret" || isSecretID(param.ID())
+}
+
+func isSecretID(id string) bool {
+	id = strings.ToLower(id)
+	for _, word := range []string{"password", "secret", "token", "key"} {
+		if strings.Contains(id, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
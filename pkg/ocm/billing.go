@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to select the billing model of clusters. The AWS
+// marketplace billing model and the cloud accounts linked to the organization aren't supported yet
+// by the version of the SDK used by the tool, so they are handled with raw requests.
+
+package ocm
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// Billing models of clusters:
+const (
+	BillingModelStandard       = "standard"
+	BillingModelMarketplace    = "marketplace"
+	BillingModelMarketplaceAWS = "marketplace-aws"
+)
+
+// BillingModels are the billing models that can be selected when creating a cluster.
+var BillingModels = []string{
+	BillingModelStandard,
+	BillingModelMarketplace,
+	BillingModelMarketplaceAWS,
+}
+
+// IsValidBillingModel checks if the given billing model is supported.
+func IsValidBillingModel(billingModel string) bool {
+	for _, item := range BillingModels {
+		if item == billingModel {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMarketplaceBillingModel checks if the given billing model charges the cluster through a
+// marketplace, and therefore needs a billing account.
+func IsMarketplaceBillingModel(billingModel string) bool {
+	return billingModel == BillingModelMarketplace || billingModel == BillingModelMarketplaceAWS
+}
+
+// GetBillingAccounts returns the identifiers of the AWS accounts linked to the organization of the
+// current user that can be used as billing accounts, sorted.
+func GetBillingAccounts(connection *sdk.Connection) ([]string, error) {
+	account, err := GetCurrentAccount(connection)
+	if err != nil {
+		return nil, err
+	}
+	response, err := connection.Get().
+		Path(fmt.Sprintf("/api/accounts_mgmt/v1/organizations/%s/quota_cost",
+			account.Organization().ID())).
+		Parameter("fetchCloudAccounts", true).
+		Parameter("size", -1).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []struct {
+			CloudAccounts []struct {
+				CloudAccountID  string `json:"cloud_account_id"`
+				CloudProviderID string `json:"cloud_provider_id"`
+			} `json:"cloud_accounts"`
+		} `json:"items"`
+	}
+	err = json.Unmarshal(response.Bytes(), &list)
+	if err != nil {
+		return nil, err
+	}
+	found := map[string]bool{}
+	accounts := []string{}
+	for _, item := range list.Items {
+		for _, cloudAccount := range item.CloudAccounts {
+			if cloudAccount.CloudProviderID != "aws" || found[cloudAccount.CloudAccountID] {
+				continue
+			}
+			found[cloudAccount.CloudAccountID] = true
+			accounts = append(accounts, cloudAccount.CloudAccountID)
+		}
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a transport wrapper that remembers the responses to GET requests that
+// contain an ETag, so that repeated requests, like the ones sent by watch loops, can ask the
+// server to send the body only if it changed.
+
+package ocm
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// cacheEntry is a response remembered by the cache transport.
+type cacheEntry struct {
+	etag   string
+	header http.Header
+	body   []byte
+}
+
+// cacheTransport is a round tripper that sends the If-None-Match header for GET requests whose
+// previous response contained an ETag, and replaces the 304 responses with the remembered ones.
+type cacheTransport struct {
+	logger  *logrus.Logger
+	next    http.RoundTripper
+	lock    sync.Mutex
+	entries map[string]*cacheEntry
+	hits    int
+	misses  int
+}
+
+// newCacheTransportWrapper returns a transport wrapper that caches the responses of GET requests
+// using the ETag returned by the server.
+func newCacheTransportWrapper(logger *logrus.Logger) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &cacheTransport{
+			logger:  logger,
+			next:    next,
+			entries: map[string]*cacheEntry{},
+		}
+	}
+}
+
+// RoundTrip is the implementation of the http.RoundTripper interface.
+func (t *cacheTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Method != http.MethodGet || request.Header.Get("If-None-Match") != "" {
+		return t.next.RoundTrip(request)
+	}
+
+	key := request.URL.String()
+	t.lock.Lock()
+	entry := t.entries[key]
+	t.lock.Unlock()
+	if entry != nil {
+		request = request.Clone(request.Context())
+		request.Header.Set("If-None-Match", entry.etag)
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return response, err
+	}
+
+	switch {
+	case response.StatusCode == http.StatusNotModified && entry != nil:
+		response.Body.Close()
+		t.count(true, request)
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         response.Proto,
+			ProtoMajor:    response.ProtoMajor,
+			ProtoMinor:    response.ProtoMinor,
+			Header:        entry.header.Clone(),
+			Body:          ioutil.NopCloser(bytes.NewReader(entry.body)),
+			ContentLength: int64(len(entry.body)),
+			Request:       response.Request,
+		}, nil
+	case response.StatusCode == http.StatusOK && response.Header.Get("ETag") != "":
+		body, err := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+		t.lock.Lock()
+		t.entries[key] = &cacheEntry{
+			etag:   response.Header.Get("ETag"),
+			header: response.Header.Clone(),
+			body:   body,
+		}
+		t.lock.Unlock()
+		t.count(false, request)
+	case entry != nil:
+		// The entry is stale and the server didn't send a new ETag:
+		t.lock.Lock()
+		delete(t.entries, key)
+		t.lock.Unlock()
+		t.count(false, request)
+	}
+	return response, nil
+}
+
+// count updates the hit and miss counters and sends them to the log.
+func (t *cacheTransport) count(hit bool, request *http.Request) {
+	t.lock.Lock()
+	if hit {
+		t.hits++
+	} else {
+		t.misses++
+	}
+	hits, misses := t.hits, t.misses
+	t.lock.Unlock()
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	t.logger.Debugf("Cache %s for GET %s (hits: %d, misses: %d)", result, request.URL.Path, hits, misses)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the function used by all the commands to find the cluster given with the
+// '--cluster' flag, so that they all accept the same kinds of identifiers.
+
+package clusters
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/properties"
+)
+
+// maxMatches is the maximum number of matching clusters loaded to describe an ambiguous key.
+const maxMatches = 10
+
+// GetCluster returns the cluster created by the given creator that has the given identifier, name
+// or external identifier. Identifiers are unique, so a cluster whose identifier matches is
+// preferred over the rest, and then a cluster whose external identifier matches. When the key is
+// the name of several clusters the returned error lists them, so that the user can choose one by
+// identifier.
+func GetCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN string) (*cmv1.Cluster, error) {
+	query := fmt.Sprintf(
+		"(id = '%s' or name = '%s' or external_id = '%s') and properties.%s = '%s'",
+		clusterKey, clusterKey, clusterKey, properties.CreatorARN, creatorARN,
+	)
+	response, err := client.List().
+		Search(query).
+		Page(1).
+		Size(maxMatches).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	items := response.Items().Slice()
+
+	switch response.Total() {
+	case 0:
+		return nil, fmt.Errorf("There is no cluster with identifier, name or external identifier '%s'",
+			clusterKey)
+	case 1:
+		return items[0], nil
+	}
+	for _, item := range items {
+		if item.ID() == clusterKey {
+			return item, nil
+		}
+	}
+	var byExternalID []*cmv1.Cluster
+	for _, item := range items {
+		if item.ExternalID() == clusterKey {
+			byExternalID = append(byExternalID, item)
+		}
+	}
+	if len(byExternalID) == 1 {
+		return byExternalID[0], nil
+	}
+	return nil, ambiguousError(clusterKey, response.Total(), items)
+}
+
+// ambiguousError returns the error that describes the clusters matching the key, so that the user
+// can select one using its identifier.
+func ambiguousError(clusterKey string, total int, items []*cmv1.Cluster) error {
+	var buffer bytes.Buffer
+	writer := tabwriter.NewWriter(&buffer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "  ID\tNAME\tEXTERNAL ID\tSTATE\n")
+	for _, item := range items {
+		fmt.Fprintf(writer, "  %s\t%s\t%s\t%s\n", item.ID(), item.Name(), item.ExternalID(), item.State())
+	}
+	writer.Flush()
+	more := ""
+	if total > len(items) {
+		more = fmt.Sprintf("  ... and %d more\n", total-len(items))
+	}
+	return fmt.Errorf("There are %d clusters matching '%s', use the identifier of one of them "+
+		"instead:\n%s", total, clusterKey, strings.TrimSuffix(buffer.String()+more, "\n"))
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
@@ -23,8 +23,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
@@ -34,6 +36,7 @@ import (
 	"github.com/openshift-online/ocm-sdk-go/authentication"
 
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/fedramp"
 )
 
 // URLAliases allows the value of the `--env` option to map to the various API URLs.
@@ -43,6 +46,14 @@ var URLAliases = map[string]string{
 	"integration": "https://api.integration.openshift.com",
 }
 
+// UITokenPage is the page where users can get a new offline access token.
+// #nosec G101
+const UITokenPage = "https://cloud.redhat.com/openshift/token/rosa"
+
+// TokenExpiryWarning is how long before the refresh token expires that commands will start warning
+// the user that a new login will be required.
+const TokenExpiryWarning = 7 * 24 * time.Hour
+
 // Config is the type used to store the configuration of the client.
 type Config struct {
 	AccessToken  string   `json:"access_token,omitempty"`
@@ -53,6 +64,13 @@ type Config struct {
 	Scopes       []string `json:"scopes,omitempty"`
 	TokenURL     string   `json:"token_url,omitempty"`
 	URL          string   `json:"url,omitempty"`
+
+	// File containing the federated token, like the ones issued to CI workflows, that is
+	// exchanged for new tokens when the stored ones expire.
+	FederatedTokenFile string `json:"federated_token_file,omitempty"`
+
+	// Indicates that the tokens and the URLs are the ones of the FedRAMP environment.
+	FedRAMP bool `json:"fedramp,omitempty"`
 }
 
 // Load loads the configuration from the configuration file. If the configuration file doesn't exist
@@ -97,13 +115,39 @@ func Save(cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("Failed to marshal config: %v", err)
 	}
-	err = ioutil.WriteFile(file, data, 0600)
+	err = writeAtomic(file, data)
 	if err != nil {
 		return fmt.Errorf("Failed to write file '%s': %v", file, err)
 	}
 	return nil
 }
 
+// writeAtomic writes the data to a temporary file in the same directory and then renames it, so
+// that other processes reading the configuration never see a partially written file.
+func writeAtomic(file string, data []byte) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(file), filepath.Base(file)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err != nil {
+		return err
+	}
+	if closeErr != nil {
+		return closeErr
+	}
+	err = os.Chmod(tmp.Name(), 0600)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), file)
+}
+
 // Remove removes the configuration file.
 func Remove() error {
 	file, err := Location()
@@ -135,6 +179,15 @@ func Location() (path string, err error) {
 	return path, nil
 }
 
+// TokenPage returns the page where the user can get a new offline access token for the
+// environment of the configuration.
+func (c *Config) TokenPage() string {
+	if c.FedRAMP {
+		return fedramp.TokenPage
+	}
+	return UITokenPage
+}
+
 func (c *Config) GetData(key string) (value string, err error) {
 	if c.AccessToken == "" {
 		return
@@ -165,13 +218,22 @@ func (c *Config) GetData(key string) (value string, err error) {
 	return
 }
 
-// Armed checks if the configuration contains either credentials or tokens that haven't expired, so
-// that it can be used to perform authenticated requests.
+// Armed checks if the configuration contains either credentials, a federated token file or tokens
+// that haven't expired, so that it can be used to perform authenticated requests.
 func (c *Config) Armed() (armed bool, err error) {
 	if c.ClientID != "" && c.ClientSecret != "" {
 		armed = true
 		return
 	}
+	if c.FederatedTokenFile != "" {
+		armed = true
+		return
+	}
+	return c.TokensArmed()
+}
+
+// TokensArmed checks if the configuration contains an access or refresh token that hasn't expired.
+func (c *Config) TokensArmed() (armed bool, err error) {
 	now := time.Now()
 	if c.AccessToken != "" {
 		var expires bool
@@ -263,6 +325,58 @@ func (c *Config) Connection() (connection *sdk.Connection, err error) {
 	return
 }
 
+// RefreshTokenExpiry returns whether the refresh token stored in the configuration expires and how
+// much time is left until it does.
+func (c *Config) RefreshTokenExpiry() (expires bool, left time.Duration, err error) {
+	if c.RefreshToken == "" {
+		return
+	}
+	token, err := parseToken(c.RefreshToken)
+	if err != nil {
+		return
+	}
+	return authentication.GetTokenExpiry(token, time.Now())
+}
+
+// TokenInfo contains the details of a token that are relevant to the user.
+type TokenInfo struct {
+	Type      string
+	Issuer    string
+	Expires   bool
+	ExpiresAt time.Time
+}
+
+// GetTokenInfo extracts the type, issuer and expiration time from the given token.
+func GetTokenInfo(textToken string) (info *TokenInfo, err error) {
+	token, err := parseToken(textToken)
+	if err != nil {
+		return
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		err = fmt.Errorf("Expected map claims but got %T", token.Claims)
+		return
+	}
+	now := time.Now()
+	expires, left, err := authentication.GetTokenExpiry(token, now)
+	if err != nil {
+		return
+	}
+	info = &TokenInfo{
+		Expires: expires,
+	}
+	if expires {
+		info.ExpiresAt = now.Add(left).Round(time.Second)
+	}
+	if typ, ok := claims["typ"].(string); ok {
+		info.Type = typ
+	}
+	if iss, ok := claims["iss"].(string); ok {
+		info.Issuer = iss
+	}
+	return
+}
+
 func parseToken(textToken string) (token *jwt.Token, err error) {
 	parser := new(jwt.Parser)
 	token, _, err = parser.ParseUnverified(textToken, jwt.MapClaims{})
@@ -272,3 +386,77 @@ func parseToken(textToken string) (token *jwt.Token, err error) {
 	}
 	return token, nil
 }
+
+// issuerEnvironments maps the host of the SSO server that issues the tokens to the environments
+// that accept them. The first environment is the one selected when the environment is detected
+// from the token.
+var issuerEnvironments = map[string][]string{
+	"sso.redhat.com":       {"production"},
+	"sso.stage.redhat.com": {"staging", "integration"},
+}
+
+// TokenEnvironment returns the alias of the environment that corresponds to the issuer of the
+// given token, and the URL used to request tokens from that issuer. The returned environment is
+// empty if the issuer isn't known.
+func TokenEnvironment(textToken string) (env string, tokenURL string, err error) {
+	info, err := GetTokenInfo(textToken)
+	if err != nil {
+		return
+	}
+	envs := issuerEnvironments[issuerHost(info.Issuer)]
+	if len(envs) == 0 {
+		return
+	}
+	env = envs[0]
+	tokenURL = strings.TrimSuffix(info.Issuer, "/") + "/protocol/openid-connect/token"
+	return
+}
+
+// EnvironmentMismatch checks if the tokens stored in the configuration were issued for a different
+// environment than the one of the API URL. It returns a message describing the mismatch, or an
+// empty string if there is no mismatch or if the environments aren't known.
+func (c *Config) EnvironmentMismatch() string {
+	textToken := c.RefreshToken
+	if textToken == "" {
+		textToken = c.AccessToken
+	}
+	if textToken == "" || c.URL == "" {
+		return ""
+	}
+	info, err := GetTokenInfo(textToken)
+	if err != nil {
+		return ""
+	}
+	envs := issuerEnvironments[issuerHost(info.Issuer)]
+	urlEnv := URLEnvironment(c.URL)
+	if len(envs) == 0 || urlEnv == "" {
+		return ""
+	}
+	for _, env := range envs {
+		if env == urlEnv {
+			return ""
+		}
+	}
+	return fmt.Sprintf("The token was issued by '%s' for the '%s' environment, but the API URL "+
+		"'%s' belongs to the '%s' environment. Requests will fail with authentication errors",
+		info.Issuer, envs[0], c.URL, urlEnv)
+}
+
+// URLEnvironment returns the alias of the environment of the given API URL, or an empty string if
+// it isn't one of the known environments.
+func URLEnvironment(apiURL string) string {
+	for env, envURL := range URLAliases {
+		if strings.TrimSuffix(apiURL, "/") == envURL {
+			return env
+		}
+	}
+	return ""
+}
+
+func issuerHost(issuer string) string {
+	parsed, err := url.Parse(issuer)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the lock used to serialize the refresh of the tokens stored in the
+// configuration file when several instances of the tool run at the same time.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Time to wait for the lock before giving up, and age after which a lock file is considered left
+// behind by a process that died while holding it.
+const (
+	lockTimeout  = 30 * time.Second
+	lockStale    = time.Minute
+	lockInterval = 50 * time.Millisecond
+)
+
+// Lock acquires an exclusive lock on the configuration file, shared by all the processes that use
+// the same file. It returns the function that releases the lock. The lock is a separate file
+// created atomically, so that it works in all the operating systems supported by the tool.
+func Lock() (unlock func(), err error) {
+	file, err := Location()
+	if err != nil {
+		return
+	}
+	lockFile := file + ".lock"
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		var lock *os.File
+		// #nosec G304
+		lock, err = os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+		if err == nil {
+			fmt.Fprintf(lock, "%d\n", os.Getpid())
+			lock.Close()
+			unlock = func() {
+				os.Remove(lockFile)
+			}
+			return
+		}
+		if !os.IsExist(err) {
+			err = fmt.Errorf("Failed to create lock file '%s': %v", lockFile, err)
+			return
+		}
+		info, statErr := os.Stat(lockFile)
+		if statErr == nil && time.Since(info.ModTime()) > lockStale {
+			os.Remove(lockFile)
+			continue
+		}
+		if time.Now().After(deadline) {
+			err = fmt.Errorf("Timed out waiting for lock file '%s'", lockFile)
+			return
+		}
+		time.Sleep(lockInterval)
+	}
+}
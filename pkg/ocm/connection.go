@@ -18,12 +18,18 @@ package ocm
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/dustin/go-humanize"
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm/config"
+	"github.com/openshift/rosa/pkg/ocm/proxy"
+	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
 // ConnectionBuilder contains the information and logic needed to build a connection to OCM. Don't
@@ -33,6 +39,18 @@ type ConnectionBuilder struct {
 	cfg    *config.Config
 }
 
+// redactedFields are the fields of the requests and responses that aren't sent to the log.
+var redactedFields = []string{
+	"access_token",
+	"admin",
+	"client_secret",
+	"id_token",
+	"kubeconfig",
+	"password",
+	"refresh_token",
+	"ssh",
+}
+
 // NewConnection creates a builder that can then be used to configure and build an OCM connection.
 // Don't create instances of this type directly; use the NewConnection function instead.
 func NewConnection() *ConnectionBuilder {
@@ -54,7 +72,24 @@ func (b *ConnectionBuilder) Config(value *config.Config) *ConnectionBuilder {
 
 // Build uses the information stored in the builder to create a new OCM connection.
 func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
-	if b.cfg == nil {
+	// Check parameters:
+	if b.logger == nil {
+		err = fmt.Errorf("Logger is mandatory")
+		return
+	}
+
+	shared := b.cfg == nil
+	if shared {
+		// Hold the lock of the configuration file until the tokens have been checked, so that
+		// when several processes run at the same time only the first one refreshes the tokens
+		// and the rest load and reuse them:
+		unlock, lockErr := config.Lock()
+		if lockErr != nil {
+			b.logger.Debugf("Failed to lock config file: %v", lockErr)
+		} else {
+			defer unlock()
+		}
+
 		// Load the configuration file:
 		b.cfg, err = config.Load()
 		if err != nil {
@@ -67,11 +102,27 @@ func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 		}
 	}
 
-	// Check parameters:
-	if b.logger == nil {
-		err = fmt.Errorf("Logger is mandatory")
+	// Warn the user ahead of time when the refresh token is about to expire, so that there is a
+	// chance to log in again before commands start failing, and when the tokens were issued for a
+	// different environment than the one of the API, as that only results in confusing
+	// authentication errors:
+	warnTokenExpiry(b.cfg)
+	warnEnvironmentMismatch(b.cfg)
+
+	// When logged in with a federated token exchange it again for new tokens once the stored ones
+	// have expired:
+	exchanged, err := b.exchangeFederatedTokens()
+	if err != nil {
+		err = fmt.Errorf("Failed to exchange federated token: %v", err)
 		return
 	}
+	if exchanged && shared {
+		err = config.Save(b.cfg)
+		if err != nil {
+			b.logger.Debugf("Failed to save exchanged tokens: %v", err)
+			err = nil
+		}
+	}
 
 	// Create the OCM logger that uses the logging framework of the project:
 	logger, err := logging.NewOCMLogger().
@@ -81,11 +132,46 @@ func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 		return
 	}
 
+	// Select the proxy, either explicitly given in the command line or else taken from the
+	// environment:
+	proxySelector, err := proxy.Func()
+	if err != nil {
+		return
+	}
+
 	// Prepare the builder for the connection adding only the properties that have explicit
-	// values in the configuration, so that default values won't be overridden:
+	// values in the configuration, so that default values won't be overridden. Note that the
+	// cache wrapper goes first so that the operation wrapper logs the real status codes and only
+	// the requests sent to the server are traced:
 	builder := sdk.NewConnectionBuilder()
 	builder.Logger(logger)
+	builder.TransportWrapper(newCacheTransportWrapper(b.logger))
+	builder.TransportWrapper(newOperationTransportWrapper(b.logger))
+	builder.TransportWrapper(newTracingTransportWrapper())
+
+	// When the proxy is given explicitly the requests are sent with a transport that uses it,
+	// instead of the one created by the SDK. That skips the round tripper that the SDK uses to
+	// send the requests to the log, so in that case our own is used:
+	if proxy.Proxy() != "" {
+		var transport http.RoundTripper = proxy.Transport(proxySelector, b.cfg.Insecure)
+		ocmLogger := logging.ForModule(b.logger, logging.ModuleOCM)
+		if ocmLogger.IsLevelEnabled(logrus.DebugLevel) {
+			dumper := logging.NewRoundTripper().
+				Logger(ocmLogger).
+				Next(transport)
+			for _, field := range redactedFields {
+				dumper.Redact(field)
+			}
+			transport, err = dumper.Build()
+			if err != nil {
+				return
+			}
+		}
+		builder.TransportWrapper(proxy.Wrapper(transport))
+	}
+	tokenURL := sdk.DefaultTokenURL
 	if b.cfg.TokenURL != "" {
+		tokenURL = b.cfg.TokenURL
 		builder.TokenURL(b.cfg.TokenURL)
 	}
 	if b.cfg.ClientID != "" || b.cfg.ClientSecret != "" {
@@ -94,9 +180,13 @@ func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 	if b.cfg.Scopes != nil {
 		builder.Scopes(b.cfg.Scopes...)
 	}
+	apiURL := sdk.DefaultURL
 	if b.cfg.URL != "" {
+		apiURL = b.cfg.URL
 		builder.URL(b.cfg.URL)
 	}
+	b.logger.Debugf("Using proxy '%s' for '%s'", proxy.Resolve(apiURL, proxySelector), apiURL)
+	b.logger.Debugf("Using proxy '%s' for '%s'", proxy.Resolve(tokenURL, proxySelector), tokenURL)
 	tokens := make([]string, 0, 2)
 	if b.cfg.AccessToken != "" {
 		tokens = append(tokens, b.cfg.AccessToken)
@@ -115,5 +205,86 @@ func (b *ConnectionBuilder) Build() (result *sdk.Connection, err error) {
 		return
 	}
 
+	// Check that the SSO server still accepts the tokens, as offline tokens can be revoked or
+	// expire between invocations. Other failures are left for the actual requests to report:
+	accessToken, refreshToken, err := result.Tokens()
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid_grant") {
+			result.Close()
+			result = nil
+			err = fmt.Errorf("Your session has expired or has been revoked. "+
+				"Get a new offline access token at %s and run 'rosa login' again", b.cfg.TokenPage())
+			return
+		}
+		if isDialError(err) {
+			result.Close()
+			result = nil
+			err = fmt.Errorf("Failed to connect to '%s': %v. If you are behind a proxy set the "+
+				"HTTPS_PROXY environment variable or use the --ocm-proxy flag", tokenURL, err)
+			return
+		}
+		b.logger.Debugf("Failed to get tokens: %v", err)
+		err = nil
+	} else if shared {
+		b.saveTokens(accessToken, refreshToken)
+	}
+
 	return
 }
+
+// saveTokens writes the tokens back to the configuration file when they have been refreshed, so
+// that the next invocations can use them instead of requesting new ones.
+func (b *ConnectionBuilder) saveTokens(accessToken, refreshToken string) {
+	if refreshToken == "" {
+		refreshToken = b.cfg.RefreshToken
+	}
+	if accessToken == b.cfg.AccessToken && refreshToken == b.cfg.RefreshToken {
+		return
+	}
+	b.cfg.AccessToken = accessToken
+	b.cfg.RefreshToken = refreshToken
+	err := config.Save(b.cfg)
+	if err != nil {
+		b.logger.Debugf("Failed to save refreshed tokens: %v", err)
+		return
+	}
+	b.logger.Debugf("Saved refreshed tokens to config file")
+}
+
+// isDialError checks if the given error was caused by the inability to open a connection to the
+// server, which is usually what happens when a required proxy isn't configured.
+func isDialError(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "dial tcp") ||
+		strings.Contains(msg, "i/o timeout") ||
+		strings.Contains(msg, "no such host") ||
+		strings.Contains(msg, "proxyconnect")
+}
+
+func warnTokenExpiry(cfg *config.Config) {
+	expires, left, err := cfg.RefreshTokenExpiry()
+	if err != nil || !expires || left <= 0 || left > config.TokenExpiryWarning {
+		return
+	}
+	reporter, err := rprtr.New().Build()
+	if err != nil {
+		return
+	}
+	reporter.Warn(rprtr.WarnTokenExpiring, "Your offline access token expires %s. "+
+		"Get a new one at %s and run 'rosa login' to avoid interruptions",
+		humanize.Time(time.Now().Add(left)), cfg.TokenPage())
+}
+
+func warnEnvironmentMismatch(cfg *config.Config) {
+	mismatch := cfg.EnvironmentMismatch()
+	if mismatch == "" {
+		return
+	}
+	reporter, err := rprtr.New().Build()
+	if err != nil {
+		return
+	}
+	reporter.Warn(rprtr.WarnTokenEnvironment,
+		"%s. Run 'rosa login' again with a token for that environment or with the "+
+			"'--env' flag", mismatch)
+}
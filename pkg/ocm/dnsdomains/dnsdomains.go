@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to manage the base DNS domains reserved by the
+// organization, which can be used as the base domain of new clusters instead of a domain generated
+// for each cluster. The DNS domains aren't supported yet by the version of the SDK used by the
+// tool, so they are managed with raw requests.
+
+package dnsdomains
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// DNSDomainsPath is the path of the collection of DNS domains of the clusters management API.
+const DNSDomainsPath = "/api/clusters_mgmt/v1/dns_domains"
+
+// Regular expression used to check the DNS domains given by the user, so that there is no risk
+// of SQL injection in search queries:
+var domainRE = regexp.MustCompile(`^([a-z0-9]([-a-z0-9]*[a-z0-9])?\.)+[a-z]{2,}$`)
+
+// DNSDomain is a base DNS domain reserved by the organization.
+type DNSDomain struct {
+	ID         string     `json:"id"`
+	ClusterID  string     `json:"cluster_id,omitempty"`
+	ReservedAt *time.Time `json:"reserved_at,omitempty"`
+}
+
+// InUse checks if the domain is used by a cluster.
+func (d *DNSDomain) InUse() bool {
+	return d.ClusterID != ""
+}
+
+// IsValidDomain checks if the given text is a valid DNS domain.
+func IsValidDomain(domain string) bool {
+	return len(domain) <= 253 && domainRE.MatchString(domain)
+}
+
+// GetDNSDomains returns the DNS domains reserved by the organization of the current user, sorted
+// by identifier.
+func GetDNSDomains(connection *sdk.Connection) ([]*DNSDomain, error) {
+	items, err := ocm.ListAttributes(connection, DNSDomainsPath, "")
+	if err != nil {
+		return nil, err
+	}
+	domains := make([]*DNSDomain, 0, len(items))
+	for _, item := range items {
+		domains = append(domains, fromAttributes(item))
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].ID < domains[j].ID
+	})
+	return domains, nil
+}
+
+// GetDNSDomain returns the DNS domain with the given identifier, or nil if the organization
+// hasn't reserved it.
+func GetDNSDomain(connection *sdk.Connection, id string) (*DNSDomain, error) {
+	if !IsValidDomain(id) {
+		return nil, fmt.Errorf("DNS domain '%s' isn't valid", id)
+	}
+	items, err := ocm.ListAttributes(connection, DNSDomainsPath, fmt.Sprintf("id = '%s'", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return fromAttributes(items[0]), nil
+}
+
+// CreateDNSDomain reserves a new DNS domain for the organization. The name of the domain is
+// generated by the service.
+func CreateDNSDomain(connection *sdk.Connection) (*DNSDomain, error) {
+	result, err := ocm.PostAttributes(connection, DNSDomainsPath, map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	return fromAttributes(result), nil
+}
+
+// DeleteDNSDomain releases the DNS domain with the given identifier.
+func DeleteDNSDomain(connection *sdk.Connection, id string) error {
+	return ocm.DeletePath(connection, fmt.Sprintf("%s/%s", DNSDomainsPath, id))
+}
+
+// ValidateBaseDomain checks that the given domain can be used as the base domain of a new
+// cluster: it must be reserved by the organization and not be in use by another cluster.
+func ValidateBaseDomain(connection *sdk.Connection, id string) error {
+	domain, err := GetDNSDomain(connection, id)
+	if err != nil {
+		return err
+	}
+	if domain == nil {
+		return fmt.Errorf("DNS domain '%s' isn't reserved by your organization, run 'rosa list "+
+			"dns-domains' to see the reserved domains or 'rosa create dns-domain' to reserve "+
+			"a new one", id)
+	}
+	if domain.InUse() {
+		return fmt.Errorf("DNS domain '%s' is already used by cluster '%s'", id, domain.ClusterID)
+	}
+	return nil
+}
+
+func fromAttributes(item map[string]interface{}) *DNSDomain {
+	domain := &DNSDomain{}
+	domain.ID, _ = item["id"].(string)
+	if cluster, ok := item["cluster"].(map[string]interface{}); ok {
+		domain.ClusterID, _ = cluster["id"].(string)
+	}
+	if text, ok := item["reserved_at"].(string); ok {
+		reservedAt, err := time.Parse(time.RFC3339, text)
+		if err == nil {
+			domain.ReservedAt = &reservedAt
+		}
+	}
+	return domain
+}
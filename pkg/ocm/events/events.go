@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to build the timeline of the events of a cluster. The
+// events resource of the clusters management API only accepts new events for analytics and can't
+// be listed, so the timeline is built from the service logs of the cluster, where state changes,
+// provisioning errors and limited support notices are reported, together with the creation of the
+// cluster itself.
+
+package events
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Event is an entry of the timeline of a cluster.
+type Event struct {
+	Timestamp   time.Time `json:"timestamp"`
+	Severity    string    `json:"severity"`
+	Source      string    `json:"source"`
+	Summary     string    `json:"summary"`
+	Description string    `json:"description,omitempty"`
+}
+
+// ParseSince parses the value of the '--since' flag, either a duration like '24h' counted back
+// from the given time, a date like '2021-06-01' or a complete RFC 3339 time.
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("Duration '%s' must be positive", value)
+		}
+		return now.Add(-duration), nil
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return since, nil
+	}
+	since, err = time.Parse("2006-01-02", value)
+	if err == nil {
+		return since, nil
+	}
+	return time.Time{}, fmt.Errorf("Expected a duration like '24h', a date like '2006-01-02' "+
+		"or a time like '2006-01-02T15:04:05Z', but got '%s'", value)
+}
+
+// GetEvents returns the events of the cluster that happened at or after the given time, in
+// chronological order. All the events are returned if the time is zero.
+func GetEvents(connection *sdk.Connection, cluster *cmv1.Cluster, since time.Time) ([]*Event, error) {
+	var events []*Event
+	created := cluster.CreationTimestamp()
+	if !created.IsZero() && !created.Before(since) {
+		events = append(events, &Event{
+			Timestamp: created,
+			Severity:  string(slv1.SeverityInfo),
+			Source:    "OpenShift Cluster Manager",
+			Summary:   fmt.Sprintf("Cluster '%s' created", cluster.Name()),
+		})
+	}
+
+	// Service logs are only recorded once the cluster has an external identifier:
+	if cluster.ExternalID() != "" {
+		entries, err := getLogEntries(connection, cluster.ExternalID(), since)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			events = append(events, &Event{
+				Timestamp:   entry.Timestamp(),
+				Severity:    string(entry.Severity()),
+				Source:      entry.ServiceName(),
+				Summary:     entry.Summary(),
+				Description: entry.Description(),
+			})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
+func getLogEntries(connection *sdk.Connection, clusterUUID string,
+	since time.Time) (entries []*slv1.LogEntry, err error) {
+	search := fmt.Sprintf("cluster_uuid = '%s'", clusterUUID)
+	if !since.IsZero() {
+		search = fmt.Sprintf("%s and timestamp >= '%s'", search, since.UTC().Format(time.RFC3339))
+	}
+	collection := connection.ServiceLogs().V1().ClusterLogs()
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := collection.List().
+			Search(search).
+			Order("timestamp asc").
+			Page(page).
+			Size(size).
+			Send()
+		if err != nil {
+			return 0, false, handleErr(response.Error(), err)
+		}
+		entries = append(entries, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
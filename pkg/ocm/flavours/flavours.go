@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to get the flavours, the sets of predefined properties
+// of clusters like the instance types of the nodes and the default network configuration.
+
+package flavours
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// DefaultFlavour is the flavour used when creating clusters if no other is requested.
+const DefaultFlavour = "osd-4"
+
+// GetFlavours returns the flavours that are available to the current user, sorted by identifier.
+func GetFlavours(client *cmv1.Client) (flavours []*cmv1.Flavour, err error) {
+	collection := client.Flavours()
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := collection.List().
+			Order("id asc").
+			Page(page).
+			Size(size).
+			Send()
+		if err != nil {
+			return 0, false, handleErr(response.Error(), err)
+		}
+		flavours = append(flavours, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// GetFlavour returns the flavour with the given identifier. Flavours that require entitlements
+// that the organization of the user doesn't have aren't found.
+func GetFlavour(client *cmv1.Client, id string) (*cmv1.Flavour, error) {
+	response, err := client.Flavours().Flavour(id).Get().Send()
+	if err != nil {
+		switch response.Status() {
+		case http.StatusNotFound, http.StatusForbidden:
+			return nil, fmt.Errorf("Flavour '%s' doesn't exist or isn't available to your "+
+				"organization. Run 'rosa list flavours' to see the available flavours", id)
+		}
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
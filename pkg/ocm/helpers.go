@@ -29,6 +29,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 
+	"github.com/openshift/rosa/pkg/exit"
 	"github.com/openshift/rosa/pkg/ocm/properties"
 )
 
@@ -59,45 +60,28 @@ func HasClusters(client *cmv1.ClustersClient, creatorARN string) (bool, error) {
 	return response.Total() > 0, nil
 }
 
-func GetCluster(client *cmv1.ClustersClient, clusterKey string, creatorARN string) (*cmv1.Cluster, error) {
-	query := fmt.Sprintf(
-		"(id = '%s' or name = '%s') and properties.%s = '%s'",
-		clusterKey, clusterKey, properties.CreatorARN, creatorARN,
-	)
-	response, err := client.List().
-		Search(query).
-		Page(1).
-		Size(1).
-		Send()
-	if err != nil {
-		return nil, handleErr(response.Error(), err)
-	}
-
-	switch response.Total() {
-	case 0:
-		return nil, fmt.Errorf("There is no cluster with identifier or name '%s'", clusterKey)
-	case 1:
-		return response.Items().Slice()[0], nil
-	default:
-		return nil, fmt.Errorf("There are %d clusters with identifier or name '%s'", response.Total(), clusterKey)
-	}
+func GetIdentityProviders(client *cmv1.ClustersClient,
+	clusterID string) (idps []*cmv1.IdentityProvider, err error) {
+	request := client.Cluster(clusterID).IdentityProviders().List()
+	err = Paginate(func(page int, size int) (int, bool, error) {
+		response, err := request.Page(page).Size(size).Send()
+		if err != nil {
+			return 0, false, handleErr(response.Error(), err)
+		}
+		idps = append(idps, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	return idps, err
 }
 
-func GetIdentityProviders(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.IdentityProvider, error) {
-	idpClient := client.Cluster(clusterID).IdentityProviders()
-	response, err := idpClient.List().
-		Page(1).
-		Size(-1).
-		Send()
-	if err != nil {
-		return nil, handleErr(response.Error(), err)
-	}
-
-	return response.Items().Slice(), nil
+func IdentityProviderType(idp *cmv1.IdentityProvider) string {
+	return IdentityProviderTypeName(string(idp.Type()))
 }
 
-func IdentityProviderType(idp *cmv1.IdentityProvider) string {
-	switch idp.Type() {
+// IdentityProviderTypeName returns the name shown to users for the given type of identity
+// provider, as returned by the API.
+func IdentityProviderTypeName(idpType string) string {
+	switch idpType {
 	case "GithubIdentityProvider":
 		return "GitHub"
 	case "GitlabIdentityProvider":
@@ -115,6 +99,17 @@ func IdentityProviderType(idp *cmv1.IdentityProvider) string {
 	return ""
 }
 
+// Load balancer types supported by the ingresses:
+const (
+	LoadBalancerTypeClassic = "classic"
+	LoadBalancerTypeNLB     = "nlb"
+)
+
+// IsValidLoadBalancerType checks if the given value is one of the supported load balancer types.
+func IsValidLoadBalancerType(lbType string) bool {
+	return lbType == LoadBalancerTypeClassic || lbType == LoadBalancerTypeNLB
+}
+
 func GetIngresses(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.Ingress, error) {
 	ingressClient := client.Cluster(clusterID).Ingresses()
 	response, err := ingressClient.List().
@@ -330,17 +325,17 @@ func GetClusterState(client *cmv1.ClustersClient, clusterID string) (cmv1.Cluste
 	return response.Body().State(), nil
 }
 
-func GetMachinePools(client *cmv1.ClustersClient, clusterID string) ([]*cmv1.MachinePool, error) {
-	response, err := client.Cluster(clusterID).MachinePools().
-		List().
-		Page(1).
-		Size(-1).
-		Send()
-	if err != nil {
-		return nil, handleErr(response.Error(), err)
-	}
-
-	return response.Items().Slice(), nil
+func GetMachinePools(client *cmv1.ClustersClient, clusterID string) (machinePools []*cmv1.MachinePool, err error) {
+	request := client.Cluster(clusterID).MachinePools().List()
+	err = Paginate(func(page int, size int) (int, bool, error) {
+		response, err := request.Page(page).Size(size).Send()
+		if err != nil {
+			return 0, false, handleErr(response.Error(), err)
+		}
+		machinePools = append(machinePools, response.Items().Slice()...)
+		return response.Size(), true, nil
+	})
+	return machinePools, err
 }
 
 func handleErr(res *ocmerrors.Error, err error) error {
@@ -348,7 +343,7 @@ func handleErr(res *ocmerrors.Error, err error) error {
 	if msg == "" {
 		msg = err.Error()
 	}
-	return errors.New(msg)
+	return exit.Wrap(errors.New(WithOperationID(msg, res)), err)
 }
 
 func GetDefaultClusterFlavors(ocmClient *cmv1.Client, flavour string) (dMachinecidr *net.IPNet, dPodcidr *net.IPNet,
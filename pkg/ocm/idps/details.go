@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to describe identity providers in detail, including the
+// fields that the version of the SDK used by the tool doesn't support yet.
+
+package idps
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Details contains the configuration of an identity provider. Secrets are never included, and CA
+// bundles are only described by their fingerprint.
+type Details struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	MappingMethod string `json:"mapping_method,omitempty"`
+	AuthURL       string `json:"auth_url,omitempty"`
+
+	// Endpoints and settings specific to the type of the provider:
+	ClientID      string   `json:"client_id,omitempty"`
+	Issuer        string   `json:"issuer,omitempty"`
+	URL           string   `json:"url,omitempty"`
+	Hostname      string   `json:"hostname,omitempty"`
+	HostedDomain  string   `json:"hosted_domain,omitempty"`
+	Organizations []string `json:"organizations,omitempty"`
+	Teams         []string `json:"teams,omitempty"`
+	CA            string   `json:"ca,omitempty"`
+
+	// Auditing of the creation, when returned by the server:
+	CreationTimestamp string `json:"creation_timestamp,omitempty"`
+	Creator           string `json:"creator,omitempty"`
+}
+
+// Endpoint returns the endpoint of the provider that identities are verified against, if the
+// type of provider has one.
+func (d *Details) Endpoint() string {
+	switch {
+	case d.Issuer != "":
+		return d.Issuer
+	case d.URL != "":
+		return d.URL
+	case d.Hostname != "":
+		return d.Hostname
+	case d.HostedDomain != "":
+		return d.HostedDomain
+	}
+	return ""
+}
+
+// GetDetails returns the details of the identity providers of the cluster, sorted by name. The
+// htpasswd identity providers are excluded, like in the rest of the tool.
+func GetDetails(connection *sdk.Connection, cluster *cmv1.Cluster) ([]*Details, error) {
+	items, err := ocm.ListAttributes(connection,
+		fmt.Sprintf("%s/identity_providers", ocm.ClusterPath(cluster.ID())), "")
+	if err != nil {
+		return nil, err
+	}
+	oauthURL := strings.Replace(cluster.Console().URL(), "console-openshift-console", "oauth-openshift", 1)
+	details := []*Details{}
+	for _, item := range items {
+		idpType, _ := item["type"].(string)
+		if idpType == "HTPasswdIdentityProvider" {
+			continue
+		}
+		detail := &Details{
+			Type: ocm.IdentityProviderTypeName(idpType),
+		}
+		detail.ID, _ = item["id"].(string)
+		detail.Name, _ = item["name"].(string)
+		detail.MappingMethod, _ = item["mapping_method"].(string)
+		detail.CreationTimestamp, _ = item["creation_timestamp"].(string)
+		detail.Creator, _ = item["creator"].(string)
+		if oauthURL != "" {
+			detail.AuthURL = fmt.Sprintf("%s/oauth2callback/%s", oauthURL, detail.Name)
+		}
+		config, _ := item[typeFields[idpType]].(map[string]interface{})
+		detail.ClientID, _ = config["client_id"].(string)
+		detail.Issuer, _ = config["issuer"].(string)
+		detail.URL, _ = config["url"].(string)
+		detail.Hostname, _ = config["hostname"].(string)
+		detail.HostedDomain, _ = config["hosted_domain"].(string)
+		detail.Organizations = toStrings(config["organizations"])
+		detail.Teams = toStrings(config["teams"])
+		if ca, _ := config["ca"].(string); ca != "" {
+			detail.CA = fingerprint(ca)
+		}
+		details = append(details, detail)
+	}
+	sort.Slice(details, func(i, j int) bool {
+		return details[i].Name < details[j].Name
+	})
+	return details, nil
+}
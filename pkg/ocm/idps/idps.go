@@ -0,0 +1,260 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to edit the mutable fields of existing identity
+// providers, so that changing them doesn't require deleting and creating the identity provider
+// again, which logs out all its users.
+//
+// The identity providers are patched with raw requests, sending the complete configuration of
+// the provider type with the changed fields, as the server replaces the nested objects. Secrets
+// aren't returned by the server, so they are only sent when they are changed.
+
+package idps
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// MappingMethods are the supported methods to map identities to users.
+var MappingMethods = []string{"add", "claim", "generate", "lookup"}
+
+// Changes contains the new values of the mutable fields of an identity provider. Fields that are
+// nil aren't changed.
+type Changes struct {
+	ClientSecret  *string
+	CA            *string
+	MappingMethod *string
+
+	// Claims of OpenID providers, or attributes of LDAP providers, used for the identity of the
+	// users:
+	EmailClaims    *[]string
+	NameClaims     *[]string
+	UsernameClaims *[]string
+}
+
+// Change is the difference in one field of an identity provider.
+type Change struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Plan is the request that applies the changes to an identity provider.
+type Plan struct {
+	Changes []*Change
+	body    map[string]interface{}
+}
+
+// typeFields contains the field of the identity provider that contains the configuration of each
+// type of identity provider.
+var typeFields = map[string]string{
+	"GithubIdentityProvider": "github",
+	"GitlabIdentityProvider": "gitlab",
+	"GoogleIdentityProvider": "google",
+	"LDAPIdentityProvider":   "ldap",
+	"OpenIDIdentityProvider": "open_id",
+}
+
+// IdentityProviderPath returns the path of the identity provider of the cluster.
+func IdentityProviderPath(clusterID, idpID string) string {
+	return fmt.Sprintf("%s/identity_providers/%s", ocm.ClusterPath(clusterID), idpID)
+}
+
+// GetIdentityProvider returns the raw attributes of the identity provider of the cluster.
+func GetIdentityProvider(connection *sdk.Connection, clusterID, idpID string) (
+	map[string]interface{}, error) {
+	return ocm.GetAttributes(connection, IdentityProviderPath(clusterID, idpID))
+}
+
+// NewPlan checks that the changes can be applied to the identity provider with the given raw
+// attributes, and returns the request that applies them together with the differences.
+func NewPlan(idp map[string]interface{}, changes *Changes) (*Plan, error) {
+	idpType, _ := idp["type"].(string)
+	typeField, ok := typeFields[idpType]
+	if !ok {
+		return nil, fmt.Errorf("Identity providers of type '%s' can't be edited", idpType)
+	}
+	current, _ := idp[typeField].(map[string]interface{})
+	updated := map[string]interface{}{}
+	for key, value := range current {
+		updated[key] = value
+	}
+
+	plan := &Plan{
+		body: map[string]interface{}{},
+	}
+
+	if changes.MappingMethod != nil {
+		if !isValidMappingMethod(*changes.MappingMethod) {
+			return nil, fmt.Errorf("Expected a valid mapping method. Options are %s", MappingMethods)
+		}
+		old, _ := idp["mapping_method"].(string)
+		if old != *changes.MappingMethod {
+			plan.body["mapping_method"] = *changes.MappingMethod
+			plan.add("mapping method", old, *changes.MappingMethod)
+		}
+	}
+
+	if changes.ClientSecret != nil {
+		// LDAP providers use a bind password instead of a client secret:
+		if typeField == "ldap" {
+			return nil, fmt.Errorf("LDAP identity providers don't have a client secret")
+		}
+		if *changes.ClientSecret == "" {
+			return nil, fmt.Errorf("Client secret can't be empty")
+		}
+		updated["client_secret"] = *changes.ClientSecret
+		plan.add("client secret", "(hidden)", "(changed)")
+	}
+
+	if changes.CA != nil {
+		if typeField == "google" {
+			return nil, fmt.Errorf("Google identity providers don't support a CA bundle")
+		}
+		old, _ := current["ca"].(string)
+		if old != *changes.CA {
+			if *changes.CA == "" {
+				delete(updated, "ca")
+			} else {
+				updated["ca"] = *changes.CA
+			}
+			plan.add("CA bundle", fingerprint(old), fingerprint(*changes.CA))
+		}
+	}
+
+	if changes.EmailClaims != nil || changes.NameClaims != nil || changes.UsernameClaims != nil {
+		var claimsField string
+		switch typeField {
+		case "open_id":
+			claimsField = "claims"
+		case "ldap":
+			claimsField = "attributes"
+		default:
+			return nil, fmt.Errorf("Only OpenID and LDAP identity providers have claim mappings")
+		}
+		claims := map[string]interface{}{}
+		oldClaims, _ := current[claimsField].(map[string]interface{})
+		for key, value := range oldClaims {
+			claims[key] = value
+		}
+		plan.setClaim(claims, oldClaims, "email", "email claims", changes.EmailClaims)
+		plan.setClaim(claims, oldClaims, "name", "name claims", changes.NameClaims)
+		plan.setClaim(claims, oldClaims, "preferred_username", "username claims",
+			changes.UsernameClaims)
+		if typeField == "open_id" && len(toStrings(claims["email"])) == 0 &&
+			len(toStrings(claims["name"])) == 0 &&
+			len(toStrings(claims["preferred_username"])) == 0 {
+			return nil, fmt.Errorf("At least one claim is required: " +
+				"[email-claims name-claims username-claims]")
+		}
+		updated[claimsField] = claims
+	}
+
+	if len(plan.Changes) > 0 && typeFieldChanged(plan) {
+		plan.body[typeField] = updated
+	}
+	return plan, nil
+}
+
+// Empty checks if the plan doesn't change anything.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Apply sends the request that applies the changes to the identity provider of the cluster.
+func (p *Plan) Apply(connection *sdk.Connection, clusterID, idpID string) error {
+	if p.Empty() {
+		return nil
+	}
+	return ocm.PatchAttributes(connection, IdentityProviderPath(clusterID, idpID), p.body)
+}
+
+func (p *Plan) add(field, old, new string) {
+	p.Changes = append(p.Changes, &Change{
+		Field: field,
+		Old:   old,
+		New:   new,
+	})
+}
+
+func (p *Plan) setClaim(claims, oldClaims map[string]interface{}, key, field string,
+	values *[]string) {
+	if values == nil {
+		return
+	}
+	old := toStrings(oldClaims[key])
+	if strings.Join(old, ",") == strings.Join(*values, ",") {
+		return
+	}
+	if len(*values) == 0 {
+		delete(claims, key)
+	} else {
+		claims[key] = *values
+	}
+	p.add(field, strings.Join(old, ","), strings.Join(*values, ","))
+}
+
+// typeFieldChanged checks if any of the changes is in the configuration of the provider type, as
+// opposed to the common fields like the mapping method.
+func typeFieldChanged(plan *Plan) bool {
+	for _, change := range plan.Changes {
+		if change.Field != "mapping method" {
+			return true
+		}
+	}
+	return false
+}
+
+func isValidMappingMethod(value string) bool {
+	for _, method := range MappingMethods {
+		if value == method {
+			return true
+		}
+	}
+	return false
+}
+
+// fingerprint returns a short description of a CA bundle, so that differences can be shown
+// without printing the complete certificates.
+func fingerprint(ca string) string {
+	if ca == "" {
+		return "(none)"
+	}
+	sum := sha256.Sum256([]byte(ca))
+	return fmt.Sprintf("%d certificate(s), sha256:%s",
+		strings.Count(ca, "BEGIN CERTIFICATE"), hex.EncodeToString(sum[:])[:12])
+}
+
+func toStrings(value interface{}) []string {
+	items, _ := value.([]interface{})
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if text, ok := item.(string); ok {
+			result = append(result, text)
+		}
+	}
+	if typed, ok := value.([]string); ok {
+		result = append(result, typed...)
+	}
+	return result
+}
@@ -0,0 +1,90 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to manage the grants that give AWS IAM users and roles
+// access to the AWS infrastructure of a cluster.
+
+package ocm
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// GetAWSInfrastructureAccessRoles returns the roles that can be granted to access the AWS
+// infrastructure of clusters.
+func GetAWSInfrastructureAccessRoles(client *cmv1.Client) ([]*cmv1.AWSInfrastructureAccessRole, error) {
+	response, err := client.AWSInfrastructureAccessRoles().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// GetAWSInfrastructureAccessRoleGrants returns the grants of AWS infrastructure access roles of
+// the given cluster.
+func GetAWSInfrastructureAccessRoleGrants(client *cmv1.ClustersClient,
+	clusterID string) ([]*cmv1.AWSInfrastructureAccessRoleGrant, error) {
+	response, err := client.Cluster(clusterID).
+		AWSInfrastructureAccessRoleGrants().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// AddAWSInfrastructureAccessRoleGrant grants the given role to the AWS IAM user or role with the
+// given ARN.
+func AddAWSInfrastructureAccessRoleGrant(client *cmv1.ClustersClient, clusterID string, roleID string,
+	userARN string) (*cmv1.AWSInfrastructureAccessRoleGrant, error) {
+	grant, err := cmv1.NewAWSInfrastructureAccessRoleGrant().
+		Role(cmv1.NewAWSInfrastructureAccessRole().ID(roleID)).
+		UserARN(userARN).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Cluster(clusterID).
+		AWSInfrastructureAccessRoleGrants().
+		Add().
+		Body(grant).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// DeleteAWSInfrastructureAccessRoleGrant revokes the grant with the given identifier.
+func DeleteAWSInfrastructureAccessRoleGrant(client *cmv1.ClustersClient, clusterID string,
+	grantID string) error {
+	response, err := client.Cluster(clusterID).
+		AWSInfrastructureAccessRoleGrants().
+		AWSInfrastructureAccessRoleGrant(grantID).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
@@ -0,0 +1,247 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to manage the kubelet configurations of clusters and
+// to attach them to machine pools. The kubelet configurations aren't supported yet by the version
+// of the SDK used by the tool, so they are managed with raw requests.
+
+package kubeletconfigs
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Limits of the values of the kubelet configurations accepted by the service:
+const (
+	MinPodPidsLimit = 4096
+	MaxPodPidsLimit = 16384
+	MinMaxPods      = 10
+	MaxMaxPods      = 500
+)
+
+// MachinePoolAttribute is the attribute of the machine pools that contains the names of the
+// kubelet configurations applied to the nodes of the pool.
+const MachinePoolAttribute = "kubelet_configs"
+
+// Regular expression used to check the names of the kubelet configurations:
+var nameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// KubeletConfig is a kubelet configuration of a cluster. Values that are zero aren't set.
+type KubeletConfig struct {
+	ID           string
+	Name         string
+	PodPidsLimit int
+	MaxPods      int
+}
+
+// KubeletConfigsPath returns the path of the collection of kubelet configurations of the cluster.
+func KubeletConfigsPath(clusterID string) string {
+	return ocm.ClusterPath(clusterID) + "/kubelet_configs"
+}
+
+// IsValidName checks if the given text can be used as the name of a kubelet configuration.
+func IsValidName(name string) bool {
+	return len(name) <= 63 && nameRE.MatchString(name)
+}
+
+// Validate checks that the values of the kubelet configuration are in the accepted ranges, and
+// that at least one of them is set.
+func (k *KubeletConfig) Validate() error {
+	if k.PodPidsLimit == 0 && k.MaxPods == 0 {
+		return fmt.Errorf("Expected at least one of the pod PIDs limit or the maximum number " +
+			"of pods")
+	}
+	if k.PodPidsLimit != 0 && (k.PodPidsLimit < MinPodPidsLimit || k.PodPidsLimit > MaxPodPidsLimit) {
+		return fmt.Errorf("Pod PIDs limit must be between %d and %d", MinPodPidsLimit,
+			MaxPodPidsLimit)
+	}
+	if k.MaxPods != 0 && (k.MaxPods < MinMaxPods || k.MaxPods > MaxMaxPods) {
+		return fmt.Errorf("Maximum number of pods per node must be between %d and %d", MinMaxPods,
+			MaxMaxPods)
+	}
+	return nil
+}
+
+// GetKubeletConfigs returns the kubelet configurations of the cluster, sorted by name.
+func GetKubeletConfigs(connection *sdk.Connection, clusterID string) ([]*KubeletConfig, error) {
+	items, err := ocm.ListAttributes(connection, KubeletConfigsPath(clusterID), "")
+	if err != nil {
+		return nil, err
+	}
+	configs := make([]*KubeletConfig, 0, len(items))
+	for _, item := range items {
+		configs = append(configs, fromAttributes(item))
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+	return configs, nil
+}
+
+// GetKubeletConfig returns the kubelet configuration of the cluster with the given name, or nil
+// if it doesn't exist.
+func GetKubeletConfig(connection *sdk.Connection, clusterID, name string) (*KubeletConfig, error) {
+	configs, err := GetKubeletConfigs(connection, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for _, config := range configs {
+		if config.Name == name {
+			return config, nil
+		}
+	}
+	return nil, nil
+}
+
+// CreateKubeletConfig adds the given kubelet configuration to the cluster.
+func CreateKubeletConfig(connection *sdk.Connection, clusterID string, config *KubeletConfig) (
+	*KubeletConfig, error) {
+	if !IsValidName(config.Name) {
+		return nil, fmt.Errorf("Kubelet configuration name '%s' isn't valid: it must contain "+
+			"only lowercase letters, digits and dashes", config.Name)
+	}
+	err := config.Validate()
+	if err != nil {
+		return nil, err
+	}
+	result, err := ocm.PostAttributes(connection, KubeletConfigsPath(clusterID),
+		toAttributes(config))
+	if err != nil {
+		return nil, err
+	}
+	return fromAttributes(result), nil
+}
+
+// UpdateKubeletConfig changes the values of an existing kubelet configuration of the cluster.
+func UpdateKubeletConfig(connection *sdk.Connection, clusterID string, config *KubeletConfig) error {
+	err := config.Validate()
+	if err != nil {
+		return err
+	}
+	attributes := toAttributes(config)
+	delete(attributes, "name")
+	return ocm.PatchAttributes(connection,
+		fmt.Sprintf("%s/%s", KubeletConfigsPath(clusterID), config.ID), attributes)
+}
+
+// CheckExist checks that the kubelet configurations with the given names exist in the cluster.
+func CheckExist(connection *sdk.Connection, clusterID string, names []string) error {
+	configs, err := GetKubeletConfigs(connection, clusterID)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for _, config := range configs {
+		existing[config.Name] = true
+	}
+	for _, name := range names {
+		if !existing[name] {
+			return fmt.Errorf("There is no kubelet configuration named '%s', create it with "+
+				"'rosa create kubeletconfig'", name)
+		}
+	}
+	return nil
+}
+
+// MaxPodsConfigName returns the name of the kubelet configuration created for the machine pool
+// when the maximum number of pods per node is given directly.
+func MaxPodsConfigName(machinePoolID string) string {
+	return machinePoolID + "-max-pods"
+}
+
+// EnsureMaxPods creates or updates the kubelet configuration of the machine pool that sets the
+// given maximum number of pods per node, and returns its name.
+func EnsureMaxPods(connection *sdk.Connection, clusterID, machinePoolID string, maxPods int) (
+	string, error) {
+	name := MaxPodsConfigName(machinePoolID)
+	existing, err := GetKubeletConfig(connection, clusterID, name)
+	if err != nil {
+		return "", err
+	}
+	if existing != nil {
+		if existing.MaxPods != maxPods {
+			existing.MaxPods = maxPods
+			err = UpdateKubeletConfig(connection, clusterID, existing)
+		}
+		return name, err
+	}
+	_, err = CreateKubeletConfig(connection, clusterID, &KubeletConfig{
+		Name:    name,
+		MaxPods: maxPods,
+	})
+	return name, err
+}
+
+// AttachToMachinePool sets the kubelet configurations applied to the nodes of the machine pool.
+func AttachToMachinePool(connection *sdk.Connection, clusterID, machinePoolID string,
+	names []string) error {
+	return ocm.PatchAttributes(connection,
+		fmt.Sprintf("%s/machine_pools/%s", ocm.ClusterPath(clusterID), machinePoolID),
+		map[string]interface{}{
+			MachinePoolAttribute: names,
+		})
+}
+
+// GetMachinePoolConfigs returns the names of the kubelet configurations applied to the nodes of
+// the machine pool.
+func GetMachinePoolConfigs(connection *sdk.Connection, clusterID, machinePoolID string) (
+	[]string, error) {
+	attributes, err := ocm.GetAttributes(connection,
+		fmt.Sprintf("%s/machine_pools/%s", ocm.ClusterPath(clusterID), machinePoolID))
+	if err != nil {
+		return nil, err
+	}
+	items, _ := attributes[MachinePoolAttribute].([]interface{})
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+func toAttributes(config *KubeletConfig) map[string]interface{} {
+	attributes := map[string]interface{}{
+		"name": config.Name,
+	}
+	if config.PodPidsLimit != 0 {
+		attributes["pod_pids_limit"] = config.PodPidsLimit
+	}
+	if config.MaxPods != 0 {
+		attributes["max_pods"] = config.MaxPods
+	}
+	return attributes
+}
+
+func fromAttributes(attributes map[string]interface{}) *KubeletConfig {
+	config := &KubeletConfig{}
+	config.ID, _ = attributes["id"].(string)
+	config.Name, _ = attributes["name"].(string)
+	if value, ok := attributes["pod_pids_limit"].(float64); ok {
+		config.PodPidsLimit = int(value)
+	}
+	if value, ok := attributes["max_pods"].(float64); ok {
+		config.MaxPods = int(value)
+	}
+	return config
+}
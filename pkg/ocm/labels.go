@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to manage the labels of organizations and subscriptions.
+// Labels are used, among other things, to enable capabilities requested via support.
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// Scopes of labels:
+const (
+	LabelScopeOrganization = "org"
+	LabelScopeSubscription = "subscription"
+)
+
+// LabelScopes contains the supported scopes of labels.
+var LabelScopes = []string{LabelScopeOrganization, LabelScopeSubscription}
+
+// labelKeyRE is the regular expression used to check label keys, which are also part of the path
+// of the label.
+var labelKeyRE = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_./]*[A-Za-z0-9])?$`)
+
+// IsValidLabelKey checks if the given text can be used as the key of a label.
+func IsValidLabelKey(key string) bool {
+	return labelKeyRE.MatchString(key)
+}
+
+// IsValidLabelScope checks if the given text is one of the supported scopes of labels.
+func IsValidLabelScope(scope string) bool {
+	for _, value := range LabelScopes {
+		if scope == value {
+			return true
+		}
+	}
+	return false
+}
+
+// GetLabelsClient returns the client for the labels of the organization of the current user or
+// of the subscription of the given cluster, depending on the scope.
+func GetLabelsClient(connection *sdk.Connection, scope string,
+	cluster *cmv1.Cluster) (*amsv1.GenericLabelsClient, error) {
+	switch scope {
+	case LabelScopeOrganization:
+		response, err := connection.AccountsMgmt().V1().CurrentAccount().
+			Get().
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		organizationID := response.Body().Organization().ID()
+		return connection.AccountsMgmt().V1().Organizations().Organization(organizationID).Labels(), nil
+	case LabelScopeSubscription:
+		if cluster == nil {
+			return nil, fmt.Errorf("A cluster is required for labels with scope '%s'", scope)
+		}
+		subscriptionID := cluster.Subscription().ID()
+		if subscriptionID == "" {
+			return nil, fmt.Errorf("Cluster '%s' has no subscription", cluster.Name())
+		}
+		return connection.AccountsMgmt().V1().Subscriptions().Subscription(subscriptionID).Labels(), nil
+	default:
+		return nil, fmt.Errorf("Unknown label scope '%s'", scope)
+	}
+}
+
+// GetLabels returns the labels of the given collection.
+func GetLabels(client *amsv1.GenericLabelsClient) ([]*amsv1.Label, error) {
+	response, err := client.List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
+
+// AddLabel adds the label with the given key and value to the given collection.
+func AddLabel(client *amsv1.GenericLabelsClient, key string, value string) (*amsv1.Label, error) {
+	label, err := amsv1.NewLabel().
+		Key(key).
+		Value(value).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	response, err := client.Add().
+		Body(label).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
+// DeleteLabel deletes the label with the given key from the given collection.
+func DeleteLabel(client *amsv1.GenericLabelsClient, key string) error {
+	response, err := client.Labels(key).
+		Delete().
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to get the reasons why a cluster is in limited
+// support, which means that SRE can't fully support it until the problems are fixed. They aren't
+// supported yet by the version of the SDK used by the tool, so they are read with raw requests.
+
+package limitedsupport
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Reason is a reason why a cluster is in limited support. The details usually explain how to fix
+// the problem.
+type Reason struct {
+	ID            string    `json:"id"`
+	Summary       string    `json:"summary"`
+	Details       string    `json:"details,omitempty"`
+	DetectionType string    `json:"detection_type,omitempty"`
+	Created       time.Time `json:"created"`
+}
+
+// GetReasons returns the reasons why the given cluster is in limited support, oldest first. The
+// cluster is fully supported when there are no reasons.
+func GetReasons(connection *sdk.Connection, clusterID string) ([]*Reason, error) {
+	items, err := ocm.ListAttributes(connection,
+		fmt.Sprintf("%s/limited_support_reasons", ocm.ClusterPath(clusterID)), "")
+	if err != nil {
+		return nil, err
+	}
+	var reasons []*Reason
+	for _, item := range items {
+		reason := &Reason{}
+		reason.ID, _ = item["id"].(string)
+		reason.Summary, _ = item["summary"].(string)
+		reason.Details, _ = item["details"].(string)
+		reason.DetectionType, _ = item["detection_type"].(string)
+		if created, ok := item["creation_timestamp"].(string); ok {
+			reason.Created, _ = time.Parse(time.RFC3339, created)
+		}
+		reasons = append(reasons, reason)
+	}
+	sort.SliceStable(reasons, func(i, j int) bool {
+		return reasons[i].Created.Before(reasons[j].Created)
+	})
+	return reasons, nil
+}
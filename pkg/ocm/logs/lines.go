@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to parse the installer logs into structured lines, so
+// that they can be filtered by component and level.
+
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Components of the cluster that log lines can be attributed to:
+const (
+	ComponentBootstrap = "bootstrap"
+	ComponentMaster    = "master"
+	ComponentWorker    = "worker"
+)
+
+// Components is the list of components that can be used to filter log lines.
+var Components = []string{
+	ComponentBootstrap,
+	ComponentMaster,
+	ComponentWorker,
+}
+
+// Levels is the list of log levels, from the least to the most severe.
+var Levels = []string{
+	"debug",
+	"info",
+	"warning",
+	"error",
+	"fatal",
+}
+
+// Line is a parsed line of the installer log.
+type Line struct {
+	// Raw is the complete text of the line, as returned by the API.
+	Raw string
+
+	Time    string
+	Level   string
+	Message string
+
+	// Component is the component the line refers to, or empty if it can't be determined.
+	Component string
+}
+
+// Filter selects the log lines to display. Empty fields match all lines.
+type Filter struct {
+	Component string
+	Level     string
+}
+
+// The installer writes lines in the logfmt format, for example:
+//
+//	time="2020-10-01T10:00:00Z" level=info msg="Waiting up to 20m0s for bootstrapping to complete..."
+var (
+	timeField  = regexp.MustCompile(`\btime="?([^" ]*)"?`)
+	levelField = regexp.MustCompile(`\blevel=([a-z]+)`)
+	msgField   = regexp.MustCompile(`\bmsg="((?:[^"\\]|\\.)*)"`)
+)
+
+// The installer doesn't tag lines with the component, so it is guessed from the message:
+var componentPatterns = []struct {
+	component string
+	pattern   *regexp.Regexp
+}{
+	{ComponentBootstrap, regexp.MustCompile(`(?i)bootstrap`)},
+	{ComponentMaster, regexp.MustCompile(`(?i)\b(master|control[ -]plane|kube-apiserver|etcd)`)},
+	{ComponentWorker, regexp.MustCompile(`(?i)\b(worker|compute|machineset|ingress)`)},
+}
+
+// errorPattern matches the parts of lines that indicate that something went wrong.
+var errorPattern = regexp.MustCompile(`(?i)\b(error|errors|failed|failure|fatal)\b`)
+
+// ParseLines parses the given log content into lines.
+func ParseLines(content string) []*Line {
+	var lines []*Line
+	for _, text := range strings.Split(content, "\n") {
+		if text == "" {
+			continue
+		}
+		lines = append(lines, ParseLine(text))
+	}
+	return lines
+}
+
+// ParseLine parses a single line of the installer log. Lines that don't use the logfmt format
+// are kept as they are, with the message set to the complete line.
+func ParseLine(text string) *Line {
+	line := &Line{
+		Raw:     text,
+		Message: text,
+	}
+	if match := timeField.FindStringSubmatch(text); match != nil {
+		line.Time = match[1]
+	}
+	if match := levelField.FindStringSubmatch(text); match != nil {
+		line.Level = normalizeLevel(match[1])
+	}
+	if match := msgField.FindStringSubmatch(text); match != nil {
+		line.Message = strings.ReplaceAll(match[1], `\"`, `"`)
+	}
+	for _, candidate := range componentPatterns {
+		if candidate.pattern.MatchString(line.Message) {
+			line.Component = candidate.component
+			break
+		}
+	}
+	return line
+}
+
+// ValidateFilter checks that the component and level of the filter are supported.
+func ValidateFilter(filter Filter) error {
+	if filter.Component != "" && indexOf(Components, filter.Component) < 0 {
+		return fmt.Errorf("Invalid component '%s', expected one of %v", filter.Component, Components)
+	}
+	if filter.Level != "" && indexOf(Levels, normalizeLevel(filter.Level)) < 0 {
+		return fmt.Errorf("Invalid log level '%s', expected one of %v", filter.Level, Levels)
+	}
+	return nil
+}
+
+// Matches checks if the line is selected by the filter. The level of the filter is the minimum
+// level, so lines with more severe levels are also selected. Lines without a level are only
+// selected when the filter doesn't have a level.
+func (f Filter) Matches(line *Line) bool {
+	if f.Component != "" && line.Component != f.Component {
+		return false
+	}
+	if f.Level != "" {
+		return indexOf(Levels, line.Level) >= indexOf(Levels, normalizeLevel(f.Level))
+	}
+	return true
+}
+
+// HighlightErrors returns the text of the line with the words that indicate errors surrounded by
+// the given prefix and suffix, usually ANSI escape sequences that change the color.
+func HighlightErrors(text string, prefix string, suffix string) string {
+	return errorPattern.ReplaceAllString(text, prefix+"$1"+suffix)
+}
+
+func normalizeLevel(level string) string {
+	level = strings.ToLower(level)
+	switch level {
+	case "warn":
+		return "warning"
+	case "err":
+		return "error"
+	}
+	return level
+}
+
+func indexOf(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -14,7 +14,7 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-package ocm
+package logs
 
 import (
 	"context"
@@ -23,7 +23,11 @@ import (
 	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
 	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
 )
 
 const interval = 15 * time.Second
@@ -107,3 +111,11 @@ func PollUninstallLogs(client *cmv1.ClustersClient, clusterID string,
 
 	return response.Body(), nil
 }
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(fmt.Errorf("%s", ocm.WithOperationID(msg, res)), err)
+}
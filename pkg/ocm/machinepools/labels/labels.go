@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to apply a set of node labels to all the machine pools
+// of a cluster. OCM only supports labels on individual machine pools, so the labels are added to
+// each machine pool in turn, keeping the labels that each machine pool already has.
+
+package labels
+
+import (
+	"errors"
+	"fmt"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// DefaultPoolID is the identifier of the machine pool created together with the cluster. Its
+// labels can't be changed after the cluster is created.
+const DefaultPoolID = "Default"
+
+// Outcomes of the update of each machine pool:
+const (
+	OutcomeUpdated   = "updated"
+	OutcomeUnchanged = "unchanged"
+	OutcomeExcluded  = "excluded"
+	OutcomeSkipped   = "skipped"
+	OutcomeFailed    = "failed"
+)
+
+// Result is the outcome of applying the labels to a machine pool. The error explains why a
+// machine pool was skipped or failed.
+type Result struct {
+	MachinePool string
+	Outcome     string
+	Error       error
+}
+
+// Converge adds the given labels to all the machine pools of the cluster, except the ones in the
+// exclusion list, replacing the values of the labels that the machine pools already have with the
+// same keys. A failure to update a machine pool doesn't stop the update of the rest, it is
+// reported in the result of that machine pool.
+func Converge(client *cmv1.ClustersClient, cluster *cmv1.Cluster, labels map[string]string,
+	exclude []string) ([]*Result, error) {
+	machinePools, err := ocm.GetMachinePools(client, cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[string]bool{}
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	results := []*Result{}
+	defaultResult := &Result{
+		MachinePool: DefaultPoolID,
+	}
+	switch {
+	case excluded[DefaultPoolID]:
+		defaultResult.Outcome = OutcomeExcluded
+	case !changed(cluster.Nodes().ComputeLabels(), labels):
+		defaultResult.Outcome = OutcomeUnchanged
+	default:
+		defaultResult.Outcome = OutcomeSkipped
+		defaultResult.Error = fmt.Errorf("Labels can't be updated on the %s machine pool",
+			DefaultPoolID)
+	}
+	results = append(results, defaultResult)
+
+	for _, machinePool := range machinePools {
+		result := &Result{
+			MachinePool: machinePool.ID(),
+		}
+		results = append(results, result)
+		if excluded[machinePool.ID()] {
+			result.Outcome = OutcomeExcluded
+			continue
+		}
+		if !changed(machinePool.Labels(), labels) {
+			result.Outcome = OutcomeUnchanged
+			continue
+		}
+		err = update(client, cluster.ID(), machinePool, labels)
+		if err != nil {
+			result.Outcome = OutcomeFailed
+			result.Error = err
+			continue
+		}
+		result.Outcome = OutcomeUpdated
+	}
+	return results, nil
+}
+
+// changed checks if adding the given labels changes the current ones.
+func changed(current map[string]string, labels map[string]string) bool {
+	for key, value := range labels {
+		if currentValue, ok := current[key]; !ok || currentValue != value {
+			return true
+		}
+	}
+	return false
+}
+
+func update(client *cmv1.ClustersClient, clusterID string, machinePool *cmv1.MachinePool,
+	labels map[string]string) error {
+	merged := map[string]string{}
+	for key, value := range machinePool.Labels() {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+	body, err := cmv1.NewMachinePool().
+		ID(machinePool.ID()).
+		Labels(merged).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(clusterID).MachinePools().MachinePool(machinePool.ID()).Update().
+		Body(body).Send()
+	if err != nil {
+		return failure(response.Error(), err)
+	}
+	return nil
+}
+
+func failure(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return errors.New(ocm.WithOperationID(msg, res))
+}
@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the replacement of machine pools. OCM doesn't allow changing the instance
+// type of an existing machine pool, so the change is done creating a new machine pool with the new
+// instance type and moving the replicas to it in steps, so that the old nodes are drained as the
+// new ones become ready.
+
+package replace
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Options controls how a machine pool is replaced.
+type Options struct {
+	// Surge is the number of nodes added above the requested replicas at each step.
+	Surge int
+
+	// Timeout is the maximum time to wait for the compute nodes at each step.
+	Timeout time.Duration
+
+	// Progress is called to report each step of the replacement. It is optional.
+	Progress func(format string, args ...interface{})
+}
+
+// PoolID returns the identifier of the machine pool that replaces the given one with the given
+// instance type. The suffix added by a previous replacement is removed, so that replacing a pool
+// several times doesn't make the identifier longer.
+func PoolID(current *cmv1.MachinePool, instanceType string) string {
+	base := strings.TrimSuffix(current.ID(), "-"+typeSuffix(current.InstanceType()))
+	return fmt.Sprintf("%s-%s", base, typeSuffix(instanceType))
+}
+
+// Replace creates a machine pool like the target, with the identifier returned by PoolID, and
+// moves the replicas of the current machine pool to it, deleting the current machine pool at the
+// end. The target contains the instance type, replicas, autoscaling, labels and taints of the new
+// machine pool. If the replacement fails the current machine pool is left in place, so that the
+// replacement can be retried or undone.
+func Replace(client *cmv1.ClustersClient, clusterID string, current *cmv1.MachinePool,
+	target *cmv1.MachinePool, options *Options) (*cmv1.MachinePool, error) {
+	if target.InstanceType() == "" {
+		return nil, fmt.Errorf("Instance type of the new machine pool is required")
+	}
+	if target.InstanceType() == current.InstanceType() {
+		return nil, fmt.Errorf("Machine pool '%s' already uses instance type '%s'",
+			current.ID(), current.InstanceType())
+	}
+	if options.Surge < 1 {
+		return nil, fmt.Errorf("Surge must be at least 1")
+	}
+	progress := options.Progress
+	if progress == nil {
+		progress = func(string, ...interface{}) {}
+	}
+
+	newID := PoolID(current, target.InstanceType())
+	r := &replacement{
+		client:    client.Cluster(clusterID).MachinePools(),
+		clusters:  client,
+		clusterID: clusterID,
+		timeout:   options.Timeout,
+		progress:  progress,
+	}
+
+	// Pools with autoscaling are replaced at once: the new pool is created with all its replicas,
+	// or with its minimum replicas when it has autoscaling, and the old pool is deleted once the
+	// nodes are ready.
+	if target.Autoscaling() != nil || current.Autoscaling() != nil {
+		if target.Autoscaling() != nil {
+			progress("Creating machine pool '%s' with instance type '%s' and %d to %d replicas",
+				newID, target.InstanceType(), target.Autoscaling().MinReplicas(),
+				target.Autoscaling().MaxReplicas())
+		} else {
+			progress("Creating machine pool '%s' with instance type '%s' and %d replicas",
+				newID, target.InstanceType(), target.Replicas())
+		}
+		created, err := r.create(newID, target, target.Replicas())
+		if err != nil {
+			return nil, err
+		}
+		err = r.wait()
+		if err != nil {
+			return nil, err
+		}
+		err = r.remove(current.ID())
+		if err != nil {
+			return nil, err
+		}
+		return created, nil
+	}
+
+	// Other pools are replaced in steps: the new pool grows by the surge while the old one shrinks
+	// by the same amount, draining its nodes.
+	desired := target.Replicas()
+	oldReplicas := current.Replicas()
+	newReplicas := min(options.Surge, desired)
+	progress("Creating machine pool '%s' with instance type '%s' and %d replicas",
+		newID, target.InstanceType(), newReplicas)
+	created, err := r.create(newID, target, newReplicas)
+	if err != nil {
+		return nil, err
+	}
+	err = r.wait()
+	if err != nil {
+		return nil, err
+	}
+	for oldReplicas > 0 {
+		oldReplicas = max(oldReplicas-options.Surge, 0)
+		if oldReplicas > 0 {
+			progress("Draining machine pool '%s' to %d replicas", current.ID(), oldReplicas)
+			err = r.scale(current.ID(), oldReplicas)
+			if err != nil {
+				return nil, err
+			}
+			err = r.wait()
+			if err != nil {
+				return nil, err
+			}
+		}
+		if newReplicas < desired {
+			newReplicas = min(newReplicas+options.Surge, desired)
+			progress("Scaling machine pool '%s' to %d replicas", newID, newReplicas)
+			err = r.scale(newID, newReplicas)
+			if err != nil {
+				return nil, err
+			}
+			err = r.wait()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	err = r.remove(current.ID())
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// replacement contains the state shared by the steps of a replacement.
+type replacement struct {
+	client    *cmv1.MachinePoolsClient
+	clusters  *cmv1.ClustersClient
+	clusterID string
+	timeout   time.Duration
+	progress  func(format string, args ...interface{})
+}
+
+func (r *replacement) create(id string, target *cmv1.MachinePool, replicas int) (*cmv1.MachinePool, error) {
+	builder := cmv1.NewMachinePool().
+		ID(id).
+		InstanceType(target.InstanceType()).
+		Labels(target.Labels())
+	if len(target.AvailabilityZones()) > 0 {
+		builder = builder.AvailabilityZones(target.AvailabilityZones()...)
+	}
+	taints := []*cmv1.TaintBuilder{}
+	for _, taint := range target.Taints() {
+		taints = append(taints, cmv1.NewTaint().
+			Key(taint.Key()).
+			Value(taint.Value()).
+			Effect(taint.Effect()))
+	}
+	builder = builder.Taints(taints...)
+	if target.Autoscaling() != nil {
+		builder = builder.Autoscaling(cmv1.NewMachinePoolAutoscaling().
+			MinReplicas(target.Autoscaling().MinReplicas()).
+			MaxReplicas(target.Autoscaling().MaxReplicas()))
+	} else {
+		builder = builder.Replicas(replicas)
+	}
+	machinePool, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.client.Add().Body(machinePool).Send()
+	if err != nil {
+		err = failure(response.Error(), err)
+		return nil, exit.Wrap(fmt.Errorf("Failed to create machine pool '%s': %v", id, err), err)
+	}
+	return response.Body(), nil
+}
+
+func (r *replacement) scale(id string, replicas int) error {
+	machinePool, err := cmv1.NewMachinePool().
+		ID(id).
+		Replicas(replicas).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := r.client.MachinePool(id).Update().Body(machinePool).Send()
+	if err != nil {
+		err = failure(response.Error(), err)
+		return exit.Wrap(fmt.Errorf("Failed to scale machine pool '%s': %v", id, err), err)
+	}
+	return nil
+}
+
+func (r *replacement) remove(id string) error {
+	r.progress("Deleting machine pool '%s'", id)
+	response, err := r.client.MachinePool(id).Delete().Send()
+	if err != nil {
+		err = failure(response.Error(), err)
+		return exit.Wrap(fmt.Errorf("Failed to delete machine pool '%s': %v", id, err), err)
+	}
+	return nil
+}
+
+func (r *replacement) wait() error {
+	nodes, err := cluster.WaitForComputeNodes(r.clusters, r.clusterID, r.timeout)
+	if err != nil {
+		return err
+	}
+	r.progress("Cluster reports %d compute nodes", nodes)
+	return nil
+}
+
+// typeSuffix returns the instance type in a form that can be used in the identifier of a machine
+// pool, for example 'm5-2xlarge' for 'm5.2xlarge'.
+func typeSuffix(instanceType string) string {
+	return strings.ReplaceAll(strings.ToLower(instanceType), ".", "-")
+}
+
+func failure(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,179 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the gradual scaling of machine pools. Adding many nodes at once makes
+// them join the cluster at the same time, which loads etcd and the API servers and can exceed the
+// rate limits of the cloud provider, so the replicas are changed in batches, waiting for each
+// batch to be ready before starting the next one.
+
+package scale
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	clusterprovider "github.com/openshift/rosa/pkg/cluster"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// DefaultPoolID is the identifier of the machine pool created together with the cluster, which is
+// scaled changing the compute nodes of the cluster.
+const DefaultPoolID = "Default"
+
+// Options controls how a machine pool is scaled.
+type Options struct {
+	// Step is the maximum number of replicas added or removed in each batch. Zero means that
+	// all the replicas are changed in a single batch.
+	Step int
+
+	// Interval is the time to wait after a batch is ready before starting the next one.
+	Interval time.Duration
+
+	// Timeout is the maximum time to wait for the compute nodes of each batch.
+	Timeout time.Duration
+
+	// Progress is called to report each batch. It is optional.
+	Progress func(format string, args ...interface{})
+}
+
+// Replicas returns the current replicas of the given machine pool of the cluster. Machine pools
+// with autoscaling can't be scaled, as the autoscaler manages their replicas.
+func Replicas(client *cmv1.ClustersClient, cluster *cmv1.Cluster, machinePoolID string) (int, error) {
+	if machinePoolID == DefaultPoolID {
+		if cluster.Nodes().AutoscaleCompute() != nil {
+			return 0, fmt.Errorf("Machine pool '%s' has autoscaling enabled", machinePoolID)
+		}
+		return cluster.Nodes().Compute(), nil
+	}
+	response, err := client.Cluster(cluster.ID()).MachinePools().MachinePool(machinePoolID).Get().Send()
+	if err != nil {
+		return 0, failure(response.Error(), err)
+	}
+	if response.Body().Autoscaling() != nil {
+		return 0, fmt.Errorf("Machine pool '%s' has autoscaling enabled", machinePoolID)
+	}
+	return response.Body().Replicas(), nil
+}
+
+// Batches returns the replicas of the machine pool after each batch needed to go from the current
+// replicas to the desired ones with the given step.
+func Batches(current int, desired int, step int) []int {
+	var batches []int
+	for current != desired {
+		switch {
+		case step <= 0:
+			current = desired
+		case current < desired:
+			current = min(current+step, desired)
+		default:
+			current = max(current-step, desired)
+		}
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// Scale changes the replicas of the given machine pool of the cluster to the desired number in
+// batches, waiting until the cluster reports the compute nodes of each batch before starting the
+// next one. If a batch fails the machine pool is left with the replicas of the last batch that
+// was requested.
+func Scale(client *cmv1.ClustersClient, cluster *cmv1.Cluster, machinePoolID string, desired int,
+	options *Options) error {
+	progress := options.Progress
+	if progress == nil {
+		progress = func(string, ...interface{}) {}
+	}
+	current, err := Replicas(client, cluster, machinePoolID)
+	if err != nil {
+		return err
+	}
+	batches := Batches(current, desired, options.Step)
+	for i, replicas := range batches {
+		if i > 0 && options.Interval > 0 {
+			progress("Waiting %s before the next batch", options.Interval)
+			time.Sleep(options.Interval)
+		}
+		progress("Scaling machine pool '%s' to %d replicas (batch %d of %d)", machinePoolID,
+			replicas, i+1, len(batches))
+		err = update(client, cluster.ID(), machinePoolID, replicas)
+		if err != nil {
+			return err
+		}
+		nodes, err := clusterprovider.WaitForComputeNodes(client, cluster.ID(), options.Timeout)
+		if err != nil {
+			return err
+		}
+		progress("Cluster reports %d compute nodes", nodes)
+	}
+	return nil
+}
+
+func update(client *cmv1.ClustersClient, clusterID string, machinePoolID string, replicas int) error {
+	if machinePoolID == DefaultPoolID {
+		body, err := cmv1.NewCluster().
+			Nodes(cmv1.NewClusterNodes().Compute(replicas)).
+			Build()
+		if err != nil {
+			return err
+		}
+		response, err := client.Cluster(clusterID).Update().Body(body).Send()
+		if err != nil {
+			return fmt.Errorf("Failed to scale machine pool '%s': %v", machinePoolID,
+				failure(response.Error(), err))
+		}
+		return nil
+	}
+	body, err := cmv1.NewMachinePool().
+		ID(machinePoolID).
+		Replicas(replicas).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(clusterID).MachinePools().MachinePool(machinePoolID).Update().
+		Body(body).Send()
+	if err != nil {
+		return fmt.Errorf("Failed to scale machine pool '%s': %v", machinePoolID,
+			failure(response.Error(), err))
+	}
+	return nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func failure(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return errors.New(ocm.WithOperationID(msg, res))
+}
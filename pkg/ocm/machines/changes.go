@@ -0,0 +1,185 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to add or remove single taints and labels of a machine
+// pool, with the same syntax and semantics as 'kubectl taint' and 'kubectl label'.
+
+package machines
+
+import (
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// TaintEffects are the effects that taints can have.
+var TaintEffects = []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}
+
+// TaintChange is a taint to add, like 'key=value:NoSchedule', or to remove, like 'key:NoSchedule-'
+// or 'key-'. An empty effect in a removal removes the taints with the key and any effect.
+type TaintChange struct {
+	Key    string
+	Value  string
+	Effect string
+	Remove bool
+}
+
+// LabelChange is a label to add, like 'key=value', or to remove, like 'key-'.
+type LabelChange struct {
+	Key    string
+	Value  string
+	Remove bool
+}
+
+// ParseTaintChange parses a taint to add or remove.
+func ParseTaintChange(text string) (*TaintChange, error) {
+	change := &TaintChange{}
+	if strings.HasSuffix(text, "-") {
+		change.Remove = true
+		text = strings.TrimSuffix(text, "-")
+	}
+	if index := strings.LastIndex(text, ":"); index != -1 {
+		change.Effect = text[index+1:]
+		text = text[:index]
+		if !isTaintEffect(change.Effect) {
+			return nil, fmt.Errorf("Invalid taint effect '%s', expected one of '%s'", change.Effect,
+				strings.Join(TaintEffects, "', '"))
+		}
+	} else if !change.Remove {
+		return nil, fmt.Errorf("Expected key=value:effect format for taint '%s'", text)
+	}
+	change.Key = text
+	if index := strings.Index(text, "="); index != -1 {
+		if change.Remove {
+			return nil, fmt.Errorf("Expected key:effect- or key- format to remove taint '%s'", text)
+		}
+		change.Key = text[:index]
+		change.Value = text[index+1:]
+	}
+	if change.Key == "" {
+		return nil, fmt.Errorf("Expected a non-empty key for taint '%s'", text)
+	}
+	return change, nil
+}
+
+// ParseLabelChange parses a label to add or remove.
+func ParseLabelChange(text string) (*LabelChange, error) {
+	if strings.HasSuffix(text, "-") && !strings.Contains(text, "=") {
+		key := strings.TrimSuffix(text, "-")
+		if key == "" {
+			return nil, fmt.Errorf("Expected a non-empty key for label '%s'", text)
+		}
+		return &LabelChange{Key: key, Remove: true}, nil
+	}
+	tokens := strings.SplitN(text, "=", 2)
+	if len(tokens) != 2 {
+		return nil, fmt.Errorf("Expected key=value format to add label '%s' or key- to remove it", text)
+	}
+	if tokens[0] == "" {
+		return nil, fmt.Errorf("Expected a non-empty key for label '%s'", text)
+	}
+	return &LabelChange{Key: tokens[0], Value: tokens[1]}, nil
+}
+
+// ApplyTaintChanges returns the taints that result from applying the changes to the given taints.
+// Like 'kubectl taint', adding a taint that already exists with the same key and effect fails
+// unless overwrite is true, and removing a taint that doesn't exist fails.
+func ApplyTaintChanges(taints []*cmv1.Taint, changes []*TaintChange,
+	overwrite bool) ([]*cmv1.TaintBuilder, error) {
+	result := make([]*TaintChange, 0, len(taints))
+	for _, taint := range taints {
+		result = append(result, &TaintChange{
+			Key:    taint.Key(),
+			Value:  taint.Value(),
+			Effect: taint.Effect(),
+		})
+	}
+	for _, change := range changes {
+		if change.Remove {
+			kept := result[:0]
+			for _, taint := range result {
+				if taint.Key != change.Key || (change.Effect != "" && taint.Effect != change.Effect) {
+					kept = append(kept, taint)
+				}
+			}
+			if len(kept) == len(result) {
+				return nil, fmt.Errorf("Taint '%s' not found", formatTaintKey(change))
+			}
+			result = kept
+			continue
+		}
+		found := false
+		for _, taint := range result {
+			if taint.Key == change.Key && taint.Effect == change.Effect {
+				if !overwrite && taint.Value != change.Value {
+					return nil, fmt.Errorf("Taint '%s' already exists with value '%s', use "+
+						"'--overwrite' to replace it", formatTaintKey(change), taint.Value)
+				}
+				taint.Value = change.Value
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, change)
+		}
+	}
+	builders := make([]*cmv1.TaintBuilder, len(result))
+	for i, taint := range result {
+		builders[i] = cmv1.NewTaint().Key(taint.Key).Value(taint.Value).Effect(taint.Effect)
+	}
+	return builders, nil
+}
+
+// ApplyLabelChanges returns the labels that result from applying the changes to the given labels.
+// Like 'kubectl label', changing the value of a label that already exists fails unless overwrite
+// is true.
+func ApplyLabelChanges(labels map[string]string, changes []*LabelChange,
+	overwrite bool) (map[string]string, error) {
+	result := make(map[string]string, len(labels))
+	for key, value := range labels {
+		result[key] = value
+	}
+	for _, change := range changes {
+		if change.Remove {
+			delete(result, change.Key)
+			continue
+		}
+		value, ok := result[change.Key]
+		if ok && value != change.Value && !overwrite {
+			return nil, fmt.Errorf("Label '%s' already exists with value '%s', use '--overwrite' "+
+				"to replace it", change.Key, value)
+		}
+		result[change.Key] = change.Value
+	}
+	return result, nil
+}
+
+func isTaintEffect(effect string) bool {
+	for _, value := range TaintEffects {
+		if effect == value {
+			return true
+		}
+	}
+	return false
+}
+
+func formatTaintKey(change *TaintChange) string {
+	if change.Effect == "" {
+		return change.Key
+	}
+	return change.Key + ":" + change.Effect
+}
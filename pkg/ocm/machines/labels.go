@@ -0,0 +1,56 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machines
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseLabels parses the node labels of a machine pool, given as a comma separated list of
+// 'key=value' pairs. An empty text results in an empty map.
+func ParseLabels(text string) (map[string]string, error) {
+	labels := map[string]string{}
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return labels, nil
+	}
+	for _, label := range strings.Split(text, ",") {
+		tokens := strings.SplitN(label, "=", 2)
+		if len(tokens) != 2 {
+			return nil, fmt.Errorf("Expected key=value format for labels")
+		}
+		key := strings.TrimSpace(tokens[0])
+		if key == "" {
+			return nil, fmt.Errorf("Expected a non-empty key for label '%s'", label)
+		}
+		labels[key] = strings.TrimSpace(tokens[1])
+	}
+	return labels, nil
+}
+
+// FormatLabels returns the text of the given labels in the format accepted by ParseLabels, sorted
+// by key.
+func FormatLabels(labels map[string]string) string {
+	pairs := make([]string, 0, len(labels))
+	for key, value := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
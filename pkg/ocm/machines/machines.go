@@ -22,15 +22,17 @@ import (
 	"strings"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
 )
 
+// DefaultMachineType is the machine type of the compute nodes when the user doesn't select one.
+const DefaultMachineType = "m5.xlarge"
+
 func GetMachineTypes(client *cmv1.Client) (machineTypes []*cmv1.MachineType, err error) {
 	collection := client.MachineTypes()
-	page := 1
-	size := 100
-	for {
-		var response *cmv1.MachineTypesListResponse
-		response, err = collection.List().
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := collection.List().
 			Search("cloud_provider.id = 'aws'").
 			Order("cpu asc").
 			Page(page).
@@ -41,13 +43,13 @@ func GetMachineTypes(client *cmv1.Client) (machineTypes []*cmv1.MachineType, err
 			if errMsg == "" {
 				errMsg = err.Error()
 			}
-			return nil, errors.New(errMsg)
+			return 0, false, errors.New(ocm.WithOperationID(errMsg, response.Error()))
 		}
 		machineTypes = append(machineTypes, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return
 }
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machines
+
+import (
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// CheckAutoscalingQuota checks if the quota of compute nodes of the organization and the AWS
+// quota of vCPUs of the region allow the autoscaler to reach the maximum number of replicas, and
+// returns a warning for each quota that doesn't. The current replicas are the nodes of the machine
+// pool that already exist, and therefore are already counted by the quotas. The vCPUs of the
+// control plane and infrastructure nodes of a cluster that doesn't exist yet aren't counted.
+func CheckAutoscalingQuota(connection *sdk.Connection, awsClient aws.Client, machineTypeID string,
+	multiAZ bool, current int, maxReplicas int) ([]string, error) {
+	var warnings []string
+	extra := maxReplicas - current
+	if extra <= 0 {
+		return nil, nil
+	}
+
+	nodes, limited, err := ocm.GetComputeNodeQuota(connection, machineTypeID, multiAZ)
+	if err != nil {
+		return nil, exit.Wrap(fmt.Errorf("Failed to get compute node quota: %v", err), err)
+	}
+	if limited && nodes < extra {
+		warnings = append(warnings, fmt.Sprintf(
+			"The quota of the organization allows %d more '%s' compute nodes: scaling up beyond "+
+				"%d replicas will fail",
+			nodes, machineTypeID, current+nodes))
+	}
+
+	machineTypes, err := GetMachineTypes(connection.ClustersMgmt().V1())
+	if err != nil {
+		return warnings, exit.Wrap(fmt.Errorf("Failed to get machine types: %v", err), err)
+	}
+	vCPUs := 0
+	for _, machineType := range machineTypes {
+		if machineType.ID() == machineTypeID {
+			vCPUs = int(machineType.CPU().Value())
+		}
+	}
+	if vCPUs <= 0 {
+		return warnings, nil
+	}
+	available, err := awsClient.GetAvailableVCPUs()
+	if err != nil {
+		return warnings, exit.Wrap(fmt.Errorf("Failed to get AWS quota of vCPUs: %v", err), err)
+	}
+	if available/vCPUs < extra {
+		warnings = append(warnings, fmt.Sprintf(
+			"The AWS quota of running On-Demand Standard instances in region '%s' allows %d more "+
+				"vCPUs, enough for %d '%s' compute nodes: scaling up beyond %d replicas will fail. "+
+				"Request an increase of quota 'L-1216C47A' in the AWS Service Quotas console",
+			awsClient.GetRegion(), available, available/vCPUs, machineTypeID, current+available/vCPUs))
+	}
+	return warnings, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a transport wrapper that sends to the log the time taken by each call to the
+// OCM API and the operation identifier returned by the server, so that failures can be traced
+// in the server logs.
+
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// OperationIDHeader is the header that the OCM API uses to return the identifier of the operation.
+const OperationIDHeader = "X-Operation-ID"
+
+// operationTransport is a round tripper that sends to the log the duration and the operation
+// identifier of every request.
+type operationTransport struct {
+	logger *logrus.Logger
+	next   http.RoundTripper
+}
+
+// newOperationTransportWrapper returns a transport wrapper that sends to the given logger the
+// duration and the operation identifier of every request.
+func newOperationTransportWrapper(logger *logrus.Logger) func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &operationTransport{
+			logger: logger,
+			next:   next,
+		}
+	}
+}
+
+// RoundTrip is the implementation of the http.RoundTripper interface.
+func (t *operationTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := t.next.RoundTrip(request)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.logger.Debugf("%s %s failed after %s: %v", request.Method, request.URL.Path, elapsed, err)
+		return response, err
+	}
+	operationID := response.Header.Get(OperationIDHeader)
+	t.logger.Debugf("%s %s returned %d in %s (operation ID: '%s')",
+		request.Method, request.URL.Path, response.StatusCode, elapsed, operationID)
+	return response, nil
+}
+
+// WithOperationID adds to the given error message the identifier of the operation that caused the
+// error, as returned by the server. Errors that didn't come from the server, like network errors,
+// don't have an operation identifier and the message is returned unchanged.
+func WithOperationID(msg string, res *ocmerrors.Error) string {
+	operationID := res.OperationID()
+	if operationID == "" {
+		return msg
+	}
+	return fmt.Sprintf("%s (operation ID: '%s')", msg, operationID)
+}
+
+// ErrorReason returns the reason of the given error including the identifier of the operation.
+func ErrorReason(res *ocmerrors.Error) string {
+	return WithOperationID(res.Reason(), res)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the helper used to retrieve all the pages of a collection. Go doesn't have
+// generics in the version used by the tool, so the helper doesn't know about the type of the
+// items: each caller sends the request for a page and appends the items to its own result.
+
+package ocm
+
+import (
+	"time"
+)
+
+// DefaultPageSize is the number of items requested in each page of a collection.
+const DefaultPageSize = 100
+
+// PageFunc sends the request for the page with the given number, starting with 1, and size. It
+// returns the number of items in the page and a flag that indicates if the next page should be
+// requested, so that callers can stop early once they have found what they were looking for.
+type PageFunc func(page int, size int) (count int, more bool, err error)
+
+// Paginator retrieves the pages of a collection until the last one or until the page function
+// asks to stop.
+type Paginator struct {
+	// Size is the number of items requested in each page. Zero means DefaultPageSize.
+	Size int
+
+	// Interval is the minimum time between the requests for two pages, so that retrieving a large
+	// collection doesn't exceed the rate limits of the API. Zero means no limit.
+	Interval time.Duration
+}
+
+// Paginate retrieves all the pages of a collection with the default page size.
+func Paginate(fetch PageFunc) error {
+	return (&Paginator{}).Each(fetch)
+}
+
+// Each calls the page function for each page of the collection. A page with fewer items than
+// the page size is the last one.
+func (p *Paginator) Each(fetch PageFunc) error {
+	size := p.Size
+	if size <= 0 {
+		size = DefaultPageSize
+	}
+	var last time.Time
+	for page := 1; ; page++ {
+		if p.Interval > 0 && !last.IsZero() {
+			if wait := p.Interval - time.Since(last); wait > 0 {
+				time.Sleep(wait)
+			}
+		}
+		last = time.Now()
+		count, more, err := fetch(page, size)
+		if err != nil {
+			return err
+		}
+		if !more || count < size {
+			return nil
+		}
+	}
+}
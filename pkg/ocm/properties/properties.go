@@ -29,3 +29,11 @@ const CreatorARN = prefix + "creator_arn"
 const CLIVersion = prefix + "cli_version"
 
 const FakeCluster = "fake_cluster"
+
+// SchedulePrefix is the prefix of the names of the properties that contain the machine pool
+// scaling schedules of the cluster. The rest of the name is the identifier of the schedule.
+const SchedulePrefix = prefix + "schedule_"
+
+// MaintenanceExclusion is the name of the property that contains the cron expression of the times
+// when the cluster shouldn't be upgraded.
+const MaintenanceExclusion = prefix + "maintenance_exclusion"
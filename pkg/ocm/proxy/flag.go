@@ -0,0 +1,102 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--ocm-proxy' command line option.
+
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/pflag"
+)
+
+// AddFlag adds the OCM proxy flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&proxy,
+		"ocm-proxy",
+		"",
+		"URL of the proxy used to connect to the OCM API. Overrides the HTTPS_PROXY and "+
+			"NO_PROXY environment variables.",
+	)
+}
+
+// Proxy returns the value of the OCM proxy flag.
+func Proxy() string {
+	return proxy
+}
+
+// Func returns the function that selects the proxy for requests sent to the OCM API. When the
+// flag isn't used the proxy is taken from the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables.
+func Func() (func(*http.Request) (*url.URL, error), error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment, nil
+	}
+	parsed, err := url.Parse(proxy)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("Invalid OCM proxy URL '%s'", proxy)
+	}
+	return http.ProxyURL(parsed), nil
+}
+
+// Resolve returns a human readable description of the proxy that the given selector will use to
+// connect to the given URL.
+func Resolve(target string, selector func(*http.Request) (*url.URL, error)) string {
+	request, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	proxyURL, err := selector(request)
+	if err != nil {
+		return fmt.Sprintf("unknown (%v)", err)
+	}
+	if proxyURL == nil {
+		return "none"
+	}
+	// Don't show the credentials that may be part of the proxy URL:
+	return fmt.Sprintf("%s://%s", proxyURL.Scheme, proxyURL.Host)
+}
+
+// Transport returns an HTTP transport that sends requests through the proxy returned by the given
+// selector. Verification of the TLS certificates of the server is skipped if insecure is true.
+func Transport(selector func(*http.Request) (*url.URL, error), insecure bool) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = selector
+	// #nosec G402
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+	return transport
+}
+
+// Wrapper returns a transport wrapper that sends the requests with the given round tripper instead
+// of the one that it wraps. The transport created by the SDK always takes the proxy from the
+// environment and, depending on the log level, the SDK adds other round trippers on top of it, so
+// it can't be reconfigured reliably. Note that the round trippers added after this wrapper are
+// skipped.
+func Wrapper(transport http.RoundTripper) func(http.RoundTripper) http.RoundTripper {
+	return func(http.RoundTripper) http.RoundTripper {
+		return transport
+	}
+}
+
+// proxy is a string flag that contains the URL of the proxy used for the OCM API.
+var proxy string
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to check the quota of compute nodes of the organization.
+// The resource name that the quota uses for a machine type isn't supported yet by the version of
+// the SDK used by the tool, so it is read with a raw request.
+
+package ocm
+
+import (
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// GetComputeNodeQuota returns the number of additional compute nodes of the given machine type
+// that the quota of the organization of the current user allows. The boolean result is false when
+// the quota doesn't limit the number of nodes, which is usually the case of ROSA as the nodes are
+// billed by AWS, or when there is no quota that applies to the machine type.
+func GetComputeNodeQuota(connection *sdk.Connection, machineTypeID string, multiAZ bool) (int, bool, error) {
+	account, err := GetCurrentAccount(connection)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resourceName := machineTypeID
+	attributes, err := GetAttributes(connection, "/api/clusters_mgmt/v1/machine_types/"+machineTypeID)
+	if err == nil {
+		if genericName, ok := attributes["generic_name"].(string); ok && genericName != "" {
+			resourceName = genericName
+		}
+	}
+
+	response, err := connection.AccountsMgmt().V1().Organizations().
+		Organization(account.Organization().ID()).
+		QuotaCost().
+		List().
+		Search("quota_id LIKE 'compute.node%'").
+		Parameter("fetchRelatedResources", true).
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return 0, false, handleErr(response.Error(), err)
+	}
+
+	azType := "single"
+	if multiAZ {
+		azType = "multi"
+	}
+	available := 0
+	limited := false
+	unlimited := false
+	response.Items().Each(func(quotaCost *amsv1.QuotaCost) bool {
+		for _, relatedResource := range quotaCost.RelatedResources() {
+			if relatedResource.ResourceType() != "compute.node" || !isCompatible(relatedResource) {
+				continue
+			}
+			name := strings.ToLower(relatedResource.ResourceName())
+			if name != "any" && name != strings.ToLower(resourceName) {
+				continue
+			}
+			az := strings.ToLower(relatedResource.AvailabilityZoneType())
+			if az != "any" && az != azType {
+				continue
+			}
+			if relatedResource.Cost() == 0 {
+				unlimited = true
+				return false
+			}
+			nodes := (quotaCost.Allowed() - quotaCost.Consumed()) / relatedResource.Cost()
+			if !limited || nodes > available {
+				available = nodes
+			}
+			limited = true
+		}
+		return true
+	})
+	if unlimited || !limited {
+		return 0, false, nil
+	}
+	if available < 0 {
+		available = 0
+	}
+	return available, true, nil
+}
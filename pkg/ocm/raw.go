@@ -0,0 +1,313 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions that send raw requests to the API. They are used for attributes
+// that aren't supported yet by the version of the SDK used by the tool.
+
+package ocm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+// ClustersPath is the path of the collection of clusters of the clusters management API.
+const ClustersPath = "/api/clusters_mgmt/v1/clusters"
+
+// ClusterPath returns the path of the cluster with the given identifier.
+func ClusterPath(clusterID string) string {
+	return fmt.Sprintf("%s/%s", ClustersPath, clusterID)
+}
+
+// GetAttributes sends a GET request to the given path and returns the attributes of the object
+// contained in the response.
+func GetAttributes(connection *sdk.Connection, path string) (map[string]interface{}, error) {
+	response, err := connection.Get().
+		Path(path).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	attributes := map[string]interface{}{}
+	err = json.Unmarshal(response.Bytes(), &attributes)
+	if err != nil {
+		return nil, err
+	}
+	return attributes, nil
+}
+
+// ListAttributes sends a GET request to the given collection path, optionally with a search
+// query, and returns the attributes of all the items contained in the response.
+func ListAttributes(connection *sdk.Connection, path string, search string) ([]map[string]interface{}, error) {
+	request := connection.Get().
+		Path(path).
+		Parameter("size", -1)
+	if search != "" {
+		request.Parameter("search", search)
+	}
+	response, err := request.Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	err = json.Unmarshal(response.Bytes(), &list)
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// PatchAttributes sends a PATCH request with the given attributes to the given path.
+func PatchAttributes(connection *sdk.Connection, path string, attributes map[string]interface{}) error {
+	body, err := json.Marshal(attributes)
+	if err != nil {
+		return err
+	}
+	response, err := connection.Patch().
+		Path(path).
+		Bytes(body).
+		Send()
+	if err != nil {
+		return err
+	}
+	return checkRawResponse(response)
+}
+
+// DeletePath sends a DELETE request to the given path.
+func DeletePath(connection *sdk.Connection, path string) error {
+	response, err := connection.Delete().
+		Path(path).
+		Send()
+	if err != nil {
+		return err
+	}
+	return checkRawResponse(response)
+}
+
+// PostAttributes sends a POST request with the given attributes to the given collection path and
+// returns the attributes of the object created.
+func PostAttributes(connection *sdk.Connection, path string, attributes map[string]interface{}) (
+	map[string]interface{}, error) {
+	body, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, err
+	}
+	response, err := connection.Post().
+		Path(path).
+		Bytes(body).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(response.Bytes(), &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// AddCluster sends the request to create the given cluster, merging the given attributes into the
+// body of the request.
+func AddCluster(connection *sdk.Connection, cluster *cmv1.Cluster, attributes map[string]interface{},
+	dryRun bool) (*cmv1.Cluster, error) {
+	buffer := &bytes.Buffer{}
+	err := cmv1.MarshalCluster(cluster, buffer)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	MergeAttributes(body, attributes)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := connection.Post().
+		Path(ClustersPath).
+		Parameter("dryRun", dryRun).
+		Bytes(data).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return nil, nil
+	}
+	return cmv1.UnmarshalCluster(response.Bytes())
+}
+
+// AddIdentityProvider sends the request to add the given identity provider to the cluster,
+// merging the given attributes into the body of the request.
+func AddIdentityProvider(connection *sdk.Connection, clusterID string, idp *cmv1.IdentityProvider,
+	attributes map[string]interface{}) (*cmv1.IdentityProvider, error) {
+	buffer := &bytes.Buffer{}
+	err := cmv1.MarshalIdentityProvider(idp, buffer)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	MergeAttributes(body, attributes)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := connection.Post().
+		Path(ClusterPath(clusterID) + "/identity_providers").
+		Bytes(data).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalIdentityProvider(response.Bytes())
+}
+
+// AddMachinePool sends the request to add the given machine pool to the cluster, merging the given
+// attributes into the body of the request.
+func AddMachinePool(connection *sdk.Connection, clusterID string, machinePool *cmv1.MachinePool,
+	attributes map[string]interface{}) (*cmv1.MachinePool, error) {
+	buffer := &bytes.Buffer{}
+	err := cmv1.MarshalMachinePool(machinePool, buffer)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	MergeAttributes(body, attributes)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := connection.Post().
+		Path(ClusterPath(clusterID) + "/machine_pools").
+		Bytes(data).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalMachinePool(response.Bytes())
+}
+
+// UpgradePoliciesPath returns the path of the collection of upgrade policies of the cluster with
+// the given identifier.
+func UpgradePoliciesPath(clusterID string) string {
+	return ClusterPath(clusterID) + "/upgrade_policies"
+}
+
+// AddUpgradePolicy sends the request to add the given upgrade policy to the cluster, merging the
+// given attributes into the body of the request.
+func AddUpgradePolicy(connection *sdk.Connection, clusterID string, policy *cmv1.UpgradePolicy,
+	attributes map[string]interface{}) (*cmv1.UpgradePolicy, error) {
+	buffer := &bytes.Buffer{}
+	err := cmv1.MarshalUpgradePolicy(policy, buffer)
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &body)
+	if err != nil {
+		return nil, err
+	}
+	MergeAttributes(body, attributes)
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := connection.Post().
+		Path(UpgradePoliciesPath(clusterID)).
+		Bytes(data).
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	err = checkRawResponse(response)
+	if err != nil {
+		return nil, err
+	}
+	return cmv1.UnmarshalUpgradePolicy(response.Bytes())
+}
+
+// MergeAttributes copies the attributes of src into dst. Nested objects are merged instead of
+// replaced, so that attributes already present in dst are preserved.
+func MergeAttributes(dst map[string]interface{}, src map[string]interface{}) {
+	for key, value := range src {
+		srcMap, srcIsMap := value.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			MergeAttributes(dstMap, srcMap)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+func checkRawResponse(response *sdk.Response) error {
+	if response.Status() < http.StatusBadRequest {
+		return nil
+	}
+	err := fmt.Errorf("Request failed with status %d", response.Status())
+	res, parseErr := ocmerrors.UnmarshalError(response.Bytes())
+	if parseErr != nil {
+		return err
+	}
+	return handleErr(res, err)
+}
@@ -20,37 +20,20 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
 
 func GetRegions(client *cmv1.Client) (regions []*cmv1.CloudRegion, err error) {
-	// Retrieve AWS credentials from the local AWS user
-	// pass these to OCM to validate what regions are available
-	// in this AWS account
-
-	// Build AWS client and retrieve credentials
-	// This ensures we use the profile flag if passed to rosa
-	// Create the AWS client:
-	reporter := rprtr.CreateReporterOrExit()
-	logger := logging.CreateLoggerOrExit(reporter)
-
-	awsClient, err := aws.NewClient().
-		Logger(logger).
-		Region(aws.DefaultRegion).
-		Build()
-	if err != nil {
-		return nil, fmt.Errorf("Error creating AWS client: %v", err)
-	}
-
-	// Get AWS region
-	currentAWSCreds, err := awsClient.GetIAMCredentials()
-
+	currentAWSCreds, err := getAWSCredentials()
 	if err != nil {
-		return nil, fmt.Errorf("Failed to get local AWS credentials: %v", err)
+		return nil, err
 	}
 
 	// Build cmv1.AWS object to get list of available regions:
@@ -63,11 +46,8 @@ func GetRegions(client *cmv1.Client) (regions []*cmv1.CloudRegion, err error) {
 	}
 
 	collection := client.CloudProviders().CloudProvider("aws").AvailableRegions()
-	page := 1
-	size := 100
-	for {
-		var response *cmv1.AvailableRegionsSearchResponse
-		response, err = collection.Search().
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := collection.Search().
 			Page(page).
 			Size(size).
 			Body(awsCredentials).
@@ -77,17 +57,71 @@ func GetRegions(client *cmv1.Client) (regions []*cmv1.CloudRegion, err error) {
 			if errMsg == "" {
 				errMsg = err.Error()
 			}
-			return nil, errors.New(errMsg)
+			return 0, false, errors.New(ocm.WithOperationID(errMsg, response.Error()))
 		}
 		regions = append(regions, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return
 }
 
+// availableRegionsPath is the path of the regions available for an AWS account.
+const availableRegionsPath = "/api/clusters_mgmt/v1/cloud_providers/aws/available_regions"
+
+// GetHostedCPSupport returns which of the regions available for the current AWS account support
+// hosted control planes, indexed by region identifier. The version of the SDK used by the tool
+// doesn't support this attribute yet, so it is read with a raw request.
+func GetHostedCPSupport(connection *sdk.Connection) (map[string]bool, error) {
+	currentAWSCreds, err := getAWSCredentials()
+	if err != nil {
+		return nil, err
+	}
+	result, err := ocm.PostAttributes(connection, availableRegionsPath, map[string]interface{}{
+		"access_key_id":     currentAWSCreds.AccessKeyID,
+		"secret_access_key": currentAWSCreds.SecretAccessKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	support := map[string]bool{}
+	items, _ := result["items"].([]interface{})
+	for _, item := range items {
+		region, _ := item.(map[string]interface{})
+		id, _ := region["id"].(string)
+		supported, _ := region["supports_hypershift"].(bool)
+		if id != "" {
+			support[id] = supported
+		}
+	}
+	return support, nil
+}
+
+// getAWSCredentials returns the credentials of the local AWS user, which are passed to OCM to
+// validate what regions are available in this AWS account.
+func getAWSCredentials() (credentials.Value, error) {
+	// Build AWS client and retrieve credentials
+	// This ensures we use the profile flag if passed to rosa
+	reporter := rprtr.CreateReporterOrExit()
+	logger := logging.CreateLoggerOrExit(reporter)
+
+	awsClient, err := aws.NewClient().
+		Logger(logger).
+		Region(aws.DefaultRegion).
+		Build()
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("Error creating AWS client: %v", err)
+	}
+
+	currentAWSCreds, err := awsClient.GetIAMCredentials()
+	if err != nil {
+		return credentials.Value{}, fmt.Errorf("Failed to get local AWS credentials: %v", err)
+	}
+	return currentAWSCreds, nil
+}
+
 func GetRegionList(client *cmv1.Client, multiAZ bool) (regionList []string, regionAZ map[string]bool, err error) {
 	regions, err := GetRegions(client)
 	if err != nil {
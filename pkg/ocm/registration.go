@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to register existing self-managed OpenShift clusters, so that
+// they are tracked by OCM together with the managed ones.
+
+package ocm
+
+import (
+	"fmt"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// registrationAuth is the name of the pull secret entry that contains the token used by connected
+// clusters to register with OCM.
+const registrationAuth = "cloud.openshift.com"
+
+// DisconnectedStatus is the status of the subscriptions of clusters registered as disconnected.
+const DisconnectedStatus = "Disconnected"
+
+// RegisterCluster registers the connected cluster with the given external identifier using the
+// pull secret of the current user, the same way that the cluster telemetry does.
+func RegisterCluster(connection *sdk.Connection, externalID string) (*amsv1.ClusterRegistrationResponse, error) {
+	tokenResponse, err := connection.AccountsMgmt().V1().AccessToken().
+		Post().
+		Send()
+	if err != nil {
+		return nil, handleErr(tokenResponse.Error(), err)
+	}
+	auth, ok := tokenResponse.Body().Auths()[registrationAuth]
+	if !ok || auth.Auth() == "" {
+		return nil, fmt.Errorf("Pull secret doesn't contain an entry for '%s'", registrationAuth)
+	}
+
+	request, err := amsv1.NewClusterRegistrationRequest().
+		AuthorizationToken(auth.Auth()).
+		ClusterID(externalID).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	response, err := connection.AccountsMgmt().V1().ClusterRegistrations().
+		Post().
+		Request(request).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Response(), nil
+}
+
+// RegisterDisconnectedCluster creates the subscription of a cluster that can't report to OCM by
+// itself, so that it needs to be updated manually.
+func RegisterDisconnectedCluster(connection *sdk.Connection, externalID string, name string,
+	consoleURL string) (*amsv1.Subscription, error) {
+	registration, err := amsv1.NewSubscriptionRegistration().
+		ClusterUUID(externalID).
+		DisplayName(name).
+		ConsoleURL(consoleURL).
+		PlanID(amsv1.PlanIDOCP).
+		Status(DisconnectedStatus).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	response, err := connection.AccountsMgmt().V1().Subscriptions().
+		Post().
+		Request(registration).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Response(), nil
+}
+
+// GetRegisteredClusters returns the subscriptions of the self-managed clusters created by the
+// current user that match the given search query.
+func GetRegisteredClusters(connection *sdk.Connection, filter string) ([]*amsv1.Subscription, error) {
+	accountResponse, err := connection.AccountsMgmt().V1().CurrentAccount().
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(accountResponse.Error(), err)
+	}
+	query := fmt.Sprintf(
+		"plan.id = '%s' and creator.id = '%s' and status in ('Active', '%s')",
+		amsv1.PlanIDOCP, accountResponse.Body().ID(), DisconnectedStatus,
+	)
+	if filter != "" {
+		query = fmt.Sprintf("%s and (%s)", query, filter)
+	}
+	response, err := connection.AccountsMgmt().V1().Subscriptions().
+		List().
+		Search(query).
+		FetchaccountsAccounts(true).
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Items().Slice(), nil
+}
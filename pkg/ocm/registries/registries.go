@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to parse the registries of the image configuration of
+// clusters: the registries that images can or can't be pulled from, the registries that don't
+// need TLS and the registries that image streams can import from.
+
+package registries
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// registryRE matches the registries accepted by the image configuration of clusters: a host name,
+// optionally with a wildcard for the subdomains, a port and a repository path.
+var registryRE = regexp.MustCompile(
+	`^(\*\.)?[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*(:[0-9]{1,5})?(/[-a-z0-9._/]+)?$`,
+)
+
+// ImportRegistry is a registry that image streams can import from.
+type ImportRegistry struct {
+	DomainName string `json:"domain_name"`
+	Insecure   bool   `json:"insecure"`
+}
+
+// IsValidRegistry checks if the given text is a valid registry, like 'quay.io',
+// '*.example.com' or 'registry.example.com:5000/team'.
+func IsValidRegistry(registry string) bool {
+	return registryRE.MatchString(strings.ToLower(registry))
+}
+
+// ParseRegistries parses a list of registries, given as a comma-separated list or as the items of
+// the list, checking that all of them are valid.
+func ParseRegistries(values ...string) ([]string, error) {
+	var registries []string
+	for _, value := range values {
+		for _, registry := range strings.Split(value, ",") {
+			registry = strings.TrimSpace(registry)
+			if registry == "" {
+				continue
+			}
+			if !IsValidRegistry(registry) {
+				return nil, fmt.Errorf("Invalid registry '%s', expected a host name optionally "+
+					"followed by a port and a path, like 'registry.example.com:5000/team'", registry)
+			}
+			registries = append(registries, registry)
+		}
+	}
+	return registries, nil
+}
+
+// ParseImportRegistries parses a list of registries that image streams can import from. Each
+// registry can be followed by ':true' or ':false' to indicate if it is insecure, for example
+// 'registry.example.com:true'.
+func ParseImportRegistries(values ...string) ([]*ImportRegistry, error) {
+	var registries []*ImportRegistry
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			registry := &ImportRegistry{
+				DomainName: item,
+			}
+			if strings.HasSuffix(item, ":true") || strings.HasSuffix(item, ":false") {
+				index := strings.LastIndex(item, ":")
+				registry.DomainName = item[:index]
+				registry.Insecure = item[index+1:] == "true"
+			}
+			if !IsValidRegistry(registry.DomainName) {
+				return nil, fmt.Errorf("Invalid registry '%s', expected a host name optionally "+
+					"followed by ':true' if it is insecure, like 'registry.example.com:true'", item)
+			}
+			registries = append(registries, registry)
+		}
+	}
+	return registries, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to find how the IAM roles of an AWS account are related to
+// OCM. The links between roles and the organization or the account of the user are stored as
+// labels, and the infrastructure identifiers of clusters aren't supported yet by the version of
+// the SDK used by the tool, so they are read with a raw request.
+
+package ocm
+
+import (
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// Keys of the labels that contain the ARNs of the roles linked to an organization or an account:
+const (
+	OCMRoleLabel  = "sts_ocm_role"
+	UserRoleLabel = "sts_user_role"
+)
+
+// GetLinkedRoles returns the ARNs of the IAM roles linked to the organization or to the account
+// of the current user, with the name of what they are linked to.
+func GetLinkedRoles(connection *sdk.Connection) (map[string]string, error) {
+	account, err := GetCurrentAccount(connection)
+	if err != nil {
+		return nil, err
+	}
+	accountsClient := connection.AccountsMgmt().V1()
+	sources := []struct {
+		key    string
+		owner  string
+		client *amsv1.GenericLabelsClient
+	}{
+		{
+			key:    OCMRoleLabel,
+			owner:  "organization",
+			client: accountsClient.Organizations().Organization(account.Organization().ID()).Labels(),
+		},
+		{
+			key:    UserRoleLabel,
+			owner:  "user",
+			client: accountsClient.Accounts().Account(account.ID()).Labels(),
+		},
+	}
+	linked := map[string]string{}
+	for _, source := range sources {
+		response, err := source.client.List().Size(-1).Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		response.Items().Each(func(label *amsv1.Label) bool {
+			if label.Key() != source.key {
+				return true
+			}
+			for _, arn := range strings.Split(label.Value(), ",") {
+				arn = strings.TrimSpace(arn)
+				if arn != "" {
+					linked[arn] = source.owner
+				}
+			}
+			return true
+		})
+	}
+	return linked, nil
+}
+
+// GetClusterInfraIDs returns the identifiers of the AWS clusters visible to the current user
+// indexed by their infrastructure identifiers, the prefix of the names of the AWS resources created
+// by the installer.
+func GetClusterInfraIDs(connection *sdk.Connection) (map[string]string, error) {
+	items, err := ListAttributes(connection, ClustersPath, "cloud_provider.id = 'aws'")
+	if err != nil {
+		return nil, err
+	}
+	infraIDs := map[string]string{}
+	for _, item := range items {
+		id, _ := item["id"].(string)
+		infraID, _ := item["infra_id"].(string)
+		if id != "" && infraID != "" {
+			infraIDs[infraID] = id
+		}
+	}
+	return infraIDs, nil
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a minimal parser for the standard five field cron expressions used by the
+// machine pool scaling schedules.
+
+package schedules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression. Each field contains the set of values that match.
+type Cron struct {
+	expression string
+	minutes    map[int]bool
+	hours      map[int]bool
+	days       map[int]bool
+	months     map[int]bool
+	weekdays   map[int]bool
+
+	// Like the traditional cron implementations, when both the day of month and the day of week
+	// are restricted the expression matches when any of them matches:
+	anyDay     bool
+	anyWeekday bool
+}
+
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// ParseCron parses a cron expression with the minute, hour, day of month, month and day of week
+// fields. Each field accepts '*', single values, ranges like '1-5', lists like '1,3,5' and steps
+// like '*/15' or '0-30/10'. Sunday can be written as 0 or 7.
+func ParseCron(expression string) (*Cron, error) {
+	parts := strings.Fields(expression)
+	if len(parts) != len(cronFields) {
+		return nil, fmt.Errorf(
+			"Cron expression '%s' should contain %d fields but it contains %d",
+			expression, len(cronFields), len(parts),
+		)
+	}
+	sets := make([]map[int]bool, len(parts))
+	for i, part := range parts {
+		set, err := parseCronField(part, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid cron expression '%s': %v", expression, err)
+		}
+		sets[i] = set
+	}
+
+	// Sunday can be 0 or 7, use always 0 as that is what the time package uses:
+	if sets[4][7] {
+		delete(sets[4], 7)
+		sets[4][0] = true
+	}
+
+	return &Cron{
+		expression: strings.Join(parts, " "),
+		minutes:    sets[0],
+		hours:      sets[1],
+		days:       sets[2],
+		months:     sets[3],
+		weekdays:   sets[4],
+		anyDay:     parts[2] == "*",
+		anyWeekday: parts[4] == "*",
+	}, nil
+}
+
+func parseCronField(text string, field cronField) (map[int]bool, error) {
+	set := map[int]bool{}
+	for _, item := range strings.Split(text, ",") {
+		step := 1
+		if i := strings.Index(item, "/"); i >= 0 {
+			value, err := strconv.Atoi(item[i+1:])
+			if err != nil || value < 1 {
+				return nil, fmt.Errorf("Invalid step '%s' in %s field", item[i+1:], field.name)
+			}
+			step = value
+			item = item[:i]
+		}
+		first, last := field.min, field.max
+		if item != "*" {
+			bounds := strings.SplitN(item, "-", 2)
+			value, err := parseCronValue(bounds[0], field)
+			if err != nil {
+				return nil, err
+			}
+			first, last = value, value
+			if len(bounds) == 2 {
+				last, err = parseCronValue(bounds[1], field)
+				if err != nil {
+					return nil, err
+				}
+				if last < first {
+					return nil, fmt.Errorf("Invalid range '%s' in %s field", item, field.name)
+				}
+			} else if step > 1 {
+				last = field.max
+			}
+		}
+		for value := first; value <= last; value += step {
+			set[value] = true
+		}
+	}
+	return set, nil
+}
+
+func parseCronValue(text string, field cronField) (int, error) {
+	value, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid value '%s' in %s field", text, field.name)
+	}
+	if value < field.min || value > field.max {
+		return 0, fmt.Errorf(
+			"Value %d in %s field should be between %d and %d",
+			value, field.name, field.min, field.max,
+		)
+	}
+	return value, nil
+}
+
+// Matches checks if the given time, truncated to the minute, matches the expression.
+func (c *Cron) Matches(t time.Time) bool {
+	return c.minutes[t.Minute()] && c.hours[t.Hour()] && c.months[int(t.Month())] && c.matchesDay(t)
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	day := c.days[t.Day()]
+	weekday := c.weekdays[int(t.Weekday())]
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// Next returns the first time after the given one that matches the expression, or the zero time
+// if there is no such time within the next four years.
+func (c *Cron) Next(t time.Time) time.Time {
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := next.AddDate(4, 0, 0)
+	for next.Before(limit) {
+		switch {
+		case !c.months[int(next.Month())]:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, next.Location())
+		case !c.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, next.Location())
+		case !c.hours[next.Hour()]:
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, next.Location())
+		case !c.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	return time.Time{}
+}
+
+// String returns the normalized text of the expression.
+func (c *Cron) String() string {
+	return c.expression
+}
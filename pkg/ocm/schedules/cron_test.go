@@ -0,0 +1,130 @@
+package schedules_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+)
+
+// date returns the given time in UTC. The 13th of August of 2021 is a Friday.
+func date(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+var _ = Describe("Cron", func() {
+	Context("ParseCron", func() {
+		invalid := map[string]string{
+			"too few fields":         "0 9 * *",
+			"too many fields":        "0 9 * * * *",
+			"value out of range":     "60 * * * *",
+			"day of month zero":      "0 0 0 * *",
+			"day of week above 7":    "0 0 * * 8",
+			"reversed range":         "0 5-1 * * *",
+			"zero step":              "*/0 * * * *",
+			"non numeric step":       "*/x * * * *",
+			"non numeric value":      "a * * * *",
+			"empty item in the list": "0,,30 * * * *",
+		}
+		for name, expression := range invalid {
+			name, expression := name, expression
+			It("rejects "+name, func() {
+				_, err := schedules.ParseCron(expression)
+				Expect(err).To(HaveOccurred())
+			})
+		}
+
+		It("normalizes the spaces of the expression", func() {
+			cron, err := schedules.ParseCron("  0  9 *   * 1-5 ")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cron.String()).To(Equal("0 9 * * 1-5"))
+		})
+	})
+
+	Context("Matches", func() {
+		cases := []struct {
+			name       string
+			expression string
+			time       time.Time
+			matches    bool
+		}{
+			{"weekday in range", "0 9 * * 1-5", date(2021, 8, 13, 9, 0), true},
+			{"weekday out of range", "0 9 * * 1-5", date(2021, 8, 14, 9, 0), false},
+			{"different minute", "0 9 * * 1-5", date(2021, 8, 13, 9, 1), false},
+			{"list of hours", "0 6,18 * * *", date(2021, 8, 13, 18, 0), true},
+			{"month out of list", "0 0 * 1,2 *", date(2021, 8, 13, 0, 0), false},
+
+			// When both the day of month and the day of week are restricted either of them
+			// has to match:
+			{"day of month only", "0 0 13 * 1", date(2021, 8, 13, 0, 0), true},
+			{"day of week only", "0 0 1 * 5", date(2021, 8, 13, 0, 0), true},
+			{"neither day", "0 0 1 * 1", date(2021, 8, 13, 0, 0), false},
+
+			// When only one of them is restricted that one has to match:
+			{"restricted day of week", "0 0 * * 4", date(2021, 8, 13, 0, 0), false},
+			{"restricted day of month", "0 0 12 * *", date(2021, 8, 13, 0, 0), false},
+
+			// Sunday can be written as 0 or 7:
+			{"Sunday as 0", "0 0 * * 0", date(2021, 8, 15, 0, 0), true},
+			{"Sunday as 7", "0 0 * * 7", date(2021, 8, 15, 0, 0), true},
+			{"range ending in 7", "0 0 * * 5-7", date(2021, 8, 15, 0, 0), true},
+			{"Monday with 7", "0 0 * * 7", date(2021, 8, 16, 0, 0), false},
+
+			// Steps:
+			{"step on wildcard", "*/15 * * * *", date(2021, 8, 13, 10, 45), true},
+			{"step on wildcard miss", "*/15 * * * *", date(2021, 8, 13, 10, 50), false},
+			{"step on range", "0-30/10 * * * *", date(2021, 8, 13, 10, 30), true},
+			{"step beyond range", "0-30/10 * * * *", date(2021, 8, 13, 10, 40), false},
+			{"step on single value", "5/20 * * * *", date(2021, 8, 13, 10, 45), true},
+			{"step on single value miss", "5/20 * * * *", date(2021, 8, 13, 10, 0), false},
+		}
+		for _, item := range cases {
+			item := item
+			It("handles "+item.name, func() {
+				cron, err := schedules.ParseCron(item.expression)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cron.Matches(item.time)).To(Equal(item.matches))
+			})
+		}
+	})
+
+	Context("Next", func() {
+		cases := []struct {
+			name       string
+			expression string
+			from       time.Time
+			next       time.Time
+		}{
+			{"later the same day", "30 2 * * *", date(2021, 3, 10, 1, 15), date(2021, 3, 10, 2, 30)},
+			{"strictly after", "30 2 * * *", date(2021, 3, 10, 2, 30), date(2021, 3, 11, 2, 30)},
+			{"next hour", "0 * * * *", date(2021, 3, 10, 2, 30), date(2021, 3, 10, 3, 0)},
+			{"next year", "0 0 1 * *", date(2021, 12, 15, 0, 0), date(2022, 1, 1, 0, 0)},
+			{"next weekday", "0 9 * * 1-5", date(2021, 8, 13, 10, 0), date(2021, 8, 16, 9, 0)},
+			{"leap day", "0 0 29 2 *", date(2021, 1, 1, 0, 0), date(2024, 2, 29, 0, 0)},
+			{"day or weekday", "0 0 20 * 1", date(2021, 8, 13, 0, 0), date(2021, 8, 16, 0, 0)},
+		}
+		for _, item := range cases {
+			item := item
+			It("finds the "+item.name, func() {
+				cron, err := schedules.ParseCron(item.expression)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cron.Next(item.from)).To(Equal(item.next))
+			})
+		}
+
+		It("ignores the seconds of the given time", func() {
+			cron, err := schedules.ParseCron("31 2 * * *")
+			Expect(err).ToNot(HaveOccurred())
+			from := time.Date(2021, 3, 10, 2, 30, 59, 0, time.UTC)
+			Expect(cron.Next(from)).To(Equal(date(2021, 3, 10, 2, 31)))
+		})
+
+		It("returns the zero time for dates that don't exist", func() {
+			cron, err := schedules.ParseCron("0 0 31 2 *")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cron.Next(date(2021, 1, 1, 0, 0)).IsZero()).To(BeTrue())
+		})
+	})
+})
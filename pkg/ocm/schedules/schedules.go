@@ -0,0 +1,208 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to manage the machine pool scaling schedules of a cluster.
+// Schedules are stored as properties of the cluster and applied by 'rosa serve'.
+
+package schedules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/ocm/properties"
+)
+
+// DefaultMachinePool is the identifier of the machine pool that contains the compute nodes defined
+// when the cluster is created.
+const DefaultMachinePool = "Default"
+
+// Regular expression used to make sure that the identifiers of schedules can be used as part of
+// the name of a property:
+var scheduleIDRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// Schedule sets the number of replicas of a machine pool every time that the cron expression
+// matches.
+type Schedule struct {
+	ID          string `json:"-"`
+	MachinePool string `json:"machine_pool"`
+	Cron        string `json:"cron"`
+	Replicas    int    `json:"replicas"`
+}
+
+// IsValidScheduleID checks if the given text can be used as the identifier of a schedule.
+func IsValidScheduleID(id string) bool {
+	return scheduleIDRE.MatchString(id)
+}
+
+// GetSchedules returns the schedules stored in the properties of the cluster, sorted by
+// identifier.
+func GetSchedules(cluster *cmv1.Cluster) ([]*Schedule, error) {
+	var schedules []*Schedule
+	for name, value := range cluster.Properties() {
+		if !strings.HasPrefix(name, properties.SchedulePrefix) || value == "" {
+			continue
+		}
+		schedule := &Schedule{}
+		err := json.Unmarshal([]byte(value), schedule)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse property '%s': %v", name, err)
+		}
+		schedule.ID = strings.TrimPrefix(name, properties.SchedulePrefix)
+		schedules = append(schedules, schedule)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules, nil
+}
+
+// NextScheduleID returns the first identifier of the form 'POOL-N' that isn't used yet by any of
+// the given schedules.
+func NextScheduleID(schedules []*Schedule, machinePoolID string) string {
+	used := map[string]bool{}
+	for _, schedule := range schedules {
+		used[schedule.ID] = true
+	}
+	prefix := strings.ToLower(machinePoolID)
+	for i := 1; ; i++ {
+		id := fmt.Sprintf("%s-%d", prefix, i)
+		if !used[id] {
+			return id
+		}
+	}
+}
+
+// AddSchedule stores the given schedule in the properties of the cluster.
+func AddSchedule(client *cmv1.ClustersClient, cluster *cmv1.Cluster, schedule *Schedule) error {
+	if !IsValidScheduleID(schedule.ID) {
+		return fmt.Errorf("Schedule identifier '%s' isn't valid", schedule.ID)
+	}
+	_, err := ParseCron(schedule.Cron)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(schedule)
+	if err != nil {
+		return err
+	}
+	props := copyProperties(cluster)
+	props[properties.SchedulePrefix+schedule.ID] = string(value)
+	return updateProperties(client, cluster.ID(), props)
+}
+
+// DeleteSchedule removes the schedule with the given identifier from the properties of the
+// cluster.
+func DeleteSchedule(client *cmv1.ClustersClient, cluster *cmv1.Cluster, id string) error {
+	name := properties.SchedulePrefix + id
+	if cluster.Properties()[name] == "" {
+		return fmt.Errorf("There is no schedule with identifier '%s'", id)
+	}
+	props := copyProperties(cluster)
+	delete(props, name)
+	return updateProperties(client, cluster.ID(), props)
+}
+
+// Due returns the schedules whose cron expression matches the given time. Schedules with invalid
+// expressions are ignored.
+func Due(schedules []*Schedule, t time.Time) []*Schedule {
+	var due []*Schedule
+	for _, schedule := range schedules {
+		cron, err := ParseCron(schedule.Cron)
+		if err != nil {
+			continue
+		}
+		if cron.Matches(t) {
+			due = append(due, schedule)
+		}
+	}
+	return due
+}
+
+// Apply scales the machine pool of the schedule to the number of replicas of the schedule.
+func Apply(client *cmv1.ClustersClient, clusterID string, schedule *Schedule) error {
+	if schedule.MachinePool == DefaultMachinePool {
+		cluster, err := cmv1.NewCluster().
+			Nodes(cmv1.NewClusterNodes().Compute(schedule.Replicas)).
+			Build()
+		if err != nil {
+			return err
+		}
+		response, err := client.Cluster(clusterID).Update().Body(cluster).Send()
+		if err != nil {
+			return handleErr(response.Error(), err)
+		}
+		return nil
+	}
+
+	machinePool, err := cmv1.NewMachinePool().
+		ID(schedule.MachinePool).
+		Replicas(schedule.Replicas).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(clusterID).
+		MachinePools().
+		MachinePool(schedule.MachinePool).
+		Update().
+		Body(machinePool).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func copyProperties(cluster *cmv1.Cluster) map[string]string {
+	props := map[string]string{}
+	for name, value := range cluster.Properties() {
+		props[name] = value
+	}
+	return props
+}
+
+func updateProperties(client *cmv1.ClustersClient, clusterID string, props map[string]string) error {
+	cluster, err := cmv1.NewCluster().
+		Properties(props).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(clusterID).Update().Body(cluster).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+func handleErr(res *ocmerrors.Error, err error) error {
+	msg := res.Reason()
+	if msg == "" {
+		msg = err.Error()
+	}
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
+}
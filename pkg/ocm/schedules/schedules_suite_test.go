@@ -0,0 +1,13 @@
+package schedules_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestSchedules(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Schedules Suite")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a holder that allows several commands running in the same process to share
+// a single OCM connection, so that TLS sessions and tokens are reused instead of being created
+// again for each command.
+
+package ocm
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/sirupsen/logrus"
+)
+
+// sharedConnection contains the connection shared by all the commands of the process and the
+// number of users that haven't released it yet.
+var sharedConnection struct {
+	sync.Mutex
+	connection *sdk.Connection
+	references int
+}
+
+// AcquireConnection returns the OCM connection shared by all the commands of the process, creating
+// it the first time that it is called. Each call must be matched by a call to ReleaseConnection,
+// and the connection is closed when the last user releases it. Don't call the Close method of the
+// returned connection directly.
+func AcquireConnection(logger *logrus.Logger) (*sdk.Connection, error) {
+	sharedConnection.Lock()
+	defer sharedConnection.Unlock()
+	if sharedConnection.connection == nil {
+		connection, err := NewConnection().
+			Logger(logger).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		sharedConnection.connection = connection
+	}
+	sharedConnection.references++
+	return sharedConnection.connection, nil
+}
+
+// ReleaseConnection releases the shared OCM connection, closing it if this was the last user.
+func ReleaseConnection() error {
+	sharedConnection.Lock()
+	defer sharedConnection.Unlock()
+	if sharedConnection.references == 0 {
+		return fmt.Errorf("Shared OCM connection released more times than it was acquired")
+	}
+	sharedConnection.references--
+	if sharedConnection.references > 0 {
+		return nil
+	}
+	err := sharedConnection.connection.Close()
+	sharedConnection.connection = nil
+	return err
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to read the AWS STS configuration of clusters. It isn't
+// supported yet by the version of the SDK used by the tool, so it is read with raw requests.
+
+package ocm
+
+import (
+	sdk "github.com/openshift-online/ocm-sdk-go"
+)
+
+// STSCluster contains the AWS STS configuration of a cluster.
+type STSCluster struct {
+	ID                  string
+	Name                string
+	State               string
+	InfraID             string
+	RoleARN             string
+	SupportRoleARN      string
+	ControlPlaneRoleARN string
+	WorkerRoleARN       string
+	OIDCEndpointURL     string
+	OperatorRoleARNs    []string
+}
+
+// GetSTSCluster returns the AWS STS configuration of the given cluster, or nil if the cluster
+// doesn't use STS.
+func GetSTSCluster(connection *sdk.Connection, clusterID string) (*STSCluster, error) {
+	attributes, err := GetAttributes(connection, ClusterPath(clusterID))
+	if err != nil {
+		return nil, err
+	}
+	return parseSTSCluster(attributes), nil
+}
+
+// ListSTSClusters returns the AWS STS configuration of the clusters visible to the current user
+// that use STS.
+func ListSTSClusters(connection *sdk.Connection) ([]*STSCluster, error) {
+	items, err := ListAttributes(connection, ClustersPath, "cloud_provider.id = 'aws'")
+	if err != nil {
+		return nil, err
+	}
+	var result []*STSCluster
+	for _, item := range items {
+		cluster := parseSTSCluster(item)
+		if cluster != nil {
+			result = append(result, cluster)
+		}
+	}
+	return result, nil
+}
+
+func parseSTSCluster(attributes map[string]interface{}) *STSCluster {
+	aws, _ := attributes["aws"].(map[string]interface{})
+	sts, _ := aws["sts"].(map[string]interface{})
+	enabled, _ := sts["enabled"].(bool)
+	if !enabled {
+		return nil
+	}
+	cluster := &STSCluster{}
+	cluster.ID, _ = attributes["id"].(string)
+	cluster.Name, _ = attributes["name"].(string)
+	cluster.State, _ = attributes["state"].(string)
+	cluster.InfraID, _ = attributes["infra_id"].(string)
+	cluster.RoleARN, _ = sts["role_arn"].(string)
+	cluster.SupportRoleARN, _ = sts["support_role_arn"].(string)
+	instanceRoles, _ := sts["instance_iam_roles"].(map[string]interface{})
+	cluster.ControlPlaneRoleARN, _ = instanceRoles["master_role_arn"].(string)
+	cluster.WorkerRoleARN, _ = instanceRoles["worker_role_arn"].(string)
+	cluster.OIDCEndpointURL, _ = sts["oidc_endpoint_url"].(string)
+	roles, _ := sts["operator_iam_roles"].([]interface{})
+	for _, item := range roles {
+		role, _ := item.(map[string]interface{})
+		arn, _ := role["role_arn"].(string)
+		if arn != "" {
+			cluster.OperatorRoleARNs = append(cluster.OperatorRoleARNs, arn)
+		}
+	}
+	return cluster
+}
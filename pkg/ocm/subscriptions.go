@@ -0,0 +1,104 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to get the subscriptions of clusters, which contain the
+// details that the clusters management service doesn't know, like who created each cluster.
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// subscriptionsBatchSize is the maximum number of clusters whose subscriptions are requested in
+// a single search query, to keep the query short.
+const subscriptionsBatchSize = 100
+
+// subscriptionStatusRE is the regular expression used to check the subscription status given by
+// the user before it is used in search queries, so that there is no risk of SQL injection.
+var subscriptionStatusRE = regexp.MustCompile(`^[a-zA-Z]+$`)
+
+// SubscriptionsFilter returns the search query that selects the subscriptions created by the
+// account with the given identifier and that have the given status, like 'active'. Empty values
+// don't restrict the query.
+func SubscriptionsFilter(creatorID string, status string) (string, error) {
+	var terms []string
+	if creatorID != "" {
+		terms = append(terms, fmt.Sprintf("creator.id = '%s'", creatorID))
+	}
+	if status != "" {
+		if !subscriptionStatusRE.MatchString(status) {
+			return "", fmt.Errorf("Status '%s' isn't valid, it must contain only letters", status)
+		}
+		// Statuses are capitalized, like 'Active':
+		status = strings.ToUpper(status[:1]) + strings.ToLower(status[1:])
+		terms = append(terms, fmt.Sprintf("status = '%s'", status))
+	}
+	return strings.Join(terms, " and "), nil
+}
+
+// GetClusterSubscriptions returns the subscriptions of the given clusters that match the given
+// search query, including the account of the creator, indexed by cluster identifier.
+func GetClusterSubscriptions(connection *sdk.Connection, clusters []*cmv1.Cluster,
+	filter string) (map[string]*amsv1.Subscription, error) {
+	result := map[string]*amsv1.Subscription{}
+	for start := 0; start < len(clusters); start += subscriptionsBatchSize {
+		end := start + subscriptionsBatchSize
+		if end > len(clusters) {
+			end = len(clusters)
+		}
+		ids := make([]string, 0, end-start)
+		for _, cluster := range clusters[start:end] {
+			ids = append(ids, fmt.Sprintf("'%s'", cluster.ID()))
+		}
+		query := fmt.Sprintf("cluster_id in (%s)", strings.Join(ids, ", "))
+		if filter != "" {
+			query = fmt.Sprintf("%s and (%s)", query, filter)
+		}
+		response, err := connection.AccountsMgmt().V1().Subscriptions().
+			List().
+			Search(query).
+			FetchaccountsAccounts(true).
+			Page(1).
+			Size(-1).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		response.Items().Each(func(subscription *amsv1.Subscription) bool {
+			result[subscription.ClusterID()] = subscription
+			return true
+		})
+	}
+	return result, nil
+}
+
+// GetCurrentAccount returns the account of the current user.
+func GetCurrentAccount(connection *sdk.Connection) (*amsv1.Account, error) {
+	response, err := connection.AccountsMgmt().V1().CurrentAccount().
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
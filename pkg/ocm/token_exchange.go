@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to exchange federated tokens, like the ones issued to
+// GitHub Actions workflows or to Kubernetes service accounts, for OCM tokens using the OAuth 2.0
+// token exchange grant described in RFC 8693. The SDK doesn't support this grant, so the request
+// is sent directly to the token endpoint.
+
+package ocm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm/config"
+	"github.com/openshift/rosa/pkg/ocm/proxy"
+)
+
+// Values of the parameters of the token exchange grant:
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// ReadFederatedToken reads the federated token from the given file.
+func ReadFederatedToken(file string) (string, error) {
+	// #nosec G304
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("Failed to read federated token file '%s': %v", file, err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("Federated token file '%s' is empty", file)
+	}
+	return token, nil
+}
+
+// ExchangeFederatedToken exchanges the given federated token for OCM access and refresh tokens,
+// using the token URL, client and scopes of the configuration. The refresh token is empty when the
+// server doesn't return one.
+func ExchangeFederatedToken(cfg *config.Config, federatedToken string) (accessToken string,
+	refreshToken string, err error) {
+	tokenURL := sdk.DefaultTokenURL
+	if cfg.TokenURL != "" {
+		tokenURL = cfg.TokenURL
+	}
+	clientID := sdk.DefaultClientID
+	if cfg.ClientID != "" {
+		clientID = cfg.ClientID
+	}
+	scopes := sdk.DefaultScopes
+	if cfg.Scopes != nil {
+		scopes = cfg.Scopes
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", tokenExchangeGrantType)
+	form.Set("subject_token", federatedToken)
+	form.Set("subject_token_type", jwtTokenType)
+	form.Set("client_id", clientID)
+	if cfg.ClientSecret != "" {
+		form.Set("client_secret", cfg.ClientSecret)
+	}
+	if len(scopes) > 0 {
+		form.Set("scope", strings.Join(scopes, " "))
+	}
+
+	proxySelector, err := proxy.Func()
+	if err != nil {
+		return
+	}
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: proxy.Transport(proxySelector, cfg.Insecure),
+	}
+	response, err := client.PostForm(tokenURL, form)
+	if err != nil {
+		err = fmt.Errorf("Failed to send token exchange request to '%s': %v", tokenURL, err)
+		return
+	}
+	defer response.Body.Close()
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		err = fmt.Errorf("Failed to read token exchange response: %v", err)
+		return
+	}
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		err = fmt.Errorf("Failed to parse token exchange response with status code %d: %v",
+			response.StatusCode, err)
+		return
+	}
+	if body.Error != "" {
+		if body.ErrorDescription != "" {
+			err = fmt.Errorf("Token exchange failed: %s: %s", body.Error, body.ErrorDescription)
+		} else {
+			err = fmt.Errorf("Token exchange failed: %s", body.Error)
+		}
+		return
+	}
+	if response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("Token exchange failed with status code %d", response.StatusCode)
+		return
+	}
+	if body.AccessToken == "" {
+		err = fmt.Errorf("Token exchange response doesn't contain an access token")
+		return
+	}
+	accessToken = body.AccessToken
+	refreshToken = body.RefreshToken
+	return
+}
+
+// exchangeFederatedTokens replaces the tokens of the configuration with new ones obtained from
+// the federated token file when the stored ones can no longer be used. Federated tokens are
+// usually short lived and rotated by the platform that issues them, so the file is read again
+// each time. It returns true if the tokens have been replaced.
+func (b *ConnectionBuilder) exchangeFederatedTokens() (exchanged bool, err error) {
+	if b.cfg.FederatedTokenFile == "" {
+		return
+	}
+	armed, err := b.cfg.TokensArmed()
+	if err == nil && armed {
+		return
+	}
+	b.logger.Debugf("Exchanging federated token from file '%s'", b.cfg.FederatedTokenFile)
+	federatedToken, err := ReadFederatedToken(b.cfg.FederatedTokenFile)
+	if err != nil {
+		return
+	}
+	accessToken, refreshToken, err := ExchangeFederatedToken(b.cfg, federatedToken)
+	if err != nil {
+		return
+	}
+	b.cfg.AccessToken = accessToken
+	b.cfg.RefreshToken = refreshToken
+	exchanged = true
+	return
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a transport wrapper that generates a span for each call to the OCM API when
+// tracing is enabled, and propagates the trace context to the server.
+
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/openshift/rosa/pkg/tracing"
+)
+
+// tracingTransport is a round tripper that generates a span for every request.
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// newTracingTransportWrapper returns a transport wrapper that generates a span for every request,
+// or that does nothing if tracing isn't enabled.
+func newTracingTransportWrapper() func(http.RoundTripper) http.RoundTripper {
+	return func(next http.RoundTripper) http.RoundTripper {
+		if !tracing.Enabled() {
+			return next
+		}
+		return &tracingTransport{
+			next: next,
+		}
+	}
+}
+
+// RoundTrip is the implementation of the http.RoundTripper interface.
+func (t *tracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	span := tracing.StartSpan(fmt.Sprintf("OCM %s %s", request.Method, request.URL.Path),
+		tracing.KindClient)
+	span.SetAttribute("http.method", request.Method)
+	span.SetAttribute("http.url", request.URL.Path)
+	span.SetAttribute("net.peer.name", request.URL.Hostname())
+
+	// Round trippers shouldn't modify the original request:
+	request = request.Clone(request.Context())
+	request.Header.Set("traceparent", span.TraceParent())
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		span.End(err)
+		return response, err
+	}
+	span.SetAttribute("http.status_code", response.StatusCode)
+	if operationID := response.Header.Get(OperationIDHeader); operationID != "" {
+		span.SetAttribute("ocm.operation_id", operationID)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		span.End(fmt.Errorf("%s %s returned %d", request.Method, request.URL.Path,
+			response.StatusCode))
+	} else {
+		span.End(nil)
+	}
+	return response, nil
+}
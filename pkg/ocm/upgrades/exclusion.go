@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to manage the maintenance exclusion window of a cluster,
+// the times when the cluster shouldn't be upgraded. The window is a cron expression that matches
+// the excluded minutes, and it is stored as a property of the cluster.
+
+package upgrades
+
+import (
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm/properties"
+	"github.com/openshift/rosa/pkg/ocm/schedules"
+)
+
+// maxExclusion is the longest time that NextAllowed looks ahead for the end of an exclusion.
+const maxExclusion = 366 * 24 * time.Hour
+
+// GetMaintenanceExclusion returns the maintenance exclusion window of the cluster, or nil if the
+// cluster doesn't have one.
+func GetMaintenanceExclusion(cluster *cmv1.Cluster) (*schedules.Cron, error) {
+	expression := cluster.Properties()[properties.MaintenanceExclusion]
+	if expression == "" {
+		return nil, nil
+	}
+	window, err := schedules.ParseCron(expression)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse maintenance exclusion window of cluster '%s': %v",
+			cluster.ID(), err)
+	}
+	return window, nil
+}
+
+// SetMaintenanceExclusion stores the given cron expression as the maintenance exclusion window of
+// the cluster. An empty expression removes the window.
+func SetMaintenanceExclusion(client *cmv1.ClustersClient, cluster *cmv1.Cluster,
+	expression string) error {
+	if expression != "" {
+		_, err := schedules.ParseCron(expression)
+		if err != nil {
+			return err
+		}
+	}
+	props := map[string]string{}
+	for name, value := range cluster.Properties() {
+		props[name] = value
+	}
+	if expression == "" {
+		delete(props, properties.MaintenanceExclusion)
+	} else {
+		props[properties.MaintenanceExclusion] = expression
+	}
+	body, err := cmv1.NewCluster().
+		Properties(props).
+		Build()
+	if err != nil {
+		return err
+	}
+	response, err := client.Cluster(cluster.ID()).Update().Body(body).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
+// NextAllowed returns the first minute after the given time that is outside of the maintenance
+// exclusion window, or the zero time if there is none in the next year.
+func NextAllowed(window *schedules.Cron, t time.Time) time.Time {
+	next := t.Truncate(time.Minute)
+	limit := next.Add(maxExclusion)
+	for next.Before(limit) {
+		if !window.Matches(next) {
+			return next
+		}
+		next = next.Add(time.Minute)
+	}
+	return time.Time{}
+}
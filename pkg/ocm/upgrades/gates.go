@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to get the version gates, which are the conditions, like
+// API removals, that need to be acknowledged before upgrading clusters to a minor version. The
+// version gates aren't supported yet by the version of the SDK used by the tool, so they are
+// retrieved with raw requests.
+
+package upgrades
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// VersionGatesPath is the path of the collection of version gates.
+const VersionGatesPath = "/api/clusters_mgmt/v1/version_gates"
+
+// Regular expression used to check the minor versions given by the user:
+var minorVersionRE = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// VersionGate is a condition that needs to be acknowledged before upgrading to a minor version.
+type VersionGate struct {
+	ID               string `json:"id"`
+	Version          string `json:"version_raw_id_prefix"`
+	Label            string `json:"label"`
+	Description      string `json:"description"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+	WarningMessage   string `json:"warning_message,omitempty"`
+	STSOnly          bool   `json:"sts_only"`
+}
+
+// MinorVersion returns the minor version, like '4.12', of the given version, like '4.12.3'.
+func MinorVersion(version string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "openshift-v"), ".")
+	if len(parts) < 2 || !minorVersionRE.MatchString(parts[0]+"."+parts[1]) {
+		return "", fmt.Errorf("Expected a version like '4.12', got '%s'", version)
+	}
+	return parts[0] + "." + parts[1], nil
+}
+
+// NextMinorVersion returns the minor version that follows the given version, for example '4.12'
+// for '4.11.5'.
+func NextMinorVersion(version string) (string, error) {
+	minor, err := MinorVersion(version)
+	if err != nil {
+		return "", err
+	}
+	number, err := minorVersion(minor)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s.%d", strings.Split(minor, ".")[0], number+1), nil
+}
+
+// GetVersionGates returns the version gates of the given minor version, like '4.12'.
+func GetVersionGates(connection *sdk.Connection, minor string) ([]*VersionGate, error) {
+	if !minorVersionRE.MatchString(minor) {
+		return nil, fmt.Errorf("Expected a version like '4.12', got '%s'", minor)
+	}
+	items, err := ocm.ListAttributes(connection, VersionGatesPath,
+		fmt.Sprintf("version_raw_id_prefix = '%s'", minor))
+	if err != nil {
+		return nil, err
+	}
+	gates := make([]*VersionGate, 0, len(items))
+	for _, item := range items {
+		gate := &VersionGate{}
+		gate.ID, _ = item["id"].(string)
+		gate.Version, _ = item["version_raw_id_prefix"].(string)
+		gate.Label, _ = item["label"].(string)
+		gate.Description, _ = item["description"].(string)
+		gate.DocumentationURL, _ = item["documentation_url"].(string)
+		gate.WarningMessage, _ = item["warning_message"].(string)
+		gate.STSOnly, _ = item["sts_only"].(bool)
+		gates = append(gates, gate)
+	}
+	sort.Slice(gates, func(i, j int) bool {
+		return gates[i].ID < gates[j].ID
+	})
+	return gates, nil
+}
+
+// GetGateAgreements returns the time when each version gate was acknowledged for the cluster,
+// indexed by the identifier of the version gate.
+func GetGateAgreements(connection *sdk.Connection, clusterID string) (map[string]time.Time, error) {
+	items, err := ocm.ListAttributes(connection, ocm.ClusterPath(clusterID)+"/gate_agreements", "")
+	if err != nil {
+		return nil, err
+	}
+	agreements := map[string]time.Time{}
+	for _, item := range items {
+		gate, _ := item["version_gate"].(map[string]interface{})
+		gateID, _ := gate["id"].(string)
+		if gateID == "" {
+			continue
+		}
+		text, _ := item["agreed_timestamp"].(string)
+		agreed, err := time.Parse(time.RFC3339, text)
+		if err != nil {
+			agreed = time.Time{}
+		}
+		agreements[gateID] = agreed
+	}
+	return agreements, nil
+}
+
+// IsSTSCluster checks if the cluster uses AWS STS, as some version gates only apply to those
+// clusters.
+func IsSTSCluster(connection *sdk.Connection, clusterID string) (bool, error) {
+	cluster, err := ocm.GetSTSCluster(connection, clusterID)
+	if err != nil {
+		return false, err
+	}
+	return cluster != nil, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to build the history of the upgrades of a cluster. Upgrade
+// policies are deleted once the upgrade is done, so the history is built from the service log
+// notifications sent when upgrades start, complete or fail, and from the state of the upgrade
+// policy that is running, if any.
+
+package upgrades
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm/events"
+)
+
+// Outcomes of the upgrades of the history:
+const (
+	OutcomeCompleted  = "completed"
+	OutcomeFailed     = "failed"
+	OutcomeInProgress = "in progress"
+)
+
+// Upgrade is an entry of the upgrade history of a cluster. The times are nil when the
+// corresponding notification isn't available.
+type Upgrade struct {
+	From     string     `json:"from,omitempty"`
+	To       string     `json:"to"`
+	Started  *time.Time `json:"started,omitempty"`
+	Finished *time.Time `json:"finished,omitempty"`
+	Outcome  string     `json:"outcome"`
+}
+
+// upgradeVersionRE extracts the target version from the text of an upgrade notification.
+var upgradeVersionRE = regexp.MustCompile(`\b\d+\.\d+\.\d+(-[0-9A-Za-z.]*[0-9A-Za-z])?`)
+
+// GetHistory returns the upgrades of the cluster, in chronological order.
+func GetHistory(connection *sdk.Connection, cluster *cmv1.Cluster) ([]*Upgrade, error) {
+	clusterEvents, err := events.GetEvents(connection, cluster, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	var history []*Upgrade
+	var current *Upgrade
+	previous := ""
+	for _, event := range clusterEvents {
+		outcome, version := parseUpgradeEvent(event)
+		if outcome == "" {
+			continue
+		}
+		if current == nil || (version != "" && version != current.To) {
+			current = &Upgrade{
+				From:    previous,
+				To:      version,
+				Outcome: OutcomeInProgress,
+			}
+			history = append(history, current)
+		}
+		if outcome == OutcomeInProgress {
+			if current.Started == nil {
+				current.Started = &event.Timestamp
+			}
+			continue
+		}
+		current.Finished = &event.Timestamp
+		current.Outcome = outcome
+		if outcome == OutcomeCompleted {
+			previous = current.To
+		}
+		current = nil
+	}
+
+	// The notification of an upgrade that is running may not have been sent yet:
+	policy, state, err := GetScheduledUpgrade(connection.ClustersMgmt().V1(), cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && state.Value() == cmv1.UpgradePolicyStateValueStarted &&
+		(current == nil || current.To != policy.Version()) {
+		started := policy.NextRun()
+		history = append(history, &Upgrade{
+			From:    cluster.OpenshiftVersion(),
+			To:      policy.Version(),
+			Started: &started,
+			Outcome: OutcomeInProgress,
+		})
+	}
+	return history, nil
+}
+
+// parseUpgradeEvent returns the outcome that the given event reports for an upgrade and the
+// target version of the upgrade, or an empty outcome if the event isn't about an upgrade that
+// started or finished.
+func parseUpgradeEvent(event *events.Event) (string, string) {
+	text := strings.ToLower(event.Summary + " " + event.Description)
+	if !strings.Contains(text, "upgrad") {
+		return "", ""
+	}
+	var outcome string
+	switch {
+	case strings.Contains(text, "fail"):
+		outcome = OutcomeFailed
+	case strings.Contains(text, "schedul"):
+		// Notifications sent ahead of the upgrade:
+		return "", ""
+	case strings.Contains(text, "complete") || strings.Contains(text, "successfully"):
+		outcome = OutcomeCompleted
+	case strings.Contains(text, "start") || strings.Contains(text, "being upgraded") ||
+		strings.Contains(text, "in progress"):
+		outcome = OutcomeInProgress
+	default:
+		return "", ""
+	}
+	return outcome, upgradeVersionRE.FindString(event.Summary + " " + event.Description)
+}
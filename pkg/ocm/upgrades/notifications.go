@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to configure the service log notifications sent ahead of
+// upgrades. The notification lead time isn't supported yet by the version of the SDK used by the
+// tool, so it is sent and read as a raw attribute of the upgrade policies.
+
+package upgrades
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// NotifyBeforeAttribute is the attribute of the upgrade policy that contains how long before the
+// upgrade the service log notification is generated, as a duration like '2h'.
+const NotifyBeforeAttribute = "notify_before"
+
+// MaxNotifyBefore is the longest lead time accepted for notifications.
+const MaxNotifyBefore = 7 * 24 * time.Hour
+
+// NotificationWindow is the period between the service log notification of an upgrade and the
+// upgrade itself.
+type NotificationWindow struct {
+	PolicyID     string
+	ScheduleType string
+	Version      string
+	NotifyAt     time.Time
+	NextRun      time.Time
+}
+
+// Pending checks if the upgrade of the window hasn't started yet at the given time.
+func (w *NotificationWindow) Pending(now time.Time) bool {
+	return now.Before(w.NextRun)
+}
+
+// Open checks if the notification has already been generated at the given time, but the upgrade
+// hasn't started yet.
+func (w *NotificationWindow) Open(now time.Time) bool {
+	return !now.Before(w.NotifyAt) && w.Pending(now)
+}
+
+// ValidateNotifyBefore checks that the given notification lead time is supported.
+func ValidateNotifyBefore(value time.Duration) error {
+	if value <= 0 {
+		return fmt.Errorf("Notification lead time must be positive")
+	}
+	if value > MaxNotifyBefore {
+		return fmt.Errorf("Notification lead time can't be longer than %s", MaxNotifyBefore)
+	}
+	if value%time.Minute != 0 {
+		return fmt.Errorf("Notification lead time must be a whole number of minutes")
+	}
+	return nil
+}
+
+// AddUpgradePolicy adds the given upgrade policy to the cluster. When the notification lead time
+// is positive the policy is configured to generate a service log notification that long before
+// each upgrade.
+func AddUpgradePolicy(connection *sdk.Connection, clusterID string, policy *cmv1.UpgradePolicy,
+	notifyBefore time.Duration) (*cmv1.UpgradePolicy, error) {
+	attributes := map[string]interface{}{}
+	if notifyBefore > 0 {
+		attributes[NotifyBeforeAttribute] = formatDuration(notifyBefore)
+	}
+	return ocm.AddUpgradePolicy(connection, clusterID, policy, attributes)
+}
+
+// GetNotificationWindows returns the notification windows of the upgrade policies of the cluster
+// that have a notification lead time configured, sorted by the time of the notification.
+func GetNotificationWindows(connection *sdk.Connection, clusterID string) ([]*NotificationWindow, error) {
+	items, err := ocm.ListAttributes(connection, ocm.UpgradePoliciesPath(clusterID), "")
+	if err != nil {
+		return nil, err
+	}
+	var windows []*NotificationWindow
+	for _, item := range items {
+		text, _ := item[NotifyBeforeAttribute].(string)
+		if text == "" {
+			continue
+		}
+		notifyBefore, err := time.ParseDuration(text)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse notification lead time '%s': %v", text, err)
+		}
+		nextRunText, _ := item["next_run"].(string)
+		nextRun, err := time.Parse(time.RFC3339, nextRunText)
+		if err != nil {
+			continue
+		}
+		window := &NotificationWindow{
+			NotifyAt: nextRun.Add(-notifyBefore),
+			NextRun:  nextRun,
+		}
+		window.PolicyID, _ = item["id"].(string)
+		window.ScheduleType, _ = item["schedule_type"].(string)
+		window.Version, _ = item["version"].(string)
+		windows = append(windows, window)
+	}
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i].NotifyAt.Before(windows[j].NotifyAt)
+	})
+	return windows, nil
+}
+
+// formatDuration returns the duration without the zero units that time.Duration adds, so that
+// '2h' is sent as '2h' and not as '2h0m0s'.
+func formatDuration(value time.Duration) string {
+	hours := value / time.Hour
+	minutes := (value % time.Hour) / time.Minute
+	switch {
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to compare the versions of the machine pools of a cluster
+// with the version of the control plane.
+
+package upgrades
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// MaxMachinePoolMinorSkew is the maximum number of minor versions that the machine pools can be
+// behind the control plane.
+const MaxMachinePoolMinorSkew = 2
+
+// MachinePoolVersion contains the version of a machine pool compared to the version of the
+// control plane.
+type MachinePoolVersion struct {
+	ID                  string
+	Version             string
+	ControlPlaneVersion string
+	// MinorSkew is the number of minor versions that the machine pool is behind the control plane.
+	MinorSkew int
+	// BlocksYStream indicates that the machine pool needs to be upgraded before the control plane
+	// can be upgraded to the next minor version.
+	BlocksYStream bool
+}
+
+// GetMachinePoolVersions returns the versions of all the machine pools of the cluster, including
+// the default one. Machine pools that don't report a version run the version of the control plane.
+func GetMachinePoolVersions(connection *sdk.Connection, cluster *cmv1.Cluster) ([]*MachinePoolVersion, error) {
+	controlPlaneVersion := cluster.OpenshiftVersion()
+	if controlPlaneVersion == "" {
+		controlPlaneVersion = strings.TrimPrefix(cluster.Version().RawID(), "openshift-v")
+	}
+
+	// The version of the SDK used by the tool doesn't support the versions of machine pools yet,
+	// so they are retrieved with a raw request:
+	attributes, err := ocm.GetAttributes(connection, ocm.ClusterPath(cluster.ID())+"/machine_pools")
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []*MachinePoolVersion{
+		newMachinePoolVersion("Default", controlPlaneVersion, controlPlaneVersion),
+	}
+	items, _ := attributes["items"].([]interface{})
+	for _, item := range items {
+		machinePool, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id, _ := machinePool["id"].(string)
+		version := controlPlaneVersion
+		if value, ok := machinePool["version"].(map[string]interface{}); ok {
+			if rawID, ok := value["raw_id"].(string); ok && rawID != "" {
+				version = rawID
+			} else if versionID, ok := value["id"].(string); ok && versionID != "" {
+				version = strings.TrimPrefix(versionID, "openshift-v")
+			}
+		}
+		versions = append(versions, newMachinePoolVersion(id, version, controlPlaneVersion))
+	}
+	return versions, nil
+}
+
+func newMachinePoolVersion(id string, version string, controlPlaneVersion string) *MachinePoolVersion {
+	result := &MachinePoolVersion{
+		ID:                  id,
+		Version:             version,
+		ControlPlaneVersion: controlPlaneVersion,
+	}
+	poolMinor, poolErr := minorVersion(version)
+	controlPlaneMinor, controlPlaneErr := minorVersion(controlPlaneVersion)
+	if poolErr == nil && controlPlaneErr == nil {
+		result.MinorSkew = controlPlaneMinor - poolMinor
+		// After a y-stream upgrade of the control plane the skew grows by one:
+		result.BlocksYStream = result.MinorSkew+1 > MaxMachinePoolMinorSkew
+	}
+	return result
+}
+
+// IsYStreamUpgrade checks if upgrading from the current version to the target version changes
+// the minor version.
+func IsYStreamUpgrade(current string, target string) bool {
+	currentMinor, err := minorVersion(current)
+	if err != nil {
+		return false
+	}
+	targetMinor, err := minorVersion(target)
+	if err != nil {
+		return false
+	}
+	return targetMinor > currentMinor
+}
+
+func minorVersion(version string) (int, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return 0, fmt.Errorf("Invalid version '%s'", version)
+	}
+	return strconv.Atoi(parts[1])
+}
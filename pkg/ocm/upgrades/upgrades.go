@@ -21,25 +21,26 @@ import (
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
 )
 
 func GetUpgradePolicies(client *cmv1.Client, clusterID string) (upgradePolicies []*cmv1.UpgradePolicy, err error) {
 	collection := client.Clusters().Cluster(clusterID).UpgradePolicies()
-	page := 1
-	size := 100
-	for {
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
 		response, err := collection.List().
 			Page(page).
 			Size(size).
 			Send()
 		if err != nil {
-			return nil, handleErr(response.Error(), err)
+			return 0, false, handleErr(response.Error(), err)
 		}
 		upgradePolicies = append(upgradePolicies, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return
 }
@@ -90,5 +91,5 @@ func handleErr(res *ocmerrors.Error, err error) error {
 	if msg == "" {
 		msg = err.Error()
 	}
-	return errors.New(msg)
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
 }
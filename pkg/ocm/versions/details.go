@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to get the details of a version that aren't supported yet by
+// the SDK, like its end of life date and the version gates that apply to it.
+
+package versions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	versionsPath     = "/api/clusters_mgmt/v1/versions"
+	versionGatesPath = "/api/clusters_mgmt/v1/version_gates"
+)
+
+// Details contains a version and the attributes of the version that the SDK doesn't support.
+type Details struct {
+	*cmv1.Version
+
+	ReleaseImage string
+	EndOfLife    time.Time
+}
+
+// Gate is a version gate, a change in a minor version, like the removal of an API, that needs
+// to be acknowledged before upgrading clusters to that minor version.
+type Gate struct {
+	ID               string
+	Label            string
+	Description      string
+	VersionPrefix    string
+	DocumentationURL string
+	WarningMessage   string
+	STSOnly          bool
+}
+
+// GetVersion returns the details of the version with the given raw identifier, like '4.10.15',
+// in the given channel group.
+func GetVersion(connection *sdk.Connection, rawID string, channelGroup string) (*Details, error) {
+	versionID := createVersionID(rawID, channelGroup)
+	response, err := connection.ClustersMgmt().V1().Versions().Version(versionID).Get().Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	details := &Details{
+		Version: response.Body(),
+	}
+
+	attributes, err := ocm.GetAttributes(connection, fmt.Sprintf("%s/%s", versionsPath, versionID))
+	if err != nil {
+		return nil, err
+	}
+	details.ReleaseImage, _ = attributes["release_image"].(string)
+	if value, ok := attributes["end_of_life_timestamp"].(string); ok {
+		details.EndOfLife, err = time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse end of life of version '%s': %v", rawID, err)
+		}
+	}
+	return details, nil
+}
+
+// GetGates returns the version gates that apply to the minor version of the given raw version
+// identifier.
+func GetGates(connection *sdk.Connection, rawID string) ([]*Gate, error) {
+	prefix, err := MinorVersion(rawID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := ocm.ListAttributes(connection, versionGatesPath,
+		fmt.Sprintf("version_raw_id_prefix = '%s'", prefix))
+	if err != nil {
+		return nil, err
+	}
+	gates := make([]*Gate, 0, len(items))
+	for _, item := range items {
+		gate := &Gate{}
+		gate.ID, _ = item["id"].(string)
+		gate.Label, _ = item["label"].(string)
+		gate.Description, _ = item["description"].(string)
+		gate.VersionPrefix, _ = item["version_raw_id_prefix"].(string)
+		gate.DocumentationURL, _ = item["documentation_url"].(string)
+		gate.WarningMessage, _ = item["warning_message"].(string)
+		gate.STSOnly, _ = item["sts_only"].(bool)
+		gates = append(gates, gate)
+	}
+	return gates, nil
+}
+
+// MinorVersion returns the major and minor parts of the given raw version identifier, for example
+// '4.10' for '4.10.15'.
+func MinorVersion(rawID string) (string, error) {
+	parts := strings.Split(rawID, ".")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("Version '%s' isn't valid, it must be like '4.10.15'", rawID)
+	}
+	return parts[0] + "." + parts[1], nil
+}
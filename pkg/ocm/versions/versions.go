@@ -22,34 +22,34 @@ import (
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/ocm"
 )
 
 const DefaultChannelGroup = "stable"
 
 func GetVersions(client *cmv1.Client, channelGroup string) (versions []*cmv1.Version, err error) {
 	collection := client.Versions()
-	page := 1
-	size := 100
 	filter := "enabled = 'true' AND rosa_enabled = 'true'"
 	if channelGroup != "" {
 		filter = fmt.Sprintf("%s AND channel_group = '%s'", filter, channelGroup)
 	}
-	for {
-		var response *cmv1.VersionsListResponse
-		response, err = collection.List().
+	err = ocm.Paginate(func(page int, size int) (int, bool, error) {
+		response, err := collection.List().
 			Search(filter).
 			Order("default desc, id desc").
 			Page(page).
 			Size(size).
 			Send()
 		if err != nil {
-			return nil, handleErr(response.Error(), err)
+			return 0, false, handleErr(response.Error(), err)
 		}
 		versions = append(versions, response.Items().Slice()...)
-		if response.Size() < size {
-			break
-		}
-		page++
+		return response.Size(), true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return
 }
@@ -98,5 +98,5 @@ func handleErr(res *ocmerrors.Error, err error) error {
 	if msg == "" {
 		msg = err.Error()
 	}
-	return errors.New(msg)
+	return exit.Wrap(errors.New(ocm.WithOperationID(msg, res)), err)
 }
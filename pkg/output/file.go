@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--output-file' command line option, that
+// writes the JSON representation of the results to a file while the usual output is still printed
+// to the terminal.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Marshaller writes the JSON representation of a value, like the Marshal functions of the SDK do.
+type Marshaller func(writer io.Writer) error
+
+// File returns the name of the file where the results should be written, or an empty string if
+// the user didn't request it.
+func File() string {
+	return outputFile
+}
+
+// WriteFile writes the JSON representation of the given value to the file requested with the
+// '--output-file' flag. The value can be a Marshaller, for types of the SDK, or anything that can
+// be marshalled with the encoding/json package. It does nothing if the flag wasn't used.
+func WriteFile(value interface{}) error {
+	if outputFile == "" {
+		return nil
+	}
+	data, err := encode(value)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first, so that an existing report isn't left truncated if
+	// writing fails:
+	tmp, err := ioutil.TempFile(filepath.Dir(outputFile), filepath.Base(outputFile)+".*")
+	if err != nil {
+		return fmt.Errorf("Failed to write output file '%s': %v", outputFile, err)
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), outputFile)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write output file '%s': %v", outputFile, err)
+	}
+	return nil
+}
+
+// Print writes the JSON representation of the given value to the standard output. The value can
+// be anything accepted by WriteFile.
+func Print(value interface{}) error {
+	data, err := encode(value)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// encode returns the indented JSON representation of the given value.
+func encode(value interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	switch typed := value.(type) {
+	case Marshaller:
+		err := typed(buffer)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(data)
+	}
+	indented := &bytes.Buffer{}
+	err := json.Indent(indented, buffer.Bytes(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	indented.WriteString("\n")
+	return indented.Bytes(), nil
+}
+
+// validateFile checks that the file requested by the user can be written.
+func validateFile() error {
+	if outputFile == "" {
+		return nil
+	}
+	info, err := os.Stat(outputFile)
+	if err == nil && info.IsDir() {
+		return fmt.Errorf("Output file '%s' is a directory", outputFile)
+	}
+	info, err = os.Stat(filepath.Dir(outputFile))
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("Directory of output file '%s' doesn't exist", outputFile)
+	}
+	return nil
+}
+
+// outputFile is the name of the file where the JSON representation of the results is written.
+var outputFile string
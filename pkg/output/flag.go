@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--output' command line option.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// Name is the output format that prints only the identifiers of the resources, one per line.
+const Name = "name"
+
+// JSON is the output format that prints the JSON representation of the resources. Commands have
+// to support it explicitly, passing it to AddFlag.
+const JSON = "json"
+
+// AddFlag adds the output flags to the given set of command line flags. The 'name' format is
+// always allowed, additional formats supported by the command can be given.
+func AddFlag(flags *pflag.FlagSet, formats ...string) {
+	allowed := append([]string{Name}, formats...)
+	usage := fmt.Sprintf("Output format. Allowed formats are [%s]. The 'name' format prints only "+
+		"the identifiers of the resources, one per line, so that they can be used in scripts.",
+		strings.Join(allowed, ", "))
+	if len(formats) > 0 {
+		usage += " The 'json' format prints the JSON representation of the resources."
+	}
+	flags.VarP(
+		&formatValue{allowed: allowed},
+		"output",
+		"o",
+		usage,
+	)
+	flags.StringVar(
+		&outputFile,
+		"output-file",
+		"",
+		"Write the JSON representation of the results to this file, in addition to the "+
+			"output printed to the terminal.",
+	)
+}
+
+// Output returns the output format requested by the user.
+func Output() string {
+	return output
+}
+
+// NameOnly returns a boolean flag that indicates if only the identifiers of the resources should
+// be printed.
+func NameOnly() bool {
+	return output == Name
+}
+
+// JSONOnly returns a boolean flag that indicates if the JSON representation of the resources
+// should be printed.
+func JSONOnly() bool {
+	return output == JSON
+}
+
+// Machine returns a boolean flag that indicates if the standard output is reserved for output
+// meant to be consumed by other programs.
+func Machine() bool {
+	return output != ""
+}
+
+// Validate checks that the output file requested by the user is supported. The output format is
+// checked when the flag is parsed.
+func Validate() error {
+	return validateFile()
+}
+
+// formatValue is the value of the output flag. Each command has its own, so that it only accepts
+// the formats supported by that command.
+type formatValue struct {
+	allowed []string
+}
+
+func (v *formatValue) String() string {
+	return output
+}
+
+// Allows checks if the given format is supported by the command. It is used to ignore defaults of
+// the output format that the command doesn't support.
+func (v *formatValue) Allows(value string) bool {
+	for _, format := range v.allowed {
+		if value == format {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *formatValue) Set(value string) error {
+	if v.Allows(value) {
+		output = value
+		return nil
+	}
+	return fmt.Errorf("Invalid output format '%s', allowed formats are [%s]",
+		value, strings.Join(v.allowed, ", "))
+}
+
+func (v *formatValue) Type() string {
+	return "string"
+}
+
+// output is a string flag that indicates the output format requested by the user.
+var output string
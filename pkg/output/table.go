@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--compact' and '--delimiter' command line
+// options, that print tables without padding so that they can be processed by tools like 'cut'
+// and 'awk'.
+
+package output
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/spf13/pflag"
+)
+
+var compact bool
+var delimiter string
+
+// Table is the writer used to print tables. Columns are separated by tabs, rows by new lines, and
+// nothing is printed till Flush is called.
+type Table interface {
+	io.Writer
+	Flush() error
+}
+
+// AddTableFlags adds the flags that control how tables are printed to the given set of command
+// line flags.
+func AddTableFlags(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&compact,
+		"compact",
+		false,
+		"Print tables one row per line without padding, separating the columns with the "+
+			"delimiter, so that they can be processed by tools like 'cut' and 'awk'.",
+	)
+	flags.StringVar(
+		&delimiter,
+		"delimiter",
+		"",
+		"Delimiter used to separate the columns of the tables. Escape sequences like '\\t' "+
+			"are accepted. The default is a tab. Implies '--compact'.",
+	)
+}
+
+// Compact returns a boolean flag that indicates if tables should be printed without padding.
+func Compact() bool {
+	return compact || delimiter != ""
+}
+
+// NewTable returns the writer that prints tables to the given output, aligning the columns or,
+// if the user requested it, separating them with the delimiter.
+func NewTable(out io.Writer) Table {
+	if !Compact() {
+		return tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	}
+	separator := "\t"
+	if delimiter != "" {
+		separator = unescape(delimiter)
+	}
+	return &compactTable{
+		out:       out,
+		separator: []byte(separator),
+	}
+}
+
+// compactTable replaces the tabs that separate the columns with the delimiter.
+type compactTable struct {
+	out       io.Writer
+	separator []byte
+	buffer    bytes.Buffer
+}
+
+func (t *compactTable) Write(data []byte) (int, error) {
+	t.buffer.Write(bytes.ReplaceAll(data, []byte("\t"), t.separator))
+	return len(data), nil
+}
+
+func (t *compactTable) Flush() error {
+	_, err := t.buffer.WriteTo(t.out)
+	return err
+}
+
+// unescape replaces the escape sequences of the delimiter, so that a tab can be given as '\t'
+// without the quoting rules of the shell.
+func unescape(value string) string {
+	result, err := strconv.Unquote(`"` + value + `"`)
+	if err != nil {
+		return value
+	}
+	return result
+}
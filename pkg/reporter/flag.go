@@ -0,0 +1,87 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--no-color' and '--quiet' command line
+// options.
+
+package reporter
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// AddNoColorFlag adds the '--no-color' flag to the given set of command line flags.
+func AddNoColorFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&noColor,
+		"no-color",
+		false,
+		"Disable colors in the output. Colors are also disabled when the output isn't a "+
+			"terminal or when the NO_COLOR environment variable is set.",
+	)
+}
+
+// AddQuietFlag adds the '--quiet' flag to the given set of command line flags.
+func AddQuietFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&quiet,
+		"quiet",
+		false,
+		"Don't print informative messages or warnings, only errors and the requested output.",
+	)
+}
+
+// AddSuppressWarningsFlag adds the '--suppress-warnings' flag to the given set of command line
+// flags.
+func AddSuppressWarningsFlag(flags *pflag.FlagSet) {
+	flags.StringSliceVar(
+		&suppressWarnings,
+		"suppress-warnings",
+		nil,
+		"Comma separated list of warning codes, like 'W0012,W0011', that won't be reported.",
+	)
+}
+
+// AddWarningsOutputFlag adds the '--warnings-output' flag to the given set of command line flags.
+func AddWarningsOutputFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&warningsOutput,
+		"warnings-output",
+		"",
+		fmt.Sprintf("Format of the coded warnings. The '%s' format writes them to the standard "+
+			"error stream as a single JSON document with a 'warnings' array when the command "+
+			"finishes.", WarningsOutputJSON),
+	)
+}
+
+// Quiet returns a boolean flag that indicates if the quiet mode is enabled.
+func Quiet() bool {
+	return quiet
+}
+
+// noColor is a boolean flag that indicates that colors are disabled.
+var noColor bool
+
+// quiet is a boolean flag that indicates that only errors should be reported.
+var quiet bool
+
+// suppressWarnings contains the codes of the warnings that shouldn't be reported.
+var suppressWarnings []string
+
+// warningsOutput is the format of the coded warnings.
+var warningsOutput string
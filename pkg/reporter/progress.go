@@ -0,0 +1,164 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a component that shows the progress of operations composed of several slow
+// steps, so that the user can see that the tool isn't stuck.
+
+package reporter
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+
+	"github.com/openshift/rosa/pkg/debug"
+)
+
+// spinnerFrames are the characters used to animate the spinner. They are plain ASCII so that they
+// are displayed correctly by all terminals.
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerInterval is the time between frames of the spinner.
+const spinnerInterval = 100 * time.Millisecond
+
+// Progress shows the progress of an operation composed of a known number of steps. When the
+// output is a terminal the current step is displayed with a spinner, otherwise each step is
+// printed as a regular informative message when it starts.
+type Progress struct {
+	reporter *Object
+	stream   *os.File
+	total    int
+	animate  bool
+
+	lock      sync.Mutex
+	step      int
+	message   string
+	committed bool
+	frame     int
+	stop      chan struct{}
+	stopped   chan struct{}
+}
+
+// activeProgress is the progress that is currently animating a spinner, if any. Messages printed
+// by the reporter while it is active need to clear the spinner first.
+var activeProgress struct {
+	sync.Mutex
+	progress *Progress
+}
+
+// NewProgress creates a progress for an operation with the given number of steps. The caller must
+// call Done when the operation finishes, also when it fails.
+func (r *Object) NewProgress(total int) *Progress {
+	stream := r.stream()
+	return &Progress{
+		reporter: r,
+		stream:   stream,
+		total:    total,
+		animate: !quiet && !debug.Enabled() && runtime.GOOS != "windows" &&
+			isatty.IsTerminal(stream.Fd()),
+	}
+}
+
+// Step finishes the current step, if any, and starts the next one with the given description.
+func (p *Progress) Step(format string, args ...interface{}) {
+	p.lock.Lock()
+	p.commitLocked()
+	p.step++
+	p.message = fmt.Sprintf("[%d/%d] %s", p.step, p.total, fmt.Sprintf(format, args...))
+	p.committed = false
+	p.lock.Unlock()
+
+	if !p.animate {
+		p.reporter.Infof("%s", p.message)
+		return
+	}
+	if p.stop == nil {
+		p.start()
+	}
+}
+
+// Done finishes the current step and stops the spinner.
+func (p *Progress) Done() {
+	if p.stop != nil {
+		close(p.stop)
+		<-p.stopped
+		p.stop = nil
+	}
+	p.lock.Lock()
+	p.commitLocked()
+	p.lock.Unlock()
+}
+
+// start starts the goroutine that animates the spinner.
+func (p *Progress) start() {
+	p.stop = make(chan struct{})
+	p.stopped = make(chan struct{})
+	activeProgress.Lock()
+	activeProgress.progress = p
+	activeProgress.Unlock()
+	go func() {
+		defer close(p.stopped)
+		defer func() {
+			activeProgress.Lock()
+			activeProgress.progress = nil
+			activeProgress.Unlock()
+		}()
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for {
+			p.lock.Lock()
+			if !p.committed {
+				fmt.Fprintf(p.stream, "\r\033[K%s %s", spinnerFrames[p.frame], p.message)
+				p.frame = (p.frame + 1) % len(spinnerFrames)
+			}
+			p.lock.Unlock()
+			select {
+			case <-p.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// commitLocked replaces the spinner line of the current step with a regular informative message,
+// so that the step stays visible in the output. The lock must be held by the caller.
+func (p *Progress) commitLocked() {
+	if !p.animate || p.step == 0 || p.committed {
+		return
+	}
+	p.committed = true
+	fmt.Fprint(p.stream, "\r\033[K")
+	write(p.stream, infoPrefix, "INFO: ", p.message)
+}
+
+// commitActiveProgress is called before printing a message, so that the message doesn't get mixed
+// with the spinner of the active progress.
+func commitActiveProgress() {
+	activeProgress.Lock()
+	p := activeProgress.progress
+	activeProgress.Unlock()
+	if p == nil {
+		return
+	}
+	p.lock.Lock()
+	p.commitLocked()
+	p.lock.Unlock()
+}
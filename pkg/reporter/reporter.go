@@ -22,7 +22,11 @@ import (
 	"os"
 	"runtime"
 
+	"github.com/mattn/go-isatty"
+
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/exit"
+	"github.com/openshift/rosa/pkg/output"
 )
 
 // Builder contains the information and logic needed to create a new reporter.
@@ -59,52 +63,103 @@ func (r *Object) Debugf(format string, args ...interface{}) {
 
 // Infof prints an informative message with the given format and arguments.
 func (r *Object) Infof(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	if r.useColors() {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", "INFO: ", message)
+	if quiet {
+		return
+	}
+	r.print(r.stream(), infoPrefix, "INFO: ", format, args...)
+}
+
+// Successf prints a message that indicates that an operation completed successfully, with the
+// given format and arguments.
+func (r *Object) Successf(format string, args ...interface{}) {
+	if quiet {
+		return
 	}
+	r.print(r.stream(), successPrefix, "INFO: ", format, args...)
 }
 
 // Warnf prints an warning message with the given format and arguments.
 func (r *Object) Warnf(format string, args ...interface{}) {
-	message := fmt.Sprintf(format, args...)
-	if r.useColors() {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", warnPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", "WARN: ", message)
+	if quiet {
+		return
 	}
+	r.print(r.stream(), warnPrefix, "WARN: ", format, args...)
 }
 
 // Errorf prints an error message with the given format and arguments. It also return an error
 // containing the same information, which will be usually discarded, except when the caller needs to
-// report the error and also return it.
+// report the error and also return it. Errors are always printed to the standard error stream.
 func (r *Object) Errorf(format string, args ...interface{}) error {
-	message := fmt.Sprintf(format, args...)
-	if r.useColors() {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorPrefix, message)
-	} else {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", "ERR: ", message)
-	}
+	message := r.print(os.Stderr, errorPrefix, "ERR: ", format, args...)
 	r.errors++
+	exit.Record(message, cause(args))
 	return errors.New(message)
 }
 
+// cause returns the last of the arguments that is an error, as it is usually the one that caused
+// the reported error. It returns nil if none of the arguments is an error.
+func cause(args []interface{}) error {
+	for i := len(args) - 1; i >= 0; i-- {
+		if err, ok := args[i].(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
 // Errors returns the number of errors that have been reported via this reporter.
 func (r *Object) Errors() int {
 	return r.errors
 }
 
+// print writes the message to the given stream, with the colored prefix if colors are enabled for
+// that stream or else with the plain one. It returns the message without the prefix.
+func (r *Object) print(stream *os.File, colorPrefix string, plainPrefix string, format string,
+	args ...interface{}) string {
+	commitActiveProgress()
+	message := fmt.Sprintf(format, args...)
+	write(stream, colorPrefix, plainPrefix, message)
+	return message
+}
+
+// write writes the message to the given stream with the prefix that corresponds to the stream.
+func write(stream *os.File, colorPrefix string, plainPrefix string, message string) {
+	prefix := plainPrefix
+	if ColorsEnabled(stream) {
+		prefix = colorPrefix
+	}
+	_, _ = fmt.Fprintf(stream, "%s%s\n", prefix, message)
+}
+
 // Message prefix using ANSI scape sequences to set colors:
 const (
-	infoPrefix  = "\033[0;36mI:\033[m "
-	warnPrefix  = "\033[0;33mW:\033[m "
-	errorPrefix = "\033[0;31mE:\033[m "
+	infoPrefix    = "\033[0;36mI:\033[m "
+	successPrefix = "\033[0;32mI:\033[m "
+	warnPrefix    = "\033[0;33mW:\033[m "
+	errorPrefix   = "\033[0;31mE:\033[m "
 )
 
-func (r *Object) useColors() bool {
-	return runtime.GOOS != "windows"
+// stream returns the stream where messages are printed. When only the identifiers or the JSON
+// representation of the resources are requested the standard output is reserved for them, so
+// messages go to the standard error.
+func (r *Object) stream() *os.File {
+	if output.Machine() {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
+// ColorsEnabled checks if ANSI colors can be used when writing to the given stream. They are
+// disabled on Windows, when the stream isn't a terminal, when the '--no-color' flag is used and
+// when the NO_COLOR environment variable is set.
+func ColorsEnabled(stream *os.File) bool {
+	if noColor || runtime.GOOS == "windows" {
+		return false
+	}
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	return isatty.IsTerminal(stream.Fd())
 }
 
 // CreateReporterOrExit creates the reportor instance or exits to the console
@@ -115,7 +170,7 @@ func CreateReporterOrExit() *Object {
 		Build()
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to create reporter: %v\n", err)
-		os.Exit(1)
+		exit.Fail()
 	}
 	return reporter
 }
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the coded warnings. Each warning that automation may want to accept has a
+// stable code, so that it can be suppressed with the '--suppress-warnings' flag or recognized in
+// the JSON document written when the '--warnings-output=json' flag is used.
+
+package reporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Codes of the warnings:
+const (
+	WarnAWSThrottled         = "W0001"
+	WarnTokenExpiring        = "W0002"
+	WarnTokenEnvironment     = "W0003"
+	WarnNoIdentityProvider   = "W0004"
+	WarnPrivateCluster       = "W0005"
+	WarnPrivateLink          = "W0006"
+	WarnKMSKeyPolicy         = "W0007"
+	WarnMinorUpgradeBlocked  = "W0008"
+	WarnUpgradeNotification  = "W0009"
+	WarnLoadBalancerReplaced = "W0010"
+	WarnSCPRestrictions      = "W0011"
+	WarnVersionEndOfLife     = "W0012"
+	WarnUnsupportedOC        = "W0013"
+	WarnIngressDNS           = "W0014"
+	WarnIngressCertificate   = "W0015"
+	WarnAWSChecksSkipped     = "W0016"
+)
+
+// WarningDescriptions contains the description of each warning code.
+var WarningDescriptions = map[string]string{
+	WarnAWSThrottled:         "Requests were slowed down by AWS API rate limits",
+	WarnTokenExpiring:        "The offline access token is about to expire",
+	WarnTokenEnvironment:     "The token was issued for a different OCM environment",
+	WarnNoIdentityProvider:   "The cluster has no identity provider",
+	WarnPrivateCluster:       "The API of the cluster is private",
+	WarnPrivateLink:          "The cluster uses AWS PrivateLink",
+	WarnKMSKeyPolicy:         "The KMS key policy doesn't allow the installer to use the key",
+	WarnMinorUpgradeBlocked:  "Machine pools block the next minor version upgrade",
+	WarnUpgradeNotification:  "The upgrade is sooner than its notification lead time",
+	WarnLoadBalancerReplaced: "The load balancer of the router will be replaced",
+	WarnSCPRestrictions:      "Service control policies restrict the account",
+	WarnVersionEndOfLife:     "The version is near or past its end of life",
+	WarnUnsupportedOC:        "The OpenShift command-line tool is missing or unsupported",
+	WarnIngressDNS:           "The domain of the router doesn't resolve to its load balancer",
+	WarnIngressCertificate:   "The certificate of the router is untrusted or about to expire",
+	WarnAWSChecksSkipped:     "AWS validations were skipped because of missing permissions",
+}
+
+// WarningsOutputJSON is the value of the '--warnings-output' flag that collects the coded warnings
+// in a JSON document instead of printing them as messages.
+const WarningsOutputJSON = "json"
+
+// Warning is a coded warning reported while running a command.
+type Warning struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// warnings are the coded warnings collected when the JSON output of warnings is enabled.
+var warnings struct {
+	sync.Mutex
+	items []*Warning
+}
+
+// Warn prints a warning message with the given code, format and arguments, unless the code has
+// been suppressed. When the JSON output of warnings is enabled the warning is collected instead,
+// and written by FlushWarnings.
+func (r *Object) Warn(code string, format string, args ...interface{}) {
+	if isSuppressed(code) {
+		return
+	}
+	if warningsOutput == WarningsOutputJSON {
+		warnings.Lock()
+		warnings.items = append(warnings.items, &Warning{
+			Code:    code,
+			Message: fmt.Sprintf(format, args...),
+		})
+		warnings.Unlock()
+		return
+	}
+	if quiet {
+		return
+	}
+	r.print(r.stream(), warnPrefix, "WARN: ", "[%s] %s", code, fmt.Sprintf(format, args...))
+}
+
+// FlushWarnings writes to the standard error stream the JSON document with the warnings collected
+// when the JSON output of warnings is enabled. It does nothing otherwise.
+func FlushWarnings() {
+	if warningsOutput != WarningsOutputJSON {
+		return
+	}
+	warnings.Lock()
+	defer warnings.Unlock()
+	document := struct {
+		Warnings []*Warning `json:"warnings"`
+	}{
+		Warnings: warnings.items,
+	}
+	if document.Warnings == nil {
+		document.Warnings = []*Warning{}
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s\n", data)
+	warnings.items = nil
+}
+
+// ValidateWarningFlags checks the values of the '--suppress-warnings' and '--warnings-output'
+// flags.
+func ValidateWarningFlags() error {
+	var unknown []string
+	for _, code := range suppressWarnings {
+		if _, ok := WarningDescriptions[strings.ToUpper(code)]; !ok {
+			unknown = append(unknown, code)
+		}
+	}
+	if len(unknown) > 0 {
+		codes := make([]string, 0, len(WarningDescriptions))
+		for code := range WarningDescriptions {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		return fmt.Errorf("Unknown warning code(s) '%s', valid codes are: %s",
+			strings.Join(unknown, "', '"), strings.Join(codes, ", "))
+	}
+	if warningsOutput != "" && warningsOutput != WarningsOutputJSON {
+		return fmt.Errorf("Invalid warnings output '%s', allowed formats are [%s]",
+			warningsOutput, WarningsOutputJSON)
+	}
+	return nil
+}
+
+func isSuppressed(code string) bool {
+	for _, suppressed := range suppressWarnings {
+		if strings.EqualFold(suppressed, code) {
+			return true
+		}
+	}
+	return false
+}
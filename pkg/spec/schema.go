@@ -0,0 +1,322 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the JSON schema of the cluster spec file. External tools can get it with
+// the 'rosa validate spec-file --schema' command. Any incompatible change to the format needs a
+// new schema version.
+
+package spec
+
+// SchemaVersion is the version of the format of the cluster spec file.
+const SchemaVersion = "v1"
+
+// APIVersion is the value of the 'apiVersion' field of cluster spec files.
+const APIVersion = "rosa.openshift.io/" + SchemaVersion
+
+// Kind is the value of the 'kind' field of cluster spec files.
+const Kind = "Cluster"
+
+// FlagKeyword is the schema keyword that contains the name of the 'rosa create cluster' flag that
+// corresponds to each field of the spec.
+const FlagKeyword = "x-rosa-flag"
+
+// Schema is the JSON schema of the cluster spec file.
+const Schema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "$id": "https://github.com/openshift/rosa/schemas/cluster/v1.json",
+  "title": "ROSA cluster spec",
+  "type": "object",
+  "required": ["apiVersion", "kind", "spec"],
+  "additionalProperties": false,
+  "properties": {
+    "apiVersion": {
+      "type": "string",
+      "const": "rosa.openshift.io/v1"
+    },
+    "kind": {
+      "type": "string",
+      "const": "Cluster"
+    },
+    "spec": {
+      "type": "object",
+      "required": ["clusterName"],
+      "additionalProperties": false,
+      "properties": {
+        "clusterName": {
+          "description": "Name of the cluster.",
+          "type": "string",
+          "pattern": "^[a-z]([-a-z0-9]{0,13}[a-z0-9])?$",
+          "x-rosa-flag": "cluster-name"
+        },
+        "baseDomain": {
+          "description": "Base DNS domain of the cluster, reserved with 'rosa create dns-domain'.",
+          "type": "string",
+          "x-rosa-flag": "base-domain"
+        },
+        "region": {
+          "description": "AWS region where the cluster is created.",
+          "type": "string",
+          "pattern": "^[a-z]{2}(-gov)?-[a-z]+-[0-9]$",
+          "x-rosa-flag": "region"
+        },
+        "version": {
+          "description": "Version of OpenShift that will be used to install the cluster.",
+          "type": "string",
+          "x-rosa-flag": "version"
+        },
+        "channelGroup": {
+          "description": "Channel group of the version.",
+          "type": "string",
+          "x-rosa-flag": "channel-group"
+        },
+        "multiAZ": {
+          "description": "Deploy to multiple data centers.",
+          "type": "boolean",
+          "x-rosa-flag": "multi-az"
+        },
+        "private": {
+          "description": "Restrict master API endpoint and application routes to direct, private connectivity.",
+          "type": "boolean",
+          "x-rosa-flag": "private"
+        },
+        "privateLink": {
+          "description": "Provide private connectivity between VPCs and services using AWS PrivateLink.",
+          "type": "boolean",
+          "x-rosa-flag": "private-link"
+        },
+        "subnetIDs": {
+          "description": "AWS subnet IDs of an existing VPC.",
+          "type": "array",
+          "items": {
+            "type": "string",
+            "pattern": "^subnet-[0-9a-f]+$"
+          },
+          "x-rosa-flag": "subnet-ids"
+        },
+        "awsAccountID": {
+          "description": "AWS account where the cluster is created, when different to the caller's.",
+          "type": "string",
+          "pattern": "^[0-9]{12}$",
+          "x-rosa-flag": "aws-account-id"
+        },
+        "assumeRole": {
+          "description": "ARN of the role assumed to create the cluster in the target AWS account.",
+          "type": "string",
+          "x-rosa-flag": "assume-role"
+        },
+        "billingModel": {
+          "description": "Billing model of the cluster.",
+          "type": "string",
+          "enum": ["standard", "marketplace", "marketplace-aws"],
+          "x-rosa-flag": "billing-model"
+        },
+        "billingAccount": {
+          "description": "AWS account that pays for the cluster when it is billed through a marketplace.",
+          "type": "string",
+          "pattern": "^[0-9]{12}$",
+          "x-rosa-flag": "billing-account"
+        },
+        "etcdEncryption": {
+          "description": "Encrypt etcd.",
+          "type": "boolean",
+          "x-rosa-flag": "etcd-encryption"
+        },
+        "kmsKeyARN": {
+          "description": "ARN of the customer managed KMS key used to encrypt etcd.",
+          "type": "string",
+          "pattern": "^arn:aws[-a-z]*:kms:",
+          "x-rosa-flag": "kms-key-arn"
+        },
+        "nodeDrainGracePeriod": {
+          "description": "Time to wait for pods to be drained when removing nodes, like 30m.",
+          "type": "string",
+          "pattern": "^([0-9]+h)?([0-9]+m)?$",
+          "x-rosa-flag": "node-drain-grace-period"
+        },
+        "disableWorkloadMonitoring": {
+          "description": "Disable the monitoring of user-defined projects.",
+          "type": "boolean",
+          "x-rosa-flag": "disable-workload-monitoring"
+        },
+        "computeMachineType": {
+          "description": "Instance type of the compute nodes.",
+          "type": "string",
+          "x-rosa-flag": "compute-machine-type"
+        },
+        "computeNodes": {
+          "description": "Number of worker nodes to provision.",
+          "type": "integer",
+          "minimum": 0,
+          "x-rosa-flag": "compute-nodes"
+        },
+        "enableAutoscaling": {
+          "description": "Enable autoscaling of the compute nodes.",
+          "type": "boolean",
+          "x-rosa-flag": "enable-autoscaling"
+        },
+        "minReplicas": {
+          "description": "Minimum number of compute nodes.",
+          "type": "integer",
+          "minimum": 0,
+          "x-rosa-flag": "min-replicas"
+        },
+        "maxReplicas": {
+          "description": "Maximum number of compute nodes.",
+          "type": "integer",
+          "minimum": 0,
+          "x-rosa-flag": "max-replicas"
+        },
+        "defaultMachinePoolLabels": {
+          "description": "Labels of the default machine pool, like 'key1=value1,key2=value2'.",
+          "type": "string",
+          "x-rosa-flag": "default-mp-labels"
+        },
+        "machineCIDR": {
+          "description": "Block of IP addresses used by OpenShift while installing the cluster.",
+          "type": "string",
+          "x-rosa-flag": "machine-cidr"
+        },
+        "serviceCIDR": {
+          "description": "Block of IP addresses for services.",
+          "type": "string",
+          "x-rosa-flag": "service-cidr"
+        },
+        "podCIDR": {
+          "description": "Block of IP addresses from which pod IP addresses are allocated.",
+          "type": "string",
+          "x-rosa-flag": "pod-cidr"
+        },
+        "hostPrefix": {
+          "description": "Subnet prefix length to assign to each individual node.",
+          "type": "integer",
+          "minimum": 1,
+          "maximum": 32,
+          "x-rosa-flag": "host-prefix"
+        },
+        "lbType": {
+          "description": "Type of load balancer of the default ingress.",
+          "type": "string",
+          "enum": ["classic", "nlb"],
+          "x-rosa-flag": "lb-type"
+        },
+        "allowedRegistries": {
+          "description": "Only registries that images can be pulled from.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-rosa-flag": "registry-config-allowed-registries"
+        },
+        "blockedRegistries": {
+          "description": "Registries that images can't be pulled from.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-rosa-flag": "registry-config-blocked-registries"
+        },
+        "insecureRegistries": {
+          "description": "Registries that don't have a valid TLS certificate or only support HTTP.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-rosa-flag": "registry-config-insecure-registries"
+        },
+        "allowedRegistriesForImport": {
+          "description": "Registries that image streams can import from, optionally followed by ':true' if insecure.",
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-rosa-flag": "registry-config-allowed-registries-for-import"
+        },
+        "machinePools": {
+          "description": "Additional machine pools of the cluster. Only used by 'rosa apply'.",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["name", "instanceType"],
+            "additionalProperties": false,
+            "properties": {
+              "name": {
+                "description": "Name of the machine pool.",
+                "type": "string",
+                "pattern": "^[a-z]([-a-z0-9]*[a-z0-9])?$"
+              },
+              "instanceType": {
+                "description": "Instance type of the nodes of the machine pool.",
+                "type": "string"
+              },
+              "replicas": {
+                "description": "Number of nodes of the machine pool.",
+                "type": "integer",
+                "minimum": 0
+              },
+              "minReplicas": {
+                "description": "Minimum number of nodes when the machine pool is autoscaled.",
+                "type": "integer",
+                "minimum": 0
+              },
+              "maxReplicas": {
+                "description": "Maximum number of nodes when the machine pool is autoscaled.",
+                "type": "integer",
+                "minimum": 1
+              },
+              "labels": {
+                "description": "Labels of the nodes of the machine pool.",
+                "type": "object"
+              },
+              "taints": {
+                "description": "Taints of the nodes of the machine pool, like 'key=value:NoSchedule'.",
+                "type": "array",
+                "items": {
+                  "type": "string",
+                  "pattern": "^[^=]+=[^:]*:(NoSchedule|PreferNoSchedule|NoExecute)$"
+                }
+              }
+            }
+          }
+        },
+        "identityProviders": {
+          "description": "Mapping methods of the identity providers of the cluster. Only used by 'rosa apply'.",
+          "type": "array",
+          "items": {
+            "type": "object",
+            "required": ["name", "mappingMethod"],
+            "additionalProperties": false,
+            "properties": {
+              "name": {
+                "description": "Name of the identity provider.",
+                "type": "string"
+              },
+              "mappingMethod": {
+                "description": "Method used to map identities to users.",
+                "type": "string",
+                "enum": ["add", "claim", "generate", "lookup"]
+              }
+            }
+          }
+        },
+        "upgradeSchedule": {
+          "description": "Cron expression, in UTC, of the automatic upgrades. Only used by 'rosa apply'.",
+          "type": "string"
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package spec contains the functions used to load and validate cluster spec files, which
+// contain the options of 'rosa create cluster' in YAML or JSON format.
+//
+// For example:
+//
+//	apiVersion: rosa.openshift.io/v1
+//	kind: Cluster
+//	spec:
+//	  clusterName: mycluster
+//	  region: us-east-2
+//	  multiAZ: true
+//	  computeNodes: 6
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/pflag"
+)
+
+// Manifest is the content of a cluster spec file.
+type Manifest struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Spec       map[string]interface{} `json:"spec"`
+}
+
+// Load reads and validates the cluster spec file with the given path. The file can be in YAML
+// or JSON format.
+func Load(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read spec file '%s': %v", path, err)
+	}
+	errs, err := Validate(data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse spec file '%s': %v", path, err)
+	}
+	if len(errs) > 0 {
+		messages := make([]string, len(errs))
+		for i, e := range errs {
+			messages[i] = e.Error()
+		}
+		return nil, fmt.Errorf("Spec file '%s' isn't valid:\n%s", path, strings.Join(messages, "\n"))
+	}
+	manifest := &Manifest{}
+	err = yaml.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse spec file '%s': %v", path, err)
+	}
+	return manifest, nil
+}
+
+// Validate checks the given content of a spec file against the schema. It returns an error if
+// the content can't be parsed, and a list with one error for each violation of the schema.
+func Validate(data []byte) ([]error, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	err = json.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return validateValue(value, parsedSchema, "$"), nil
+}
+
+// Flags returns the names of the fields of the spec and the names of the command line flags that
+// correspond to them.
+func Flags() map[string]string {
+	result := map[string]string{}
+	spec := parsedSchema["properties"].(map[string]interface{})["spec"].(map[string]interface{})
+	for name, property := range spec["properties"].(map[string]interface{}) {
+		if flag, ok := property.(map[string]interface{})[FlagKeyword].(string); ok {
+			result[name] = flag
+		}
+	}
+	return result
+}
+
+// Conflict describes a field of the spec whose value was overridden by a command line flag.
+type Conflict struct {
+	Field     string
+	Flag      string
+	SpecValue string
+	FlagValue string
+}
+
+func (c *Conflict) String() string {
+	return fmt.Sprintf("flag '--%s=%s' overrides spec field '%s: %s'", c.Flag, c.FlagValue, c.Field,
+		c.SpecValue)
+}
+
+// Apply sets the command line flags that correspond to the fields of the spec. Flags explicitly
+// given in the command line take precedence over the values of the spec. The fields whose value
+// was overridden with a different one are returned, so that the caller can warn about them.
+func (m *Manifest) Apply(flags *pflag.FlagSet) ([]*Conflict, error) {
+	fields := Flags()
+	names := make([]string, 0, len(m.Spec))
+	for name := range m.Spec {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var conflicts []*Conflict
+	for _, name := range names {
+		flagName, ok := fields[name]
+		if !ok {
+			// Fields without a flag describe the state of an existing cluster, and are only
+			// used by 'rosa apply':
+			continue
+		}
+		flag := flags.Lookup(flagName)
+		if flag == nil {
+			return nil, fmt.Errorf("Spec field '%s' can't be used with this command", name)
+		}
+		value := flagValue(m.Spec[name])
+		if flag.Changed {
+			current := flag.Value.String()
+			if strings.HasSuffix(flag.Value.Type(), "Slice") {
+				current = strings.Trim(current, "[]")
+			}
+			if current != value {
+				conflicts = append(conflicts, &Conflict{
+					Field:     name,
+					Flag:      flagName,
+					SpecValue: value,
+					FlagValue: current,
+				})
+			}
+			continue
+		}
+		err := flags.Set(flagName, value)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid value for spec field '%s': %v", name, err)
+		}
+	}
+	return conflicts, nil
+}
+
+// StateFields returns the names of the fields of the spec that don't correspond to command line
+// flags, sorted by name. They are only used by 'rosa apply'.
+func (m *Manifest) StateFields() []string {
+	fields := Flags()
+	var names []string
+	for name := range m.Spec {
+		if _, ok := fields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// flagValue converts a value of the spec to the text representation used by command line flags.
+func flagValue(value interface{}) string {
+	switch typed := value.(type) {
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case []interface{}:
+		items := make([]string, len(typed))
+		for i, item := range typed {
+			items[i] = flagValue(item)
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprintf("%v", typed)
+	}
+}
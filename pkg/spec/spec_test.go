@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec_test
+
+import (
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/spec"
+)
+
+var _ = Describe("Spec", func() {
+	Context("Apply", func() {
+		var flags *pflag.FlagSet
+
+		BeforeEach(func() {
+			flags = pflag.NewFlagSet("create cluster", pflag.ContinueOnError)
+			flags.String("cluster-name", "", "")
+			flags.String("region", "", "")
+			flags.Bool("multi-az", false, "")
+			flags.Int("compute-nodes", 2, "")
+			flags.StringSlice("subnet-ids", nil, "")
+		})
+
+		manifest := func(text string) *spec.Manifest {
+			result := &spec.Manifest{}
+			err := yaml.Unmarshal([]byte(text), result)
+			Expect(err).ToNot(HaveOccurred())
+			return result
+		}
+
+		It("sets the flags that weren't given", func() {
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  multiAZ: true\n" +
+					"  computeNodes: 6\n" +
+					"  subnetIDs: [subnet-1, subnet-2]\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+			Expect(flags.Changed("cluster-name")).To(BeTrue())
+			Expect(flags.GetString("cluster-name")).To(Equal("mycluster"))
+			Expect(flags.GetBool("multi-az")).To(BeTrue())
+			Expect(flags.GetInt("compute-nodes")).To(Equal(6))
+			Expect(flags.GetStringSlice("subnet-ids")).To(Equal([]string{"subnet-1", "subnet-2"}))
+			Expect(flags.Changed("region")).To(BeFalse())
+		})
+
+		It("ignores the fields that don't correspond to flags", func() {
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  machinePools:\n" +
+					"  - name: mypool\n" +
+					"    instanceType: m5.xlarge\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+		})
+
+		It("doesn't report flags given with the same value", func() {
+			Expect(flags.Set("cluster-name", "mycluster")).To(Succeed())
+			Expect(flags.Set("compute-nodes", "6")).To(Succeed())
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  computeNodes: 6\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+		})
+
+		It("reports flags given with a different value and keeps it", func() {
+			Expect(flags.Set("region", "us-west-2")).To(Succeed())
+			Expect(flags.Set("multi-az", "false")).To(Succeed())
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  multiAZ: true\n" +
+					"  region: us-east-1\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(HaveLen(2))
+			Expect(*conflicts[0]).To(Equal(spec.Conflict{
+				Field:     "multiAZ",
+				Flag:      "multi-az",
+				SpecValue: "true",
+				FlagValue: "false",
+			}))
+			Expect(*conflicts[1]).To(Equal(spec.Conflict{
+				Field:     "region",
+				Flag:      "region",
+				SpecValue: "us-east-1",
+				FlagValue: "us-west-2",
+			}))
+			Expect(conflicts[1].String()).To(Equal(
+				"flag '--region=us-west-2' overrides spec field 'region: us-east-1'"))
+			Expect(flags.GetString("region")).To(Equal("us-west-2"))
+		})
+
+		It("doesn't report slice flags given with the same values", func() {
+			Expect(flags.Set("subnet-ids", "subnet-1,subnet-2")).To(Succeed())
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  subnetIDs: [subnet-1, subnet-2]\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(BeEmpty())
+		})
+
+		It("reports slice flags given with different values", func() {
+			Expect(flags.Set("subnet-ids", "subnet-3")).To(Succeed())
+			conflicts, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  subnetIDs: [subnet-1, subnet-2]\n",
+			).Apply(flags)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(conflicts).To(HaveLen(1))
+			Expect(*conflicts[0]).To(Equal(spec.Conflict{
+				Field:     "subnetIDs",
+				Flag:      "subnet-ids",
+				SpecValue: "subnet-1,subnet-2",
+				FlagValue: "subnet-3",
+			}))
+			Expect(flags.GetStringSlice("subnet-ids")).To(Equal([]string{"subnet-3"}))
+		})
+
+		It("fails if the command doesn't have the flag of a field", func() {
+			_, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  version: 4.7.0\n",
+			).Apply(flags)
+			Expect(err).To(MatchError("Spec field 'version' can't be used with this command"))
+		})
+
+		It("fails if the value of a field isn't valid for the flag", func() {
+			_, err := manifest(
+				"spec:\n" +
+					"  clusterName: mycluster\n" +
+					"  computeNodes: many\n",
+			).Apply(flags)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("Invalid value for spec field 'computeNodes'"))
+		})
+	})
+})
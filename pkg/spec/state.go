@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types used by 'rosa apply' to compare the state declared in a cluster
+// spec file with the state of an existing cluster. Fields that aren't present in the spec file are
+// nil, and the corresponding settings of the cluster are left unchanged.
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// State is the part of a cluster spec that can be changed after the cluster has been created.
+type State struct {
+	ClusterName               string                   `json:"clusterName"`
+	Private                   *bool                    `json:"private"`
+	DisableWorkloadMonitoring *bool                    `json:"disableWorkloadMonitoring"`
+	ComputeNodes              *int                     `json:"computeNodes"`
+	EnableAutoscaling         *bool                    `json:"enableAutoscaling"`
+	MinReplicas               *int                     `json:"minReplicas"`
+	MaxReplicas               *int                     `json:"maxReplicas"`
+	MachinePools              []*MachinePoolState      `json:"machinePools"`
+	IdentityProviders         []*IdentityProviderState `json:"identityProviders"`
+	UpgradeSchedule           *string                  `json:"upgradeSchedule"`
+}
+
+// MachinePoolState is the declared state of an additional machine pool.
+type MachinePoolState struct {
+	Name         string            `json:"name"`
+	InstanceType string            `json:"instanceType"`
+	Replicas     *int              `json:"replicas"`
+	MinReplicas  *int              `json:"minReplicas"`
+	MaxReplicas  *int              `json:"maxReplicas"`
+	Labels       map[string]string `json:"labels"`
+	Taints       []string          `json:"taints"`
+}
+
+// Autoscaling returns true if the machine pool uses autoscaling.
+func (p *MachinePoolState) Autoscaling() bool {
+	return p.MaxReplicas != nil
+}
+
+// IdentityProviderState is the declared state of an existing identity provider.
+type IdentityProviderState struct {
+	Name          string `json:"name"`
+	MappingMethod string `json:"mappingMethod"`
+}
+
+// State returns the part of the spec that can be changed after the cluster has been created.
+func (m *Manifest) State() (*State, error) {
+	data, err := json.Marshal(m.Spec)
+	if err != nil {
+		return nil, err
+	}
+	state := &State{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse spec: %v", err)
+	}
+	if state.EnableAutoscaling != nil && *state.EnableAutoscaling {
+		if state.ComputeNodes != nil {
+			return nil, fmt.Errorf("Fields 'computeNodes' and 'enableAutoscaling' can't be " +
+				"used together")
+		}
+		if state.MinReplicas == nil || state.MaxReplicas == nil {
+			return nil, fmt.Errorf("Fields 'minReplicas' and 'maxReplicas' are required " +
+				"when 'enableAutoscaling' is true")
+		}
+	}
+	names := map[string]bool{}
+	for _, pool := range state.MachinePools {
+		if names[pool.Name] {
+			return nil, fmt.Errorf("Machine pool '%s' declared more than once", pool.Name)
+		}
+		names[pool.Name] = true
+		hasMin := pool.MinReplicas != nil
+		switch {
+		case pool.Replicas != nil && (hasMin || pool.Autoscaling()):
+			return nil, fmt.Errorf("Machine pool '%s' can't have both 'replicas' and "+
+				"autoscaling limits", pool.Name)
+		case hasMin != pool.Autoscaling():
+			return nil, fmt.Errorf("Machine pool '%s' needs both 'minReplicas' and "+
+				"'maxReplicas' for autoscaling", pool.Name)
+		case hasMin && *pool.MinReplicas > *pool.MaxReplicas:
+			return nil, fmt.Errorf("Machine pool '%s' has 'minReplicas' greater than "+
+				"'maxReplicas'", pool.Name)
+		case pool.Replicas == nil && !hasMin:
+			return nil, fmt.Errorf("Expected 'replicas' or 'minReplicas' and 'maxReplicas' "+
+				"for machine pool '%s'", pool.Name)
+		}
+	}
+	names = map[string]bool{}
+	for _, idp := range state.IdentityProviders {
+		if names[idp.Name] {
+			return nil, fmt.Errorf("Identity provider '%s' declared more than once", idp.Name)
+		}
+		names[idp.Name] = true
+	}
+	return state, nil
+}
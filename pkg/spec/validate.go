@@ -0,0 +1,219 @@
+/*
+Copyright (c) 2020 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a validator for the subset of JSON schema used by the cluster spec file:
+// the 'type', 'properties', 'required', 'additionalProperties', 'items', 'const', 'enum',
+// 'pattern', 'minimum' and 'maximum' keywords.
+
+package spec
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// schemaObject is the parsed representation of a schema or of one of its subschemas.
+type schemaObject map[string]interface{}
+
+// parsedSchema contains the parsed version of the schema of the cluster spec file.
+var parsedSchema = mustParseSchema(Schema)
+
+func mustParseSchema(text string) schemaObject {
+	var result schemaObject
+	err := json.Unmarshal([]byte(text), &result)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to parse cluster spec schema: %v", err))
+	}
+	return result
+}
+
+// validateValue checks the given value against the given schema, returning one error for each
+// violation found. The path is used to tell the user where the problem is.
+func validateValue(value interface{}, schema schemaObject, path string) []error {
+	var errs []error
+	add := func(format string, a ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", path, fmt.Sprintf(format, a...)))
+	}
+
+	if expected, ok := schema["type"].(string); ok && !hasType(value, expected) {
+		add("expected %s but found %s", expected, typeName(value))
+		return errs
+	}
+	if expected, ok := schema["const"]; ok && value != expected {
+		add("must be '%v'", expected)
+	}
+	if values, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range values {
+			if value == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			add("must be one of %s", joinValues(values))
+		}
+	}
+
+	switch typed := value.(type) {
+	case string:
+		if pattern, ok := schema["pattern"].(string); ok {
+			if !regexp.MustCompile(pattern).MatchString(typed) {
+				add("value '%s' doesn't match pattern '%s'", typed, pattern)
+			}
+		}
+	case float64:
+		if minimum, ok := schema["minimum"].(float64); ok && typed < minimum {
+			add("must be at least %v", minimum)
+		}
+		if maximum, ok := schema["maximum"].(float64); ok && typed > maximum {
+			add("must be at most %v", maximum)
+		}
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range typed {
+				errs = append(errs, validateValue(item, items, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case map[string]interface{}:
+		properties, _ := schema["properties"].(map[string]interface{})
+		if required, ok := schema["required"].([]interface{}); ok {
+			for _, name := range required {
+				if _, ok := typed[name.(string)]; !ok {
+					add("missing required field '%s'", name)
+				}
+			}
+		}
+		names := make([]string, 0, len(typed))
+		for name := range typed {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		known := make([]string, 0, len(properties))
+		for name := range properties {
+			known = append(known, name)
+		}
+		sort.Strings(known)
+		for _, name := range names {
+			property, ok := properties[name].(map[string]interface{})
+			if !ok {
+				if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+					add("unknown field '%s'%s", name, suggestion(name, known))
+				}
+				continue
+			}
+			errs = append(errs, validateValue(typed[name], property, path+"."+name)...)
+		}
+	}
+
+	return errs
+}
+
+func hasType(value interface{}, expected string) bool {
+	switch expected {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return typeName(value) == expected
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func joinValues(values []interface{}) string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = fmt.Sprintf("'%v'", value)
+	}
+	return strings.Join(result, ", ")
+}
+
+// suggestion returns the text that suggests the most similar of the given names, or an empty
+// string if none of them is close enough to the given name to be a likely typo.
+func suggestion(name string, names []string) string {
+	best := ""
+	bestDistance := 0
+	for _, candidate := range names {
+		distance := levenshtein(strings.ToLower(name), strings.ToLower(candidate))
+		if best == "" || distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	if best == "" || bestDistance > len(name)/3+1 {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean '%s'?", best)
+}
+
+// levenshtein returns the minimum number of single character insertions, deletions and
+// substitutions needed to change one string into the other.
+func levenshtein(a string, b string) int {
+	source := []rune(a)
+	target := []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
+
+func min(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
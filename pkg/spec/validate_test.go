@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spec
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	Context("levenshtein", func() {
+		cases := []struct {
+			a        string
+			b        string
+			distance int
+		}{
+			{"", "", 0},
+			{"region", "region", 0},
+			{"region", "", 6},
+			{"", "region", 6},
+			{"regoin", "region", 2},
+			{"kitten", "sitting", 3},
+			{"flaw", "lawn", 2},
+			{"multiAZ", "multiAz", 1},
+			{"añb", "anb", 1},
+		}
+		for _, c := range cases {
+			c := c
+			It(fmt.Sprintf("returns %d for '%s' and '%s'", c.distance, c.a, c.b), func() {
+				Expect(levenshtein(c.a, c.b)).To(Equal(c.distance))
+				Expect(levenshtein(c.b, c.a)).To(Equal(c.distance))
+			})
+		}
+	})
+
+	Context("suggestion", func() {
+		names := []string{"clusterName", "computeNodes", "multiAZ", "region", "version"}
+
+		It("suggests the most similar name", func() {
+			Expect(suggestion("clustrName", names)).To(Equal(", did you mean 'clusterName'?"))
+			Expect(suggestion("regoin", names)).To(Equal(", did you mean 'region'?"))
+		})
+
+		It("ignores the case of the names", func() {
+			Expect(suggestion("MultiAz", names)).To(Equal(", did you mean 'multiAZ'?"))
+		})
+
+		It("doesn't suggest names that aren't similar enough", func() {
+			Expect(suggestion("foo", names)).To(BeEmpty())
+			Expect(suggestion("privateLink", names)).To(BeEmpty())
+		})
+
+		It("doesn't suggest anything without names", func() {
+			Expect(suggestion("region", nil)).To(BeEmpty())
+		})
+	})
+
+	It("suggests the name of unknown fields", func() {
+		errs, err := Validate([]byte(
+			"apiVersion: rosa.openshift.io/v1\n" +
+				"kind: Cluster\n" +
+				"spec:\n" +
+				"  clusterName: mycluster\n" +
+				"  regoin: us-east-1\n",
+		))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Error()).To(ContainSubstring("unknown field 'regoin', did you mean 'region'?"))
+	})
+})
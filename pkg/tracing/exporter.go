@@ -0,0 +1,243 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the exporter that sends the spans to the collector using the JSON encoding
+// of the OpenTelemetry protocol over HTTP. It is configured with the standard OTEL_* environment
+// variables.
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/info"
+)
+
+// exporterTimeout is the maximum time spent sending each batch of spans, so that an unavailable
+// collector doesn't slow down commands.
+const exporterTimeout = 5 * time.Second
+
+// Status codes of the spans, with the values used by the OpenTelemetry protocol:
+const (
+	statusOK    = 1
+	statusError = 2
+)
+
+type exporter struct {
+	url         string
+	headers     map[string]string
+	serviceName string
+	client      *http.Client
+
+	lock    sync.Mutex
+	pending []*Span
+}
+
+// newExporterFromEnv creates the exporter configured by the environment, or returns nil if
+// exporting spans isn't enabled.
+func newExporterFromEnv() *exporter {
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") ||
+		strings.EqualFold(os.Getenv("OTEL_TRACES_EXPORTER"), "none") {
+		return nil
+	}
+	url := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if url == "" {
+		endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+		if endpoint == "" {
+			return nil
+		}
+		url = strings.TrimSuffix(endpoint, "/") + "/v1/traces"
+	}
+	protocol := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_PROTOCOL")
+	if protocol == "" {
+		protocol = os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL")
+	}
+	if protocol != "" && protocol != "http/json" {
+		debugf("Protocol '%s' isn't supported, spans will be exported with 'http/json'", protocol)
+	}
+	headers := parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS"))
+	for key, value := range parseHeaders(os.Getenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS")) {
+		headers[key] = value
+	}
+	serviceName := os.Getenv("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = "rosa"
+	}
+	return &exporter{
+		url:         url,
+		headers:     headers,
+		serviceName: serviceName,
+		client: &http.Client{
+			Timeout: exporterTimeout,
+		},
+	}
+}
+
+// add queues the given span for export.
+func (e *exporter) add(span *Span) {
+	e.lock.Lock()
+	e.pending = append(e.pending, span)
+	e.lock.Unlock()
+}
+
+// flush sends the queued spans to the collector. Failures are only reported in debug mode, as
+// tracing should never cause commands to fail.
+func (e *exporter) flush() {
+	e.lock.Lock()
+	spans := e.pending
+	e.pending = nil
+	e.lock.Unlock()
+	if len(spans) == 0 {
+		return
+	}
+	body, err := json.Marshal(e.document(spans))
+	if err != nil {
+		debugf("Failed to encode spans: %v", err)
+		return
+	}
+	request, err := http.NewRequest(http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		debugf("Failed to create request to export spans: %v", err)
+		return
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		request.Header.Set(key, value)
+	}
+	response, err := e.client.Do(request)
+	if err != nil {
+		debugf("Failed to export spans to '%s': %v", e.url, err)
+		return
+	}
+	response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		debugf("Failed to export spans to '%s': status %d", e.url, response.StatusCode)
+	}
+}
+
+// document returns the OpenTelemetry protocol representation of the given spans.
+func (e *exporter) document(spans []*Span) map[string]interface{} {
+	items := make([]interface{}, 0, len(spans))
+	for _, span := range spans {
+		items = append(items, span.document())
+	}
+	return map[string]interface{}{
+		"resourceSpans": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": attributes(map[string]interface{}{
+						"service.name":    e.serviceName,
+						"service.version": info.Version,
+					}),
+				},
+				"scopeSpans": []interface{}{
+					map[string]interface{}{
+						"scope": map[string]interface{}{
+							"name":    "github.com/openshift/rosa",
+							"version": info.Version,
+						},
+						"spans": items,
+					},
+				},
+			},
+		},
+	}
+}
+
+func (s *Span) document() map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	status := map[string]interface{}{
+		"code": statusOK,
+	}
+	if s.err != nil {
+		status["code"] = statusError
+		status["message"] = s.err.Error()
+	}
+	result := map[string]interface{}{
+		"traceId":           s.traceID,
+		"spanId":            s.spanID,
+		"name":              s.name,
+		"kind":              s.kind,
+		"startTimeUnixNano": strconv.FormatInt(s.start.UnixNano(), 10),
+		"endTimeUnixNano":   strconv.FormatInt(s.end.UnixNano(), 10),
+		"attributes":        attributes(s.attributes),
+		"status":            status,
+	}
+	if s.parentID != "" {
+		result["parentSpanId"] = s.parentID
+	}
+	return result
+}
+
+// attributes returns the OpenTelemetry protocol representation of the given attributes, sorted by
+// key.
+func attributes(values map[string]interface{}) []interface{} {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		var value map[string]interface{}
+		switch typed := values[key].(type) {
+		case bool:
+			value = map[string]interface{}{"boolValue": typed}
+		case int:
+			value = map[string]interface{}{"intValue": strconv.Itoa(typed)}
+		case int64:
+			value = map[string]interface{}{"intValue": strconv.FormatInt(typed, 10)}
+		default:
+			value = map[string]interface{}{"stringValue": fmt.Sprintf("%v", typed)}
+		}
+		result = append(result, map[string]interface{}{
+			"key":   key,
+			"value": value,
+		})
+	}
+	return result
+}
+
+// parseHeaders parses headers in the 'key1=value1,key2=value2' format of the OTEL_* environment
+// variables.
+func parseHeaders(text string) map[string]string {
+	headers := map[string]string{}
+	for _, item := range strings.Split(text, ",") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			continue
+		}
+		headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return headers
+}
+
+func debugf(format string, args ...interface{}) {
+	if debug.Enabled() {
+		fmt.Fprintf(os.Stderr, "tracing: "+format+"\n", args...)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the tracing of the requests sent to the OCM and AWS APIs. When the
+// OTEL_EXPORTER_OTLP_ENDPOINT environment variable is set each command generates a trace, with a
+// span for the command and a span for each request, that is exported with the OpenTelemetry
+// protocol. When the TRACEPARENT environment variable contains a W3C trace context, like the ones
+// set by CI systems, the trace of the command becomes part of that trace.
+
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kinds of spans, with the values used by the OpenTelemetry protocol:
+const (
+	KindInternal = 1
+	KindClient   = 3
+)
+
+// Span is an operation of the trace of the command.
+type Span struct {
+	traceID  string
+	spanID   string
+	parentID string
+	name     string
+	kind     int
+	start    time.Time
+
+	lock       sync.Mutex
+	end        time.Time
+	attributes map[string]interface{}
+	err        error
+}
+
+// state contains the trace of the command.
+var state struct {
+	sync.Mutex
+	exporter *exporter
+	root     *Span
+}
+
+// Regular expression used to parse the W3C trace context of the TRACEPARENT environment variable:
+var traceParentRE = regexp.MustCompile(`^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$`)
+
+// Enabled checks if the spans are exported.
+func Enabled() bool {
+	state.Lock()
+	defer state.Unlock()
+	return state.exporter != nil
+}
+
+// Start starts the trace of the command with the given name, if the OpenTelemetry exporter is
+// configured in the environment. It does nothing otherwise.
+func Start(name string) {
+	exporter := newExporterFromEnv()
+	if exporter == nil {
+		return
+	}
+	root := &Span{
+		traceID: newID(16),
+		spanID:  newID(8),
+		name:    name,
+		kind:    KindInternal,
+		start:   time.Now(),
+	}
+	matches := traceParentRE.FindStringSubmatch(strings.TrimSpace(os.Getenv("TRACEPARENT")))
+	if matches != nil {
+		root.traceID = matches[1]
+		root.parentID = matches[2]
+	}
+	state.Lock()
+	state.exporter = exporter
+	state.root = root
+	state.Unlock()
+}
+
+// StartSpan starts a span that is a child of the span of the command. It returns nil if the trace
+// hasn't been started, and all the methods of the span accept a nil receiver, so callers don't
+// need to check if tracing is enabled.
+func StartSpan(name string, kind int) *Span {
+	state.Lock()
+	root := state.root
+	state.Unlock()
+	if root == nil {
+		return nil
+	}
+	return &Span{
+		traceID:  root.traceID,
+		spanID:   newID(8),
+		parentID: root.spanID,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+	}
+}
+
+// SetAttribute sets an attribute of the span. The value should be a string, an integer or a
+// boolean.
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.attributes == nil {
+		s.attributes = map[string]interface{}{}
+	}
+	s.attributes[key] = value
+}
+
+// TraceParent returns the W3C trace context that identifies the span, to be sent in the
+// 'traceparent' header of the requests.
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", s.traceID, s.spanID)
+}
+
+// End ends the span, marking it as failed if the given error isn't nil, and queues it for export.
+// Failed spans are exported immediately, as commands usually exit right after a failure.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if !s.end.IsZero() {
+		s.lock.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.err = err
+	s.lock.Unlock()
+	state.Lock()
+	exporter := state.exporter
+	state.Unlock()
+	if exporter == nil {
+		return
+	}
+	exporter.add(s)
+	if err != nil {
+		exporter.flush()
+	}
+}
+
+// Shutdown ends the span of the command, marking it as failed if the given error isn't nil, and
+// exports the spans that haven't been exported yet.
+func Shutdown(err error) {
+	state.Lock()
+	root := state.root
+	exporter := state.exporter
+	state.Unlock()
+	if exporter == nil {
+		return
+	}
+	root.End(err)
+	exporter.flush()
+}
+
+// newID returns a random identifier with the given number of bytes, encoded in hexadecimal.
+func newID(size int) string {
+	data := make([]byte, size)
+	_, err := rand.Read(data)
+	if err != nil {
+		// Use the time so that identifiers are still unlikely to collide:
+		now := time.Now().UnixNano()
+		for i := range data {
+			data[i] = byte(now >> (8 * (i % 8)))
+		}
+	}
+	return hex.EncodeToString(data)
+}
@@ -0,0 +1,280 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the functions used to check if there is a newer release of the tool and
+// to replace the running binary with it.
+
+package update
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// releasesURL is the URL of the API that returns the latest release of the tool. It can be changed
+// with the ROSA_RELEASES_URL environment variable, for example to use a mirror that returns the
+// same document as the GitHub API.
+const releasesURL = "https://api.github.com/repos/openshift/rosa/releases/latest"
+
+// timeout is the maximum time of each request.
+const timeout = 5 * time.Minute
+
+// Release contains the details of a release of the tool.
+type Release struct {
+	Version string
+	URL     string
+	Assets  map[string]string
+}
+
+// Latest returns the latest release of the tool.
+func Latest() (*Release, error) {
+	url := os.Getenv("ROSA_RELEASES_URL")
+	if url == "" {
+		url = releasesURL
+	}
+	client := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	response, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get latest release: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Failed to get latest release: %s", response.Status)
+	}
+	var document struct {
+		TagName string `json:"tag_name"`
+		HTMLURL string `json:"html_url"`
+		Assets  []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+		} `json:"assets"`
+	}
+	err = json.NewDecoder(response.Body).Decode(&document)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse latest release: %v", err)
+	}
+	release := &Release{
+		Version: strings.TrimPrefix(document.TagName, "v"),
+		URL:     document.HTMLURL,
+		Assets:  map[string]string{},
+	}
+	for _, asset := range document.Assets {
+		release.Assets[asset.Name] = asset.URL
+	}
+	return release, nil
+}
+
+// Newer checks if the version of the release is newer than the given version.
+func (r *Release) Newer(version string) bool {
+	return Compare(r.Version, version) > 0
+}
+
+// AssetName returns the name of the release asset that contains the binary for the operating
+// system and architecture of the running binary.
+func AssetName() string {
+	name := fmt.Sprintf("rosa-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Install downloads the binary of the release for the running platform, verifies its checksum
+// and replaces the given binary with it.
+func Install(release *Release, path string) error {
+	name := AssetName()
+	binaryURL, ok := release.Assets[name]
+	if !ok {
+		return fmt.Errorf("Release %s doesn't contain a binary for %s/%s",
+			release.Version, runtime.GOOS, runtime.GOARCH)
+	}
+	checksumURL, ok := release.Assets[name+".sha256"]
+	if !ok {
+		return fmt.Errorf("Release %s doesn't contain a checksum for '%s'", release.Version, name)
+	}
+	client := &http.Client{
+		Timeout: timeout,
+	}
+	expected, err := fetchChecksum(client, checksumURL)
+	if err != nil {
+		return err
+	}
+
+	// The new binary is written next to the current one so that it can be renamed over it:
+	path, err = filepath.EvalSymlinks(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".rosa-update-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	actual, err := download(client, binaryURL, tmp)
+	tmp.Close()
+	if err != nil {
+		return err
+	}
+	if actual != expected {
+		return fmt.Errorf("Checksum of '%s' is '%s', expected '%s'", name, actual, expected)
+	}
+	err = os.Chmod(tmp.Name(), 0755) // #nosec G302
+	if err != nil {
+		return err
+	}
+
+	// Windows doesn't allow replacing a running binary, but it allows renaming it:
+	if runtime.GOOS == "windows" {
+		old := path + ".old"
+		os.Remove(old)
+		err = os.Rename(path, old)
+		if err != nil {
+			return err
+		}
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// fetchChecksum returns the SHA-256 checksum contained in the given URL, in the format generated
+// by the sha256sum command.
+func fetchChecksum(client *http.Client, url string) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download checksum: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to download checksum: %s", response.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(response.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("Failed to download checksum: %v", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("Checksum file is empty")
+	}
+	return strings.ToLower(fields[0]), nil
+}
+
+// download writes the content of the given URL to the given file, and returns its SHA-256
+// checksum.
+func download(client *http.Client, url string, out io.Writer) (string, error) {
+	response, err := client.Get(url)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download binary: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Failed to download binary: %s", response.Status)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(out, hash), response.Body)
+	if err != nil {
+		return "", fmt.Errorf("Failed to download binary: %v", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Compare compares two versions like '1.0.5' or '1.1.0-rc1', returning a negative number if a is
+// older than b, zero if they are equal and a positive number if a is newer than b. The numeric
+// components are compared as numbers, and missing ones are considered zero. As in semantic
+// versioning a pre-release like '1.1.0-rc1' is older than the release '1.1.0', pre-releases are
+// compared identifier by identifier, and build metadata after a '+' is ignored.
+func Compare(a, b string) int {
+	aRelease, aPre := splitVersion(a)
+	bRelease, bPre := splitVersion(b)
+	result := compareIdentifiers(aRelease, bRelease, "0")
+	if result != 0 {
+		return result
+	}
+	switch {
+	case aPre == nil && bPre == nil:
+		return 0
+	case aPre == nil:
+		return 1
+	case bPre == nil:
+		return -1
+	}
+	return compareIdentifiers(aPre, bPre, "")
+}
+
+// splitVersion returns the dot separated components of the release and of the pre-release of the
+// given version. The pre-release is nil if the version doesn't have one.
+func splitVersion(version string) (release []string, pre []string) {
+	version = strings.TrimPrefix(version, "v")
+	if index := strings.Index(version, "+"); index != -1 {
+		version = version[:index]
+	}
+	if index := strings.Index(version, "-"); index != -1 {
+		pre = strings.Split(version[index+1:], ".")
+		version = version[:index]
+	}
+	release = strings.Split(version, ".")
+	return
+}
+
+// compareIdentifiers compares two lists of identifiers one by one. Numeric identifiers are compared
+// as numbers and are older than the rest, which are compared as text. Missing identifiers are
+// replaced with the given padding, an empty padding makes the shorter list older.
+func compareIdentifiers(a, b []string, padding string) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		aPart, bPart := padding, padding
+		if i < len(a) {
+			aPart = a[i]
+		}
+		if i < len(b) {
+			bPart = b[i]
+		}
+		if aPart == bPart {
+			continue
+		}
+		if aPart == "" {
+			return -1
+		}
+		if bPart == "" {
+			return 1
+		}
+		aNumber, aErr := strconv.Atoi(aPart)
+		bNumber, bErr := strconv.Atoi(bPart)
+		switch {
+		case aErr == nil && bErr == nil:
+			if aNumber != bNumber {
+				return aNumber - bNumber
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			return strings.Compare(aPart, bPart)
+		}
+	}
+	return 0
+}
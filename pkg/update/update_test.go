@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package update_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/update"
+)
+
+var _ = Describe("Update", func() {
+	Context("Compare", func() {
+		// Each pair is sorted from older to newer:
+		older := [][2]string{
+			{"1.0.5", "1.0.6"},
+			{"1.0.9", "1.0.10"},
+			{"1.0.5", "1.1.0"},
+			{"1.9.0", "2.0.0"},
+			{"1.0", "1.0.1"},
+			{"1.1.0-rc1", "1.1.0"},
+			{"1.1.0-rc.1", "1.1.0"},
+			{"1.0.5", "1.1.0-rc1"},
+			{"1.1.0-rc1", "1.1.0-rc2"},
+			{"1.1.0-alpha", "1.1.0-beta"},
+			{"1.1.0-rc.2", "1.1.0-rc.10"},
+			{"1.1.0-rc", "1.1.0-rc.1"},
+			{"1.1.0-1", "1.1.0-rc"},
+		}
+		for _, pair := range older {
+			a, b := pair[0], pair[1]
+			It(fmt.Sprintf("considers '%s' older than '%s'", a, b), func() {
+				Expect(update.Compare(a, b)).To(BeNumerically("<", 0))
+				Expect(update.Compare(b, a)).To(BeNumerically(">", 0))
+			})
+		}
+
+		equal := [][2]string{
+			{"1.0.5", "1.0.5"},
+			{"v1.0.5", "1.0.5"},
+			{"1.0", "1.0.0"},
+			{"1.1.0-rc1", "v1.1.0-rc1"},
+			{"1.1.0+build.1", "1.1.0"},
+		}
+		for _, pair := range equal {
+			a, b := pair[0], pair[1]
+			It(fmt.Sprintf("considers '%s' equal to '%s'", a, b), func() {
+				Expect(update.Compare(a, b)).To(BeZero())
+				Expect(update.Compare(b, a)).To(BeZero())
+			})
+		}
+	})
+
+	Context("Newer", func() {
+		It("doesn't offer a pre-release of the running version", func() {
+			release := &update.Release{
+				Version: "1.1.0-rc1",
+			}
+			Expect(release.Newer("1.1.0")).To(BeFalse())
+		})
+
+		It("offers the release of the running pre-release", func() {
+			release := &update.Release{
+				Version: "1.1.0",
+			}
+			Expect(release.Newer("1.1.0-rc1")).To(BeTrue())
+		})
+	})
+})
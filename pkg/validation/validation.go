@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2021 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This package contains the validators of the values given by the user. They have the signature
+// of the validators of the survey library, so the same checks are used for the flags and for the
+// interactive prompts, where they report errors as soon as each answer is entered. Empty values
+// are accepted by all the validators, as required values are checked separately.
+
+package validation
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+
+	"github.com/openshift/rosa/pkg/ocm/machines"
+)
+
+// Validator checks a value given by the user, returning an error that explains what is wrong.
+type Validator = func(value interface{}) error
+
+// Cluster names must be valid DNS-1035 labels, so they must consist of lower case alphanumeric
+// characters or '-', start with an alphabetic character, and end with an alphanumeric character
+var clusterNameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]{0,13}[a-z0-9])?$`)
+
+// Machine pool names must be valid DNS-1035 labels too.
+var machinePoolNameRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// AWS account identifiers are 12 digits.
+var awsAccountIDRE = regexp.MustCompile(`^[0-9]{12}$`)
+
+// ClusterName checks that the value is a valid cluster name.
+func ClusterName(value interface{}) error {
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return err
+	}
+	if !clusterNameRE.MatchString(text) {
+		return fmt.Errorf("Cluster name must consist of no more than 15 lowercase alphanumeric " +
+			"characters or '-', start with a letter, and end with an alphanumeric character.")
+	}
+	return nil
+}
+
+// MachinePoolName checks that the value is a valid machine pool name.
+func MachinePoolName(value interface{}) error {
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return err
+	}
+	if !machinePoolNameRE.MatchString(text) {
+		return fmt.Errorf("Machine pool name must consist of lowercase alphanumeric characters " +
+			"or '-', start with a letter, and end with an alphanumeric character.")
+	}
+	return nil
+}
+
+// CIDR checks that the value is a block of IP addresses in CIDR notation.
+func CIDR(value interface{}) error {
+	switch typed := value.(type) {
+	case net.IPNet, *net.IPNet:
+		return nil
+	default:
+		text, err := toString(typed)
+		if err != nil || text == "" {
+			return err
+		}
+		_, _, err = net.ParseCIDR(text)
+		if err != nil {
+			return fmt.Errorf("Expected a valid CIDR value like '10.0.0.0/16', got '%s'", text)
+		}
+		return nil
+	}
+}
+
+// HostPrefix checks that the value is a valid subnet prefix length.
+func HostPrefix(value interface{}) error {
+	return IntRange(1, 32)(value)
+}
+
+// Int checks that the value is a whole number.
+func Int(value interface{}) error {
+	_, _, err := toInt(value)
+	return err
+}
+
+// IntRange returns a validator that checks that the value is a whole number between the given
+// minimum and maximum, both included.
+func IntRange(min, max int) Validator {
+	return func(value interface{}) error {
+		number, ok, err := toInt(value)
+		if !ok || err != nil {
+			return err
+		}
+		if number < min || number > max {
+			return fmt.Errorf("Expected a number between %d and %d, got %d", min, max, number)
+		}
+		return nil
+	}
+}
+
+// MinInt returns a validator that checks that the value is a whole number at least as large as
+// the given minimum.
+func MinInt(min int) Validator {
+	return func(value interface{}) error {
+		number, ok, err := toInt(value)
+		if !ok || err != nil {
+			return err
+		}
+		if number < min {
+			return fmt.Errorf("Expected a number greater than or equal to %d, got %d", min, number)
+		}
+		return nil
+	}
+}
+
+// MultipleOf returns a validator that checks that the value is a whole number multiple of the
+// given factor.
+func MultipleOf(factor int) Validator {
+	return func(value interface{}) error {
+		number, ok, err := toInt(value)
+		if !ok || err != nil {
+			return err
+		}
+		if number%factor != 0 {
+			return fmt.Errorf("Expected a multiple of %d, got %d", factor, number)
+		}
+		return nil
+	}
+}
+
+// OneOf returns a validator that checks that the value is one of the given options.
+func OneOf(options []string) Validator {
+	return func(value interface{}) error {
+		text, err := toString(value)
+		if err != nil || text == "" {
+			return err
+		}
+		for _, option := range options {
+			if option == text {
+				return nil
+			}
+		}
+		return fmt.Errorf("Expected one of '%s', got '%s'", strings.Join(options, "', '"), text)
+	}
+}
+
+// AWSAccountID checks that the value is an AWS account identifier.
+func AWSAccountID(value interface{}) error {
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return err
+	}
+	if !awsAccountIDRE.MatchString(text) {
+		return fmt.Errorf("Expected an AWS account identifier of 12 digits, got '%s'", text)
+	}
+	return nil
+}
+
+// IAMRoleARN checks that the value is the ARN of an IAM role.
+func IAMRoleARN(value interface{}) error {
+	return resourceARN(value, "iam", "role/", "IAM role")
+}
+
+// KMSKeyARN checks that the value is the ARN of a KMS key.
+func KMSKeyARN(value interface{}) error {
+	return resourceARN(value, "kms", "key/", "KMS key")
+}
+
+// Labels checks that the value is a comma-separated list of 'key=value' labels.
+func Labels(value interface{}) error {
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return err
+	}
+	_, err = machines.ParseLabels(text)
+	return err
+}
+
+// resourceARN checks that the value is the ARN of a resource of the given service, with the given
+// resource type prefix.
+func resourceARN(value interface{}, service string, prefix string, kind string) error {
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return err
+	}
+	parsed, err := arn.Parse(text)
+	if err != nil || parsed.Service != service || !strings.HasPrefix(parsed.Resource, prefix) {
+		return fmt.Errorf("Expected a valid %s ARN, got '%s'", kind, text)
+	}
+	return nil
+}
+
+func toString(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(typed), nil
+	case fmt.Stringer:
+		return strings.TrimSpace(typed.String()), nil
+	default:
+		return "", fmt.Errorf("Can only validate strings, got %v", value)
+	}
+}
+
+// toInt returns the number contained in the value, that can be a number or the text of a number.
+// The returned flag is false when the value is empty.
+func toInt(value interface{}) (int, bool, error) {
+	if number, ok := value.(int); ok {
+		return number, true, nil
+	}
+	text, err := toString(value)
+	if err != nil || text == "" {
+		return 0, false, err
+	}
+	number, err := strconv.Atoi(text)
+	if err != nil {
+		return 0, false, fmt.Errorf("Expected a whole number, got '%s'", text)
+	}
+	return number, true, nil
+}